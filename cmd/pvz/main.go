@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	_ "time/tzdata"
 
 	"github.com/whaleship/pvz/internal/app"
 )
@@ -22,6 +23,10 @@ func main() {
 	pvzApp.InitializeMetrics()
 	pvzApp.InitializeHTTPServer()
 	pvzApp.InitializeGRPCServer()
+	pvzApp.InitializeStorageSweeper()
+	pvzApp.InitializeJobWorkers()
+	pvzApp.InitializeRollups()
+	pvzApp.InitializeReportDelivery()
 
 	pvzApp.Start()
 
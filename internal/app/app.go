@@ -21,6 +21,10 @@ type PVZApp struct {
 
 	ipcManager *infrastructure.IPCManager
 	aggregator *metrics.Aggregator
+	storage    storageProcessor
+	jobs       jobProcessor
+	rollups    rollupProcessor
+	reports    reportDeliveryProcessor
 }
 
 func New(isPrefork bool) *PVZApp {
@@ -51,6 +55,18 @@ func (app *PVZApp) Start() {
 	if app.grpcSrv != nil && !fiber.IsChild() {
 		go app.startGRPCServer()
 	}
+	if app.storage != nil && !fiber.IsChild() {
+		go app.startStorageSweeper()
+	}
+	if app.jobs != nil && !fiber.IsChild() {
+		app.startJobWorkers()
+	}
+	if app.rollups != nil && !fiber.IsChild() {
+		go app.startRollupRefresher()
+	}
+	if app.reports != nil && !fiber.IsChild() {
+		go app.startReportDelivery()
+	}
 }
 
 func (app *PVZApp) GetDBConn() database.PgxIface {
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/whaleship/pvz/internal/config"
+	"github.com/whaleship/pvz/internal/infrastructure"
+	"github.com/whaleship/pvz/internal/repository"
+	"github.com/whaleship/pvz/internal/service"
+)
+
+type jobProcessor interface {
+	ProcessNextJob(ctx context.Context) (bool, error)
+	ExpireJobs(ctx context.Context) (int, error)
+}
+
+func (app *PVZApp) InitializeJobWorkers() {
+	app.jobs = service.NewJobService(
+		repository.NewJobRepository(app.db),
+		repository.NewExportRepository(app.db),
+		service.NewReportService(repository.NewReportRepository(app.db)),
+		infrastructure.NewLocalFileStorage(config.GetJobStorageDir()),
+	)
+}
+
+func (app *PVZApp) startJobWorkers() {
+	for range config.JobWorkers {
+		go app.runJobWorker()
+	}
+	go app.startJobSweeper()
+}
+
+func (app *PVZApp) runJobWorker() {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.JobTimeout)
+		processed, err := app.jobs.ProcessNextJob(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("job worker error: %v", err)
+		}
+		if !processed {
+			time.Sleep(config.JobPollInterval)
+		}
+	}
+}
+
+func (app *PVZApp) startJobSweeper() {
+	ticker := time.NewTicker(config.JobSweepInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.JobSweepTimeout)
+		expired, err := app.jobs.ExpireJobs(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("job sweep error: %v", err)
+		} else if expired > 0 {
+			log.Printf("job sweep: %d job results expired", expired)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/whaleship/pvz/internal/config"
+	"github.com/whaleship/pvz/internal/infrastructure"
+	"github.com/whaleship/pvz/internal/repository"
+	"github.com/whaleship/pvz/internal/service"
+)
+
+type reportDeliveryProcessor interface {
+	DeliverDueReports(ctx context.Context) (int, error)
+}
+
+func (app *PVZApp) InitializeReportDelivery() {
+	var mailer infrastructure.EmailSender
+	if addr, ok := os.LookupEnv("SMTP_ADDR"); ok && addr != "" {
+		mailer = infrastructure.NewSMTPSender(
+			addr,
+			os.Getenv("SMTP_FROM"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+		)
+	}
+	reportRepo := repository.NewReportRepository(app.db)
+	app.reports = service.NewReportSubscriptionService(
+		repository.NewReportSubscriptionRepository(app.db),
+		service.NewReportService(reportRepo),
+		reportRepo,
+		repository.NewDamageRepository(app.db),
+		infrastructure.NewReportDeliverer(config.GetReportDeliveryDir(), mailer),
+	)
+}
+
+func (app *PVZApp) startReportDelivery() {
+	ticker := time.NewTicker(config.ReportScheduleInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.ReportDeliveryTimeout)
+		delivered, err := app.reports.DeliverDueReports(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("report delivery error: %v", err)
+		}
+		if delivered > 0 {
+			log.Printf("report delivery: %d scheduled reports delivered", delivered)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/whaleship/pvz/internal/config"
+	"github.com/whaleship/pvz/internal/repository"
+	"github.com/whaleship/pvz/internal/service"
+)
+
+type rollupProcessor interface {
+	RefreshRollups(ctx context.Context) (int, error)
+}
+
+func (app *PVZApp) InitializeRollups() {
+	app.rollups = service.NewRollupService(repository.NewRollupRepository(app.db))
+}
+
+func (app *PVZApp) startRollupRefresher() {
+	ticker := time.NewTicker(config.RollupInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.RollupTimeout)
+		refreshed, err := app.rollups.RefreshRollups(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("rollup refresh error: %v", err)
+		} else if refreshed > 1 {
+			log.Printf("rollup refresh: %d days recomputed", refreshed)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/whaleship/pvz/internal/config"
+	"github.com/whaleship/pvz/internal/infrastructure"
+	"github.com/whaleship/pvz/internal/repository"
+	"github.com/whaleship/pvz/internal/service"
+)
+
+type storageProcessor interface {
+	ProcessStorage(ctx context.Context) (int, error)
+}
+
+func (app *PVZApp) InitializeStorageSweeper() {
+	storageRepo := repository.NewStoragePeriodRepository(app.db)
+	if url, ok := os.LookupEnv("STORAGE_REMINDER_WEBHOOK_URL"); ok && url != "" {
+		app.storage = service.NewStoragePeriodService(storageRepo, infrastructure.NewWebhookNotifier(url))
+		return
+	}
+	app.storage = service.NewStoragePeriodService(storageRepo, nil)
+}
+
+func (app *PVZApp) startStorageSweeper() {
+	ticker := time.NewTicker(config.StorageSweepInterval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), config.StorageSweepTimeout)
+		flagged, err := app.storage.ProcessStorage(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("storage sweep error: %v", err)
+		} else if flagged > 0 {
+			log.Printf("storage sweep: %d items flagged as unclaimed", flagged)
+		}
+		<-ticker.C
+	}
+}
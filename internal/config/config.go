@@ -9,4 +9,23 @@ var (
 const (
 	TokenValidityPeriod = time.Hour * 24
 	IpcSockPath         = "/tmp/metrics.sock"
+
+	StorageSweepInterval = time.Hour
+	StorageSweepTimeout  = 5 * time.Minute
+
+	JobWorkers          = 2
+	JobPollInterval     = 2 * time.Second
+	JobTimeout          = 30 * time.Minute
+	JobSweepInterval    = 10 * time.Minute
+	JobSweepTimeout     = time.Minute
+	JobStorageDirectory = "/tmp/pvz-jobs"
+
+	RollupInterval = 15 * time.Minute
+	RollupTimeout  = 10 * time.Minute
+
+	ReportScheduleInterval  = time.Minute
+	ReportDeliveryTimeout   = 5 * time.Minute
+	ReportDeliveryDirectory = "/tmp/pvz-reports"
+
+	DefaultReceptionSLA = 2 * time.Hour
 )
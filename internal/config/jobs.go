@@ -0,0 +1,10 @@
+package config
+
+import "os"
+
+func GetJobStorageDir() string {
+	if dir := os.Getenv("JOB_STORAGE_DIR"); dir != "" {
+		return dir
+	}
+	return JobStorageDirectory
+}
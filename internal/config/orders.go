@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+	"sync"
+)
+
+var (
+	pickupCodeOnce   sync.Once
+	pickupCodeSecret []byte
+)
+
+// GetPickupCodeWebhookURL returns the customer notification webhook used to
+// deliver reissued pickup codes. Reissuing is disabled while it is empty.
+func GetPickupCodeWebhookURL() string {
+	return os.Getenv("PICKUP_CODE_WEBHOOK_URL")
+}
+
+// GetPickupCodeSecret returns the key of the pickup code HMAC, falling back
+// to the JWT secret when PICKUP_CODE_SECRET is not set.
+func GetPickupCodeSecret() []byte {
+	pickupCodeOnce.Do(func() {
+		if secret := os.Getenv("PICKUP_CODE_SECRET"); secret != "" {
+			pickupCodeSecret = []byte(secret)
+			return
+		}
+		pickupCodeSecret = GetJWTSecret()
+	})
+	return pickupCodeSecret
+}
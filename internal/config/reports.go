@@ -0,0 +1,10 @@
+package config
+
+import "os"
+
+func GetReportDeliveryDir() string {
+	if dir := os.Getenv("REPORT_DELIVERY_DIR"); dir != "" {
+		return dir
+	}
+	return ReportDeliveryDirectory
+}
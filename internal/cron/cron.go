@@ -0,0 +1,150 @@
+package cron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds Next for schedules such as "0 0 30 2 *" that never fire.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+var ErrInvalidSchedule = errors.New("invalid cron schedule")
+
+type field struct {
+	min, max int
+}
+
+var (
+	minutes  = field{0, 59}
+	hours    = field{0, 23}
+	days     = field{1, 31}
+	months   = field{1, 12}
+	weekdays = field{0, 7}
+)
+
+// Schedule is a parsed five-field cron expression: minute, hour, day of
+// month, month and day of week. Sunday is both 0 and 7.
+type Schedule struct {
+	minute, hour, day, month, weekday uint64
+	// as in Vixie cron, a restricted day of month and day of week match
+	// when either of them matches
+	anyDay, anyWeekday bool
+}
+
+func Parse(spec string) (Schedule, error) {
+	parts := strings.Fields(spec)
+	if len(parts) != 5 {
+		return Schedule{}, ErrInvalidSchedule
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, err = parseField(parts[0], minutes); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = parseField(parts[1], hours); err != nil {
+		return Schedule{}, err
+	}
+	if s.day, err = parseField(parts[2], days); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = parseField(parts[3], months); err != nil {
+		return Schedule{}, err
+	}
+	if s.weekday, err = parseField(parts[4], weekdays); err != nil {
+		return Schedule{}, err
+	}
+	if s.weekday&(1<<7) != 0 {
+		s.weekday |= 1
+	}
+	s.anyDay = strings.HasPrefix(parts[2], "*")
+	s.anyWeekday = strings.HasPrefix(parts[4], "*")
+	return s, nil
+}
+
+// Next returns the first activation strictly after t in t's location, or the
+// zero time when the schedule never fires.
+func (s Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	day, weekday := has(s.day, t.Day()), has(s.weekday, int(t.Weekday()))
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+func parseField(spec string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepSpec); err != nil || step < 1 {
+				return 0, ErrInvalidSchedule
+			}
+		}
+
+		lo, hi := f.min, f.max
+		if rangeSpec != "*" {
+			loSpec, hiSpec, isRange := strings.Cut(rangeSpec, "-")
+			var err error
+			if lo, err = strconv.Atoi(loSpec); err != nil {
+				return 0, ErrInvalidSchedule
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiSpec); err != nil {
+					return 0, ErrInvalidSchedule
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, ErrInvalidSchedule
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<v) != 0
+}
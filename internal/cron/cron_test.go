@@ -0,0 +1,44 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	// Friday
+	from := time.Date(2025, 3, 7, 7, 30, 15, 0, time.UTC)
+
+	cases := map[string]struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		"every minute":       {"* * * * *", from, time.Date(2025, 3, 7, 7, 31, 0, 0, time.UTC)},
+		"weekday mornings":   {"0 7 * * 1-5", from, time.Date(2025, 3, 10, 7, 0, 0, 0, time.UTC)},
+		"steps":              {"*/20 */6 * * *", from, time.Date(2025, 3, 7, 12, 0, 0, 0, time.UTC)},
+		"sunday as seven":    {"0 0 * * 7", from, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		"day or weekday":     {"0 0 1 * 6", from, time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)},
+		"end of february":    {"0 12 29 2 *", from, time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		"schedule time zone": {"0 9 * * *", from.In(moscow), time.Date(2025, 3, 8, 9, 0, 0, 0, moscow)},
+		"never":              {"0 0 30 2 *", from, time.Time{}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			require.NoError(t, err)
+			require.True(t, tc.want.Equal(s.Next(tc.from)), "got %s", s.Next(tc.from))
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"}
+	for _, spec := range specs {
+		_, err := Parse(spec)
+		require.ErrorIs(t, err, ErrInvalidSchedule, spec)
+	}
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/whaleship/pvz/internal/gen/oapi"
+
+type ContainerWithProducts struct {
+	Container oapi.Container `json:"container"`
+	Products  []oapi.Product `json:"products"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type ForecastPVZ struct {
+	RegisteredAt time.Time
+	// IntakeCapacity is how many products the PVZ can receive in a day, nil
+	// when a moderator has not set it
+	IntakeCapacity *int
+}
+
+// HourlyIntake counts the products received in one local hour. Day is the
+// local date at midnight UTC, as Postgres dates are scanned.
+type HourlyIntake struct {
+	Day      time.Time
+	Hour     int
+	Products int
+}
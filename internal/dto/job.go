@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/google/uuid"
+
+type JobFile struct {
+	ID     uuid.UUID
+	Format string
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type LabelData struct {
+	ProductID  uuid.UUID
+	PvzID      uuid.UUID
+	City       string
+	Type       string
+	Barcode    *string
+	ReceivedAt time.Time
+	Zone       *string
+	Rack       *string
+	Shelf      *string
+	Template   *oapi.LabelTemplate
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+type PickupCodeNotice struct {
+	OrderID     uuid.UUID `json:"orderId"`
+	CustomerRef string    `json:"customerRef"`
+	PvzID       uuid.UUID `json:"pvzId"`
+	PickupCode  string    `json:"pickupCode"`
+}
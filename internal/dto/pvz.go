@@ -8,8 +8,9 @@ import (
 )
 
 type ReceptionWithProducts struct {
-	Reception Reception      `json:"receptions"`
-	Products  []oapi.Product `json:"products"`
+	Reception  Reception        `json:"receptions"`
+	Products   []oapi.Product   `json:"products"`
+	Containers []oapi.Container `json:"containers,omitempty"`
 }
 
 type PVZWithReceptions struct {
@@ -23,4 +24,15 @@ type Reception struct {
 	DateTime      time.Time            `json:"dateTime"`
 	CloseDateTime *time.Time           `json:"closeDateTime,omitempty"`
 	Status        oapi.ReceptionStatus `json:"status"`
+	ReceptionWaybill
+}
+
+type ReceptionWaybill struct {
+	Carrier       *string    `json:"carrier,omitempty"`
+	VehiclePlate  *string    `json:"vehiclePlate,omitempty"`
+	WaybillNumber *string    `json:"waybillNumber,omitempty"`
+	SealNumber    *string    `json:"sealNumber,omitempty"`
+	Comment       *string    `json:"comment,omitempty"`
+	CreatedBy     *uuid.UUID `json:"createdBy,omitempty"`
+	ClosedBy      *uuid.UUID `json:"closedBy,omitempty"`
 }
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Region struct {
+	ID           uuid.UUID
+	Name         string
+	GeometryType string
+	Coordinates  []byte
+	PvzCount     int
+}
+
+type PVZLocation struct {
+	ID        uuid.UUID
+	Latitude  float64
+	Longitude float64
+}
+
+type PVZPoint struct {
+	PVZLocation
+	City         string
+	RegisteredAt time.Time
+	RegionID     *uuid.UUID
+	RegionName   *string
+}
+
+type PVZPointFilter struct {
+	City     *string
+	RegionID *uuid.UUID
+}
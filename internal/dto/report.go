@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IntakeFilter struct {
+	From        time.Time
+	To          time.Time
+	Granularity string
+	GroupBy     string
+	City        *string
+	PvzID       *uuid.UUID
+	ProductType *string
+	Rollups     bool
+}
+
+type IntakeRow struct {
+	PeriodStart time.Time
+	Key         string
+	Receptions  int
+	Products    int
+}
+
+type ExportFilter struct {
+	From        time.Time
+	To          time.Time
+	PvzID       *uuid.UUID
+	RegionID    *uuid.UUID
+	ProductType *string
+}
+
+type ProductivityFilter struct {
+	From  time.Time
+	To    time.Time
+	PvzID *uuid.UUID
+}
+
+type EmployeeStats struct {
+	UserID              uuid.UUID
+	Email               *string
+	ProductsScanned     int
+	ProductsDeleted     int
+	ActiveHours         int
+	ReceptionsHandled   int
+	AvgReceptionSeconds *float64
+}
+
+type ReceptionSLAFilter struct {
+	From       time.Time
+	To         time.Time
+	PvzID      *uuid.UUID
+	City       *string
+	SLASeconds float64
+	Timezone   string
+}
+
+// ReceptionSLAGroup holds the statistics of one PVZ, one city when PvzID is
+// nil, or all receptions when City is nil too. Percentile slices are nil
+// when the group has no values to rank.
+type ReceptionSLAGroup struct {
+	PvzID       *uuid.UUID
+	City        *string
+	Receptions  int
+	Breaches    int
+	Durations   []float64
+	ScanSpans   []float64
+	MaxIdleGaps []float64
+}
+
+type ReceptionSLAHour struct {
+	Weekday     int
+	Hour        int
+	Receptions  int
+	Breaches    int
+	DurationP50 *float64
+}
+
+type OpenReception struct {
+	ReceptionID   uuid.UUID
+	PvzID         uuid.UUID
+	City          string
+	OpenedAt      time.Time
+	Carrier       *string
+	WaybillNumber *string
+	Products      int
+}
+
+type ReportFile struct {
+	Name        string
+	Subject     string
+	ContentType string
+	Body        []byte
+}
@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StorageReminder struct {
+	ProductID   uuid.UUID `json:"productId"`
+	OrderID     uuid.UUID `json:"orderId"`
+	CustomerRef string    `json:"customerRef"`
+	PvzID       uuid.UUID `json:"pvzId"`
+	Barcode     *string   `json:"barcode,omitempty"`
+	Deadline    time.Time `json:"deadline"`
+}
@@ -21,14 +21,148 @@ var (
 	ErrSelectPVZFailed     = errors.New("ошибка выбора ПВЗ")
 
 	// receptions
-	ErrOpenReceptionExists    = errors.New("открытая приёмка существует")
-	ErrCloseReceptionFailed   = errors.New("ПВЗ или приёмка не найдена")
-	ErrSelectReceptionsFailed = errors.New("ошибка выбора приёмки")
+	ErrOpenReceptionExists      = errors.New("открытая приёмка существует")
+	ErrCloseReceptionFailed     = errors.New("ПВЗ или приёмка не найдена")
+	ErrSelectReceptionsFailed   = errors.New("ошибка выбора приёмки")
+	ErrInvalidWaybill           = errors.New("некорректные данные накладной")
+	ErrReceptionSummaryNotFound = errors.New("итоги приёмки не найдены")
 
 	// products
 	ErrInvalidProduct       = errors.New("некорректный тип продукта")
 	ErrDeletingProduct      = errors.New("не удалось удалить продукт")
 	ErrSelectProductsFailed = errors.New("ошибка выбора товара")
+	ErrProductNotFound      = errors.New("товар не найден")
+	ErrInvalidBarcode       = errors.New("некорректный штрихкод или артикул")
+	ErrDuplicateBarcodeScan = errors.New("товар с таким штрихкодом уже отсканирован в текущую приёмку")
+	ErrBarcodeAlreadyAtPVZ  = errors.New("товар с таким штрихкодом уже находится в ПВЗ")
+	ErrInvalidProductBatch  = errors.New("некорректный пакет товаров")
+	ErrInvalidProductUpdate = errors.New("нет данных для изменения товара")
+
+	// product trash
+	ErrProductNotInOpenReception = errors.New("товар не найден в открытой приёмке")
+	ErrDeletedProductNotFound    = errors.New("товар не найден в корзине открытой приёмки")
+
+	// product types
+	ErrInvalidProductType       = errors.New("некорректные данные типа товара")
+	ErrProductTypeNotFound      = errors.New("тип товара не найден")
+	ErrProductTypeAlreadyExists = errors.New("тип товара уже существует")
+	ErrSelectProductTypesFailed = errors.New("ошибка выбора типов товаров")
+
+	// damage
+	ErrInvalidDamage      = errors.New("некорректные данные о повреждении")
+	ErrSelectDamageFailed = errors.New("ошибка выбора повреждений")
+
+	// containers
+	ErrInvalidContainer         = errors.New("некорректные данные контейнера")
+	ErrContainerAlreadyExists   = errors.New("контейнер с таким штрихкодом уже принят")
+	ErrContainerNotFound        = errors.New("контейнер не найден")
+	ErrInvalidContainerState    = errors.New("недопустимое состояние контейнера")
+	ErrInvalidContainerProducts = errors.New("товары не принадлежат контейнеру")
+	ErrSelectContainersFailed   = errors.New("ошибка выбора контейнеров")
+
+	// orders
+	ErrInvalidOrder          = errors.New("некорректные данные заказа")
+	ErrInvalidOrderProducts  = errors.New("товары недоступны для заказа или выдачи")
+	ErrOrderNotFound         = errors.New("заказ не найден")
+	ErrOrderAlreadyIssued    = errors.New("заказ уже выдан")
+	ErrInvalidPickupCode     = errors.New("неверный код получения")
+	ErrPickupCodeLocked      = errors.New("код получения заблокирован после неудачных попыток")
+	ErrPickupCodeNotifierOff = errors.New("канал уведомления покупателей не настроен")
+	ErrPickupCodeNotSent     = errors.New("не удалось отправить код получения покупателю")
+
+	// returns
+	ErrInvalidReturn             = errors.New("некорректные данные возврата")
+	ErrInvalidReturnReason       = errors.New("некорректная или неактивная причина возврата")
+	ErrReturnReasonAlreadyExists = errors.New("причина возврата уже существует")
+	ErrReturnProductNotIssued    = errors.New("товар не выдавался клиенту")
+	ErrProductAlreadyReturned    = errors.New("товар уже возвращен")
+	ErrSelectReturnsFailed       = errors.New("ошибка выбора возвратов")
+	ErrInvalidReturnShipment     = errors.New("некорректные данные отправки возвратов")
+	ErrReturnShipmentNotFound    = errors.New("отправка возвратов не найдена")
+	ErrReturnShipmentNotOpen     = errors.New("отправка возвратов уже закрыта")
+	ErrInvalidShipmentReturns    = errors.New("возвраты недоступны для добавления в отправку")
+	ErrInvalidShipmentTransition = errors.New("недопустимая смена статуса отправки")
+	ErrEmptyReturnShipment       = errors.New("нельзя отправить пустую отправку возвратов")
+
+	// transfers
+	ErrInvalidTransfer         = errors.New("некорректные данные перемещения")
+	ErrInvalidTransferProducts = errors.New("товары недоступны для перемещения или получения")
+	ErrTransferNotFound        = errors.New("перемещение не найдено")
+	ErrTransferAlreadyReceived = errors.New("перемещение уже получено")
+	ErrSelectTransfersFailed   = errors.New("ошибка выбора перемещений")
+
+	// storage cells
+	ErrInvalidCell        = errors.New("некорректные данные ячейки хранения")
+	ErrCellNotFound       = errors.New("ячейка хранения не найдена")
+	ErrCellFull           = errors.New("ячейка хранения заполнена")
+	ErrProductNotStorable = errors.New("товар нельзя разместить в ячейке")
+	ErrSelectCellsFailed  = errors.New("ошибка выбора ячеек хранения")
+
+	// inventory
+	ErrInvalidInventory           = errors.New("некорректные данные пересчета")
+	ErrInventoryNotFound          = errors.New("пересчет не найден")
+	ErrInventoryInProgress        = errors.New("в ПВЗ уже идет пересчет")
+	ErrInventoryAlreadyCounted    = errors.New("пересчет уже завершен")
+	ErrInventoryNotCounted        = errors.New("пересчет еще не завершен")
+	ErrInvalidInventoryAdjustment = errors.New("расхождения не найдены или уже приняты")
+
+	// storage periods
+	ErrInvalidStoragePeriod  = errors.New("некорректный срок хранения")
+	ErrSelectUnclaimedFailed = errors.New("ошибка выбора невостребованных товаров")
+
+	// labels
+	ErrInvalidLabel       = errors.New("некорректные параметры этикетки")
+	ErrLabelNotPrintable  = errors.New("штрихкод нельзя напечатать выбранной символикой")
+	ErrSelectLabelsFailed = errors.New("ошибка выбора данных для этикеток")
+
+	// reports
+	ErrInvalidReport      = errors.New("некорректные параметры отчета")
+	ErrSelectReportFailed = errors.New("ошибка построения отчета")
+
+	// export
+	ErrInvalidExport      = errors.New("некорректные параметры выгрузки")
+	ErrSelectExportFailed = errors.New("ошибка выборки данных для выгрузки")
+
+	// jobs
+	ErrInvalidJob       = errors.New("некорректные параметры задачи")
+	ErrJobForbidden     = errors.New("недостаточно прав для задачи")
+	ErrJobNotFound      = errors.New("задача не найдена")
+	ErrJobNotReady      = errors.New("результат задачи недоступен")
+	ErrJobExpired       = errors.New("срок хранения результата задачи истек")
+	ErrJobFinished      = errors.New("задача уже завершена")
+	ErrJobCancelled     = errors.New("задача отменена")
+	ErrSelectJobsFailed = errors.New("ошибка выборки задач")
+
+	// rollups
+	ErrRefreshRollupsFailed = errors.New("ошибка пересчета дневных агрегатов")
+
+	// report subscriptions
+	ErrInvalidReportSubscription  = errors.New("некорректные параметры подписки на отчет")
+	ErrReportSubscriptionNotFound = errors.New("подписка на отчет не найдена")
+	ErrDeliveryTargetUnavailable  = errors.New("канал доставки отчета не настроен")
+	ErrDeliveryAddressForbidden   = errors.New("адрес вебхука указывает во внутреннюю сеть")
+
+	// forecasts
+	ErrInvalidForecast          = errors.New("некорректные параметры прогноза")
+	ErrForecastHistoryTooShort  = errors.New("недостаточно истории приемки для прогноза")
+	ErrSelectForecastDataFailed = errors.New("ошибка выборки истории приемки для прогноза")
+	ErrInvalidIntakeCapacity    = errors.New("некорректная пропускная способность приемки ПВЗ")
+
+	// regions
+	ErrInvalidPVZLocation  = errors.New("некорректные координаты ПВЗ")
+	ErrInvalidRegion       = errors.New("некорректные границы региона")
+	ErrRegionNotFound      = errors.New("регион не найден")
+	ErrSelectRegionsFailed = errors.New("ошибка выборки регионов")
+	ErrAssignRegionsFailed = errors.New("ошибка привязки ПВЗ к регионам")
+
+	// approvals
+	ErrInvalidApprovalOperation = errors.New("некорректная операция для подтверждения")
+	ErrInvalidApproval          = errors.New("некорректные данные подтверждения")
+	ErrApprovalNotFound         = errors.New("запрос на подтверждение не найден")
+	ErrApprovalAlreadyPending   = errors.New("операция уже ожидает подтверждения")
+	ErrApprovalAlreadyDecided   = errors.New("запрос на подтверждение уже рассмотрен")
+	ErrApprovalSelfDecision     = errors.New("нельзя подтвердить собственный запрос")
+	ErrSelectApprovalsFailed    = errors.New("ошибка выбора запросов на подтверждение")
 
 	// middlewares
 	ErrMissingAuthHeader       = errors.New("отсутствует заголовок авторизации")
@@ -62,12 +196,200 @@ func GetErrorStatusCode(err error) int {
 		return fiber.StatusConflict
 	case errors.Is(err, ErrCloseReceptionFailed):
 		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInvalidWaybill):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrReceptionSummaryNotFound):
+		return fiber.StatusNotFound
 
 		// products
 	case errors.Is(err, ErrInvalidProduct):
 		return fiber.StatusBadRequest
 	case errors.Is(err, ErrDeletingProduct):
 		return fiber.StatusBadRequest
+	case errors.Is(err, ErrProductNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrInvalidBarcode):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrDuplicateBarcodeScan):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrBarcodeAlreadyAtPVZ):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInvalidProductBatch):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInvalidProductUpdate):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrProductNotInOpenReception):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrDeletedProductNotFound):
+		return fiber.StatusNotFound
+
+	// product types
+	case errors.Is(err, ErrInvalidProductType):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrProductTypeNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrProductTypeAlreadyExists):
+		return fiber.StatusConflict
+
+	// damage
+	case errors.Is(err, ErrInvalidDamage):
+		return fiber.StatusBadRequest
+
+	// containers
+	case errors.Is(err, ErrInvalidContainer):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrContainerAlreadyExists):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrContainerNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrInvalidContainerState):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInvalidContainerProducts):
+		return fiber.StatusBadRequest
+
+	// orders
+	case errors.Is(err, ErrInvalidOrder):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInvalidOrderProducts):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrOrderNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrOrderAlreadyIssued):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInvalidPickupCode):
+		return fiber.StatusForbidden
+	case errors.Is(err, ErrPickupCodeLocked):
+		return fiber.StatusLocked
+	case errors.Is(err, ErrPickupCodeNotifierOff):
+		return fiber.StatusServiceUnavailable
+	case errors.Is(err, ErrPickupCodeNotSent):
+		return fiber.StatusBadGateway
+
+	// returns
+	case errors.Is(err, ErrInvalidReturn):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInvalidReturnReason):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrReturnReasonAlreadyExists):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrReturnProductNotIssued):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrProductAlreadyReturned):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInvalidReturnShipment):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrReturnShipmentNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrReturnShipmentNotOpen):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInvalidShipmentReturns):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInvalidShipmentTransition):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrEmptyReturnShipment):
+		return fiber.StatusConflict
+
+	// transfers
+	case errors.Is(err, ErrInvalidTransfer):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInvalidTransferProducts):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrTransferNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrTransferAlreadyReceived):
+		return fiber.StatusConflict
+
+	// storage cells
+	case errors.Is(err, ErrInvalidCell):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrCellNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrCellFull):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrProductNotStorable):
+		return fiber.StatusConflict
+
+	// inventory
+	case errors.Is(err, ErrInvalidInventory):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInventoryNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrInventoryInProgress):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInventoryAlreadyCounted):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInventoryNotCounted):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrInvalidInventoryAdjustment):
+		return fiber.StatusConflict
+
+	// storage periods
+	case errors.Is(err, ErrInvalidStoragePeriod):
+		return fiber.StatusBadRequest
+
+	// labels
+	case errors.Is(err, ErrInvalidLabel):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrLabelNotPrintable):
+		return fiber.StatusConflict
+
+	// reports
+	case errors.Is(err, ErrInvalidReport):
+		return fiber.StatusBadRequest
+
+	// export
+	case errors.Is(err, ErrInvalidExport):
+		return fiber.StatusBadRequest
+
+	// jobs
+	case errors.Is(err, ErrInvalidJob):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrJobForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, ErrJobNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrJobNotReady):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrJobExpired):
+		return fiber.StatusGone
+	case errors.Is(err, ErrJobFinished):
+		return fiber.StatusConflict
+
+	// report subscriptions
+	case errors.Is(err, ErrInvalidReportSubscription):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrReportSubscriptionNotFound):
+		return fiber.StatusNotFound
+
+	// forecasts
+	case errors.Is(err, ErrInvalidForecast):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrForecastHistoryTooShort):
+		return fiber.StatusUnprocessableEntity
+	case errors.Is(err, ErrInvalidIntakeCapacity):
+		return fiber.StatusBadRequest
+
+	// regions
+	case errors.Is(err, ErrInvalidPVZLocation):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInvalidRegion):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrRegionNotFound):
+		return fiber.StatusNotFound
+
+	// approvals
+	case errors.Is(err, ErrInvalidApprovalOperation):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrInvalidApproval):
+		return fiber.StatusBadRequest
+	case errors.Is(err, ErrApprovalNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, ErrApprovalAlreadyPending):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrApprovalAlreadyDecided):
+		return fiber.StatusConflict
+	case errors.Is(err, ErrApprovalSelfDecision):
+		return fiber.StatusForbidden
 
 	default:
 		return fiber.StatusInternalServerError
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Format string
+
+const (
+	CSV    Format = "csv"
+	XLSX   Format = "xlsx"
+	NDJSON Format = "ndjson"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+var contentTypes = map[Format]string{
+	CSV:    "text/csv; charset=utf-8",
+	XLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	NDJSON: "application/x-ndjson",
+}
+
+type Writer interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []any) error
+	Close() error
+}
+
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSVWriter(w)
+	case XLSX:
+		return newXLSXWriter(w)
+	case NDJSON:
+		return newNDJSONWriter(w), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func ContentType(format Format) string {
+	return contentTypes[format]
+}
+
+// normalize dereferences pointers and converts values to the types every
+// writer knows how to render: nil, string, int64, float64, bool.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case *int:
+		if v == nil {
+			return nil
+		}
+		return int64(*v)
+	case float32:
+		return float64(v)
+	case *float32:
+		if v == nil {
+			return nil
+		}
+		return float64(*v)
+	case float64:
+		return v
+	case bool:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.Format(time.RFC3339)
+	case uuid.UUID:
+		return v.String()
+	case [16]byte:
+		return uuid.UUID(v).String()
+	case *uuid.UUID:
+		if v == nil {
+			return nil
+		}
+		return v.String()
+	default:
+		return nil
+	}
+}
+
+func text(v any) string {
+	switch v := normalize(v).(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAll(t *testing.T, format Format, rows [][]any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteHeader([]string{"id", "city", "count", "closedAt"}))
+	for _, row := range rows {
+		require.NoError(t, w.WriteRow(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestWriters(t *testing.T) {
+	id := uuid.MustParse("6f1a3c3e-6c1e-4b7b-9a51-3d9f2a0a7b10")
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	rows := [][]any{
+		{id, "Москва, \"центр\"", int64(3), at},
+		{[16]byte(id), "Казань <1>", 0, (*time.Time)(nil)},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		out := string(writeAll(t, CSV, rows))
+		require.True(t, strings.HasPrefix(out, "\xEF\xBB\xBF"))
+		lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(out, "\xEF\xBB\xBF")), "\n")
+		require.Equal(t, []string{
+			"id,city,count,closedAt",
+			id.String() + `,"Москва, ""центр""",3,2025-03-01T10:00:00Z`,
+			id.String() + ",Казань <1>,0,",
+		}, lines)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(string(writeAll(t, NDJSON, rows))), "\n")
+		require.Len(t, lines, 2)
+		var first, second map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+		require.Equal(t, id.String(), first["id"])
+		require.Equal(t, float64(3), first["count"])
+		require.Nil(t, second["closedAt"])
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		out := writeAll(t, XLSX, rows)
+		zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+		require.NoError(t, err)
+
+		var sheet string
+		for _, f := range zr.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				rc, err := f.Open()
+				require.NoError(t, err)
+				body, err := io.ReadAll(rc)
+				require.NoError(t, err)
+				sheet = string(body)
+			}
+		}
+		require.Equal(t, 3, strings.Count(sheet, "<row>"))
+		require.Contains(t, sheet, "<t>Казань &lt;1&gt;</t>")
+		require.Contains(t, sheet, "<c><v>3</v></c>")
+		require.True(t, strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := NewWriter("pdf", io.Discard)
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
+
+func TestNormalizeFloatPointer(t *testing.T) {
+	pct := float32(12.5)
+	require.Equal(t, 12.5, normalize(&pct))
+	require.Nil(t, normalize((*float32)(nil)))
+}
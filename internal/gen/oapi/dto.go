@@ -13,6 +13,121 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for ApprovalStatus.
+const (
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+)
+
+// Defines values for ApprovalOperation.
+const (
+	CloseReception    ApprovalOperation = "close_reception"
+	DeleteLastProduct ApprovalOperation = "delete_last_product"
+	DeleteProduct     ApprovalOperation = "delete_product"
+)
+
+// Defines values for ContainerKind.
+const (
+	ContainerKindContainer ContainerKind = "container"
+	ContainerKindPallet    ContainerKind = "pallet"
+)
+
+// Defines values for ContainerState.
+const (
+	ContainerStateOpened   ContainerState = "opened"
+	ContainerStateSealed   ContainerState = "sealed"
+	ContainerStateVerified ContainerState = "verified"
+)
+
+// Defines values for CustomerReturnStatus.
+const (
+	Accepted   CustomerReturnStatus = "accepted"
+	InShipment CustomerReturnStatus = "in_shipment"
+	Shipped    CustomerReturnStatus = "shipped"
+)
+
+// Defines values for DeliveryTargetType.
+const (
+	Directory DeliveryTargetType = "directory"
+	Email     DeliveryTargetType = "email"
+	Webhook   DeliveryTargetType = "webhook"
+)
+
+// Defines values for ExportFormat.
+const (
+	Csv    ExportFormat = "csv"
+	Ndjson ExportFormat = "ndjson"
+	Xlsx   ExportFormat = "xlsx"
+)
+
+// Defines values for GeoJsonFeatureCollectionType.
+const (
+	FeatureCollection GeoJsonFeatureCollectionType = "FeatureCollection"
+)
+
+// Defines values for GeoJsonFeatureType.
+const (
+	Feature GeoJsonFeatureType = "Feature"
+)
+
+// Defines values for IntakeGroupBy.
+const (
+	IntakeGroupByCity        IntakeGroupBy = "city"
+	IntakeGroupByProductType IntakeGroupBy = "productType"
+	IntakeGroupByPvz         IntakeGroupBy = "pvz"
+)
+
+// Defines values for InventoryDiscrepancyKind.
+const (
+	Misplaced  InventoryDiscrepancyKind = "misplaced"
+	Missing    InventoryDiscrepancyKind = "missing"
+	Unexpected InventoryDiscrepancyKind = "unexpected"
+)
+
+// Defines values for InventorySessionStatus.
+const (
+	Counted  InventorySessionStatus = "counted"
+	Counting InventorySessionStatus = "counting"
+)
+
+// Defines values for JobKind.
+const (
+	ExportProducts   JobKind = "export_products"
+	ExportPvz        JobKind = "export_pvz"
+	ExportReceptions JobKind = "export_receptions"
+	ReportIntake     JobKind = "report_intake"
+)
+
+// Defines values for JobStatus.
+const (
+	Cancelled JobStatus = "cancelled"
+	Errored   JobStatus = "errored"
+	Expired   JobStatus = "expired"
+	Queued    JobStatus = "queued"
+	Running   JobStatus = "running"
+	Succeeded JobStatus = "succeeded"
+)
+
+// Defines values for LabelFormat.
+const (
+	Pdf LabelFormat = "pdf"
+	Zpl LabelFormat = "zpl"
+)
+
+// Defines values for LabelSymbology.
+const (
+	Code128 LabelSymbology = "code128"
+	Qr      LabelSymbology = "qr"
+)
+
+// Defines values for OrderStatus.
+const (
+	AwaitingPickup  OrderStatus = "awaiting_pickup"
+	Completed       OrderStatus = "completed"
+	PartiallyIssued OrderStatus = "partially_issued"
+)
+
 // Defines values for PVZCity.
 const (
 	Казань         PVZCity = "Казань"
@@ -20,11 +135,46 @@ const (
 	СанктПетербург PVZCity = "Санкт-Петербург"
 )
 
-// Defines values for ProductType.
+// Defines values for ProductBatchItemResultStatus.
+const (
+	Created ProductBatchItemResultStatus = "created"
+	Failed  ProductBatchItemResultStatus = "failed"
+	Skipped ProductBatchItemResultStatus = "skipped"
+)
+
+// Defines values for ProductBatchMode.
+const (
+	Atomic  ProductBatchMode = "atomic"
+	Partial ProductBatchMode = "partial"
+)
+
+// Defines values for ProductDamageKind.
+const (
+	ProductDamageKindDamaged ProductDamageKind = "damaged"
+	ProductDamageKindOpened  ProductDamageKind = "opened"
+	ProductDamageKindWet     ProductDamageKind = "wet"
+)
+
+// Defines values for ProductDamageSeverity.
+const (
+	ProductDamageSeverityHigh   ProductDamageSeverity = "high"
+	ProductDamageSeverityLow    ProductDamageSeverity = "low"
+	ProductDamageSeverityMedium ProductDamageSeverity = "medium"
+)
+
+// Defines values for ProductStatus.
+const (
+	InTransit ProductStatus = "in_transit"
+	Issued    ProductStatus = "issued"
+	Lost      ProductStatus = "lost"
+	Ready     ProductStatus = "ready"
+	Received  ProductStatus = "received"
+	Unclaimed ProductStatus = "unclaimed"
+)
+
+// Defines values for PvzPointType.
 const (
-	ProductTypeОбувь       ProductType = "обувь"
-	ProductTypeОдежда      ProductType = "одежда"
-	ProductTypeЭлектроника ProductType = "электроника"
+	Point PvzPointType = "Point"
 )
 
 // Defines values for ReceptionStatus.
@@ -33,23 +183,83 @@ const (
 	InProgress ReceptionStatus = "in_progress"
 )
 
+// Defines values for RegionGeometryType.
+const (
+	MultiPolygon RegionGeometryType = "MultiPolygon"
+	Polygon      RegionGeometryType = "Polygon"
+)
+
+// Defines values for ReportDeliveryStatus.
+const (
+	Sent        ReportDeliveryStatus = "sent"
+	Undelivered ReportDeliveryStatus = "undelivered"
+)
+
+// Defines values for ReportGranularity.
+const (
+	Day   ReportGranularity = "day"
+	Month ReportGranularity = "month"
+	Week  ReportGranularity = "week"
+)
+
+// Defines values for ReportSubscriptionKind.
+const (
+	Damage         ReportSubscriptionKind = "damage"
+	Intake         ReportSubscriptionKind = "intake"
+	OpenReceptions ReportSubscriptionKind = "open_receptions"
+)
+
+// Defines values for ReturnShipmentStatus.
+const (
+	Delivered  ReturnShipmentStatus = "delivered"
+	Dispatched ReturnShipmentStatus = "dispatched"
+	Open       ReturnShipmentStatus = "open"
+)
+
+// Defines values for TransferStatus.
+const (
+	AwaitingReceipt   TransferStatus = "awaiting_receipt"
+	FullyReceived     TransferStatus = "fully_received"
+	PartiallyReceived TransferStatus = "partially_received"
+)
+
 // Defines values for UserRole.
 const (
 	UserRoleEmployee  UserRole = "employee"
 	UserRoleModerator UserRole = "moderator"
 )
 
+// Defines values for GetApprovalsParamsStatus.
+const (
+	GetApprovalsParamsStatusApproved GetApprovalsParamsStatus = "approved"
+	GetApprovalsParamsStatusPending  GetApprovalsParamsStatus = "pending"
+	GetApprovalsParamsStatusRejected GetApprovalsParamsStatus = "rejected"
+)
+
+// Defines values for PostContainersJSONBodyKind.
+const (
+	PostContainersJSONBodyKindContainer PostContainersJSONBodyKind = "container"
+	PostContainersJSONBodyKindPallet    PostContainersJSONBodyKind = "pallet"
+)
+
 // Defines values for PostDummyLoginJSONBodyRole.
 const (
 	PostDummyLoginJSONBodyRoleEmployee  PostDummyLoginJSONBodyRole = "employee"
 	PostDummyLoginJSONBodyRoleModerator PostDummyLoginJSONBodyRole = "moderator"
 )
 
-// Defines values for PostProductsJSONBodyType.
+// Defines values for PostProductsProductIdDamageJSONBodyKind.
+const (
+	Damaged PostProductsProductIdDamageJSONBodyKind = "damaged"
+	Opened  PostProductsProductIdDamageJSONBodyKind = "opened"
+	Wet     PostProductsProductIdDamageJSONBodyKind = "wet"
+)
+
+// Defines values for PostProductsProductIdDamageJSONBodySeverity.
 const (
-	PostProductsJSONBodyTypeОбувь       PostProductsJSONBodyType = "обувь"
-	PostProductsJSONBodyTypeОдежда      PostProductsJSONBodyType = "одежда"
-	PostProductsJSONBodyTypeЭлектроника PostProductsJSONBodyType = "электроника"
+	PostProductsProductIdDamageJSONBodySeverityHigh   PostProductsProductIdDamageJSONBodySeverity = "high"
+	PostProductsProductIdDamageJSONBodySeverityLow    PostProductsProductIdDamageJSONBodySeverity = "low"
+	PostProductsProductIdDamageJSONBodySeverityMedium PostProductsProductIdDamageJSONBodySeverity = "medium"
 )
 
 // Defines values for PostRegisterJSONBodyRole.
@@ -58,15 +268,382 @@ const (
 	Moderator PostRegisterJSONBodyRole = "moderator"
 )
 
+// Approval defines model for Approval.
+type Approval struct {
+	Comment     *string             `json:"comment,omitempty"`
+	DecidedAt   *time.Time          `json:"decidedAt,omitempty"`
+	DecidedBy   *openapi_types.UUID `json:"decidedBy,omitempty"`
+	Id          *openapi_types.UUID `json:"id,omitempty"`
+	Operation   ApprovalOperation   `json:"operation"`
+	PvzId       openapi_types.UUID  `json:"pvzId"`
+	RequestedAt *time.Time          `json:"requestedAt,omitempty"`
+	RequestedBy *openapi_types.UUID `json:"requestedBy,omitempty"`
+	Status      ApprovalStatus      `json:"status"`
+	TargetId    openapi_types.UUID  `json:"targetId"`
+}
+
+// ApprovalStatus defines model for Approval.Status.
+type ApprovalStatus string
+
+// ApprovalDecision defines model for ApprovalDecision.
+type ApprovalDecision struct {
+	Comment *string `json:"comment,omitempty"`
+}
+
+// ApprovalOperation defines model for ApprovalOperation.
+type ApprovalOperation string
+
+// ApprovalRule defines model for ApprovalRule.
+type ApprovalRule struct {
+	Enabled   bool                `json:"enabled"`
+	Operation ApprovalOperation   `json:"operation"`
+	UpdatedAt *time.Time          `json:"updatedAt,omitempty"`
+	UpdatedBy *openapi_types.UUID `json:"updatedBy,omitempty"`
+}
+
+// Container defines model for Container.
+type Container struct {
+	Barcode       string              `json:"barcode"`
+	CreatedAt     *time.Time          `json:"createdAt,omitempty"`
+	DeclaredItems int                 `json:"declaredItems"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+	Kind          ContainerKind       `json:"kind"`
+	OpenedAt      *time.Time          `json:"openedAt,omitempty"`
+	ReceptionId   openapi_types.UUID  `json:"receptionId"`
+	State         ContainerState      `json:"state"`
+	VerifiedAt    *time.Time          `json:"verifiedAt,omitempty"`
+	VerifiedItems int                 `json:"verifiedItems"`
+}
+
+// ContainerKind defines model for Container.Kind.
+type ContainerKind string
+
+// ContainerState defines model for Container.State.
+type ContainerState string
+
+// CustomerReturn defines model for CustomerReturn.
+type CustomerReturn struct {
+	Barcode *string            `json:"barcode,omitempty"`
+	Comment *string            `json:"comment,omitempty"`
+	Id      openapi_types.UUID `json:"id"`
+
+	// OrderId Исходный заказ, если товар выдавался по заказу
+	OrderId *openapi_types.UUID `json:"orderId,omitempty"`
+
+	// ProductId Исходный товар, если его удалось определить
+	ProductId  *openapi_types.UUID  `json:"productId,omitempty"`
+	PvzId      openapi_types.UUID   `json:"pvzId"`
+	ReasonCode string               `json:"reasonCode"`
+	ReceivedAt time.Time            `json:"receivedAt"`
+	ReceivedBy *openapi_types.UUID  `json:"receivedBy,omitempty"`
+	ShipmentId *openapi_types.UUID  `json:"shipmentId,omitempty"`
+	Status     CustomerReturnStatus `json:"status"`
+}
+
+// CustomerReturnStatus defines model for CustomerReturnStatus.
+type CustomerReturnStatus string
+
+// DeletedProduct defines model for DeletedProduct.
+type DeletedProduct struct {
+	DeletedAt time.Time           `json:"deletedAt"`
+	DeletedBy *openapi_types.UUID `json:"deletedBy,omitempty"`
+	Product   Product             `json:"product"`
+}
+
+// DeliveryTarget Для directory адрес задает подкаталог каталога рассылок на сервере, для email - адрес получателя,
+// для webhook - URL, на который отправляется POST с файлом отчета. Вебхуки на localhost, loopback,
+// частные и link-local адреса не принимаются.
+type DeliveryTarget struct {
+	Address string             `json:"address"`
+	Type    DeliveryTargetType `json:"type"`
+}
+
+// DeliveryTargetType defines model for DeliveryTargetType.
+type DeliveryTargetType string
+
+// EmployeeProductivity defines model for EmployeeProductivity.
+type EmployeeProductivity struct {
+	// ActiveHours Число часов, в которые сотрудник сканировал товары
+	ActiveHours int `json:"activeHours"`
+
+	// AvgReceptionDurationSeconds Средняя длительность закрытых приемок, открытых сотрудником
+	AvgReceptionDurationSeconds *int64 `json:"avgReceptionDurationSeconds,omitempty"`
+
+	// DeletionRate Доля отсканированных сотрудником товаров, удаленных в корзину
+	DeletionRate    float32 `json:"deletionRate"`
+	Email           *string `json:"email,omitempty"`
+	ItemsPerHour    float32 `json:"itemsPerHour"`
+	ProductsDeleted int     `json:"productsDeleted"`
+
+	// ProductsScanned Отсканированные товары, включая позже удаленные
+	ProductsScanned int `json:"productsScanned"`
+
+	// ReceptionsHandled Приемки, которые сотрудник открыл, закрыл или в которые сканировал товары
+	ReceptionsHandled int                `json:"receptionsHandled"`
+	UserId            openapi_types.UUID `json:"userId"`
+}
+
+// EmployeeProductivityReport defines model for EmployeeProductivityReport.
+type EmployeeProductivityReport struct {
+	From time.Time              `json:"from"`
+	Rows []EmployeeProductivity `json:"rows"`
+	To   time.Time              `json:"to"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Message string `json:"message"`
 }
 
+// ExportFormat defines model for ExportFormat.
+type ExportFormat string
+
+// ForecastDay defines model for ForecastDay.
+type ForecastDay struct {
+	// CapacityRisk Верхняя граница интервала превышает дневную пропускную способность ПВЗ
+	CapacityRisk bool `json:"capacityRisk"`
+
+	// Date Начало дня в часовом поясе прогноза
+	Date    time.Time      `json:"date"`
+	Holiday bool           `json:"holiday"`
+	Hours   []ForecastHour `json:"hours"`
+	Lower   float32        `json:"lower"`
+
+	// OverCapacity Ожидаемый объем приемки за день превышает дневную пропускную способность ПВЗ
+	OverCapacity bool    `json:"overCapacity"`
+	Upper        float32 `json:"upper"`
+
+	// Volume Ожидаемое число принятых товаров
+	Volume float32 `json:"volume"`
+
+	// Weekday День недели, 1 - понедельник
+	Weekday int `json:"weekday"`
+}
+
+// ForecastHour defines model for ForecastHour.
+type ForecastHour struct {
+	Hour   int     `json:"hour"`
+	Lower  float32 `json:"lower"`
+	Upper  float32 `json:"upper"`
+	Volume float32 `json:"volume"`
+}
+
+// GeoJsonFeatureCollectionType defines model for GeoJsonFeatureCollectionType.
+type GeoJsonFeatureCollectionType string
+
+// GeoJsonFeatureType defines model for GeoJsonFeatureType.
+type GeoJsonFeatureType string
+
+// IntakeCounts defines model for IntakeCounts.
+type IntakeCounts struct {
+	Products   int `json:"products"`
+	Receptions int `json:"receptions"`
+}
+
+// IntakeForecast Прогноз строится по товарам, принятым в ПВЗ за период истории: линейный тренд, умноженный на
+// коэффициент дня недели или праздника. Праздниками считаются нерабочие праздничные дни РФ; если в истории
+// их нет, праздник прогнозируется как самый спокойный день недели. Почасовой объем распределяется по
+// профилю часов того же дня недели.
+type IntakeForecast struct {
+	// Capacity Дневная пропускная способность приемки ПВЗ (dailyIntakeCapacity), с которой сравнивается прогноз каждого дня; не заполняется, если она не задана. Вместимость ячеек хранения здесь не учитывается: она ограничивает остаток товаров, а не их поступление за день.
+	Capacity *int `json:"capacity,omitempty"`
+
+	// Confidence Доверительная вероятность интервалов
+	Confidence    float32            `json:"confidence"`
+	Days          []ForecastDay      `json:"days"`
+	HistoryFrom   time.Time          `json:"historyFrom"`
+	HistoryTo     time.Time          `json:"historyTo"`
+	HolidayFactor float32            `json:"holidayFactor"`
+	PvzId         openapi_types.UUID `json:"pvzId"`
+	Timezone      string             `json:"timezone"`
+
+	// TrendPerDay Изменение базового объема за день
+	TrendPerDay float32  `json:"trendPerDay"`
+	Warnings    []string `json:"warnings"`
+
+	// WeekdayFactors Коэффициенты дней недели с понедельника
+	WeekdayFactors []float32 `json:"weekdayFactors"`
+}
+
+// IntakeGroupBy defines model for IntakeGroupBy.
+type IntakeGroupBy string
+
+// IntakeReport defines model for IntakeReport.
+type IntakeReport struct {
+	From              time.Time         `json:"from"`
+	Granularity       ReportGranularity `json:"granularity"`
+	GroupBy           *IntakeGroupBy    `json:"groupBy,omitempty"`
+	PreviousTotals    IntakeCounts      `json:"previousTotals"`
+	ProductsChangePct *float32          `json:"productsChangePct,omitempty"`
+	Rows              []IntakeReportRow `json:"rows"`
+	To                time.Time         `json:"to"`
+	Totals            IntakeCounts      `json:"totals"`
+}
+
+// IntakeReportRow defines model for IntakeReportRow.
+type IntakeReportRow struct {
+	// Key Город, идентификатор ПВЗ или тип товара в зависимости от группировки
+	Key         *string   `json:"key,omitempty"`
+	PeriodStart time.Time `json:"periodStart"`
+
+	// PreviousProducts Товаров за предыдущий период той же группы
+	PreviousProducts int `json:"previousProducts"`
+	Products         int `json:"products"`
+
+	// ProductsChangePct Изменение к предыдущему периоду в процентах; отсутствует, если в предыдущем периоде товаров не было
+	ProductsChangePct *float32 `json:"productsChangePct,omitempty"`
+	Receptions        int      `json:"receptions"`
+}
+
+// InventoryDiscrepancy defines model for InventoryDiscrepancy.
+type InventoryDiscrepancy struct {
+	AcceptedAt *time.Time          `json:"acceptedAt,omitempty"`
+	AcceptedBy *openapi_types.UUID `json:"acceptedBy,omitempty"`
+
+	// ActualCellId Ячейка, в которой товар найден при пересчете
+	ActualCellId *openapi_types.UUID `json:"actualCellId,omitempty"`
+	Barcode      string              `json:"barcode"`
+
+	// ExpectedCellId Ячейка, в которой товар числится
+	ExpectedCellId *openapi_types.UUID      `json:"expectedCellId,omitempty"`
+	Kind           InventoryDiscrepancyKind `json:"kind"`
+	ProductId      *openapi_types.UUID      `json:"productId,omitempty"`
+}
+
+// InventoryDiscrepancyKind defines model for InventoryDiscrepancyKind.
+type InventoryDiscrepancyKind string
+
+// InventoryScan defines model for InventoryScan.
+type InventoryScan struct {
+	Barcode   string              `json:"barcode"`
+	CellId    *openapi_types.UUID `json:"cellId,omitempty"`
+	ScannedAt time.Time           `json:"scannedAt"`
+	ScannedBy *openapi_types.UUID `json:"scannedBy,omitempty"`
+}
+
+// InventorySession defines model for InventorySession.
+type InventorySession struct {
+	ClosedAt      *time.Time              `json:"closedAt,omitempty"`
+	ClosedBy      *openapi_types.UUID     `json:"closedBy,omitempty"`
+	Discrepancies *[]InventoryDiscrepancy `json:"discrepancies,omitempty"`
+	Id            openapi_types.UUID      `json:"id"`
+	PvzId         openapi_types.UUID      `json:"pvzId"`
+	ScannedCount  int                     `json:"scannedCount"`
+	StartedAt     time.Time               `json:"startedAt"`
+	StartedBy     *openapi_types.UUID     `json:"startedBy,omitempty"`
+	Status        InventorySessionStatus  `json:"status"`
+}
+
+// InventorySessionStatus defines model for InventorySessionStatus.
+type InventorySessionStatus string
+
+// Job defines model for Job.
+type Job struct {
+	CreatedAt time.Time           `json:"createdAt"`
+	CreatedBy *openapi_types.UUID `json:"createdBy,omitempty"`
+	Error     *string             `json:"error,omitempty"`
+
+	// ExpiresAt После этого момента файл результата удаляется
+	ExpiresAt  *time.Time         `json:"expiresAt,omitempty"`
+	FinishedAt *time.Time         `json:"finishedAt,omitempty"`
+	Format     ExportFormat       `json:"format"`
+	Id         openapi_types.UUID `json:"id"`
+	Kind       JobKind            `json:"kind"`
+
+	// Params Параметры выгрузки или отчета; для отчета startDate и endDate задают его период
+	Params JobParams `json:"params"`
+
+	// Progress Количество уже выгруженных строк
+	Progress  int        `json:"progress"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	Status    JobStatus  `json:"status"`
+}
+
+// JobKind defines model for JobKind.
+type JobKind string
+
+// JobParams Параметры выгрузки или отчета; для отчета startDate и endDate задают его период
+type JobParams struct {
+	City        *string            `json:"city,omitempty"`
+	EndDate     *time.Time         `json:"endDate,omitempty"`
+	Granularity *ReportGranularity `json:"granularity,omitempty"`
+	GroupBy     *IntakeGroupBy     `json:"groupBy,omitempty"`
+
+	// ProductType Код типа товара из справочника типов
+	ProductType *ProductType        `json:"productType,omitempty"`
+	PvzId       *openapi_types.UUID `json:"pvzId,omitempty"`
+	RegionId    *openapi_types.UUID `json:"regionId,omitempty"`
+	StartDate   *time.Time          `json:"startDate,omitempty"`
+}
+
+// JobStatus defines model for JobStatus.
+type JobStatus string
+
+// LabelFormat defines model for LabelFormat.
+type LabelFormat string
+
+// LabelSymbology defines model for LabelSymbology.
+type LabelSymbology string
+
+// LabelTemplate Шаблон этикетки ПВЗ; если шаблон не настроен, используется шаблон по умолчанию
+type LabelTemplate struct {
+	// Dpi Разрешение термопринтера для ZPL (203, 300 или 600)
+	Dpi       int                `json:"dpi"`
+	Header    *string            `json:"header,omitempty"`
+	HeightMm  int                `json:"heightMm"`
+	PvzId     openapi_types.UUID `json:"pvzId"`
+	Symbology LabelSymbology     `json:"symbology"`
+	UpdatedAt *time.Time         `json:"updatedAt,omitempty"`
+	WidthMm   int                `json:"widthMm"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	CreatedAt   time.Time           `json:"createdAt"`
+	CreatedBy   *openapi_types.UUID `json:"createdBy,omitempty"`
+	CustomerRef string              `json:"customerRef"`
+	Id          openapi_types.UUID  `json:"id"`
+	IssuedAt    *time.Time          `json:"issuedAt,omitempty"`
+	Items       []OrderItem         `json:"items"`
+	PvzId       openapi_types.UUID  `json:"pvzId"`
+	Status      OrderStatus         `json:"status"`
+}
+
+// OrderStatus defines model for Order.Status.
+type OrderStatus string
+
+// OrderItem defines model for OrderItem.
+type OrderItem struct {
+	IssuedAt  *time.Time          `json:"issuedAt,omitempty"`
+	IssuedBy  *openapi_types.UUID `json:"issuedBy,omitempty"`
+	ProductId openapi_types.UUID  `json:"productId"`
+	Status    ProductStatus       `json:"status"`
+}
+
+// OrderWithPickupCode defines model for OrderWithPickupCode.
+type OrderWithPickupCode struct {
+	Order Order `json:"order"`
+
+	// PickupCode Одноразовый код получения; возвращается только при создании заказа
+	PickupCode *string `json:"pickupCode,omitempty"`
+}
+
 // PVZ defines model for PVZ.
 type PVZ struct {
-	City             PVZCity             `json:"city"`
-	Id               *openapi_types.UUID `json:"id,omitempty"`
+	City PVZCity `json:"city"`
+
+	// DailyIntakeCapacity Сколько товаров ПВЗ может принять за день; задается через PUT /pvz/{pvzId}/intake-capacity
+	DailyIntakeCapacity *int                `json:"dailyIntakeCapacity,omitempty"`
+	Id                  *openapi_types.UUID `json:"id,omitempty"`
+
+	// Latitude Широта; указывается вместе с долготой
+	Latitude *float64 `json:"latitude,omitempty"`
+
+	// Longitude Долгота; указывается вместе с широтой
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// RegionId Регион, в границы которого попадает ПВЗ; назначается автоматически по координатам
+	RegionId         *openapi_types.UUID `json:"regionId,omitempty"`
 	RegistrationDate *time.Time          `json:"registrationDate,omitempty"`
 }
 
@@ -75,29 +652,455 @@ type PVZCity string
 
 // Product defines model for Product.
 type Product struct {
+	Barcode *string `json:"barcode,omitempty"`
+
+	// CellId Ячейка хранения, в которой лежит товар
+	CellId      *openapi_types.UUID `json:"cellId,omitempty"`
+	ContainerId *openapi_types.UUID `json:"containerId,omitempty"`
+
+	// CreatedBy Сотрудник, отсканировавший товар
+	CreatedBy   *openapi_types.UUID `json:"createdBy,omitempty"`
 	DateTime    *time.Time          `json:"dateTime,omitempty"`
 	Id          *openapi_types.UUID `json:"id,omitempty"`
 	ReceptionId openapi_types.UUID  `json:"receptionId"`
-	Type        ProductType         `json:"type"`
+	Sku         *string             `json:"sku,omitempty"`
+	Status      *ProductStatus      `json:"status,omitempty"`
+
+	// Type Код типа товара из справочника типов
+	Type ProductType `json:"type"`
+}
+
+// ProductBatchItem defines model for ProductBatchItem.
+type ProductBatchItem struct {
+	Barcode *string `json:"barcode,omitempty"`
+
+	// CellId Ячейка хранения, в которую кладется товар при приемке
+	CellId *openapi_types.UUID `json:"cellId,omitempty"`
+	Sku    *string             `json:"sku,omitempty"`
+
+	// Type Код типа товара из справочника типов
+	Type ProductType `json:"type"`
+}
+
+// ProductBatchItemResult defines model for ProductBatchItemResult.
+type ProductBatchItemResult struct {
+	Error   *string                      `json:"error,omitempty"`
+	Index   int                          `json:"index"`
+	Product *Product                     `json:"product,omitempty"`
+	Status  ProductBatchItemResultStatus `json:"status"`
+}
+
+// ProductBatchItemResultStatus defines model for ProductBatchItemResult.Status.
+type ProductBatchItemResultStatus string
+
+// ProductBatchMode defines model for ProductBatchMode.
+type ProductBatchMode string
+
+// ProductBatchResult defines model for ProductBatchResult.
+type ProductBatchResult struct {
+	Created     int                      `json:"created"`
+	Failed      int                      `json:"failed"`
+	Items       []ProductBatchItemResult `json:"items"`
+	Mode        ProductBatchMode         `json:"mode"`
+	ReceptionId *openapi_types.UUID      `json:"receptionId,omitempty"`
+}
+
+// ProductDamage defines model for ProductDamage.
+type ProductDamage struct {
+	Carrier       *string               `json:"carrier,omitempty"`
+	City          *string               `json:"city,omitempty"`
+	Comment       *string               `json:"comment,omitempty"`
+	CreatedAt     *time.Time            `json:"createdAt,omitempty"`
+	Id            *openapi_types.UUID   `json:"id,omitempty"`
+	Kind          ProductDamageKind     `json:"kind"`
+	ProductId     openapi_types.UUID    `json:"productId"`
+	PvzId         *openapi_types.UUID   `json:"pvzId,omitempty"`
+	ReceptionId   openapi_types.UUID    `json:"receptionId"`
+	ReportedBy    *openapi_types.UUID   `json:"reportedBy,omitempty"`
+	Severity      ProductDamageSeverity `json:"severity"`
+	WaybillNumber *string               `json:"waybillNumber,omitempty"`
+}
+
+// ProductDamageKind defines model for ProductDamage.Kind.
+type ProductDamageKind string
+
+// ProductDamageSeverity defines model for ProductDamage.Severity.
+type ProductDamageSeverity string
+
+// ProductLocation defines model for ProductLocation.
+type ProductLocation struct {
+	Barcode   *string             `json:"barcode,omitempty"`
+	CellId    *openapi_types.UUID `json:"cellId,omitempty"`
+	ProductId openapi_types.UUID  `json:"productId"`
+	Rack      *string             `json:"rack,omitempty"`
+	Shelf     *string             `json:"shelf,omitempty"`
+	Status    ProductStatus       `json:"status"`
+	Zone      *string             `json:"zone,omitempty"`
+}
+
+// ProductStatus defines model for ProductStatus.
+type ProductStatus string
+
+// ProductType Код типа товара из справочника типов
+type ProductType = string
+
+// ProductTypeInfo defines model for ProductTypeInfo.
+type ProductTypeInfo struct {
+	Active bool `json:"active"`
+
+	// Code Код типа товара из справочника типов
+	Code      ProductType `json:"code"`
+	CreatedAt *time.Time  `json:"createdAt,omitempty"`
+
+	// DisplayNames Отображаемые названия по кодам языков
+	DisplayNames map[string]string `json:"displayNames"`
+	Fragile      bool              `json:"fragile"`
+	HighValue    bool              `json:"highValue"`
+	Oversized    bool              `json:"oversized"`
+	UpdatedAt    *time.Time        `json:"updatedAt,omitempty"`
+}
+
+// PvzFeature defines model for PvzFeature.
+type PvzFeature struct {
+	Geometry   PvzPoint             `json:"geometry"`
+	Id         openapi_types.UUID   `json:"id"`
+	Properties PvzFeatureProperties `json:"properties"`
+	Type       GeoJsonFeatureType   `json:"type"`
+}
+
+// PvzFeatureCollection defines model for PvzFeatureCollection.
+type PvzFeatureCollection struct {
+	Features []PvzFeature                 `json:"features"`
+	Type     GeoJsonFeatureCollectionType `json:"type"`
+}
+
+// PvzFeatureProperties defines model for PvzFeatureProperties.
+type PvzFeatureProperties struct {
+	City             string              `json:"city"`
+	RegionId         *openapi_types.UUID `json:"regionId,omitempty"`
+	RegionName       *string             `json:"regionName,omitempty"`
+	RegistrationDate time.Time           `json:"registrationDate"`
+}
+
+// PvzPoint defines model for PvzPoint.
+type PvzPoint struct {
+	// Coordinates Долгота и широта
+	Coordinates []float64    `json:"coordinates"`
+	Type        PvzPointType `json:"type"`
 }
 
-// ProductType defines model for Product.Type.
-type ProductType string
+// PvzPointType defines model for PvzPoint.Type.
+type PvzPointType string
 
 // Reception defines model for Reception.
 type Reception struct {
-	DateTime time.Time           `json:"dateTime"`
-	Id       *openapi_types.UUID `json:"id,omitempty"`
-	PvzId    openapi_types.UUID  `json:"pvzId"`
-	Status   ReceptionStatus     `json:"status"`
+	Carrier       *string             `json:"carrier,omitempty"`
+	CloseDateTime *time.Time          `json:"closeDateTime,omitempty"`
+	ClosedBy      *openapi_types.UUID `json:"closedBy,omitempty"`
+	Comment       *string             `json:"comment,omitempty"`
+	CreatedBy     *openapi_types.UUID `json:"createdBy,omitempty"`
+	DateTime      time.Time           `json:"dateTime"`
+	Id            *openapi_types.UUID `json:"id,omitempty"`
+	PvzId         openapi_types.UUID  `json:"pvzId"`
+	SealNumber    *string             `json:"sealNumber,omitempty"`
+	Status        ReceptionStatus     `json:"status"`
+	Summary       *ReceptionSummary   `json:"summary,omitempty"`
+	VehiclePlate  *string             `json:"vehiclePlate,omitempty"`
+	WaybillNumber *string             `json:"waybillNumber,omitempty"`
 }
 
 // ReceptionStatus defines model for Reception.Status.
 type ReceptionStatus string
 
+// ReceptionSlaCityRow defines model for ReceptionSlaCityRow.
+type ReceptionSlaCityRow struct {
+	City string `json:"city"`
+
+	// Stats Учитываются закрытые приемки, открытые в заданном периоде. Длительность - от открытия до закрытия
+	// приемки, время сканирования - от первого до последнего товара, простой - самый длинный перерыв между
+	// соседними сканами в приемке. Перцентили не заполняются, если данных нет.
+	Stats ReceptionSlaStats `json:"stats"`
+}
+
+// ReceptionSlaHourRow defines model for ReceptionSlaHourRow.
+type ReceptionSlaHourRow struct {
+	Breaches           int    `json:"breaches"`
+	DurationP50Seconds *int64 `json:"durationP50Seconds,omitempty"`
+	Hour               int    `json:"hour"`
+	Receptions         int    `json:"receptions"`
+
+	// Weekday День недели открытия приемки, 1 - понедельник
+	Weekday int `json:"weekday"`
+}
+
+// ReceptionSlaPvzRow defines model for ReceptionSlaPvzRow.
+type ReceptionSlaPvzRow struct {
+	City  string             `json:"city"`
+	PvzId openapi_types.UUID `json:"pvzId"`
+
+	// Stats Учитываются закрытые приемки, открытые в заданном периоде. Длительность - от открытия до закрытия
+	// приемки, время сканирования - от первого до последнего товара, простой - самый длинный перерыв между
+	// соседними сканами в приемке. Перцентили не заполняются, если данных нет.
+	Stats ReceptionSlaStats `json:"stats"`
+}
+
+// ReceptionSlaReport defines model for ReceptionSlaReport.
+type ReceptionSlaReport struct {
+	// ByHour Разбивка по дню недели и часу открытия приемки в часовом поясе отчета
+	ByHour []ReceptionSlaHourRow `json:"byHour"`
+	Cities []ReceptionSlaCityRow `json:"cities"`
+	From   time.Time             `json:"from"`
+
+	// Pvzs ПВЗ по убыванию числа нарушений SLA
+	Pvzs       []ReceptionSlaPvzRow `json:"pvzs"`
+	SlaMinutes int                  `json:"slaMinutes"`
+	Timezone   string               `json:"timezone"`
+	To         time.Time            `json:"to"`
+
+	// Totals Учитываются закрытые приемки, открытые в заданном периоде. Длительность - от открытия до закрытия
+	// приемки, время сканирования - от первого до последнего товара, простой - самый длинный перерыв между
+	// соседними сканами в приемке. Перцентили не заполняются, если данных нет.
+	Totals ReceptionSlaStats `json:"totals"`
+}
+
+// ReceptionSlaStats Учитываются закрытые приемки, открытые в заданном периоде. Длительность - от открытия до закрытия
+// приемки, время сканирования - от первого до последнего товара, простой - самый длинный перерыв между
+// соседними сканами в приемке. Перцентили не заполняются, если данных нет.
+type ReceptionSlaStats struct {
+	BreachRate float32 `json:"breachRate"`
+
+	// Breaches Приемки, длительность которых превысила SLA
+	Breaches             int    `json:"breaches"`
+	DurationP50Seconds   *int64 `json:"durationP50Seconds,omitempty"`
+	DurationP90Seconds   *int64 `json:"durationP90Seconds,omitempty"`
+	DurationP99Seconds   *int64 `json:"durationP99Seconds,omitempty"`
+	MaxIdleGapP50Seconds *int64 `json:"maxIdleGapP50Seconds,omitempty"`
+	MaxIdleGapP90Seconds *int64 `json:"maxIdleGapP90Seconds,omitempty"`
+	Receptions           int    `json:"receptions"`
+	ScanSpanP50Seconds   *int64 `json:"scanSpanP50Seconds,omitempty"`
+	ScanSpanP90Seconds   *int64 `json:"scanSpanP90Seconds,omitempty"`
+}
+
+// ReceptionSummary defines model for ReceptionSummary.
+type ReceptionSummary struct {
+	CountsByType        map[string]int     `json:"countsByType"`
+	FirstScanAt         *time.Time         `json:"firstScanAt,omitempty"`
+	ItemsPerMinute      float64            `json:"itemsPerMinute"`
+	LastScanAt          *time.Time         `json:"lastScanAt,omitempty"`
+	OpenDurationSeconds int64              `json:"openDurationSeconds"`
+	ReceptionId         openapi_types.UUID `json:"receptionId"`
+	TotalItems          int                `json:"totalItems"`
+}
+
+// RegionFeature defines model for RegionFeature.
+type RegionFeature struct {
+	Geometry   RegionGeometry      `json:"geometry"`
+	Id         *openapi_types.UUID `json:"id,omitempty"`
+	Properties RegionProperties    `json:"properties"`
+	Type       GeoJsonFeatureType  `json:"type"`
+}
+
+// RegionFeatureCollection defines model for RegionFeatureCollection.
+type RegionFeatureCollection struct {
+	Features []RegionFeature              `json:"features"`
+	Type     GeoJsonFeatureCollectionType `json:"type"`
+}
+
+// RegionGeometry defines model for RegionGeometry.
+type RegionGeometry struct {
+	// Coordinates Координаты по RFC 7946: долгота, затем широта. Первое кольцо полигона задает внешнюю границу,
+	// остальные — вырезы; каждое кольцо замкнуто и содержит не менее четырех точек.
+	Coordinates interface{}        `json:"coordinates"`
+	Type        RegionGeometryType `json:"type"`
+}
+
+// RegionGeometryType defines model for RegionGeometry.Type.
+type RegionGeometryType string
+
+// RegionProperties defines model for RegionProperties.
+type RegionProperties struct {
+	Name string `json:"name"`
+
+	// PvzCount Количество ПВЗ в регионе
+	PvzCount *int `json:"pvzCount,omitempty"`
+}
+
+// ReportDelivery defines model for ReportDelivery.
+type ReportDelivery struct {
+	CreatedAt      time.Time            `json:"createdAt"`
+	Error          *string              `json:"error,omitempty"`
+	FileName       *string              `json:"fileName,omitempty"`
+	Id             openapi_types.UUID   `json:"id"`
+	ScheduledAt    time.Time            `json:"scheduledAt"`
+	Status         ReportDeliveryStatus `json:"status"`
+	SubscriptionId openapi_types.UUID   `json:"subscriptionId"`
+
+	// Target Для directory адрес задает подкаталог каталога рассылок на сервере, для email - адрес получателя,
+	// для webhook - URL, на который отправляется POST с файлом отчета. Вебхуки на localhost, loopback,
+	// частные и link-local адреса не принимаются.
+	Target DeliveryTarget `json:"target"`
+}
+
+// ReportDeliveryStatus defines model for ReportDeliveryStatus.
+type ReportDeliveryStatus string
+
+// ReportGranularity defines model for ReportGranularity.
+type ReportGranularity string
+
+// ReportSubscription defines model for ReportSubscription.
+type ReportSubscription struct {
+	Active    bool                `json:"active"`
+	CreatedAt time.Time           `json:"createdAt"`
+	CreatedBy *openapi_types.UUID `json:"createdBy,omitempty"`
+	Format    ExportFormat        `json:"format"`
+	Id        openapi_types.UUID  `json:"id"`
+	LastRunAt *time.Time          `json:"lastRunAt,omitempty"`
+	Name      string              `json:"name"`
+	NextRunAt *time.Time          `json:"nextRunAt,omitempty"`
+
+	// Params Фильтры отчета; период отчета заканчивается в полночь перед запуском
+	Params   ReportSubscriptionParams `json:"params"`
+	Report   ReportSubscriptionKind   `json:"report"`
+	Schedule string                   `json:"schedule"`
+	Targets  []DeliveryTarget         `json:"targets"`
+	Timezone string                   `json:"timezone"`
+}
+
+// ReportSubscriptionKind defines model for ReportSubscriptionKind.
+type ReportSubscriptionKind string
+
+// ReportSubscriptionParams Фильтры отчета; период отчета заканчивается в полночь перед запуском
+type ReportSubscriptionParams struct {
+	Carrier     *string            `json:"carrier,omitempty"`
+	City        *string            `json:"city,omitempty"`
+	Granularity *ReportGranularity `json:"granularity,omitempty"`
+	GroupBy     *IntakeGroupBy     `json:"groupBy,omitempty"`
+
+	// LookbackDays Длина периода отчета по приемке и повреждениям в днях
+	LookbackDays *int `json:"lookbackDays,omitempty"`
+
+	// ProductType Код типа товара из справочника типов
+	ProductType *ProductType        `json:"productType,omitempty"`
+	PvzId       *openapi_types.UUID `json:"pvzId,omitempty"`
+}
+
+// ReportSubscriptionRequest defines model for ReportSubscriptionRequest.
+type ReportSubscriptionRequest struct {
+	Active *bool         `json:"active,omitempty"`
+	Format *ExportFormat `json:"format,omitempty"`
+	Name   string        `json:"name"`
+
+	// Params Фильтры отчета; период отчета заканчивается в полночь перед запуском
+	Params *ReportSubscriptionParams `json:"params,omitempty"`
+	Report ReportSubscriptionKind    `json:"report"`
+
+	// Schedule Расписание в формате cron из пяти полей (минута, час, день месяца, месяц, день недели)
+	Schedule string           `json:"schedule"`
+	Targets  []DeliveryTarget `json:"targets"`
+
+	// Timezone Часовой пояс расписания и границ периода, по умолчанию UTC
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// ReturnReason defines model for ReturnReason.
+type ReturnReason struct {
+	Active       bool              `json:"active"`
+	Code         string            `json:"code"`
+	CreatedAt    *time.Time        `json:"createdAt,omitempty"`
+	DisplayNames map[string]string `json:"displayNames"`
+}
+
+// ReturnShipment defines model for ReturnShipment.
+type ReturnShipment struct {
+	CreatedAt    time.Time            `json:"createdAt"`
+	CreatedBy    *openapi_types.UUID  `json:"createdBy,omitempty"`
+	DeliveredAt  *time.Time           `json:"deliveredAt,omitempty"`
+	DispatchedAt *time.Time           `json:"dispatchedAt,omitempty"`
+	Id           openapi_types.UUID   `json:"id"`
+	PvzId        openapi_types.UUID   `json:"pvzId"`
+	Returns      *[]CustomerReturn    `json:"returns,omitempty"`
+	Status       ReturnShipmentStatus `json:"status"`
+}
+
+// ReturnShipmentStatus defines model for ReturnShipmentStatus.
+type ReturnShipmentStatus string
+
+// StorageCell defines model for StorageCell.
+type StorageCell struct {
+	Active   bool               `json:"active"`
+	Capacity int                `json:"capacity"`
+	Id       openapi_types.UUID `json:"id"`
+
+	// Occupied Количество товаров в ячейке
+	Occupied int                `json:"occupied"`
+	PvzId    openapi_types.UUID `json:"pvzId"`
+	Rack     string             `json:"rack"`
+	Shelf    string             `json:"shelf"`
+	Zone     string             `json:"zone"`
+}
+
+// StorageCellSpec defines model for StorageCellSpec.
+type StorageCellSpec struct {
+	Active   *bool  `json:"active,omitempty"`
+	Capacity int    `json:"capacity"`
+	Rack     string `json:"rack"`
+	Shelf    string `json:"shelf"`
+	Zone     string `json:"zone"`
+}
+
+// StoragePeriod Срок хранения; без ПВЗ и типа товара действует для всех товаров
+type StoragePeriod struct {
+	Days int `json:"days"`
+
+	// ProductType Код типа товара из справочника типов
+	ProductType *ProductType        `json:"productType,omitempty"`
+	PvzId       *openapi_types.UUID `json:"pvzId,omitempty"`
+	UpdatedAt   *time.Time          `json:"updatedAt,omitempty"`
+	UpdatedBy   *openapi_types.UUID `json:"updatedBy,omitempty"`
+}
+
 // Token defines model for Token.
 type Token = string
 
+// Transfer defines model for Transfer.
+type Transfer struct {
+	CompletedAt      *time.Time          `json:"completedAt,omitempty"`
+	CreatedAt        time.Time           `json:"createdAt"`
+	CreatedBy        *openapi_types.UUID `json:"createdBy,omitempty"`
+	DestinationPvzId openapi_types.UUID  `json:"destinationPvzId"`
+
+	// ExpectedAt Срок, после которого незавершенное перемещение считается просроченным
+	ExpectedAt  time.Time          `json:"expectedAt"`
+	Id          openapi_types.UUID `json:"id"`
+	Items       *[]TransferItem    `json:"items,omitempty"`
+	SourcePvzId openapi_types.UUID `json:"sourcePvzId"`
+	Status      TransferStatus     `json:"status"`
+}
+
+// TransferItem defines model for TransferItem.
+type TransferItem struct {
+	ProductId  openapi_types.UUID  `json:"productId"`
+	ReceivedAt *time.Time          `json:"receivedAt,omitempty"`
+	ReceivedBy *openapi_types.UUID `json:"receivedBy,omitempty"`
+}
+
+// TransferStatus defines model for TransferStatus.
+type TransferStatus string
+
+// UnclaimedItem defines model for UnclaimedItem.
+type UnclaimedItem struct {
+	Barcode   *string             `json:"barcode,omitempty"`
+	CellId    *openapi_types.UUID `json:"cellId,omitempty"`
+	FlaggedAt time.Time           `json:"flaggedAt"`
+	OrderId   *openapi_types.UUID `json:"orderId,omitempty"`
+	ProductId openapi_types.UUID  `json:"productId"`
+	PvzId     openapi_types.UUID  `json:"pvzId"`
+	Rack      *string             `json:"rack,omitempty"`
+	ReturnId  openapi_types.UUID  `json:"returnId"`
+	Shelf     *string             `json:"shelf,omitempty"`
+	Zone      *string             `json:"zone,omitempty"`
+}
+
 // User defines model for User.
 type User struct {
 	Email openapi_types.Email `json:"email"`
@@ -108,6 +1111,63 @@ type User struct {
 // UserRole defines model for User.Role.
 type UserRole string
 
+// GetApprovalsParams defines parameters for GetApprovals.
+type GetApprovalsParams struct {
+	Status *GetApprovalsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Page   *int                      `form:"page,omitempty" json:"page,omitempty"`
+	Limit  *int                      `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetApprovalsParamsStatus defines parameters for GetApprovals.
+type GetApprovalsParamsStatus string
+
+// PutApprovalsRulesOperationJSONBody defines parameters for PutApprovalsRulesOperation.
+type PutApprovalsRulesOperationJSONBody struct {
+	Enabled bool `json:"enabled"`
+}
+
+// PostContainersJSONBody defines parameters for PostContainers.
+type PostContainersJSONBody struct {
+	Barcode string `json:"barcode"`
+	Items   []struct {
+		Quantity int `json:"quantity"`
+
+		// Type Код типа товара из справочника типов
+		Type ProductType `json:"type"`
+	} `json:"items"`
+	Kind  PostContainersJSONBodyKind `json:"kind"`
+	PvzId openapi_types.UUID         `json:"pvzId"`
+}
+
+// PostContainersJSONBodyKind defines parameters for PostContainers.
+type PostContainersJSONBodyKind string
+
+// PostContainersContainerIdVerifyJSONBody defines parameters for PostContainersContainerIdVerify.
+type PostContainersContainerIdVerifyJSONBody struct {
+	ProductIds []openapi_types.UUID `json:"productIds"`
+}
+
+// GetDamageIncidentsParams defines parameters for GetDamageIncidents.
+type GetDamageIncidentsParams struct {
+	// StartDate Начальная дата диапазона
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// PvzId Фильтр по ПВЗ
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+
+	// Carrier Фильтр по перевозчику
+	Carrier *string `form:"carrier,omitempty" json:"carrier,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // PostDummyLoginJSONBody defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBody struct {
 	Role PostDummyLoginJSONBodyRole `json:"role"`
@@ -116,20 +1176,179 @@ type PostDummyLoginJSONBody struct {
 // PostDummyLoginJSONBodyRole defines parameters for PostDummyLogin.
 type PostDummyLoginJSONBodyRole string
 
+// GetExportProductsParams defines parameters for GetExportProducts.
+type GetExportProductsParams struct {
+	// StartDate Начальная дата диапазона
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Format Формат выгрузки; если не указан, выбирается по заголовку Accept
+	Format      *ExportFormat       `form:"format,omitempty" json:"format,omitempty"`
+	PvzId       *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+	ProductType *string             `form:"productType,omitempty" json:"productType,omitempty"`
+}
+
+// GetExportPvzParams defines parameters for GetExportPvz.
+type GetExportPvzParams struct {
+	// StartDate Начальная дата диапазона
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// RegionId Только ПВЗ указанного региона
+	RegionId *openapi_types.UUID `form:"regionId,omitempty" json:"regionId,omitempty"`
+
+	// Format Формат выгрузки; если не указан, выбирается по заголовку Accept
+	Format *ExportFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetExportReceptionsParams defines parameters for GetExportReceptions.
+type GetExportReceptionsParams struct {
+	// StartDate Начальная дата диапазона
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Конечная дата диапазона
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// Format Формат выгрузки; если не указан, выбирается по заголовку Accept
+	Format *ExportFormat       `form:"format,omitempty" json:"format,omitempty"`
+	PvzId  *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+}
+
+// PostInventorySessionsJSONBody defines parameters for PostInventorySessions.
+type PostInventorySessionsJSONBody struct {
+	PvzId openapi_types.UUID `json:"pvzId"`
+}
+
+// PostInventorySessionsSessionIdAdjustmentsJSONBody defines parameters for PostInventorySessionsSessionIdAdjustments.
+type PostInventorySessionsSessionIdAdjustmentsJSONBody struct {
+	Barcodes []string `json:"barcodes"`
+}
+
+// PostInventorySessionsSessionIdScansJSONBody defines parameters for PostInventorySessionsSessionIdScans.
+type PostInventorySessionsSessionIdScansJSONBody struct {
+	Barcode string `json:"barcode"`
+
+	// CellId Ячейка, в которой найден товар
+	CellId *openapi_types.UUID `json:"cellId,omitempty"`
+}
+
+// PostJobsJSONBody defines parameters for PostJobs.
+type PostJobsJSONBody struct {
+	Format *ExportFormat `json:"format,omitempty"`
+	Kind   JobKind       `json:"kind"`
+
+	// Params Параметры выгрузки или отчета; для отчета startDate и endDate задают его период
+	Params *JobParams `json:"params,omitempty"`
+}
+
+// PostLabelsJSONBody defines parameters for PostLabels.
+type PostLabelsJSONBody struct {
+	Format     *LabelFormat         `json:"format,omitempty"`
+	ProductIds []openapi_types.UUID `json:"productIds"`
+}
+
 // PostLoginJSONBody defines parameters for PostLogin.
 type PostLoginJSONBody struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
 }
 
+// PostOrdersJSONBody defines parameters for PostOrders.
+type PostOrdersJSONBody struct {
+	CustomerRef string               `json:"customerRef"`
+	ProductIds  []openapi_types.UUID `json:"productIds"`
+	PvzId       openapi_types.UUID   `json:"pvzId"`
+}
+
+// PostOrdersOrderIdIssueJSONBody defines parameters for PostOrdersOrderIdIssue.
+type PostOrdersOrderIdIssueJSONBody struct {
+	PickupCode string `json:"pickupCode"`
+
+	// ProductIds Товары для выдачи; если не указаны, выдаются все оставшиеся
+	ProductIds *[]openapi_types.UUID `json:"productIds,omitempty"`
+}
+
+// GetProductTypesParams defines parameters for GetProductTypes.
+type GetProductTypesParams struct {
+	IncludeInactive *bool `form:"includeInactive,omitempty" json:"includeInactive,omitempty"`
+}
+
+// PutProductTypesCodeJSONBody defines parameters for PutProductTypesCode.
+type PutProductTypesCodeJSONBody struct {
+	Active       bool              `json:"active"`
+	DisplayNames map[string]string `json:"displayNames"`
+	Fragile      bool              `json:"fragile"`
+	HighValue    bool              `json:"highValue"`
+	Oversized    bool              `json:"oversized"`
+}
+
 // PostProductsJSONBody defines parameters for PostProducts.
 type PostProductsJSONBody struct {
-	PvzId openapi_types.UUID       `json:"pvzId"`
-	Type  PostProductsJSONBodyType `json:"type"`
+	Barcode *string `json:"barcode,omitempty"`
+
+	// CellId Ячейка хранения, в которую кладется товар при приемке
+	CellId *openapi_types.UUID `json:"cellId,omitempty"`
+	PvzId  openapi_types.UUID  `json:"pvzId"`
+	Sku    *string             `json:"sku,omitempty"`
+
+	// Type Код типа товара из справочника типов
+	Type ProductType `json:"type"`
 }
 
-// PostProductsJSONBodyType defines parameters for PostProducts.
-type PostProductsJSONBodyType string
+// PostProductsBatchJSONBody defines parameters for PostProductsBatch.
+type PostProductsBatchJSONBody struct {
+	Items []ProductBatchItem `json:"items"`
+	Mode  *ProductBatchMode  `json:"mode,omitempty"`
+	PvzId openapi_types.UUID `json:"pvzId"`
+}
+
+// GetProductsByBarcodeCodeParams defines parameters for GetProductsByBarcodeCode.
+type GetProductsByBarcodeCodeParams struct {
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+}
+
+// GetProductsLocationsParams defines parameters for GetProductsLocations.
+type GetProductsLocationsParams struct {
+	Barcode *string             `form:"barcode,omitempty" json:"barcode,omitempty"`
+	OrderId *openapi_types.UUID `form:"orderId,omitempty" json:"orderId,omitempty"`
+	PvzId   *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+}
+
+// PatchProductsProductIdJSONBody defines parameters for PatchProductsProductId.
+type PatchProductsProductIdJSONBody struct {
+	Barcode *string `json:"barcode,omitempty"`
+	Sku     *string `json:"sku,omitempty"`
+
+	// Type Код типа товара из справочника типов
+	Type *ProductType `json:"type,omitempty"`
+}
+
+// PutProductsProductIdCellJSONBody defines parameters for PutProductsProductIdCell.
+type PutProductsProductIdCellJSONBody struct {
+	CellId openapi_types.UUID `json:"cellId"`
+}
+
+// PostProductsProductIdDamageJSONBody defines parameters for PostProductsProductIdDamage.
+type PostProductsProductIdDamageJSONBody struct {
+	Comment  *string                                     `json:"comment,omitempty"`
+	Kind     PostProductsProductIdDamageJSONBodyKind     `json:"kind"`
+	Severity PostProductsProductIdDamageJSONBodySeverity `json:"severity"`
+}
+
+// PostProductsProductIdDamageJSONBodyKind defines parameters for PostProductsProductIdDamage.
+type PostProductsProductIdDamageJSONBodyKind string
+
+// PostProductsProductIdDamageJSONBodySeverity defines parameters for PostProductsProductIdDamage.
+type PostProductsProductIdDamageJSONBodySeverity string
+
+// GetProductsProductIdLabelParams defines parameters for GetProductsProductIdLabel.
+type GetProductsProductIdLabelParams struct {
+	Format *LabelFormat `form:"format,omitempty" json:"format,omitempty"`
+}
 
 // GetPvzParams defines parameters for GetPvz.
 type GetPvzParams struct {
@@ -144,11 +1363,72 @@ type GetPvzParams struct {
 
 	// Limit Количество элементов на странице
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// RegionId Только ПВЗ указанного региона
+	RegionId *openapi_types.UUID `form:"regionId,omitempty" json:"regionId,omitempty"`
+}
+
+// GetPvzGeojsonParams defines parameters for GetPvzGeojson.
+type GetPvzGeojsonParams struct {
+	// City Только ПВЗ указанного города
+	City *string `form:"city,omitempty" json:"city,omitempty"`
+
+	// RegionId Только ПВЗ указанного региона
+	RegionId *openapi_types.UUID `form:"regionId,omitempty" json:"regionId,omitempty"`
+}
+
+// PostPvzPvzIdCellsJSONBody defines parameters for PostPvzPvzIdCells.
+type PostPvzPvzIdCellsJSONBody struct {
+	Cells []StorageCellSpec `json:"cells"`
+}
+
+// GetPvzPvzIdForecastParams defines parameters for GetPvzPvzIdForecast.
+type GetPvzPvzIdForecastParams struct {
+	// Days Горизонт прогноза в днях начиная с завтрашнего, по умолчанию 14
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+
+	// HistoryDays Глубина истории в днях, по умолчанию 84
+	HistoryDays *int `form:"historyDays,omitempty" json:"historyDays,omitempty"`
+
+	// Timezone Часовой пояс ПВЗ, по умолчанию UTC
+	Timezone *string `form:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// PutPvzPvzIdIntakeCapacityJSONBody defines parameters for PutPvzPvzIdIntakeCapacity.
+type PutPvzPvzIdIntakeCapacityJSONBody struct {
+	// DailyIntakeCapacity Сколько товаров ПВЗ может принять за день; null снимает ограничение
+	DailyIntakeCapacity *int `json:"dailyIntakeCapacity"`
+}
+
+// PutPvzPvzIdLabelTemplateJSONBody defines parameters for PutPvzPvzIdLabelTemplate.
+type PutPvzPvzIdLabelTemplateJSONBody struct {
+	Dpi       int            `json:"dpi"`
+	Header    *string        `json:"header,omitempty"`
+	HeightMm  int            `json:"heightMm"`
+	Symbology LabelSymbology `json:"symbology"`
+	WidthMm   int            `json:"widthMm"`
+}
+
+// PutPvzPvzIdLocationJSONBody defines parameters for PutPvzPvzIdLocation.
+type PutPvzPvzIdLocationJSONBody struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 // PostReceptionsJSONBody defines parameters for PostReceptions.
 type PostReceptionsJSONBody struct {
-	PvzId openapi_types.UUID `json:"pvzId"`
+	Carrier       *string            `json:"carrier,omitempty"`
+	Comment       *string            `json:"comment,omitempty"`
+	PvzId         openapi_types.UUID `json:"pvzId"`
+	SealNumber    *string            `json:"sealNumber,omitempty"`
+	VehiclePlate  *string            `json:"vehiclePlate,omitempty"`
+	WaybillNumber *string            `json:"waybillNumber,omitempty"`
+}
+
+// GetReceptionsSearchParams defines parameters for GetReceptionsSearch.
+type GetReceptionsSearchParams struct {
+	// WaybillNumber Номер накладной
+	WaybillNumber string `form:"waybillNumber" json:"waybillNumber"`
 }
 
 // PostRegisterJSONBody defines parameters for PostRegister.
@@ -161,20 +1441,267 @@ type PostRegisterJSONBody struct {
 // PostRegisterJSONBodyRole defines parameters for PostRegister.
 type PostRegisterJSONBodyRole string
 
+// GetReportSubscriptionsSubscriptionIdDeliveriesParams defines parameters for GetReportSubscriptionsSubscriptionIdDeliveries.
+type GetReportSubscriptionsSubscriptionIdDeliveriesParams struct {
+	Page  *int `form:"page,omitempty" json:"page,omitempty"`
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetReportsEmployeesParams defines parameters for GetReportsEmployees.
+type GetReportsEmployeesParams struct {
+	From  time.Time           `form:"from" json:"from"`
+	To    time.Time           `form:"to" json:"to"`
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+}
+
+// GetReportsIntakeParams defines parameters for GetReportsIntake.
+type GetReportsIntakeParams struct {
+	From        time.Time           `form:"from" json:"from"`
+	To          time.Time           `form:"to" json:"to"`
+	Granularity *ReportGranularity  `form:"granularity,omitempty" json:"granularity,omitempty"`
+	GroupBy     *IntakeGroupBy      `form:"groupBy,omitempty" json:"groupBy,omitempty"`
+	City        *string             `form:"city,omitempty" json:"city,omitempty"`
+	PvzId       *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+	ProductType *string             `form:"productType,omitempty" json:"productType,omitempty"`
+}
+
+// GetReportsReceptionSlaParams defines parameters for GetReportsReceptionSla.
+type GetReportsReceptionSlaParams struct {
+	From  time.Time           `form:"from" json:"from"`
+	To    time.Time           `form:"to" json:"to"`
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+	City  *string             `form:"city,omitempty" json:"city,omitempty"`
+
+	// SlaMinutes Допустимая длительность приемки, по умолчанию 120 минут
+	SlaMinutes *int `form:"slaMinutes,omitempty" json:"slaMinutes,omitempty"`
+
+	// Timezone Часовой пояс для разбивки по дням недели и часам, по умолчанию UTC
+	Timezone *string `form:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// GetReturnReasonsParams defines parameters for GetReturnReasons.
+type GetReturnReasonsParams struct {
+	IncludeInactive *bool `form:"includeInactive,omitempty" json:"includeInactive,omitempty"`
+}
+
+// GetReturnShipmentsParams defines parameters for GetReturnShipments.
+type GetReturnShipmentsParams struct {
+	// PvzId Фильтр по ПВЗ
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+
+	// Status Фильтр по статусу отправки
+	Status *ReturnShipmentStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostReturnShipmentsJSONBody defines parameters for PostReturnShipments.
+type PostReturnShipmentsJSONBody struct {
+	PvzId openapi_types.UUID `json:"pvzId"`
+}
+
+// PostReturnShipmentsShipmentIdReturnsJSONBody defines parameters for PostReturnShipmentsShipmentIdReturns.
+type PostReturnShipmentsShipmentIdReturnsJSONBody struct {
+	ReturnIds []openapi_types.UUID `json:"returnIds"`
+}
+
+// PostReturnShipmentsShipmentIdStatusJSONBody defines parameters for PostReturnShipmentsShipmentIdStatus.
+type PostReturnShipmentsShipmentIdStatusJSONBody struct {
+	Status ReturnShipmentStatus `json:"status"`
+}
+
+// GetReturnsParams defines parameters for GetReturns.
+type GetReturnsParams struct {
+	// PvzId Фильтр по ПВЗ
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+
+	// Status Фильтр по статусу возврата
+	Status *CustomerReturnStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostReturnsJSONBody defines parameters for PostReturns.
+type PostReturnsJSONBody struct {
+	// Barcode Штрихкод возвращаемого товара; используется для поиска исходного товара, если productId не указан
+	Barcode    *string             `json:"barcode,omitempty"`
+	Comment    *string             `json:"comment,omitempty"`
+	ProductId  *openapi_types.UUID `json:"productId,omitempty"`
+	PvzId      openapi_types.UUID  `json:"pvzId"`
+	ReasonCode string              `json:"reasonCode"`
+}
+
+// PutStoragePeriodsJSONBody defines parameters for PutStoragePeriods.
+type PutStoragePeriodsJSONBody struct {
+	Days int `json:"days"`
+
+	// ProductType Код типа товара из справочника типов
+	ProductType *ProductType        `json:"productType,omitempty"`
+	PvzId       *openapi_types.UUID `json:"pvzId,omitempty"`
+}
+
+// PostTransfersJSONBody defines parameters for PostTransfers.
+type PostTransfersJSONBody struct {
+	DestinationPvzId openapi_types.UUID `json:"destinationPvzId"`
+
+	// ExpectedAt Ожидаемый срок доставки; по умолчанию 72 часа с момента создания
+	ExpectedAt  *time.Time           `json:"expectedAt,omitempty"`
+	ProductIds  []openapi_types.UUID `json:"productIds"`
+	SourcePvzId openapi_types.UUID   `json:"sourcePvzId"`
+}
+
+// GetTransfersOverdueParams defines parameters for GetTransfersOverdue.
+type GetTransfersOverdueParams struct {
+	// PvzId ПВЗ отправления или назначения
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostTransfersTransferIdReceiveJSONBody defines parameters for PostTransfersTransferIdReceive.
+type PostTransfersTransferIdReceiveJSONBody struct {
+	ProductIds []openapi_types.UUID `json:"productIds"`
+}
+
+// GetUnclaimedParams defines parameters for GetUnclaimed.
+type GetUnclaimedParams struct {
+	PvzId *openapi_types.UUID `form:"pvzId,omitempty" json:"pvzId,omitempty"`
+
+	// Page Номер страницы
+	Page *int `form:"page,omitempty" json:"page,omitempty"`
+
+	// Limit Количество элементов на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PutApprovalsRulesOperationJSONRequestBody defines body for PutApprovalsRulesOperation for application/json ContentType.
+type PutApprovalsRulesOperationJSONRequestBody PutApprovalsRulesOperationJSONBody
+
+// PostApprovalsApprovalIdApproveJSONRequestBody defines body for PostApprovalsApprovalIdApprove for application/json ContentType.
+type PostApprovalsApprovalIdApproveJSONRequestBody = ApprovalDecision
+
+// PostApprovalsApprovalIdRejectJSONRequestBody defines body for PostApprovalsApprovalIdReject for application/json ContentType.
+type PostApprovalsApprovalIdRejectJSONRequestBody = ApprovalDecision
+
+// PostContainersJSONRequestBody defines body for PostContainers for application/json ContentType.
+type PostContainersJSONRequestBody PostContainersJSONBody
+
+// PostContainersContainerIdVerifyJSONRequestBody defines body for PostContainersContainerIdVerify for application/json ContentType.
+type PostContainersContainerIdVerifyJSONRequestBody PostContainersContainerIdVerifyJSONBody
+
 // PostDummyLoginJSONRequestBody defines body for PostDummyLogin for application/json ContentType.
 type PostDummyLoginJSONRequestBody PostDummyLoginJSONBody
 
+// PostInventorySessionsJSONRequestBody defines body for PostInventorySessions for application/json ContentType.
+type PostInventorySessionsJSONRequestBody PostInventorySessionsJSONBody
+
+// PostInventorySessionsSessionIdAdjustmentsJSONRequestBody defines body for PostInventorySessionsSessionIdAdjustments for application/json ContentType.
+type PostInventorySessionsSessionIdAdjustmentsJSONRequestBody PostInventorySessionsSessionIdAdjustmentsJSONBody
+
+// PostInventorySessionsSessionIdScansJSONRequestBody defines body for PostInventorySessionsSessionIdScans for application/json ContentType.
+type PostInventorySessionsSessionIdScansJSONRequestBody PostInventorySessionsSessionIdScansJSONBody
+
+// PostJobsJSONRequestBody defines body for PostJobs for application/json ContentType.
+type PostJobsJSONRequestBody PostJobsJSONBody
+
+// PostLabelsJSONRequestBody defines body for PostLabels for application/json ContentType.
+type PostLabelsJSONRequestBody PostLabelsJSONBody
+
 // PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
 type PostLoginJSONRequestBody PostLoginJSONBody
 
+// PostOrdersJSONRequestBody defines body for PostOrders for application/json ContentType.
+type PostOrdersJSONRequestBody PostOrdersJSONBody
+
+// PostOrdersOrderIdIssueJSONRequestBody defines body for PostOrdersOrderIdIssue for application/json ContentType.
+type PostOrdersOrderIdIssueJSONRequestBody PostOrdersOrderIdIssueJSONBody
+
+// PostProductTypesJSONRequestBody defines body for PostProductTypes for application/json ContentType.
+type PostProductTypesJSONRequestBody = ProductTypeInfo
+
+// PutProductTypesCodeJSONRequestBody defines body for PutProductTypesCode for application/json ContentType.
+type PutProductTypesCodeJSONRequestBody PutProductTypesCodeJSONBody
+
 // PostProductsJSONRequestBody defines body for PostProducts for application/json ContentType.
 type PostProductsJSONRequestBody PostProductsJSONBody
 
+// PostProductsBatchJSONRequestBody defines body for PostProductsBatch for application/json ContentType.
+type PostProductsBatchJSONRequestBody PostProductsBatchJSONBody
+
+// PatchProductsProductIdJSONRequestBody defines body for PatchProductsProductId for application/json ContentType.
+type PatchProductsProductIdJSONRequestBody PatchProductsProductIdJSONBody
+
+// PutProductsProductIdCellJSONRequestBody defines body for PutProductsProductIdCell for application/json ContentType.
+type PutProductsProductIdCellJSONRequestBody PutProductsProductIdCellJSONBody
+
+// PostProductsProductIdDamageJSONRequestBody defines body for PostProductsProductIdDamage for application/json ContentType.
+type PostProductsProductIdDamageJSONRequestBody PostProductsProductIdDamageJSONBody
+
 // PostPvzJSONRequestBody defines body for PostPvz for application/json ContentType.
 type PostPvzJSONRequestBody = PVZ
 
+// PostPvzPvzIdCellsJSONRequestBody defines body for PostPvzPvzIdCells for application/json ContentType.
+type PostPvzPvzIdCellsJSONRequestBody PostPvzPvzIdCellsJSONBody
+
+// PutPvzPvzIdIntakeCapacityJSONRequestBody defines body for PutPvzPvzIdIntakeCapacity for application/json ContentType.
+type PutPvzPvzIdIntakeCapacityJSONRequestBody PutPvzPvzIdIntakeCapacityJSONBody
+
+// PutPvzPvzIdLabelTemplateJSONRequestBody defines body for PutPvzPvzIdLabelTemplate for application/json ContentType.
+type PutPvzPvzIdLabelTemplateJSONRequestBody PutPvzPvzIdLabelTemplateJSONBody
+
+// PutPvzPvzIdLocationJSONRequestBody defines body for PutPvzPvzIdLocation for application/json ContentType.
+type PutPvzPvzIdLocationJSONRequestBody PutPvzPvzIdLocationJSONBody
+
 // PostReceptionsJSONRequestBody defines body for PostReceptions for application/json ContentType.
 type PostReceptionsJSONRequestBody PostReceptionsJSONBody
 
+// PostRegionsApplicationGeoPlusJSONRequestBody defines body for PostRegions for application/geo+json ContentType.
+type PostRegionsApplicationGeoPlusJSONRequestBody = RegionFeatureCollection
+
+// PostRegionsJSONRequestBody defines body for PostRegions for application/json ContentType.
+type PostRegionsJSONRequestBody = RegionFeatureCollection
+
 // PostRegisterJSONRequestBody defines body for PostRegister for application/json ContentType.
 type PostRegisterJSONRequestBody PostRegisterJSONBody
+
+// PostReportSubscriptionsJSONRequestBody defines body for PostReportSubscriptions for application/json ContentType.
+type PostReportSubscriptionsJSONRequestBody = ReportSubscriptionRequest
+
+// PutReportSubscriptionsSubscriptionIdJSONRequestBody defines body for PutReportSubscriptionsSubscriptionId for application/json ContentType.
+type PutReportSubscriptionsSubscriptionIdJSONRequestBody = ReportSubscriptionRequest
+
+// PostReturnReasonsJSONRequestBody defines body for PostReturnReasons for application/json ContentType.
+type PostReturnReasonsJSONRequestBody = ReturnReason
+
+// PostReturnShipmentsJSONRequestBody defines body for PostReturnShipments for application/json ContentType.
+type PostReturnShipmentsJSONRequestBody PostReturnShipmentsJSONBody
+
+// PostReturnShipmentsShipmentIdReturnsJSONRequestBody defines body for PostReturnShipmentsShipmentIdReturns for application/json ContentType.
+type PostReturnShipmentsShipmentIdReturnsJSONRequestBody PostReturnShipmentsShipmentIdReturnsJSONBody
+
+// PostReturnShipmentsShipmentIdStatusJSONRequestBody defines body for PostReturnShipmentsShipmentIdStatus for application/json ContentType.
+type PostReturnShipmentsShipmentIdStatusJSONRequestBody PostReturnShipmentsShipmentIdStatusJSONBody
+
+// PostReturnsJSONRequestBody defines body for PostReturns for application/json ContentType.
+type PostReturnsJSONRequestBody PostReturnsJSONBody
+
+// PutStoragePeriodsJSONRequestBody defines body for PutStoragePeriods for application/json ContentType.
+type PutStoragePeriodsJSONRequestBody PutStoragePeriodsJSONBody
+
+// PostTransfersJSONRequestBody defines body for PostTransfers for application/json ContentType.
+type PostTransfersJSONRequestBody PostTransfersJSONBody
+
+// PostTransfersTransferIdReceiveJSONRequestBody defines body for PostTransfersTransferIdReceive for application/json ContentType.
+type PostTransfersTransferIdReceiveJSONRequestBody PostTransfersTransferIdReceiveJSONBody
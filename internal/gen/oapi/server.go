@@ -14,33 +14,270 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Очередь операций, ожидающих подтверждения (только для модераторов)
+	// (GET /approvals)
+	GetApprovals(c *fiber.Ctx, params GetApprovalsParams) error
+	// Правила двойного контроля (только для модераторов)
+	// (GET /approvals/rules)
+	GetApprovalsRules(c *fiber.Ctx) error
+	// Включение или отключение двойного контроля для операции (только для модераторов)
+	// (PUT /approvals/rules/{operation})
+	PutApprovalsRulesOperation(c *fiber.Ctx, operation ApprovalOperation) error
+	// Подтверждение и применение операции (только для модераторов)
+	// (POST /approvals/{approvalId}/approve)
+	PostApprovalsApprovalIdApprove(c *fiber.Ctx, approvalId openapi_types.UUID) error
+	// Отклонение операции (только для модераторов)
+	// (POST /approvals/{approvalId}/reject)
+	PostApprovalsApprovalIdReject(c *fiber.Ctx, approvalId openapi_types.UUID) error
+	// Приемка контейнера или паллеты целиком по штрихкоду (только для сотрудников ПВЗ)
+	// (POST /containers)
+	PostContainers(c *fiber.Ctx) error
+	// Вскрытие контейнера для поштучной проверки (только для сотрудников ПВЗ)
+	// (POST /containers/{containerId}/open)
+	PostContainersContainerIdOpen(c *fiber.Ctx, containerId openapi_types.UUID) error
+	// Поштучная проверка содержимого вскрытого контейнера (только для сотрудников ПВЗ)
+	// (POST /containers/{containerId}/verify)
+	PostContainersContainerIdVerify(c *fiber.Ctx, containerId openapi_types.UUID) error
+	// Список повреждений по перевозчикам и ПВЗ (только для модераторов)
+	// (GET /damage/incidents)
+	GetDamageIncidents(c *fiber.Ctx, params GetDamageIncidentsParams) error
 	// Получение тестового токена
 	// (POST /dummyLogin)
 	PostDummyLogin(c *fiber.Ctx) error
+	// Выгрузка товаров из приемок, открытых в заданном диапазоне дат
+	// (GET /export/products)
+	GetExportProducts(c *fiber.Ctx, params GetExportProductsParams) error
+	// Выгрузка ПВЗ с приемками в заданном диапазоне дат
+	// (GET /export/pvz)
+	GetExportPvz(c *fiber.Ctx, params GetExportPvzParams) error
+	// Выгрузка приемок, открытых в заданном диапазоне дат
+	// (GET /export/receptions)
+	GetExportReceptions(c *fiber.Ctx, params GetExportReceptionsParams) error
+	// Начало пересчета товаров в ПВЗ (только для сотрудников ПВЗ)
+	// (POST /inventory-sessions)
+	PostInventorySessions(c *fiber.Ctx) error
+	// Пересчет с отчетом о расхождениях
+	// (GET /inventory-sessions/{sessionId})
+	GetInventorySessionsSessionId(c *fiber.Ctx, sessionId openapi_types.UUID) error
+	// Принятие корректировок по расхождениям пересчета (только для модераторов)
+	// (POST /inventory-sessions/{sessionId}/adjustments)
+	PostInventorySessionsSessionIdAdjustments(c *fiber.Ctx, sessionId openapi_types.UUID) error
+	// Завершение пересчета и построение отчета о расхождениях (только для сотрудников ПВЗ)
+	// (POST /inventory-sessions/{sessionId}/close)
+	PostInventorySessionsSessionIdClose(c *fiber.Ctx, sessionId openapi_types.UUID) error
+	// Сканирование товара при пересчете (только для сотрудников ПВЗ)
+	// (POST /inventory-sessions/{sessionId}/scans)
+	PostInventorySessionsSessionIdScans(c *fiber.Ctx, sessionId openapi_types.UUID) error
+	// Постановка выгрузки или отчета в очередь фоновых задач
+	// (POST /jobs)
+	PostJobs(c *fiber.Ctx) error
+	// Состояние фоновой задачи
+	// (GET /jobs/{jobId})
+	GetJobsJobId(c *fiber.Ctx, jobId openapi_types.UUID) error
+	// Отмена фоновой задачи
+	// (POST /jobs/{jobId}/cancel)
+	PostJobsJobIdCancel(c *fiber.Ctx, jobId openapi_types.UUID) error
+	// Скачивание результата фоновой задачи
+	// (GET /jobs/{jobId}/file)
+	GetJobsJobIdFile(c *fiber.Ctx, jobId openapi_types.UUID) error
+	// Пакетная печать этикеток
+	// (POST /labels)
+	PostLabels(c *fiber.Ctx) error
 	// Авторизация пользователя
 	// (POST /login)
 	PostLogin(c *fiber.Ctx) error
+	// Создание заказа из принятых товаров с выпуском кода получения (только для сотрудников ПВЗ)
+	// (POST /orders)
+	PostOrders(c *fiber.Ctx) error
+	// Получение заказа
+	// (GET /orders/{orderId})
+	GetOrdersOrderId(c *fiber.Ctx, orderId openapi_types.UUID) error
+	// Выдача заказа клиенту по коду получения, в том числе частичная (только для сотрудников ПВЗ)
+	// (POST /orders/{orderId}/issue)
+	PostOrdersOrderIdIssue(c *fiber.Ctx, orderId openapi_types.UUID) error
+	// Перевыпуск кода получения с отправкой покупателю (для сотрудников ПВЗ и модераторов)
+	// (POST /orders/{orderId}/pickup-code)
+	PostOrdersOrderIdPickupCode(c *fiber.Ctx, orderId openapi_types.UUID) error
+	// Справочник типов товаров
+	// (GET /product-types)
+	GetProductTypes(c *fiber.Ctx, params GetProductTypesParams) error
+	// Добавление типа товара (только для модераторов)
+	// (POST /product-types)
+	PostProductTypes(c *fiber.Ctx) error
+	// Изменение типа товара (только для модераторов)
+	// (PUT /product-types/{code})
+	PutProductTypesCode(c *fiber.Ctx, code string) error
 	// Добавление товара в текущую приемку (только для сотрудников ПВЗ)
 	// (POST /products)
 	PostProducts(c *fiber.Ctx) error
+	// Пакетное добавление товаров в текущую приемку (только для сотрудников ПВЗ)
+	// (POST /products/batch)
+	PostProductsBatch(c *fiber.Ctx) error
+	// Поиск товара по штрихкоду
+	// (GET /products/by-barcode/{code})
+	GetProductsByBarcodeCode(c *fiber.Ctx, code string, params GetProductsByBarcodeCodeParams) error
+	// Поиск ячеек товаров по штрихкоду или заказу
+	// (GET /products/locations)
+	GetProductsLocations(c *fiber.Ctx, params GetProductsLocationsParams) error
+	// Удаление товара из открытой приемки в корзину (только для сотрудников ПВЗ)
+	// (DELETE /products/{productId})
+	DeleteProductsProductId(c *fiber.Ctx, productId openapi_types.UUID) error
+	// Исправление товара в открытой приемке (только для сотрудников ПВЗ)
+	// (PATCH /products/{productId})
+	PatchProductsProductId(c *fiber.Ctx, productId openapi_types.UUID) error
+	// Размещение товара в ячейке или перемещение между ячейками (только для сотрудников ПВЗ)
+	// (PUT /products/{productId}/cell)
+	PutProductsProductIdCell(c *fiber.Ctx, productId openapi_types.UUID) error
+	// Отметка товара как поврежденного (только для сотрудников ПВЗ)
+	// (POST /products/{productId}/damage)
+	PostProductsProductIdDamage(c *fiber.Ctx, productId openapi_types.UUID) error
+	// Этикетка товара со штрихкодом
+	// (GET /products/{productId}/label)
+	GetProductsProductIdLabel(c *fiber.Ctx, productId openapi_types.UUID, params GetProductsProductIdLabelParams) error
+	// Восстановление товара из корзины, пока приемка открыта (только для сотрудников ПВЗ)
+	// (POST /products/{productId}/restore)
+	PostProductsProductIdRestore(c *fiber.Ctx, productId openapi_types.UUID) error
 	// Получение списка ПВЗ с фильтрацией по дате приемки и пагинацией
 	// (GET /pvz)
 	GetPvz(c *fiber.Ctx, params GetPvzParams) error
 	// Создание ПВЗ (только для модераторов)
 	// (POST /pvz)
 	PostPvz(c *fiber.Ctx) error
+	// Расположение ПВЗ в формате GeoJSON
+	// (GET /pvz.geojson)
+	GetPvzGeojson(c *fiber.Ctx, params GetPvzGeojsonParams) error
+	// Ячейки хранения ПВЗ с заполненностью
+	// (GET /pvz/{pvzId}/cells)
+	GetPvzPvzIdCells(c *fiber.Ctx, pvzId openapi_types.UUID) error
+	// Добавление или изменение ячеек хранения ПВЗ (только для модераторов)
+	// (POST /pvz/{pvzId}/cells)
+	PostPvzPvzIdCells(c *fiber.Ctx, pvzId openapi_types.UUID) error
 	// Закрытие последней открытой приемки товаров в рамках ПВЗ
 	// (POST /pvz/{pvzId}/close_last_reception)
 	PostPvzPvzIdCloseLastReception(c *fiber.Ctx, pvzId openapi_types.UUID) error
 	// Удаление последнего добавленного товара из текущей приемки (LIFO, только для сотрудников ПВЗ)
 	// (POST /pvz/{pvzId}/delete_last_product)
 	PostPvzPvzIdDeleteLastProduct(c *fiber.Ctx, pvzId openapi_types.UUID) error
+	// Прогноз объема приемки ПВЗ по дням и часам (только для модераторов)
+	// (GET /pvz/{pvzId}/forecast)
+	GetPvzPvzIdForecast(c *fiber.Ctx, pvzId openapi_types.UUID, params GetPvzPvzIdForecastParams) error
+	// Указание дневной пропускной способности приемки ПВЗ (только для модераторов)
+	// (PUT /pvz/{pvzId}/intake-capacity)
+	PutPvzPvzIdIntakeCapacity(c *fiber.Ctx, pvzId openapi_types.UUID) error
+	// Шаблон этикеток ПВЗ
+	// (GET /pvz/{pvzId}/label-template)
+	GetPvzPvzIdLabelTemplate(c *fiber.Ctx, pvzId openapi_types.UUID) error
+	// Настройка шаблона этикеток ПВЗ (только для модераторов)
+	// (PUT /pvz/{pvzId}/label-template)
+	PutPvzPvzIdLabelTemplate(c *fiber.Ctx, pvzId openapi_types.UUID) error
+	// Указание координат ПВЗ (только для модераторов)
+	// (PUT /pvz/{pvzId}/location)
+	PutPvzPvzIdLocation(c *fiber.Ctx, pvzId openapi_types.UUID) error
 	// Создание новой приемки товаров (только для сотрудников ПВЗ)
 	// (POST /receptions)
 	PostReceptions(c *fiber.Ctx) error
+	// Поиск приемок по номеру накладной во всех ПВЗ
+	// (GET /receptions/search)
+	GetReceptionsSearch(c *fiber.Ctx, params GetReceptionsSearchParams) error
+	// Контейнеры приемки и их состояние
+	// (GET /receptions/{receptionId}/containers)
+	GetReceptionsReceptionIdContainers(c *fiber.Ctx, receptionId openapi_types.UUID) error
+	// Отчет о повреждениях в рамках приемки
+	// (GET /receptions/{receptionId}/damage)
+	GetReceptionsReceptionIdDamage(c *fiber.Ctx, receptionId openapi_types.UUID) error
+	// Корзина удаленных товаров приемки
+	// (GET /receptions/{receptionId}/deleted-products)
+	GetReceptionsReceptionIdDeletedProducts(c *fiber.Ctx, receptionId openapi_types.UUID) error
+	// Получение итогов закрытой приемки
+	// (GET /receptions/{receptionId}/summary)
+	GetReceptionsReceptionIdSummary(c *fiber.Ctx, receptionId openapi_types.UUID) error
+	// Границы регионов в формате GeoJSON
+	// (GET /regions)
+	GetRegions(c *fiber.Ctx) error
+	// Загрузка границ регионов в формате GeoJSON (только для модераторов)
+	// (POST /regions)
+	PostRegions(c *fiber.Ctx) error
+	// Удаление региона (только для модераторов)
+	// (DELETE /regions/{regionId})
+	DeleteRegionsRegionId(c *fiber.Ctx, regionId openapi_types.UUID) error
 	// Регистрация пользователя
 	// (POST /register)
 	PostRegister(c *fiber.Ctx) error
+	// Список подписок на рассылку отчетов (только для модераторов)
+	// (GET /report-subscriptions)
+	GetReportSubscriptions(c *fiber.Ctx) error
+	// Создание подписки на рассылку отчета (только для модераторов)
+	// (POST /report-subscriptions)
+	PostReportSubscriptions(c *fiber.Ctx) error
+	// Удаление подписки вместе с историей доставок (только для модераторов)
+	// (DELETE /report-subscriptions/{subscriptionId})
+	DeleteReportSubscriptionsSubscriptionId(c *fiber.Ctx, subscriptionId openapi_types.UUID) error
+	// Подписка на рассылку отчета (только для модераторов)
+	// (GET /report-subscriptions/{subscriptionId})
+	GetReportSubscriptionsSubscriptionId(c *fiber.Ctx, subscriptionId openapi_types.UUID) error
+	// Изменение подписки на рассылку отчета (только для модераторов)
+	// (PUT /report-subscriptions/{subscriptionId})
+	PutReportSubscriptionsSubscriptionId(c *fiber.Ctx, subscriptionId openapi_types.UUID) error
+	// История доставок отчета, сначала новые (только для модераторов)
+	// (GET /report-subscriptions/{subscriptionId}/deliveries)
+	GetReportSubscriptionsSubscriptionIdDeliveries(c *fiber.Ctx, subscriptionId openapi_types.UUID, params GetReportSubscriptionsSubscriptionIdDeliveriesParams) error
+	// Производительность сотрудников (только для модераторов)
+	// (GET /reports/employees)
+	GetReportsEmployees(c *fiber.Ctx, params GetReportsEmployeesParams) error
+	// Аналитика приемки товаров (только для модераторов)
+	// (GET /reports/intake)
+	GetReportsIntake(c *fiber.Ctx, params GetReportsIntakeParams) error
+	// Длительность приемок и нарушения SLA по ПВЗ и городам (только для модераторов)
+	// (GET /reports/reception-sla)
+	GetReportsReceptionSla(c *fiber.Ctx, params GetReportsReceptionSlaParams) error
+	// Справочник причин возврата
+	// (GET /return-reasons)
+	GetReturnReasons(c *fiber.Ctx, params GetReturnReasonsParams) error
+	// Добавление причины возврата (только для модераторов)
+	// (POST /return-reasons)
+	PostReturnReasons(c *fiber.Ctx) error
+	// Список отправок возвратов
+	// (GET /return-shipments)
+	GetReturnShipments(c *fiber.Ctx, params GetReturnShipmentsParams) error
+	// Создание отправки возвратов продавцу (только для сотрудников ПВЗ)
+	// (POST /return-shipments)
+	PostReturnShipments(c *fiber.Ctx) error
+	// Получение отправки возвратов вместе с возвратами
+	// (GET /return-shipments/{shipmentId})
+	GetReturnShipmentsShipmentId(c *fiber.Ctx, shipmentId openapi_types.UUID) error
+	// Добавление возвратов в открытую отправку (только для сотрудников ПВЗ)
+	// (POST /return-shipments/{shipmentId}/returns)
+	PostReturnShipmentsShipmentIdReturns(c *fiber.Ctx, shipmentId openapi_types.UUID) error
+	// Смена статуса отправки возвратов (только для сотрудников ПВЗ)
+	// (POST /return-shipments/{shipmentId}/status)
+	PostReturnShipmentsShipmentIdStatus(c *fiber.Ctx, shipmentId openapi_types.UUID) error
+	// Список возвратов
+	// (GET /returns)
+	GetReturns(c *fiber.Ctx, params GetReturnsParams) error
+	// Приём возврата от клиента (только для сотрудников ПВЗ)
+	// (POST /returns)
+	PostReturns(c *fiber.Ctx) error
+	// Настроенные сроки хранения
+	// (GET /storage-periods)
+	GetStoragePeriods(c *fiber.Ctx) error
+	// Установка срока хранения для ПВЗ, типа товара или их сочетания (только для модераторов)
+	// (PUT /storage-periods)
+	PutStoragePeriods(c *fiber.Ctx) error
+	// Перемещение товаров в другой ПВЗ (только для сотрудников ПВЗ)
+	// (POST /transfers)
+	PostTransfers(c *fiber.Ctx) error
+	// Список просроченных перемещений
+	// (GET /transfers/overdue)
+	GetTransfersOverdue(c *fiber.Ctx, params GetTransfersOverdueParams) error
+	// Получение перемещения
+	// (GET /transfers/{transferId})
+	GetTransfersTransferId(c *fiber.Ctx, transferId openapi_types.UUID) error
+	// Подтверждение получения товаров в ПВЗ назначения (только для сотрудников ПВЗ)
+	// (POST /transfers/{transferId}/receive)
+	PostTransfersTransferIdReceive(c *fiber.Ctx, transferId openapi_types.UUID) error
+	// Невостребованные товары, которые нужно снять с полок и вернуть отправителю
+	// (GET /unclaimed)
+	GetUnclaimed(c *fiber.Ctx, params GetUnclaimedParams) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -50,35 +287,1772 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
+// GetApprovals operation middleware
+func (siw *ServerInterfaceWrapper) GetApprovals(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApprovalsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter status: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetApprovals(c, params)
+}
+
+// GetApprovalsRules operation middleware
+func (siw *ServerInterfaceWrapper) GetApprovalsRules(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetApprovalsRules(c)
+}
+
+// PutApprovalsRulesOperation operation middleware
+func (siw *ServerInterfaceWrapper) PutApprovalsRulesOperation(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "operation" -------------
+	var operation ApprovalOperation
+
+	err = runtime.BindStyledParameterWithOptions("simple", "operation", c.Params("operation"), &operation, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter operation: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutApprovalsRulesOperation(c, operation)
+}
+
+// PostApprovalsApprovalIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostApprovalsApprovalIdApprove(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "approvalId" -------------
+	var approvalId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "approvalId", c.Params("approvalId"), &approvalId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter approvalId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostApprovalsApprovalIdApprove(c, approvalId)
+}
+
+// PostApprovalsApprovalIdReject operation middleware
+func (siw *ServerInterfaceWrapper) PostApprovalsApprovalIdReject(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "approvalId" -------------
+	var approvalId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "approvalId", c.Params("approvalId"), &approvalId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter approvalId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostApprovalsApprovalIdReject(c, approvalId)
+}
+
+// PostContainers operation middleware
+func (siw *ServerInterfaceWrapper) PostContainers(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostContainers(c)
+}
+
+// PostContainersContainerIdOpen operation middleware
+func (siw *ServerInterfaceWrapper) PostContainersContainerIdOpen(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "containerId" -------------
+	var containerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "containerId", c.Params("containerId"), &containerId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter containerId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostContainersContainerIdOpen(c, containerId)
+}
+
+// PostContainersContainerIdVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostContainersContainerIdVerify(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "containerId" -------------
+	var containerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "containerId", c.Params("containerId"), &containerId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter containerId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostContainersContainerIdVerify(c, containerId)
+}
+
+// GetDamageIncidents operation middleware
+func (siw *ServerInterfaceWrapper) GetDamageIncidents(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDamageIncidentsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter startDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter endDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "carrier" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "carrier", query, &params.Carrier)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter carrier: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetDamageIncidents(c, params)
+}
+
 // PostDummyLogin operation middleware
 func (siw *ServerInterfaceWrapper) PostDummyLogin(c *fiber.Ctx) error {
 
-	return siw.Handler.PostDummyLogin(c)
+	return siw.Handler.PostDummyLogin(c)
+}
+
+// GetExportProducts operation middleware
+func (siw *ServerInterfaceWrapper) GetExportProducts(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExportProductsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter startDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter endDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "productType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "productType", query, &params.ProductType)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productType: %w", err).Error())
+	}
+
+	return siw.Handler.GetExportProducts(c, params)
+}
+
+// GetExportPvz operation middleware
+func (siw *ServerInterfaceWrapper) GetExportPvz(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExportPvzParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter startDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter endDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "regionId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "regionId", query, &params.RegionId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter regionId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	return siw.Handler.GetExportPvz(c, params)
+}
+
+// GetExportReceptions operation middleware
+func (siw *ServerInterfaceWrapper) GetExportReceptions(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExportReceptionsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter startDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter endDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	return siw.Handler.GetExportReceptions(c, params)
+}
+
+// PostInventorySessions operation middleware
+func (siw *ServerInterfaceWrapper) PostInventorySessions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostInventorySessions(c)
+}
+
+// GetInventorySessionsSessionId operation middleware
+func (siw *ServerInterfaceWrapper) GetInventorySessionsSessionId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Params("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sessionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetInventorySessionsSessionId(c, sessionId)
+}
+
+// PostInventorySessionsSessionIdAdjustments operation middleware
+func (siw *ServerInterfaceWrapper) PostInventorySessionsSessionIdAdjustments(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Params("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sessionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostInventorySessionsSessionIdAdjustments(c, sessionId)
+}
+
+// PostInventorySessionsSessionIdClose operation middleware
+func (siw *ServerInterfaceWrapper) PostInventorySessionsSessionIdClose(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Params("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sessionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostInventorySessionsSessionIdClose(c, sessionId)
+}
+
+// PostInventorySessionsSessionIdScans operation middleware
+func (siw *ServerInterfaceWrapper) PostInventorySessionsSessionIdScans(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", c.Params("sessionId"), &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sessionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostInventorySessionsSessionIdScans(c, sessionId)
+}
+
+// PostJobs operation middleware
+func (siw *ServerInterfaceWrapper) PostJobs(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostJobs(c)
+}
+
+// GetJobsJobId operation middleware
+func (siw *ServerInterfaceWrapper) GetJobsJobId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Params("jobId"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter jobId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetJobsJobId(c, jobId)
+}
+
+// PostJobsJobIdCancel operation middleware
+func (siw *ServerInterfaceWrapper) PostJobsJobIdCancel(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Params("jobId"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter jobId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostJobsJobIdCancel(c, jobId)
+}
+
+// GetJobsJobIdFile operation middleware
+func (siw *ServerInterfaceWrapper) GetJobsJobIdFile(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", c.Params("jobId"), &jobId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter jobId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetJobsJobIdFile(c, jobId)
+}
+
+// PostLabels operation middleware
+func (siw *ServerInterfaceWrapper) PostLabels(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostLabels(c)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(c *fiber.Ctx) error {
+
+	return siw.Handler.PostLogin(c)
+}
+
+// PostOrders operation middleware
+func (siw *ServerInterfaceWrapper) PostOrders(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostOrders(c)
+}
+
+// GetOrdersOrderId operation middleware
+func (siw *ServerInterfaceWrapper) GetOrdersOrderId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", c.Params("orderId"), &orderId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter orderId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetOrdersOrderId(c, orderId)
+}
+
+// PostOrdersOrderIdIssue operation middleware
+func (siw *ServerInterfaceWrapper) PostOrdersOrderIdIssue(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", c.Params("orderId"), &orderId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter orderId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostOrdersOrderIdIssue(c, orderId)
+}
+
+// PostOrdersOrderIdPickupCode operation middleware
+func (siw *ServerInterfaceWrapper) PostOrdersOrderIdPickupCode(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", c.Params("orderId"), &orderId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter orderId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostOrdersOrderIdPickupCode(c, orderId)
+}
+
+// GetProductTypes operation middleware
+func (siw *ServerInterfaceWrapper) GetProductTypes(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductTypesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "includeInactive" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeInactive", query, &params.IncludeInactive)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter includeInactive: %w", err).Error())
+	}
+
+	return siw.Handler.GetProductTypes(c, params)
+}
+
+// PostProductTypes operation middleware
+func (siw *ServerInterfaceWrapper) PostProductTypes(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostProductTypes(c)
+}
+
+// PutProductTypesCode operation middleware
+func (siw *ServerInterfaceWrapper) PutProductTypesCode(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Params("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter code: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutProductTypesCode(c, code)
+}
+
+// PostProducts operation middleware
+func (siw *ServerInterfaceWrapper) PostProducts(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostProducts(c)
+}
+
+// PostProductsBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostProductsBatch(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostProductsBatch(c)
+}
+
+// GetProductsByBarcodeCode operation middleware
+func (siw *ServerInterfaceWrapper) GetProductsByBarcodeCode(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Params("code"), &code, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter code: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductsByBarcodeCodeParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	return siw.Handler.GetProductsByBarcodeCode(c, code, params)
+}
+
+// GetProductsLocations operation middleware
+func (siw *ServerInterfaceWrapper) GetProductsLocations(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductsLocationsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "barcode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "barcode", query, &params.Barcode)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter barcode: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "orderId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "orderId", query, &params.OrderId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter orderId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	return siw.Handler.GetProductsLocations(c, params)
+}
+
+// DeleteProductsProductId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProductsProductId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Params("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteProductsProductId(c, productId)
+}
+
+// PatchProductsProductId operation middleware
+func (siw *ServerInterfaceWrapper) PatchProductsProductId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Params("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PatchProductsProductId(c, productId)
+}
+
+// PutProductsProductIdCell operation middleware
+func (siw *ServerInterfaceWrapper) PutProductsProductIdCell(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Params("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutProductsProductIdCell(c, productId)
+}
+
+// PostProductsProductIdDamage operation middleware
+func (siw *ServerInterfaceWrapper) PostProductsProductIdDamage(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Params("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostProductsProductIdDamage(c, productId)
+}
+
+// GetProductsProductIdLabel operation middleware
+func (siw *ServerInterfaceWrapper) GetProductsProductIdLabel(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Params("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProductsProductIdLabelParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	return siw.Handler.GetProductsProductIdLabel(c, productId, params)
+}
+
+// PostProductsProductIdRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostProductsProductIdRestore(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "productId" -------------
+	var productId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "productId", c.Params("productId"), &productId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostProductsProductIdRestore(c, productId)
+}
+
+// GetPvz operation middleware
+func (siw *ServerInterfaceWrapper) GetPvz(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter startDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter endDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "regionId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "regionId", query, &params.RegionId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter regionId: %w", err).Error())
+	}
+
+	return siw.Handler.GetPvz(c, params)
+}
+
+// PostPvz operation middleware
+func (siw *ServerInterfaceWrapper) PostPvz(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostPvz(c)
+}
+
+// GetPvzGeojson operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzGeojson(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzGeojsonParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "city" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "city", query, &params.City)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter city: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "regionId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "regionId", query, &params.RegionId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter regionId: %w", err).Error())
+	}
+
+	return siw.Handler.GetPvzGeojson(c, params)
+}
+
+// GetPvzPvzIdCells operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzIdCells(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetPvzPvzIdCells(c, pvzId)
+}
+
+// PostPvzPvzIdCells operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdCells(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostPvzPvzIdCells(c, pvzId)
+}
+
+// PostPvzPvzIdCloseLastReception operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdCloseLastReception(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostPvzPvzIdCloseLastReception(c, pvzId)
+}
+
+// PostPvzPvzIdDeleteLastProduct operation middleware
+func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeleteLastProduct(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostPvzPvzIdDeleteLastProduct(c, pvzId)
+}
+
+// GetPvzPvzIdForecast operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzIdForecast(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPvzPvzIdForecastParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", query, &params.Days)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter days: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "historyDays" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "historyDays", query, &params.HistoryDays)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter historyDays: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "timezone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timezone", query, &params.Timezone)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter timezone: %w", err).Error())
+	}
+
+	return siw.Handler.GetPvzPvzIdForecast(c, pvzId, params)
+}
+
+// PutPvzPvzIdIntakeCapacity operation middleware
+func (siw *ServerInterfaceWrapper) PutPvzPvzIdIntakeCapacity(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutPvzPvzIdIntakeCapacity(c, pvzId)
+}
+
+// GetPvzPvzIdLabelTemplate operation middleware
+func (siw *ServerInterfaceWrapper) GetPvzPvzIdLabelTemplate(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetPvzPvzIdLabelTemplate(c, pvzId)
+}
+
+// PutPvzPvzIdLabelTemplate operation middleware
+func (siw *ServerInterfaceWrapper) PutPvzPvzIdLabelTemplate(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutPvzPvzIdLabelTemplate(c, pvzId)
+}
+
+// PutPvzPvzIdLocation operation middleware
+func (siw *ServerInterfaceWrapper) PutPvzPvzIdLocation(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "pvzId" -------------
+	var pvzId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutPvzPvzIdLocation(c, pvzId)
+}
+
+// PostReceptions operation middleware
+func (siw *ServerInterfaceWrapper) PostReceptions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostReceptions(c)
+}
+
+// GetReceptionsSearch operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsSearch(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReceptionsSearchParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "waybillNumber" -------------
+
+	if paramValue := c.Query("waybillNumber"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument waybillNumber is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "waybillNumber", query, &params.WaybillNumber)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter waybillNumber: %w", err).Error())
+	}
+
+	return siw.Handler.GetReceptionsSearch(c, params)
+}
+
+// GetReceptionsReceptionIdContainers operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsReceptionIdContainers(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Params("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter receptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetReceptionsReceptionIdContainers(c, receptionId)
+}
+
+// GetReceptionsReceptionIdDamage operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsReceptionIdDamage(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Params("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter receptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetReceptionsReceptionIdDamage(c, receptionId)
+}
+
+// GetReceptionsReceptionIdDeletedProducts operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsReceptionIdDeletedProducts(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Params("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter receptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetReceptionsReceptionIdDeletedProducts(c, receptionId)
+}
+
+// GetReceptionsReceptionIdSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetReceptionsReceptionIdSummary(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "receptionId" -------------
+	var receptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "receptionId", c.Params("receptionId"), &receptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter receptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetReceptionsReceptionIdSummary(c, receptionId)
+}
+
+// GetRegions operation middleware
+func (siw *ServerInterfaceWrapper) GetRegions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetRegions(c)
+}
+
+// PostRegions operation middleware
+func (siw *ServerInterfaceWrapper) PostRegions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostRegions(c)
+}
+
+// DeleteRegionsRegionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRegionsRegionId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "regionId" -------------
+	var regionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "regionId", c.Params("regionId"), &regionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter regionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteRegionsRegionId(c, regionId)
+}
+
+// PostRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostRegister(c *fiber.Ctx) error {
+
+	return siw.Handler.PostRegister(c)
+}
+
+// GetReportSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) GetReportSubscriptions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetReportSubscriptions(c)
+}
+
+// PostReportSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) PostReportSubscriptions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostReportSubscriptions(c)
+}
+
+// DeleteReportSubscriptionsSubscriptionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReportSubscriptionsSubscriptionId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", c.Params("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscriptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.DeleteReportSubscriptionsSubscriptionId(c, subscriptionId)
+}
+
+// GetReportSubscriptionsSubscriptionId operation middleware
+func (siw *ServerInterfaceWrapper) GetReportSubscriptionsSubscriptionId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", c.Params("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscriptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetReportSubscriptionsSubscriptionId(c, subscriptionId)
+}
+
+// PutReportSubscriptionsSubscriptionId operation middleware
+func (siw *ServerInterfaceWrapper) PutReportSubscriptionsSubscriptionId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", c.Params("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscriptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutReportSubscriptionsSubscriptionId(c, subscriptionId)
+}
+
+// GetReportSubscriptionsSubscriptionIdDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetReportSubscriptionsSubscriptionIdDeliveries(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "subscriptionId" -------------
+	var subscriptionId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionId", c.Params("subscriptionId"), &subscriptionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscriptionId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportSubscriptionsSubscriptionIdDeliveriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetReportSubscriptionsSubscriptionIdDeliveries(c, subscriptionId, params)
+}
+
+// GetReportsEmployees operation middleware
+func (siw *ServerInterfaceWrapper) GetReportsEmployees(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportsEmployeesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := c.Query("from"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument from is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := c.Query("to"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument to is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	return siw.Handler.GetReportsEmployees(c, params)
+}
+
+// GetReportsIntake operation middleware
+func (siw *ServerInterfaceWrapper) GetReportsIntake(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportsIntakeParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := c.Query("from"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument from is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := c.Query("to"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument to is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "granularity" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "granularity", query, &params.Granularity)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter granularity: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "groupBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "groupBy", query, &params.GroupBy)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter groupBy: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "city" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "city", query, &params.City)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter city: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "productType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "productType", query, &params.ProductType)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter productType: %w", err).Error())
+	}
+
+	return siw.Handler.GetReportsIntake(c, params)
+}
+
+// GetReportsReceptionSla operation middleware
+func (siw *ServerInterfaceWrapper) GetReportsReceptionSla(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReportsReceptionSlaParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := c.Query("from"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument from is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := c.Query("to"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument to is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "city" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "city", query, &params.City)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter city: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "slaMinutes" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "slaMinutes", query, &params.SlaMinutes)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter slaMinutes: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "timezone" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timezone", query, &params.Timezone)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter timezone: %w", err).Error())
+	}
+
+	return siw.Handler.GetReportsReceptionSla(c, params)
+}
+
+// GetReturnReasons operation middleware
+func (siw *ServerInterfaceWrapper) GetReturnReasons(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReturnReasonsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "includeInactive" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeInactive", query, &params.IncludeInactive)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter includeInactive: %w", err).Error())
+	}
+
+	return siw.Handler.GetReturnReasons(c, params)
+}
+
+// PostReturnReasons operation middleware
+func (siw *ServerInterfaceWrapper) PostReturnReasons(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostReturnReasons(c)
+}
+
+// GetReturnShipments operation middleware
+func (siw *ServerInterfaceWrapper) GetReturnShipments(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReturnShipmentsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter status: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetReturnShipments(c, params)
 }
 
-// PostLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostLogin(c *fiber.Ctx) error {
+// PostReturnShipments operation middleware
+func (siw *ServerInterfaceWrapper) PostReturnShipments(c *fiber.Ctx) error {
 
-	return siw.Handler.PostLogin(c)
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostReturnShipments(c)
 }
 
-// PostProducts operation middleware
-func (siw *ServerInterfaceWrapper) PostProducts(c *fiber.Ctx) error {
+// GetReturnShipmentsShipmentId operation middleware
+func (siw *ServerInterfaceWrapper) GetReturnShipmentsShipmentId(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "shipmentId" -------------
+	var shipmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shipmentId", c.Params("shipmentId"), &shipmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter shipmentId: %w", err).Error())
+	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return siw.Handler.PostProducts(c)
+	return siw.Handler.GetReturnShipmentsShipmentId(c, shipmentId)
 }
 
-// GetPvz operation middleware
-func (siw *ServerInterfaceWrapper) GetPvz(c *fiber.Ctx) error {
+// PostReturnShipmentsShipmentIdReturns operation middleware
+func (siw *ServerInterfaceWrapper) PostReturnShipmentsShipmentIdReturns(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "shipmentId" -------------
+	var shipmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shipmentId", c.Params("shipmentId"), &shipmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter shipmentId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostReturnShipmentsShipmentIdReturns(c, shipmentId)
+}
+
+// PostReturnShipmentsShipmentIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PostReturnShipmentsShipmentIdStatus(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "shipmentId" -------------
+	var shipmentId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "shipmentId", c.Params("shipmentId"), &shipmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter shipmentId: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostReturnShipmentsShipmentIdStatus(c, shipmentId)
+}
+
+// GetReturns operation middleware
+func (siw *ServerInterfaceWrapper) GetReturns(c *fiber.Ctx) error {
 
 	var err error
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPvzParams
+	var params GetReturnsParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -86,18 +2060,18 @@ func (siw *ServerInterfaceWrapper) GetPvz(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "startDate" -------------
+	// ------------- Optional query parameter "pvzId" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "startDate", query, &params.StartDate)
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter startDate: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "endDate" -------------
+	// ------------- Optional query parameter "status" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "endDate", query, &params.EndDate)
+	err = runtime.BindQueryParameter("form", true, false, "status", query, &params.Status)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter endDate: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter status: %w", err).Error())
 	}
 
 	// ------------- Optional query parameter "page" -------------
@@ -114,65 +2088,155 @@ func (siw *ServerInterfaceWrapper) GetPvz(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
 	}
 
-	return siw.Handler.GetPvz(c, params)
+	return siw.Handler.GetReturns(c, params)
 }
 
-// PostPvz operation middleware
-func (siw *ServerInterfaceWrapper) PostPvz(c *fiber.Ctx) error {
+// PostReturns operation middleware
+func (siw *ServerInterfaceWrapper) PostReturns(c *fiber.Ctx) error {
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return siw.Handler.PostPvz(c)
+	return siw.Handler.PostReturns(c)
 }
 
-// PostPvzPvzIdCloseLastReception operation middleware
-func (siw *ServerInterfaceWrapper) PostPvzPvzIdCloseLastReception(c *fiber.Ctx) error {
+// GetStoragePeriods operation middleware
+func (siw *ServerInterfaceWrapper) GetStoragePeriods(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.GetStoragePeriods(c)
+}
+
+// PutStoragePeriods operation middleware
+func (siw *ServerInterfaceWrapper) PutStoragePeriods(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PutStoragePeriods(c)
+}
+
+// PostTransfers operation middleware
+func (siw *ServerInterfaceWrapper) PostTransfers(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	return siw.Handler.PostTransfers(c)
+}
+
+// GetTransfersOverdue operation middleware
+func (siw *ServerInterfaceWrapper) GetTransfersOverdue(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "pvzId" -------------
-	var pvzId openapi_types.UUID
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTransfersOverdueParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "page" -------------
 
-	return siw.Handler.PostPvzPvzIdCloseLastReception(c, pvzId)
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetTransfersOverdue(c, params)
 }
 
-// PostPvzPvzIdDeleteLastProduct operation middleware
-func (siw *ServerInterfaceWrapper) PostPvzPvzIdDeleteLastProduct(c *fiber.Ctx) error {
+// GetTransfersTransferId operation middleware
+func (siw *ServerInterfaceWrapper) GetTransfersTransferId(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "pvzId" -------------
-	var pvzId openapi_types.UUID
+	// ------------- Path parameter "transferId" -------------
+	var transferId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "pvzId", c.Params("pvzId"), &pvzId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "transferId", c.Params("transferId"), &transferId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter transferId: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return siw.Handler.PostPvzPvzIdDeleteLastProduct(c, pvzId)
+	return siw.Handler.GetTransfersTransferId(c, transferId)
 }
 
-// PostReceptions operation middleware
-func (siw *ServerInterfaceWrapper) PostReceptions(c *fiber.Ctx) error {
+// PostTransfersTransferIdReceive operation middleware
+func (siw *ServerInterfaceWrapper) PostTransfersTransferIdReceive(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "transferId" -------------
+	var transferId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transferId", c.Params("transferId"), &transferId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter transferId: %w", err).Error())
+	}
 
 	c.Context().SetUserValue(BearerAuthScopes, []string{})
 
-	return siw.Handler.PostReceptions(c)
+	return siw.Handler.PostTransfersTransferIdReceive(c, transferId)
 }
 
-// PostRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostRegister(c *fiber.Ctx) error {
+// GetUnclaimed operation middleware
+func (siw *ServerInterfaceWrapper) GetUnclaimed(c *fiber.Ctx) error {
 
-	return siw.Handler.PostRegister(c)
+	var err error
+
+	c.Context().SetUserValue(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUnclaimedParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "pvzId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pvzId", query, &params.PvzId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pvzId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", query, &params.Page)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetUnclaimed(c, params)
 }
 
 // FiberServerOptions provides options for the Fiber server.
@@ -196,22 +2260,180 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(fiber.Handler(m))
 	}
 
+	router.Get(options.BaseURL+"/approvals", wrapper.GetApprovals)
+
+	router.Get(options.BaseURL+"/approvals/rules", wrapper.GetApprovalsRules)
+
+	router.Put(options.BaseURL+"/approvals/rules/:operation", wrapper.PutApprovalsRulesOperation)
+
+	router.Post(options.BaseURL+"/approvals/:approvalId/approve", wrapper.PostApprovalsApprovalIdApprove)
+
+	router.Post(options.BaseURL+"/approvals/:approvalId/reject", wrapper.PostApprovalsApprovalIdReject)
+
+	router.Post(options.BaseURL+"/containers", wrapper.PostContainers)
+
+	router.Post(options.BaseURL+"/containers/:containerId/open", wrapper.PostContainersContainerIdOpen)
+
+	router.Post(options.BaseURL+"/containers/:containerId/verify", wrapper.PostContainersContainerIdVerify)
+
+	router.Get(options.BaseURL+"/damage/incidents", wrapper.GetDamageIncidents)
+
 	router.Post(options.BaseURL+"/dummyLogin", wrapper.PostDummyLogin)
 
+	router.Get(options.BaseURL+"/export/products", wrapper.GetExportProducts)
+
+	router.Get(options.BaseURL+"/export/pvz", wrapper.GetExportPvz)
+
+	router.Get(options.BaseURL+"/export/receptions", wrapper.GetExportReceptions)
+
+	router.Post(options.BaseURL+"/inventory-sessions", wrapper.PostInventorySessions)
+
+	router.Get(options.BaseURL+"/inventory-sessions/:sessionId", wrapper.GetInventorySessionsSessionId)
+
+	router.Post(options.BaseURL+"/inventory-sessions/:sessionId/adjustments", wrapper.PostInventorySessionsSessionIdAdjustments)
+
+	router.Post(options.BaseURL+"/inventory-sessions/:sessionId/close", wrapper.PostInventorySessionsSessionIdClose)
+
+	router.Post(options.BaseURL+"/inventory-sessions/:sessionId/scans", wrapper.PostInventorySessionsSessionIdScans)
+
+	router.Post(options.BaseURL+"/jobs", wrapper.PostJobs)
+
+	router.Get(options.BaseURL+"/jobs/:jobId", wrapper.GetJobsJobId)
+
+	router.Post(options.BaseURL+"/jobs/:jobId/cancel", wrapper.PostJobsJobIdCancel)
+
+	router.Get(options.BaseURL+"/jobs/:jobId/file", wrapper.GetJobsJobIdFile)
+
+	router.Post(options.BaseURL+"/labels", wrapper.PostLabels)
+
 	router.Post(options.BaseURL+"/login", wrapper.PostLogin)
 
+	router.Post(options.BaseURL+"/orders", wrapper.PostOrders)
+
+	router.Get(options.BaseURL+"/orders/:orderId", wrapper.GetOrdersOrderId)
+
+	router.Post(options.BaseURL+"/orders/:orderId/issue", wrapper.PostOrdersOrderIdIssue)
+
+	router.Post(options.BaseURL+"/orders/:orderId/pickup-code", wrapper.PostOrdersOrderIdPickupCode)
+
+	router.Get(options.BaseURL+"/product-types", wrapper.GetProductTypes)
+
+	router.Post(options.BaseURL+"/product-types", wrapper.PostProductTypes)
+
+	router.Put(options.BaseURL+"/product-types/:code", wrapper.PutProductTypesCode)
+
 	router.Post(options.BaseURL+"/products", wrapper.PostProducts)
 
+	router.Post(options.BaseURL+"/products/batch", wrapper.PostProductsBatch)
+
+	router.Get(options.BaseURL+"/products/by-barcode/:code", wrapper.GetProductsByBarcodeCode)
+
+	router.Get(options.BaseURL+"/products/locations", wrapper.GetProductsLocations)
+
+	router.Delete(options.BaseURL+"/products/:productId", wrapper.DeleteProductsProductId)
+
+	router.Patch(options.BaseURL+"/products/:productId", wrapper.PatchProductsProductId)
+
+	router.Put(options.BaseURL+"/products/:productId/cell", wrapper.PutProductsProductIdCell)
+
+	router.Post(options.BaseURL+"/products/:productId/damage", wrapper.PostProductsProductIdDamage)
+
+	router.Get(options.BaseURL+"/products/:productId/label", wrapper.GetProductsProductIdLabel)
+
+	router.Post(options.BaseURL+"/products/:productId/restore", wrapper.PostProductsProductIdRestore)
+
 	router.Get(options.BaseURL+"/pvz", wrapper.GetPvz)
 
 	router.Post(options.BaseURL+"/pvz", wrapper.PostPvz)
 
+	router.Get(options.BaseURL+"/pvz.geojson", wrapper.GetPvzGeojson)
+
+	router.Get(options.BaseURL+"/pvz/:pvzId/cells", wrapper.GetPvzPvzIdCells)
+
+	router.Post(options.BaseURL+"/pvz/:pvzId/cells", wrapper.PostPvzPvzIdCells)
+
 	router.Post(options.BaseURL+"/pvz/:pvzId/close_last_reception", wrapper.PostPvzPvzIdCloseLastReception)
 
 	router.Post(options.BaseURL+"/pvz/:pvzId/delete_last_product", wrapper.PostPvzPvzIdDeleteLastProduct)
 
+	router.Get(options.BaseURL+"/pvz/:pvzId/forecast", wrapper.GetPvzPvzIdForecast)
+
+	router.Put(options.BaseURL+"/pvz/:pvzId/intake-capacity", wrapper.PutPvzPvzIdIntakeCapacity)
+
+	router.Get(options.BaseURL+"/pvz/:pvzId/label-template", wrapper.GetPvzPvzIdLabelTemplate)
+
+	router.Put(options.BaseURL+"/pvz/:pvzId/label-template", wrapper.PutPvzPvzIdLabelTemplate)
+
+	router.Put(options.BaseURL+"/pvz/:pvzId/location", wrapper.PutPvzPvzIdLocation)
+
 	router.Post(options.BaseURL+"/receptions", wrapper.PostReceptions)
 
+	router.Get(options.BaseURL+"/receptions/search", wrapper.GetReceptionsSearch)
+
+	router.Get(options.BaseURL+"/receptions/:receptionId/containers", wrapper.GetReceptionsReceptionIdContainers)
+
+	router.Get(options.BaseURL+"/receptions/:receptionId/damage", wrapper.GetReceptionsReceptionIdDamage)
+
+	router.Get(options.BaseURL+"/receptions/:receptionId/deleted-products", wrapper.GetReceptionsReceptionIdDeletedProducts)
+
+	router.Get(options.BaseURL+"/receptions/:receptionId/summary", wrapper.GetReceptionsReceptionIdSummary)
+
+	router.Get(options.BaseURL+"/regions", wrapper.GetRegions)
+
+	router.Post(options.BaseURL+"/regions", wrapper.PostRegions)
+
+	router.Delete(options.BaseURL+"/regions/:regionId", wrapper.DeleteRegionsRegionId)
+
 	router.Post(options.BaseURL+"/register", wrapper.PostRegister)
 
+	router.Get(options.BaseURL+"/report-subscriptions", wrapper.GetReportSubscriptions)
+
+	router.Post(options.BaseURL+"/report-subscriptions", wrapper.PostReportSubscriptions)
+
+	router.Delete(options.BaseURL+"/report-subscriptions/:subscriptionId", wrapper.DeleteReportSubscriptionsSubscriptionId)
+
+	router.Get(options.BaseURL+"/report-subscriptions/:subscriptionId", wrapper.GetReportSubscriptionsSubscriptionId)
+
+	router.Put(options.BaseURL+"/report-subscriptions/:subscriptionId", wrapper.PutReportSubscriptionsSubscriptionId)
+
+	router.Get(options.BaseURL+"/report-subscriptions/:subscriptionId/deliveries", wrapper.GetReportSubscriptionsSubscriptionIdDeliveries)
+
+	router.Get(options.BaseURL+"/reports/employees", wrapper.GetReportsEmployees)
+
+	router.Get(options.BaseURL+"/reports/intake", wrapper.GetReportsIntake)
+
+	router.Get(options.BaseURL+"/reports/reception-sla", wrapper.GetReportsReceptionSla)
+
+	router.Get(options.BaseURL+"/return-reasons", wrapper.GetReturnReasons)
+
+	router.Post(options.BaseURL+"/return-reasons", wrapper.PostReturnReasons)
+
+	router.Get(options.BaseURL+"/return-shipments", wrapper.GetReturnShipments)
+
+	router.Post(options.BaseURL+"/return-shipments", wrapper.PostReturnShipments)
+
+	router.Get(options.BaseURL+"/return-shipments/:shipmentId", wrapper.GetReturnShipmentsShipmentId)
+
+	router.Post(options.BaseURL+"/return-shipments/:shipmentId/returns", wrapper.PostReturnShipmentsShipmentIdReturns)
+
+	router.Post(options.BaseURL+"/return-shipments/:shipmentId/status", wrapper.PostReturnShipmentsShipmentIdStatus)
+
+	router.Get(options.BaseURL+"/returns", wrapper.GetReturns)
+
+	router.Post(options.BaseURL+"/returns", wrapper.PostReturns)
+
+	router.Get(options.BaseURL+"/storage-periods", wrapper.GetStoragePeriods)
+
+	router.Put(options.BaseURL+"/storage-periods", wrapper.PutStoragePeriods)
+
+	router.Post(options.BaseURL+"/transfers", wrapper.PostTransfers)
+
+	router.Get(options.BaseURL+"/transfers/overdue", wrapper.GetTransfersOverdue)
+
+	router.Get(options.BaseURL+"/transfers/:transferId", wrapper.GetTransfersTransferId)
+
+	router.Post(options.BaseURL+"/transfers/:transferId/receive", wrapper.PostTransfersTransferIdReceive)
+
+	router.Get(options.BaseURL+"/unclaimed", wrapper.GetUnclaimed)
+
 }
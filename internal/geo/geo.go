@@ -0,0 +1,117 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+const (
+	TypePolygon      = "Polygon"
+	TypeMultiPolygon = "MultiPolygon"
+)
+
+var ErrInvalidGeometry = errors.New("invalid GeoJSON polygon")
+
+// Position is a GeoJSON position: longitude first, then latitude. Any
+// altitude is dropped when parsing.
+type Position [2]float64
+
+// Polygon is a list of closed rings; the first one is the outer boundary and
+// the rest are holes.
+type Polygon [][]Position
+
+type MultiPolygon []Polygon
+
+// Parse reads the coordinates of a Polygon or MultiPolygon geometry as
+// described in RFC 7946.
+func Parse(geometryType string, coordinates []byte) (MultiPolygon, error) {
+	var raw [][][][]float64
+	switch geometryType {
+	case TypePolygon:
+		var polygon [][][]float64
+		if err := json.Unmarshal(coordinates, &polygon); err != nil {
+			return nil, ErrInvalidGeometry
+		}
+		raw = [][][][]float64{polygon}
+	case TypeMultiPolygon:
+		if err := json.Unmarshal(coordinates, &raw); err != nil {
+			return nil, ErrInvalidGeometry
+		}
+	default:
+		return nil, ErrInvalidGeometry
+	}
+	if len(raw) == 0 {
+		return nil, ErrInvalidGeometry
+	}
+
+	area := make(MultiPolygon, 0, len(raw))
+	for _, rawPolygon := range raw {
+		if len(rawPolygon) == 0 {
+			return nil, ErrInvalidGeometry
+		}
+		polygon := make(Polygon, 0, len(rawPolygon))
+		for _, rawRing := range rawPolygon {
+			ring, err := parseRing(rawRing)
+			if err != nil {
+				return nil, err
+			}
+			polygon = append(polygon, ring)
+		}
+		area = append(area, polygon)
+	}
+	return area, nil
+}
+
+func parseRing(raw [][]float64) ([]Position, error) {
+	if len(raw) < 4 {
+		return nil, ErrInvalidGeometry
+	}
+	ring := make([]Position, 0, len(raw))
+	for _, coords := range raw {
+		if len(coords) < 2 || coords[0] < -180 || coords[0] > 180 || coords[1] < -90 || coords[1] > 90 {
+			return nil, ErrInvalidGeometry
+		}
+		ring = append(ring, Position{coords[0], coords[1]})
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return nil, ErrInvalidGeometry
+	}
+	return ring, nil
+}
+
+// Contains reports whether the point lies inside one of the polygons and
+// outside of its holes. Points on a boundary may fall either way.
+func (m MultiPolygon) Contains(longitude, latitude float64) bool {
+	for _, polygon := range m {
+		if polygon.contains(longitude, latitude) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Polygon) contains(longitude, latitude float64) bool {
+	if !ringContains(p[0], longitude, latitude) {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if ringContains(hole, longitude, latitude) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains casts a ray from the point along the parallel and counts the
+// edges it crosses.
+func ringContains(ring []Position, x, y float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,61 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContains(t *testing.T) {
+	// a square around central Moscow with a hole over the Kremlin and a
+	// separate triangle near Kazan
+	area, err := Parse(TypeMultiPolygon, []byte(`[
+		[
+			[[37.3, 55.5], [37.9, 55.5], [37.9, 55.9], [37.3, 55.9], [37.3, 55.5]],
+			[[37.6, 55.74], [37.63, 55.74], [37.63, 55.76], [37.6, 55.76], [37.6, 55.74]]
+		],
+		[[[49.0, 55.7], [49.3, 55.7], [49.1, 55.9, 120], [49.0, 55.7]]]
+	]`))
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		longitude, latitude float64
+		want                bool
+	}{
+		"inside":           {37.5, 55.7, true},
+		"in the hole":      {37.615, 55.75, false},
+		"second polygon":   {49.1, 55.75, true},
+		"outside":          {30.3, 59.9, false},
+		"latitude swapped": {55.7, 37.5, false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, area.Contains(tc.longitude, tc.latitude))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	polygon, err := Parse(TypePolygon, []byte(`[[[0, 0], [1, 0], [1, 1], [0, 0]]]`))
+	require.NoError(t, err)
+	require.Equal(t, MultiPolygon{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}, polygon)
+
+	invalid := map[string]struct {
+		geometryType, coordinates string
+	}{
+		"point":        {"Point", `[37.6, 55.75]`},
+		"not closed":   {TypePolygon, `[[[0, 0], [1, 0], [1, 1], [0, 1]]]`},
+		"too few":      {TypePolygon, `[[[0, 0], [1, 0], [0, 0]]]`},
+		"no rings":     {TypePolygon, `[]`},
+		"no polygons":  {TypeMultiPolygon, `[]`},
+		"out of range": {TypePolygon, `[[[0, 0], [200, 0], [1, 1], [0, 0]]]`},
+		"wrong depth":  {TypeMultiPolygon, `[[[0, 0], [1, 0], [1, 1], [0, 0]]]`},
+		"not numbers":  {TypePolygon, `[[["0", "0"]]]`},
+	}
+	for name, tc := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tc.geometryType, []byte(tc.coordinates))
+			require.ErrorIs(t, err, ErrInvalidGeometry)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+// approvalGate holds an operation for moderator approval when its rule is
+// enabled. The subject is the product for delete_product and the PVZ of the
+// open reception for the other operations.
+type approvalGate interface {
+	RequestApproval(
+		ctx context.Context,
+		operation oapi.ApprovalOperation,
+		subjectID, requestedBy uuid.UUID,
+	) (oapi.Approval, bool, error)
+}
+
+type approvalService interface {
+	GetApprovals(ctx context.Context, params oapi.GetApprovalsParams) ([]oapi.Approval, error)
+	Approve(ctx context.Context, userID, approvalID uuid.UUID, req oapi.ApprovalDecision) (oapi.Approval, error)
+	Reject(ctx context.Context, userID, approvalID uuid.UUID, req oapi.ApprovalDecision) (oapi.Approval, error)
+	GetApprovalRules(ctx context.Context) ([]oapi.ApprovalRule, error)
+	UpdateApprovalRule(
+		ctx context.Context,
+		userID uuid.UUID,
+		operation oapi.ApprovalOperation,
+		req oapi.PutApprovalsRulesOperationJSONRequestBody,
+	) (oapi.ApprovalRule, error)
+}
+
+type ApprovalHandler struct {
+	approvalService approvalService
+}
+
+func NewApprovalHandler(approvalSvc approvalService) *ApprovalHandler {
+	return &ApprovalHandler{approvalService: approvalSvc}
+}
+
+func (h *ApprovalHandler) GetApprovals(c *fiber.Ctx, params oapi.GetApprovalsParams) error {
+	approvals, err := h.approvalService.GetApprovals(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(approvals)
+}
+
+func (h *ApprovalHandler) Approve(c *fiber.Ctx, approvalId openapi_types.UUID) error {
+	var req oapi.ApprovalDecision
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	approval, err := h.approvalService.Approve(c.UserContext(), userIDFromLocals(c), approvalId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(approval)
+}
+
+func (h *ApprovalHandler) Reject(c *fiber.Ctx, approvalId openapi_types.UUID) error {
+	var req oapi.ApprovalDecision
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	approval, err := h.approvalService.Reject(c.UserContext(), userIDFromLocals(c), approvalId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(approval)
+}
+
+func (h *ApprovalHandler) GetApprovalRules(c *fiber.Ctx) error {
+	rules, err := h.approvalService.GetApprovalRules(c.UserContext())
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(rules)
+}
+
+func (h *ApprovalHandler) PutApprovalRule(c *fiber.Ctx, operation oapi.ApprovalOperation) error {
+	var req oapi.PutApprovalsRulesOperationJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	rule, err := h.approvalService.UpdateApprovalRule(c.UserContext(), userIDFromLocals(c), operation, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(rule)
+}
+
+func requireApproval(
+	c *fiber.Ctx,
+	gate approvalGate,
+	operation oapi.ApprovalOperation,
+	subjectID uuid.UUID,
+) (bool, error) {
+	if gate == nil {
+		return false, nil
+	}
+
+	approval, pending, err := gate.RequestApproval(c.UserContext(), operation, subjectID, userIDFromLocals(c))
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return true, fiber.NewError(status, err.Error())
+	}
+	if !pending {
+		return false, nil
+	}
+	return true, c.Status(fiber.StatusAccepted).JSON(approval)
+}
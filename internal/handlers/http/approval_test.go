@@ -0,0 +1,280 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockApprovalService struct{ mock.Mock }
+
+func (m *mockApprovalService) RequestApproval(
+	ctx context.Context,
+	operation oapi.ApprovalOperation,
+	pvzID, requestedBy uuid.UUID) (oapi.Approval, bool, error) {
+	args := m.Called(ctx, operation, pvzID, requestedBy)
+	return args.Get(0).(oapi.Approval), args.Bool(1), args.Error(2)
+}
+
+func (m *mockApprovalService) GetApprovals(
+	ctx context.Context,
+	params oapi.GetApprovalsParams) ([]oapi.Approval, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.Approval), args.Error(1)
+}
+
+func (m *mockApprovalService) Approve(
+	ctx context.Context,
+	userID, approvalID uuid.UUID,
+	req oapi.ApprovalDecision) (oapi.Approval, error) {
+	args := m.Called(ctx, userID, approvalID, req)
+	return args.Get(0).(oapi.Approval), args.Error(1)
+}
+
+func (m *mockApprovalService) Reject(
+	ctx context.Context,
+	userID, approvalID uuid.UUID,
+	req oapi.ApprovalDecision) (oapi.Approval, error) {
+	args := m.Called(ctx, userID, approvalID, req)
+	return args.Get(0).(oapi.Approval), args.Error(1)
+}
+
+func (m *mockApprovalService) GetApprovalRules(ctx context.Context) ([]oapi.ApprovalRule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]oapi.ApprovalRule), args.Error(1)
+}
+
+func (m *mockApprovalService) UpdateApprovalRule(
+	ctx context.Context,
+	userID uuid.UUID,
+	operation oapi.ApprovalOperation,
+	req oapi.PutApprovalsRulesOperationJSONRequestBody) (oapi.ApprovalRule, error) {
+	args := m.Called(ctx, userID, operation, req)
+	return args.Get(0).(oapi.ApprovalRule), args.Error(1)
+}
+
+func TestDeleteLastProductRequiresApproval(t *testing.T) {
+	mockSvc := new(mockProductService)
+	mockGate := new(mockApprovalService)
+	h := NewProductHandler(mockSvc, mockGate)
+	app := fiber.New()
+	app.Post("/pvz/:pvzId/delete_last_product", func(c *fiber.Ctx) error {
+		return h.PostPvzPvzIdDeleteLastProduct(c, uuid.MustParse(c.Params("pvzId")))
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		pvzID := uuid.New()
+		approval := oapi.Approval{Id: ptrUUID(uuid.New()), Operation: oapi.DeleteLastProduct, Status: "pending"}
+		mockGate.
+			On("RequestApproval", mock.Anything, oapi.DeleteLastProduct, pvzID, uuid.Nil).
+			Return(approval, true, nil)
+		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/delete_last_product", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var got oapi.Approval
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, approval, got)
+		mockSvc.AssertNotCalled(t, "DeleteLastProduct", mock.Anything, mock.Anything, pvzID)
+	})
+
+	t.Run("not required", func(t *testing.T) {
+		pvzID := uuid.New()
+		mockGate.
+			On("RequestApproval", mock.Anything, oapi.DeleteLastProduct, pvzID, uuid.Nil).
+			Return(oapi.Approval{}, false, nil)
+		mockSvc.On("DeleteLastProduct", mock.Anything, uuid.Nil, pvzID).Return(nil)
+		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/delete_last_product", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("gate error", func(t *testing.T) {
+		pvzID := uuid.New()
+		mockGate.
+			On("RequestApproval", mock.Anything, oapi.DeleteLastProduct, pvzID, uuid.Nil).
+			Return(oapi.Approval{}, false, pvz_errors.ErrApprovalAlreadyPending)
+		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/delete_last_product", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+
+	mockSvc.AssertExpectations(t)
+	mockGate.AssertExpectations(t)
+}
+
+func TestCloseReceptionRequiresApproval(t *testing.T) {
+	mockSvc := new(mockReceptionService)
+	mockGate := new(mockApprovalService)
+	h := NewReceptionHandler(mockSvc, mockGate)
+	app := fiber.New()
+	app.Post("/pvz/:pvzId/close_last_reception", func(c *fiber.Ctx) error {
+		return h.CloseReception(c, uuid.MustParse(c.Params("pvzId")))
+	})
+
+	pvzID := uuid.New()
+	approval := oapi.Approval{Id: ptrUUID(uuid.New()), Operation: oapi.CloseReception, Status: "pending"}
+	mockGate.
+		On("RequestApproval", mock.Anything, oapi.CloseReception, pvzID, uuid.Nil).
+		Return(approval, true, nil)
+	req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzID.String()+"/close_last_reception", nil)
+	resp, _ := app.Test(req, -1)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+	mockSvc.AssertNotCalled(t, "CloseLastReception", mock.Anything, mock.Anything, pvzID)
+	mockGate.AssertExpectations(t)
+}
+
+func TestApprovalDecisions(t *testing.T) {
+	mockSvc := new(mockApprovalService)
+	h := NewApprovalHandler(mockSvc)
+	app := fiber.New()
+	app.Post("/approvals/:approvalId/approve", func(c *fiber.Ctx) error {
+		return h.Approve(c, uuid.MustParse(c.Params("approvalId")))
+	})
+	app.Post("/approvals/:approvalId/reject", func(c *fiber.Ctx) error {
+		return h.Reject(c, uuid.MustParse(c.Params("approvalId")))
+	})
+
+	t.Run("approve without body", func(t *testing.T) {
+		id := uuid.New()
+		want := oapi.Approval{Id: &id, Operation: oapi.CloseReception, Status: "approved"}
+		mockSvc.On("Approve", mock.Anything, uuid.Nil, id, oapi.ApprovalDecision{}).Return(want, nil)
+		req := httptest.NewRequest(http.MethodPost, "/approvals/"+id.String()+"/approve", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.Approval
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+	})
+
+	t.Run("approve already decided", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.
+			On("Approve", mock.Anything, uuid.Nil, id, oapi.ApprovalDecision{}).
+			Return(oapi.Approval{}, pvz_errors.ErrApprovalAlreadyDecided)
+		req := httptest.NewRequest(http.MethodPost, "/approvals/"+id.String()+"/approve", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("reject bad body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/approvals/"+uuid.NewString()+"/reject",
+			bytes.NewBufferString(`{{`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("reject with comment", func(t *testing.T) {
+		id := uuid.New()
+		body := oapi.ApprovalDecision{Comment: ptrString("нет оснований")}
+		want := oapi.Approval{Id: &id, Operation: oapi.DeleteLastProduct, Status: "rejected", Comment: body.Comment}
+		mockSvc.On("Reject", mock.Anything, uuid.Nil, id, body).Return(want, nil)
+		req := httptest.NewRequest(http.MethodPost, "/approvals/"+id.String()+"/reject", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestApprovalQueueAndRules(t *testing.T) {
+	mockSvc := new(mockApprovalService)
+	h := NewApprovalHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/approvals", func(c *fiber.Ctx) error {
+		return h.GetApprovals(c, oapi.GetApprovalsParams{})
+	})
+	app.Get("/approvals/rules", h.GetApprovalRules)
+	app.Put("/approvals/rules/:operation", func(c *fiber.Ctx) error {
+		return h.PutApprovalRule(c, oapi.ApprovalOperation(c.Params("operation")))
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		want := []oapi.Approval{{Id: ptrUUID(uuid.New()), Operation: oapi.DeleteLastProduct, Status: "pending"}}
+		mockSvc.On("GetApprovals", mock.Anything, oapi.GetApprovalsParams{}).Return(want, nil)
+		req := httptest.NewRequest(http.MethodGet, "/approvals", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got []oapi.Approval
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		want := []oapi.ApprovalRule{{Operation: oapi.CloseReception, Enabled: true}}
+		mockSvc.On("GetApprovalRules", mock.Anything).Return(want, nil)
+		req := httptest.NewRequest(http.MethodGet, "/approvals/rules", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("update unknown operation", func(t *testing.T) {
+		body := oapi.PutApprovalsRulesOperationJSONRequestBody{Enabled: true}
+		mockSvc.
+			On("UpdateApprovalRule", mock.Anything, uuid.Nil, oapi.ApprovalOperation("drop_pvz"), body).
+			Return(oapi.ApprovalRule{}, pvz_errors.ErrInvalidApprovalOperation)
+		req := httptest.NewRequest(http.MethodPut, "/approvals/rules/drop_pvz", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestDeleteProductRequiresApproval(t *testing.T) {
+	mockSvc := new(mockProductService)
+	mockGate := new(mockApprovalService)
+	h := NewProductHandler(mockSvc, mockGate)
+	app := fiber.New()
+	app.Delete("/products/:productId", func(c *fiber.Ctx) error {
+		return h.DeleteProduct(c, uuid.MustParse(c.Params("productId")))
+	})
+
+	productID := uuid.New()
+	approval := oapi.Approval{
+		Id:        ptrUUID(uuid.New()),
+		Operation: oapi.DeleteProduct,
+		Status:    "pending",
+		TargetId:  productID,
+	}
+	mockGate.
+		On("RequestApproval", mock.Anything, oapi.DeleteProduct, productID, uuid.Nil).
+		Return(approval, true, nil)
+	req := httptest.NewRequest(http.MethodDelete, "/products/"+productID.String(), nil)
+	resp, _ := app.Test(req, -1)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+	var got oapi.Approval
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.Equal(t, approval, got)
+	mockSvc.AssertNotCalled(t, "DeleteProduct", mock.Anything, mock.Anything, productID)
+	mockGate.AssertExpectations(t)
+}
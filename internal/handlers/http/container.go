@@ -0,0 +1,77 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type containerService interface {
+	ScanContainer(ctx context.Context, req oapi.PostContainersJSONRequestBody) (dto.ContainerWithProducts, error)
+	OpenContainer(ctx context.Context, containerID uuid.UUID) (oapi.Container, error)
+	VerifyContainer(
+		ctx context.Context,
+		containerID uuid.UUID,
+		req oapi.PostContainersContainerIdVerifyJSONRequestBody,
+	) (oapi.Container, error)
+	GetReceptionContainers(ctx context.Context, receptionID uuid.UUID) ([]oapi.Container, error)
+}
+
+type ContainerHandler struct {
+	containerService containerService
+}
+
+func NewContainerHandler(containerSvc containerService) *ContainerHandler {
+	return &ContainerHandler{containerService: containerSvc}
+}
+
+func (h *ContainerHandler) PostContainer(c *fiber.Ctx) error {
+	var req oapi.PostContainersJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	result, err := h.containerService.ScanContainer(c.UserContext(), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
+func (h *ContainerHandler) OpenContainer(c *fiber.Ctx, containerId openapi_types.UUID) error {
+	container, err := h.containerService.OpenContainer(c.UserContext(), containerId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(container)
+}
+
+func (h *ContainerHandler) VerifyContainer(c *fiber.Ctx, containerId openapi_types.UUID) error {
+	var req oapi.PostContainersContainerIdVerifyJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	container, err := h.containerService.VerifyContainer(c.UserContext(), containerId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(container)
+}
+
+func (h *ContainerHandler) GetReceptionContainers(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	containers, err := h.containerService.GetReceptionContainers(c.UserContext(), receptionId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(containers)
+}
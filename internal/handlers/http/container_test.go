@@ -0,0 +1,176 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockContainerService struct{ mock.Mock }
+
+func (m *mockContainerService) ScanContainer(
+	ctx context.Context,
+	req oapi.PostContainersJSONRequestBody) (dto.ContainerWithProducts, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(dto.ContainerWithProducts), args.Error(1)
+}
+
+func (m *mockContainerService) OpenContainer(ctx context.Context, containerID uuid.UUID) (oapi.Container, error) {
+	args := m.Called(ctx, containerID)
+	return args.Get(0).(oapi.Container), args.Error(1)
+}
+
+func (m *mockContainerService) VerifyContainer(
+	ctx context.Context,
+	containerID uuid.UUID,
+	req oapi.PostContainersContainerIdVerifyJSONRequestBody) (oapi.Container, error) {
+	args := m.Called(ctx, containerID, req)
+	return args.Get(0).(oapi.Container), args.Error(1)
+}
+
+func (m *mockContainerService) GetReceptionContainers(
+	ctx context.Context,
+	receptionID uuid.UUID) ([]oapi.Container, error) {
+	args := m.Called(ctx, receptionID)
+	return args.Get(0).([]oapi.Container), args.Error(1)
+}
+
+func TestPostContainer(t *testing.T) {
+	mockSvc := new(mockContainerService)
+	h := NewContainerHandler(mockSvc)
+	app := fiber.New()
+	app.Post("/containers", h.PostContainer)
+
+	t.Run("bad body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/containers", bytes.NewBufferString(`{{`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("duplicate barcode", func(t *testing.T) {
+		body := oapi.PostContainersJSONRequestBody{PvzId: uuid.New(), Barcode: "DUP", Kind: "container"}
+		mockSvc.
+			On("ScanContainer", mock.Anything, body).
+			Return(dto.ContainerWithProducts{}, pvz_errors.ErrContainerAlreadyExists)
+		req := httptest.NewRequest(http.MethodPost, "/containers", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		body := oapi.PostContainersJSONRequestBody{PvzId: uuid.New(), Barcode: "PAL-1", Kind: "pallet"}
+		want := dto.ContainerWithProducts{
+			Container: oapi.Container{Id: ptrUUID(uuid.New()), Barcode: "PAL-1", Kind: "pallet", State: "sealed"},
+			Products:  []oapi.Product{{Id: ptrUUID(uuid.New()), Type: "обувь"}},
+		}
+		mockSvc.On("ScanContainer", mock.Anything, body).Return(want, nil)
+		req := httptest.NewRequest(http.MethodPost, "/containers", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var got dto.ContainerWithProducts
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestOpenAndVerifyContainer(t *testing.T) {
+	mockSvc := new(mockContainerService)
+	h := NewContainerHandler(mockSvc)
+	app := fiber.New()
+	app.Post("/containers/:containerId/open", func(c *fiber.Ctx) error {
+		return h.OpenContainer(c, uuid.MustParse(c.Params("containerId")))
+	})
+	app.Post("/containers/:containerId/verify", func(c *fiber.Ctx) error {
+		return h.VerifyContainer(c, uuid.MustParse(c.Params("containerId")))
+	})
+
+	t.Run("open not found", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("OpenContainer", mock.Anything, id).Return(oapi.Container{}, pvz_errors.ErrContainerNotFound)
+		req := httptest.NewRequest(http.MethodPost, "/containers/"+id.String()+"/open", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("open success", func(t *testing.T) {
+		id := uuid.New()
+		want := oapi.Container{Id: &id, Barcode: "C-1", Kind: "container", State: "opened"}
+		mockSvc.On("OpenContainer", mock.Anything, id).Return(want, nil)
+		req := httptest.NewRequest(http.MethodPost, "/containers/"+id.String()+"/open", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.Container
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+	})
+
+	t.Run("verify bad body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/containers/"+uuid.NewString()+"/verify",
+			bytes.NewBufferString(`{{`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("verify sealed container", func(t *testing.T) {
+		id := uuid.New()
+		body := oapi.PostContainersContainerIdVerifyJSONRequestBody{ProductIds: []uuid.UUID{uuid.New()}}
+		mockSvc.
+			On("VerifyContainer", mock.Anything, id, body).
+			Return(oapi.Container{}, pvz_errors.ErrInvalidContainerState)
+		req := httptest.NewRequest(http.MethodPost, "/containers/"+id.String()+"/verify", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetReceptionContainers(t *testing.T) {
+	mockSvc := new(mockContainerService)
+	h := NewContainerHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/receptions/:receptionId/containers", func(c *fiber.Ctx) error {
+		return h.GetReceptionContainers(c, uuid.MustParse(c.Params("receptionId")))
+	})
+
+	id := uuid.New()
+	want := []oapi.Container{{Id: ptrUUID(uuid.New()), ReceptionId: id, Barcode: "C-1", State: "verified"}}
+	mockSvc.On("GetReceptionContainers", mock.Anything, id).Return(want, nil)
+	req := httptest.NewRequest(http.MethodGet, "/receptions/"+id.String()+"/containers", nil)
+	resp, _ := app.Test(req, -1)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var got []oapi.Container
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.Equal(t, want, got)
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,61 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type damageService interface {
+	MarkProductDamage(
+		ctx context.Context,
+		userID, productID uuid.UUID,
+		req oapi.PostProductsProductIdDamageJSONRequestBody,
+	) (oapi.ProductDamage, error)
+	GetReceptionDamage(ctx context.Context, receptionID uuid.UUID) ([]oapi.ProductDamage, error)
+	GetDamageIncidents(ctx context.Context, params oapi.GetDamageIncidentsParams) ([]oapi.ProductDamage, error)
+}
+
+type DamageHandler struct {
+	damageService damageService
+}
+
+func NewDamageHandler(damageSvc damageService) *DamageHandler {
+	return &DamageHandler{damageService: damageSvc}
+}
+
+func (h *DamageHandler) PostProductDamage(c *fiber.Ctx, productId openapi_types.UUID) error {
+	var req oapi.PostProductsProductIdDamageJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	damage, err := h.damageService.MarkProductDamage(c.UserContext(), userIDFromLocals(c), productId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(damage)
+}
+
+func (h *DamageHandler) GetReceptionDamage(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	damages, err := h.damageService.GetReceptionDamage(c.UserContext(), receptionId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(damages)
+}
+
+func (h *DamageHandler) GetDamageIncidents(c *fiber.Ctx, params oapi.GetDamageIncidentsParams) error {
+	incidents, err := h.damageService.GetDamageIncidents(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(incidents)
+}
@@ -0,0 +1,151 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockDamageService struct{ mock.Mock }
+
+func (m *mockDamageService) MarkProductDamage(
+	ctx context.Context,
+	userID, productID uuid.UUID,
+	req oapi.PostProductsProductIdDamageJSONRequestBody) (oapi.ProductDamage, error) {
+	args := m.Called(ctx, userID, productID, req)
+	return args.Get(0).(oapi.ProductDamage), args.Error(1)
+}
+
+func (m *mockDamageService) GetReceptionDamage(
+	ctx context.Context,
+	receptionID uuid.UUID) ([]oapi.ProductDamage, error) {
+	args := m.Called(ctx, receptionID)
+	return args.Get(0).([]oapi.ProductDamage), args.Error(1)
+}
+
+func (m *mockDamageService) GetDamageIncidents(
+	ctx context.Context,
+	params oapi.GetDamageIncidentsParams) ([]oapi.ProductDamage, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.ProductDamage), args.Error(1)
+}
+
+func TestPostProductDamage(t *testing.T) {
+	mockSvc := new(mockDamageService)
+	h := NewDamageHandler(mockSvc)
+	app := fiber.New()
+	app.Post("/products/:productId/damage", func(c *fiber.Ctx) error {
+		return h.PostProductDamage(c, uuid.MustParse(c.Params("productId")))
+	})
+
+	t.Run("bad body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/products/"+uuid.NewString()+"/damage",
+			bytes.NewBufferString(`{{`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		id := uuid.New()
+		body := oapi.PostProductsProductIdDamageJSONRequestBody{Kind: "wet", Severity: "low"}
+		mockSvc.
+			On("MarkProductDamage", mock.Anything, uuid.Nil, id, body).
+			Return(oapi.ProductDamage{}, pvz_errors.ErrProductNotFound)
+		req := httptest.NewRequest(http.MethodPost, "/products/"+id.String()+"/damage", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		id := uuid.New()
+		body := oapi.PostProductsProductIdDamageJSONRequestBody{Kind: "opened", Severity: "medium"}
+		want := oapi.ProductDamage{Id: ptrUUID(uuid.New()), ProductId: id, Kind: "opened", Severity: "medium"}
+		mockSvc.On("MarkProductDamage", mock.Anything, uuid.Nil, id, body).Return(want, nil)
+		req := httptest.NewRequest(http.MethodPost, "/products/"+id.String()+"/damage", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var got oapi.ProductDamage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestGetReceptionDamage(t *testing.T) {
+	mockSvc := new(mockDamageService)
+	h := NewDamageHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/receptions/:receptionId/damage", func(c *fiber.Ctx) error {
+		return h.GetReceptionDamage(c, uuid.MustParse(c.Params("receptionId")))
+	})
+
+	id := uuid.New()
+	want := []oapi.ProductDamage{{ProductId: uuid.New(), ReceptionId: id, Kind: "wet", Severity: "low"}}
+	mockSvc.On("GetReceptionDamage", mock.Anything, id).Return(want, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/receptions/"+id.String()+"/damage", nil)
+	resp, _ := app.Test(req, -1)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var got []oapi.ProductDamage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.Equal(t, want, got)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetDamageIncidents(t *testing.T) {
+	mockSvc := new(mockDamageService)
+	h := NewDamageHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/damage/incidents", func(c *fiber.Ctx) error {
+		return h.GetDamageIncidents(c, oapi.GetDamageIncidentsParams{})
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockSvc.
+			On("GetDamageIncidents", mock.Anything, oapi.GetDamageIncidentsParams{}).
+			Return([]oapi.ProductDamage(nil), pvz_errors.ErrSelectDamageFailed).
+			Once()
+		req := httptest.NewRequest(http.MethodGet, "/damage/incidents", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		want := []oapi.ProductDamage{{ProductId: uuid.New(), Kind: "damaged", Severity: "high"}}
+		mockSvc.
+			On("GetDamageIncidents", mock.Anything, oapi.GetDamageIncidentsParams{}).
+			Return(want, nil).
+			Once()
+		req := httptest.NewRequest(http.MethodGet, "/damage/incidents", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got []oapi.ProductDamage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+		mockSvc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,111 @@
+package http_handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	mimeCSV    = "text/csv"
+	mimeXLSX   = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	mimeNDJSON = "application/x-ndjson"
+)
+
+type exportService interface {
+	ExportPVZs(
+		ctx context.Context,
+		params oapi.GetExportPvzParams,
+		format oapi.ExportFormat,
+	) (func(io.Writer) error, error)
+	ExportReceptions(
+		ctx context.Context,
+		params oapi.GetExportReceptionsParams,
+		format oapi.ExportFormat,
+	) (func(io.Writer) error, error)
+	ExportProducts(
+		ctx context.Context,
+		params oapi.GetExportProductsParams,
+		format oapi.ExportFormat,
+	) (func(io.Writer) error, error)
+}
+
+type ExportHandler struct {
+	exportService exportService
+}
+
+func NewExportHandler(exportSvc exportService) *ExportHandler {
+	return &ExportHandler{exportService: exportSvc}
+}
+
+func (h *ExportHandler) ExportPVZs(c *fiber.Ctx, params oapi.GetExportPvzParams) error {
+	format, err := exportFormat(c, params.Format)
+	if err != nil {
+		return err
+	}
+	stream, err := h.exportService.ExportPVZs(c.UserContext(), params, format)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return sendExport(c, stream, format, "pvz")
+}
+
+func (h *ExportHandler) ExportReceptions(c *fiber.Ctx, params oapi.GetExportReceptionsParams) error {
+	format, err := exportFormat(c, params.Format)
+	if err != nil {
+		return err
+	}
+	stream, err := h.exportService.ExportReceptions(c.UserContext(), params, format)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return sendExport(c, stream, format, "receptions")
+}
+
+func (h *ExportHandler) ExportProducts(c *fiber.Ctx, params oapi.GetExportProductsParams) error {
+	format, err := exportFormat(c, params.Format)
+	if err != nil {
+		return err
+	}
+	stream, err := h.exportService.ExportProducts(c.UserContext(), params, format)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return sendExport(c, stream, format, "products")
+}
+
+func exportFormat(c *fiber.Ctx, format *oapi.ExportFormat) (oapi.ExportFormat, error) {
+	if format != nil {
+		return *format, nil
+	}
+	switch c.Accepts(mimeCSV, mimeXLSX, mimeNDJSON) {
+	case mimeCSV:
+		return oapi.Csv, nil
+	case mimeXLSX:
+		return oapi.Xlsx, nil
+	case mimeNDJSON:
+		return oapi.Ndjson, nil
+	}
+	return "", fiber.NewError(fiber.StatusNotAcceptable, "поддерживаются форматы csv, xlsx и ndjson")
+}
+
+func sendExport(c *fiber.Ctx, stream func(io.Writer) error, format oapi.ExportFormat, name string) error {
+	c.Set(fiber.HeaderContentType, export.ContentType(export.Format(format)))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := stream(w); err != nil {
+			log.Printf("export %s failed: %v", name, err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,102 @@
+package http_handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockExportService struct{ mock.Mock }
+
+func (m *mockExportService) ExportPVZs(
+	ctx context.Context,
+	params oapi.GetExportPvzParams,
+	format oapi.ExportFormat,
+) (func(io.Writer) error, error) {
+	args := m.Called(ctx, params, format)
+	stream, _ := args.Get(0).(func(io.Writer) error)
+	return stream, args.Error(1)
+}
+
+func (m *mockExportService) ExportReceptions(
+	ctx context.Context,
+	params oapi.GetExportReceptionsParams,
+	format oapi.ExportFormat,
+) (func(io.Writer) error, error) {
+	args := m.Called(ctx, params, format)
+	stream, _ := args.Get(0).(func(io.Writer) error)
+	return stream, args.Error(1)
+}
+
+func (m *mockExportService) ExportProducts(
+	ctx context.Context,
+	params oapi.GetExportProductsParams,
+	format oapi.ExportFormat,
+) (func(io.Writer) error, error) {
+	args := m.Called(ctx, params, format)
+	stream, _ := args.Get(0).(func(io.Writer) error)
+	return stream, args.Error(1)
+}
+
+func TestExportHandlers(t *testing.T) {
+	mockSvc := new(mockExportService)
+	h := NewExportHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/export/pvz", func(c *fiber.Ctx) error {
+		var params oapi.GetExportPvzParams
+		if f := c.Query("format"); f != "" {
+			format := oapi.ExportFormat(f)
+			params.Format = &format
+		}
+		return h.ExportPVZs(c, params)
+	})
+
+	stream := func(body string) func(io.Writer) error {
+		return func(w io.Writer) error {
+			_, err := io.WriteString(w, body)
+			return err
+		}
+	}
+
+	t.Run("accept header", func(t *testing.T) {
+		mockSvc.On("ExportPVZs", mock.Anything, oapi.GetExportPvzParams{}, oapi.Ndjson).Return(stream("{}\n"), nil)
+		req := httptest.NewRequest(http.MethodGet, "/export/pvz", nil)
+		req.Header.Set("Accept", "application/x-ndjson")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/x-ndjson", resp.Header.Get(fiber.HeaderContentType))
+		require.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "pvz.ndjson")
+
+		body, _ := io.ReadAll(resp.Body)
+		require.Equal(t, "{}\n", string(body))
+	})
+
+	t.Run("format parameter wins", func(t *testing.T) {
+		format := oapi.Xlsx
+		mockSvc.
+			On("ExportPVZs", mock.Anything, oapi.GetExportPvzParams{Format: &format}, oapi.Xlsx).
+			Return(stream("PK"), nil)
+		req := httptest.NewRequest(http.MethodGet, "/export/pvz?format=xlsx", nil)
+		req.Header.Set("Accept", "text/csv")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, mimeXLSX, resp.Header.Get(fiber.HeaderContentType))
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/export/pvz", nil)
+		req.Header.Set("Accept", "application/pdf")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotAcceptable, resp.StatusCode)
+	})
+}
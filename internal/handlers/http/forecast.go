@@ -0,0 +1,40 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type forecastService interface {
+	GetIntakeForecast(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		params oapi.GetPvzPvzIdForecastParams,
+	) (oapi.IntakeForecast, error)
+}
+
+type ForecastHandler struct {
+	forecastService forecastService
+}
+
+func NewForecastHandler(forecastSvc forecastService) *ForecastHandler {
+	return &ForecastHandler{forecastService: forecastSvc}
+}
+
+func (h *ForecastHandler) GetIntakeForecast(
+	c *fiber.Ctx,
+	pvzId openapi_types.UUID,
+	params oapi.GetPvzPvzIdForecastParams,
+) error {
+	forecast, err := h.forecastService.GetIntakeForecast(c.UserContext(), pvzId, params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(forecast)
+}
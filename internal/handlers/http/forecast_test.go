@@ -0,0 +1,65 @@
+package http_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockForecastService struct{ mock.Mock }
+
+func (m *mockForecastService) GetIntakeForecast(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	params oapi.GetPvzPvzIdForecastParams,
+) (oapi.IntakeForecast, error) {
+	args := m.Called(ctx, pvzID, params)
+	return args.Get(0).(oapi.IntakeForecast), args.Error(1)
+}
+
+func TestForecastHandlers(t *testing.T) {
+	mockSvc := new(mockForecastService)
+	h := NewForecastHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/pvz/:pvzId/forecast", func(c *fiber.Ctx) error {
+		return h.GetIntakeForecast(c, uuid.MustParse(c.Params("pvzId")), oapi.GetPvzPvzIdForecastParams{})
+	})
+
+	t.Run("forecast", func(t *testing.T) {
+		pvzID := uuid.New()
+		forecast := oapi.IntakeForecast{PvzId: pvzID, Warnings: []string{"2025-03-12: ожидается 540 товаров"}}
+		mockSvc.
+			On("GetIntakeForecast", mock.Anything, pvzID, oapi.GetPvzPvzIdForecastParams{}).
+			Return(forecast, nil)
+		req := httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/forecast", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.IntakeForecast
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, pvzID, got.PvzId)
+		require.Len(t, got.Warnings, 1)
+	})
+
+	t.Run("not enough history", func(t *testing.T) {
+		pvzID := uuid.New()
+		mockSvc.
+			On("GetIntakeForecast", mock.Anything, pvzID, oapi.GetPvzPvzIdForecastParams{}).
+			Return(oapi.IntakeForecast{}, pvz_errors.ErrForecastHistoryTooShort)
+		req := httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/forecast", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
@@ -13,6 +13,7 @@ import (
 func ptrUUID(u uuid.UUID) *uuid.UUID { return &u }
 func ptrTime(t time.Time) *time.Time { return &t }
 func ptrInt(i int) *int              { return &i }
+func ptrString(s string) *string     { return &s }
 func marshaled(t *testing.T, v interface{}) *bytes.Buffer {
 	b, err := json.Marshal(v)
 	require.NoError(t, err)
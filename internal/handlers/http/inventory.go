@@ -0,0 +1,99 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type inventoryService interface {
+	StartSession(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PostInventorySessionsJSONRequestBody,
+	) (oapi.InventorySession, error)
+	GetSession(ctx context.Context, sessionID uuid.UUID) (oapi.InventorySession, error)
+	Scan(
+		ctx context.Context,
+		userID, sessionID uuid.UUID,
+		req oapi.PostInventorySessionsSessionIdScansJSONRequestBody,
+	) (oapi.InventoryScan, error)
+	CloseSession(ctx context.Context, userID, sessionID uuid.UUID) (oapi.InventorySession, error)
+	AcceptAdjustments(
+		ctx context.Context,
+		userID, sessionID uuid.UUID,
+		req oapi.PostInventorySessionsSessionIdAdjustmentsJSONRequestBody,
+	) (oapi.InventorySession, error)
+}
+
+type InventoryHandler struct {
+	inventoryService inventoryService
+}
+
+func NewInventoryHandler(inventorySvc inventoryService) *InventoryHandler {
+	return &InventoryHandler{inventoryService: inventorySvc}
+}
+
+func (h *InventoryHandler) StartSession(c *fiber.Ctx) error {
+	var req oapi.PostInventorySessionsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	session, err := h.inventoryService.StartSession(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+func (h *InventoryHandler) GetSession(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	session, err := h.inventoryService.GetSession(c.UserContext(), sessionId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(session)
+}
+
+func (h *InventoryHandler) Scan(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	var req oapi.PostInventorySessionsSessionIdScansJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	scan, err := h.inventoryService.Scan(c.UserContext(), userIDFromLocals(c), sessionId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(scan)
+}
+
+func (h *InventoryHandler) CloseSession(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	session, err := h.inventoryService.CloseSession(c.UserContext(), userIDFromLocals(c), sessionId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(session)
+}
+
+func (h *InventoryHandler) AcceptAdjustments(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	var req oapi.PostInventorySessionsSessionIdAdjustmentsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	session, err := h.inventoryService.AcceptAdjustments(c.UserContext(), userIDFromLocals(c), sessionId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(session)
+}
@@ -0,0 +1,99 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockInventoryService struct{ mock.Mock }
+
+func (m *mockInventoryService) StartSession(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostInventorySessionsJSONRequestBody,
+) (oapi.InventorySession, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func (m *mockInventoryService) GetSession(ctx context.Context, sessionID uuid.UUID) (oapi.InventorySession, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func (m *mockInventoryService) Scan(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+	req oapi.PostInventorySessionsSessionIdScansJSONRequestBody,
+) (oapi.InventoryScan, error) {
+	args := m.Called(ctx, userID, sessionID, req)
+	return args.Get(0).(oapi.InventoryScan), args.Error(1)
+}
+
+func (m *mockInventoryService) CloseSession(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+) (oapi.InventorySession, error) {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func (m *mockInventoryService) AcceptAdjustments(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+	req oapi.PostInventorySessionsSessionIdAdjustmentsJSONRequestBody,
+) (oapi.InventorySession, error) {
+	args := m.Called(ctx, userID, sessionID, req)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func TestInventoryHandlers(t *testing.T) {
+	mockSvc := new(mockInventoryService)
+	h := NewInventoryHandler(mockSvc)
+	userID := uuid.New()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		return c.Next()
+	})
+	app.Post("/inventory-sessions", h.StartSession)
+	app.Post("/inventory-sessions/:sessionId/close", func(c *fiber.Ctx) error {
+		return h.CloseSession(c, uuid.MustParse(c.Params("sessionId")))
+	})
+
+	t.Run("start", func(t *testing.T) {
+		body := oapi.PostInventorySessionsJSONRequestBody{PvzId: uuid.New()}
+		mockSvc.
+			On("StartSession", mock.Anything, userID, body).
+			Return(oapi.InventorySession{Status: oapi.Counting}, nil)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/inventory-sessions", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("close twice", func(t *testing.T) {
+		sessionID := uuid.New()
+		mockSvc.
+			On("CloseSession", mock.Anything, userID, sessionID).
+			Return(oapi.InventorySession{}, pvz_errors.ErrInventoryAlreadyCounted)
+		req := httptest.NewRequest(http.MethodPost, "/inventory-sessions/"+sessionID.String()+"/close", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
@@ -0,0 +1,82 @@
+package http_handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type jobService interface {
+	CreateJob(
+		ctx context.Context,
+		userID uuid.UUID,
+		role oapi.UserRole,
+		req oapi.PostJobsJSONRequestBody,
+	) (oapi.Job, error)
+	GetJob(ctx context.Context, userID uuid.UUID, role oapi.UserRole, jobID uuid.UUID) (oapi.Job, error)
+	OpenJobFile(
+		ctx context.Context,
+		userID uuid.UUID,
+		role oapi.UserRole,
+		jobID uuid.UUID,
+	) (io.ReadCloser, oapi.Job, error)
+	CancelJob(ctx context.Context, userID uuid.UUID, role oapi.UserRole, jobID uuid.UUID) (oapi.Job, error)
+}
+
+type JobHandler struct {
+	jobService jobService
+}
+
+func NewJobHandler(jobSvc jobService) *JobHandler {
+	return &JobHandler{jobService: jobSvc}
+}
+
+func (h *JobHandler) PostJob(c *fiber.Ctx) error {
+	var req oapi.PostJobsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	job, err := h.jobService.CreateJob(c.UserContext(), userIDFromLocals(c), roleFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusAccepted).JSON(job)
+}
+
+func (h *JobHandler) GetJob(c *fiber.Ctx, jobId openapi_types.UUID) error {
+	job, err := h.jobService.GetJob(c.UserContext(), userIDFromLocals(c), roleFromLocals(c), jobId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(job)
+}
+
+func (h *JobHandler) GetJobFile(c *fiber.Ctx, jobId openapi_types.UUID) error {
+	file, job, err := h.jobService.OpenJobFile(c.UserContext(), userIDFromLocals(c), roleFromLocals(c), jobId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	c.Set(fiber.HeaderContentType, export.ContentType(export.Format(job.Format)))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, job.Kind, job.Format))
+	return c.SendStream(file)
+}
+
+func (h *JobHandler) CancelJob(c *fiber.Ctx, jobId openapi_types.UUID) error {
+	job, err := h.jobService.CancelJob(c.UserContext(), userIDFromLocals(c), roleFromLocals(c), jobId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(job)
+}
@@ -0,0 +1,135 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockJobService struct{ mock.Mock }
+
+func (m *mockJobService) CreateJob(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	req oapi.PostJobsJSONRequestBody,
+) (oapi.Job, error) {
+	args := m.Called(ctx, userID, role, req)
+	return args.Get(0).(oapi.Job), args.Error(1)
+}
+
+func (m *mockJobService) GetJob(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	jobID uuid.UUID,
+) (oapi.Job, error) {
+	args := m.Called(ctx, userID, role, jobID)
+	return args.Get(0).(oapi.Job), args.Error(1)
+}
+
+func (m *mockJobService) OpenJobFile(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	jobID uuid.UUID,
+) (io.ReadCloser, oapi.Job, error) {
+	args := m.Called(ctx, userID, role, jobID)
+	file, _ := args.Get(0).(io.ReadCloser)
+	return file, args.Get(1).(oapi.Job), args.Error(2)
+}
+
+func (m *mockJobService) CancelJob(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	jobID uuid.UUID,
+) (oapi.Job, error) {
+	args := m.Called(ctx, userID, role, jobID)
+	return args.Get(0).(oapi.Job), args.Error(1)
+}
+
+func TestJobHandlers(t *testing.T) {
+	mockSvc := new(mockJobService)
+	h := NewJobHandler(mockSvc)
+	userID, jobID := uuid.New(), uuid.New()
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		c.Locals("role", "employee")
+		return c.Next()
+	})
+	app.Post("/jobs", h.PostJob)
+	app.Get("/jobs/:jobId", func(c *fiber.Ctx) error {
+		return h.GetJob(c, uuid.MustParse(c.Params("jobId")))
+	})
+	app.Get("/jobs/:jobId/file", func(c *fiber.Ctx) error {
+		return h.GetJobFile(c, uuid.MustParse(c.Params("jobId")))
+	})
+
+	t.Run("enqueue", func(t *testing.T) {
+		format := oapi.Xlsx
+		req := oapi.PostJobsJSONRequestBody{Kind: oapi.ExportReceptions, Format: &format}
+		mockSvc.On("CreateJob", mock.Anything, userID, oapi.UserRoleEmployee, req).
+			Return(oapi.Job{Id: jobID, Kind: oapi.ExportReceptions, Format: format, Status: oapi.Queued}, nil)
+
+		body, _ := json.Marshal(req)
+		httpReq := httptest.NewRequest(http.MethodPost, "/jobs", bytes.NewReader(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(httpReq, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusAccepted, resp.StatusCode)
+
+		var job oapi.Job
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+		require.Equal(t, oapi.Queued, job.Status)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		missing := uuid.New()
+		mockSvc.On("GetJob", mock.Anything, userID, oapi.UserRoleEmployee, missing).
+			Return(oapi.Job{}, pvz_errors.ErrJobNotFound)
+
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/jobs/"+missing.String(), nil), -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("download", func(t *testing.T) {
+		mockSvc.On("OpenJobFile", mock.Anything, userID, oapi.UserRoleEmployee, jobID).
+			Return(io.NopCloser(strings.NewReader("id\n1\n")), oapi.Job{Kind: oapi.ExportPvz, Format: oapi.Csv}, nil)
+
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/jobs/"+jobID.String()+"/file", nil), -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/csv; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+		require.Equal(t, `attachment; filename="export_pvz.csv"`, resp.Header.Get(fiber.HeaderContentDisposition))
+
+		body, _ := io.ReadAll(resp.Body)
+		require.Equal(t, "id\n1\n", string(body))
+	})
+
+	t.Run("result expired", func(t *testing.T) {
+		expired := uuid.New()
+		mockSvc.On("OpenJobFile", mock.Anything, userID, oapi.UserRoleEmployee, expired).
+			Return(nil, oapi.Job{}, pvz_errors.ErrJobExpired)
+
+		resp, _ := app.Test(httptest.NewRequest(http.MethodGet, "/jobs/"+expired.String()+"/file", nil), -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusGone, resp.StatusCode)
+	})
+}
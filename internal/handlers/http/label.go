@@ -0,0 +1,95 @@
+package http_handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type labelService interface {
+	RenderProductLabel(
+		ctx context.Context,
+		productID uuid.UUID,
+		params oapi.GetProductsProductIdLabelParams,
+	) ([]byte, oapi.LabelFormat, error)
+	RenderLabels(ctx context.Context, req oapi.PostLabelsJSONRequestBody) ([]byte, oapi.LabelFormat, error)
+	GetTemplate(ctx context.Context, pvzID uuid.UUID) (oapi.LabelTemplate, error)
+	SetTemplate(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		req oapi.PutPvzPvzIdLabelTemplateJSONRequestBody,
+	) (oapi.LabelTemplate, error)
+}
+
+type LabelHandler struct {
+	labelService labelService
+}
+
+func NewLabelHandler(labelSvc labelService) *LabelHandler {
+	return &LabelHandler{labelService: labelSvc}
+}
+
+func (h *LabelHandler) GetProductLabel(
+	c *fiber.Ctx,
+	productId openapi_types.UUID,
+	params oapi.GetProductsProductIdLabelParams,
+) error {
+	body, format, err := h.labelService.RenderProductLabel(c.UserContext(), productId, params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return sendLabel(c, body, format, "label-"+productId.String())
+}
+
+func (h *LabelHandler) PostLabels(c *fiber.Ctx) error {
+	var req oapi.PostLabelsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	body, format, err := h.labelService.RenderLabels(c.UserContext(), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return sendLabel(c, body, format, "labels")
+}
+
+func (h *LabelHandler) GetTemplate(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	tmpl, err := h.labelService.GetTemplate(c.UserContext(), pvzId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(tmpl)
+}
+
+func (h *LabelHandler) PutTemplate(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	var req oapi.PutPvzPvzIdLabelTemplateJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	tmpl, err := h.labelService.SetTemplate(c.UserContext(), pvzId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(tmpl)
+}
+
+func sendLabel(c *fiber.Ctx, body []byte, format oapi.LabelFormat, name string) error {
+	contentType := "application/pdf"
+	if format == oapi.Zpl {
+		contentType = "application/x-zpl"
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s.%s"`, name, format))
+	return c.Send(body)
+}
@@ -0,0 +1,104 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockLabelService struct{ mock.Mock }
+
+func (m *mockLabelService) RenderProductLabel(
+	ctx context.Context,
+	productID uuid.UUID,
+	params oapi.GetProductsProductIdLabelParams,
+) ([]byte, oapi.LabelFormat, error) {
+	args := m.Called(ctx, productID, params)
+	body, _ := args.Get(0).([]byte)
+	return body, args.Get(1).(oapi.LabelFormat), args.Error(2)
+}
+
+func (m *mockLabelService) RenderLabels(
+	ctx context.Context,
+	req oapi.PostLabelsJSONRequestBody,
+) ([]byte, oapi.LabelFormat, error) {
+	args := m.Called(ctx, req)
+	body, _ := args.Get(0).([]byte)
+	return body, args.Get(1).(oapi.LabelFormat), args.Error(2)
+}
+
+func (m *mockLabelService) GetTemplate(ctx context.Context, pvzID uuid.UUID) (oapi.LabelTemplate, error) {
+	args := m.Called(ctx, pvzID)
+	return args.Get(0).(oapi.LabelTemplate), args.Error(1)
+}
+
+func (m *mockLabelService) SetTemplate(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PutPvzPvzIdLabelTemplateJSONRequestBody,
+) (oapi.LabelTemplate, error) {
+	args := m.Called(ctx, pvzID, req)
+	return args.Get(0).(oapi.LabelTemplate), args.Error(1)
+}
+
+func TestLabelHandlers(t *testing.T) {
+	mockSvc := new(mockLabelService)
+	h := NewLabelHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/products/:productId/label", func(c *fiber.Ctx) error {
+		return h.GetProductLabel(c, uuid.MustParse(c.Params("productId")), oapi.GetProductsProductIdLabelParams{})
+	})
+	app.Post("/labels", h.PostLabels)
+
+	t.Run("pdf label", func(t *testing.T) {
+		productID := uuid.New()
+		mockSvc.
+			On("RenderProductLabel", mock.Anything, productID, oapi.GetProductsProductIdLabelParams{}).
+			Return([]byte("%PDF-1.4"), oapi.Pdf, nil)
+		req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/label", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/pdf", resp.Header.Get(fiber.HeaderContentType))
+
+		body, _ := io.ReadAll(resp.Body)
+		require.Equal(t, "%PDF-1.4", string(body))
+	})
+
+	t.Run("zpl batch", func(t *testing.T) {
+		format := oapi.Zpl
+		reqBody := oapi.PostLabelsJSONRequestBody{ProductIds: []uuid.UUID{uuid.New()}, Format: &format}
+		mockSvc.On("RenderLabels", mock.Anything, reqBody).Return([]byte("^XA^XZ"), oapi.Zpl, nil)
+		b, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/labels", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/x-zpl", resp.Header.Get(fiber.HeaderContentType))
+		require.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "labels.zpl")
+	})
+
+	t.Run("not printable", func(t *testing.T) {
+		productID := uuid.New()
+		mockSvc.
+			On("RenderProductLabel", mock.Anything, productID, oapi.GetProductsProductIdLabelParams{}).
+			Return(nil, oapi.LabelFormat(""), pvz_errors.ErrLabelNotPrintable)
+		req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String()+"/label", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
@@ -0,0 +1,17 @@
+package http_handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func userIDFromLocals(c *fiber.Ctx) uuid.UUID {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	return userID
+}
+
+func roleFromLocals(c *fiber.Ctx) oapi.UserRole {
+	role, _ := c.Locals("role").(string)
+	return oapi.UserRole(role)
+}
@@ -0,0 +1,80 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type orderService interface {
+	CreateOrder(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PostOrdersJSONRequestBody,
+	) (oapi.OrderWithPickupCode, error)
+	GetOrder(ctx context.Context, orderID uuid.UUID) (oapi.Order, error)
+	IssueOrder(
+		ctx context.Context,
+		userID, orderID uuid.UUID,
+		req oapi.PostOrdersOrderIdIssueJSONRequestBody,
+	) (oapi.Order, error)
+	ResetPickupCode(ctx context.Context, role oapi.UserRole, orderID uuid.UUID) (oapi.Order, error)
+}
+
+type OrderHandler struct {
+	orderService orderService
+}
+
+func NewOrderHandler(orderSvc orderService) *OrderHandler {
+	return &OrderHandler{orderService: orderSvc}
+}
+
+func (h *OrderHandler) PostOrder(c *fiber.Ctx) error {
+	var req oapi.PostOrdersJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	order, err := h.orderService.CreateOrder(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(order)
+}
+
+func (h *OrderHandler) GetOrder(c *fiber.Ctx, orderId openapi_types.UUID) error {
+	order, err := h.orderService.GetOrder(c.UserContext(), orderId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(order)
+}
+
+func (h *OrderHandler) IssueOrder(c *fiber.Ctx, orderId openapi_types.UUID) error {
+	var req oapi.PostOrdersOrderIdIssueJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	order, err := h.orderService.IssueOrder(c.UserContext(), userIDFromLocals(c), orderId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(order)
+}
+
+func (h *OrderHandler) ResetPickupCode(c *fiber.Ctx, orderId openapi_types.UUID) error {
+	order, err := h.orderService.ResetPickupCode(c.UserContext(), roleFromLocals(c), orderId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(order)
+}
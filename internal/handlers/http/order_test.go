@@ -0,0 +1,144 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockOrderService struct{ mock.Mock }
+
+func (m *mockOrderService) CreateOrder(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostOrdersJSONRequestBody,
+) (oapi.OrderWithPickupCode, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.OrderWithPickupCode), args.Error(1)
+}
+
+func (m *mockOrderService) GetOrder(ctx context.Context, orderID uuid.UUID) (oapi.Order, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).(oapi.Order), args.Error(1)
+}
+
+func (m *mockOrderService) IssueOrder(
+	ctx context.Context,
+	userID, orderID uuid.UUID,
+	req oapi.PostOrdersOrderIdIssueJSONRequestBody,
+) (oapi.Order, error) {
+	args := m.Called(ctx, userID, orderID, req)
+	return args.Get(0).(oapi.Order), args.Error(1)
+}
+
+func (m *mockOrderService) ResetPickupCode(
+	ctx context.Context,
+	role oapi.UserRole,
+	orderID uuid.UUID,
+) (oapi.Order, error) {
+	args := m.Called(ctx, role, orderID)
+	return args.Get(0).(oapi.Order), args.Error(1)
+}
+
+func TestOrderHandlers(t *testing.T) {
+	mockSvc := new(mockOrderService)
+	h := NewOrderHandler(mockSvc)
+	userID := uuid.New()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		c.Locals("role", "employee")
+		return c.Next()
+	})
+	app.Post("/orders", h.PostOrder)
+	app.Get("/orders/:orderId", func(c *fiber.Ctx) error {
+		return h.GetOrder(c, uuid.MustParse(c.Params("orderId")))
+	})
+	app.Post("/orders/:orderId/issue", func(c *fiber.Ctx) error {
+		return h.IssueOrder(c, uuid.MustParse(c.Params("orderId")))
+	})
+	app.Post("/orders/:orderId/pickup-code", func(c *fiber.Ctx) error {
+		return h.ResetPickupCode(c, uuid.MustParse(c.Params("orderId")))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		body := oapi.PostOrdersJSONRequestBody{CustomerRef: "ORD-1", PvzId: uuid.New(), ProductIds: []uuid.UUID{uuid.New()}}
+		code := "123456"
+		mockSvc.
+			On("CreateOrder", mock.Anything, userID, body).
+			Return(oapi.OrderWithPickupCode{Order: oapi.Order{CustomerRef: "ORD-1"}, PickupCode: &code}, nil)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var got oapi.OrderWithPickupCode
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, &code, got.PickupCode)
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		orderID := uuid.New()
+		mockSvc.On("GetOrder", mock.Anything, orderID).Return(oapi.Order{}, pvz_errors.ErrOrderNotFound)
+		req := httptest.NewRequest(http.MethodGet, "/orders/"+orderID.String(), nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("issue wrong code", func(t *testing.T) {
+		orderID := uuid.New()
+		body := oapi.PostOrdersOrderIdIssueJSONRequestBody{PickupCode: "000000"}
+		mockSvc.
+			On("IssueOrder", mock.Anything, userID, orderID, body).
+			Return(oapi.Order{}, pvz_errors.ErrInvalidPickupCode)
+		req := httptest.NewRequest(http.MethodPost, "/orders/"+orderID.String()+"/issue",
+			bytes.NewBufferString(`{"pickupCode":"000000"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("issue locked", func(t *testing.T) {
+		orderID := uuid.New()
+		body := oapi.PostOrdersOrderIdIssueJSONRequestBody{PickupCode: "111111"}
+		mockSvc.
+			On("IssueOrder", mock.Anything, userID, orderID, body).
+			Return(oapi.Order{}, pvz_errors.ErrPickupCodeLocked)
+		req := httptest.NewRequest(http.MethodPost, "/orders/"+orderID.String()+"/issue",
+			bytes.NewBufferString(`{"pickupCode":"111111"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusLocked, resp.StatusCode)
+	})
+
+	t.Run("reset code", func(t *testing.T) {
+		orderID := uuid.New()
+		mockSvc.
+			On("ResetPickupCode", mock.Anything, oapi.UserRoleEmployee, orderID).
+			Return(oapi.Order{Id: orderID, CustomerRef: "ORD-1"}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/orders/"+orderID.String()+"/pickup-code", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.NotContains(t, got, "pickupCode")
+	})
+}
@@ -11,16 +11,38 @@ import (
 )
 
 type productService interface {
-	AddProduct(ctx context.Context, req oapi.PostProductsJSONRequestBody) (oapi.Product, error)
-	DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error
+	AddProduct(ctx context.Context, userID uuid.UUID, req oapi.PostProductsJSONRequestBody) (oapi.Product, error)
+	DeleteLastProduct(ctx context.Context, userID, pvzID uuid.UUID) error
+	GetProductByBarcode(
+		ctx context.Context,
+		code string,
+		params oapi.GetProductsByBarcodeCodeParams,
+	) (oapi.Product, error)
+	AddProductBatch(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PostProductsBatchJSONRequestBody,
+	) (oapi.ProductBatchResult, error)
+	DeleteProduct(ctx context.Context, userID, productID uuid.UUID) (oapi.DeletedProduct, error)
+	UpdateProduct(
+		ctx context.Context,
+		productID uuid.UUID,
+		req oapi.PatchProductsProductIdJSONRequestBody,
+	) (oapi.Product, error)
+	RestoreProduct(ctx context.Context, productID uuid.UUID) (oapi.Product, error)
+	GetDeletedProducts(ctx context.Context, receptionID uuid.UUID) ([]oapi.DeletedProduct, error)
 }
 
 type ProductHandler struct {
 	productService productService
+	approvalGate   approvalGate
 }
 
-func NewProductHandler(prodSvc productService) *ProductHandler {
-	return &ProductHandler{productService: prodSvc}
+func NewProductHandler(prodSvc productService, approvals approvalGate) *ProductHandler {
+	return &ProductHandler{
+		productService: prodSvc,
+		approvalGate:   approvals,
+	}
 }
 
 func (h *ProductHandler) PostProducts(c *fiber.Ctx) error {
@@ -29,7 +51,7 @@ func (h *ProductHandler) PostProducts(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	product, err := h.productService.AddProduct(c.UserContext(), req)
+	product, err := h.productService.AddProduct(c.UserContext(), userIDFromLocals(c), req)
 	if err != nil {
 		status := pvz_errors.GetErrorStatusCode(err)
 		return fiber.NewError(status, err.Error())
@@ -38,10 +60,88 @@ func (h *ProductHandler) PostProducts(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(product)
 }
 
+func (h *ProductHandler) PostProductsBatch(c *fiber.Ctx) error {
+	var req oapi.PostProductsBatchJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	result, err := h.productService.AddProductBatch(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		if len(result.Items) > 0 {
+			return c.Status(status).JSON(result)
+		}
+		return fiber.NewError(status, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
 func (h *ProductHandler) PostPvzPvzIdDeleteLastProduct(c *fiber.Ctx, pvzId openapi_types.UUID) error {
-	if err := h.productService.DeleteLastProduct(c.UserContext(), pvzId); err != nil {
+	if handled, err := requireApproval(c, h.approvalGate, oapi.DeleteLastProduct, pvzId); handled {
+		return err
+	}
+	if err := h.productService.DeleteLastProduct(c.UserContext(), userIDFromLocals(c), pvzId); err != nil {
 		status := pvz_errors.GetErrorStatusCode(err)
 		return fiber.NewError(status, err.Error())
 	}
 	return c.SendStatus(fiber.StatusOK)
 }
+
+func (h *ProductHandler) GetProductByBarcode(
+	c *fiber.Ctx,
+	code string,
+	params oapi.GetProductsByBarcodeCodeParams,
+) error {
+	product, err := h.productService.GetProductByBarcode(c.UserContext(), code, params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(product)
+}
+
+func (h *ProductHandler) DeleteProduct(c *fiber.Ctx, productId openapi_types.UUID) error {
+	if handled, err := requireApproval(c, h.approvalGate, oapi.DeleteProduct, productId); handled {
+		return err
+	}
+	deleted, err := h.productService.DeleteProduct(c.UserContext(), userIDFromLocals(c), productId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(deleted)
+}
+
+func (h *ProductHandler) PatchProduct(c *fiber.Ctx, productId openapi_types.UUID) error {
+	var req oapi.PatchProductsProductIdJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	product, err := h.productService.UpdateProduct(c.UserContext(), productId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(product)
+}
+
+func (h *ProductHandler) RestoreProduct(c *fiber.Ctx, productId openapi_types.UUID) error {
+	product, err := h.productService.RestoreProduct(c.UserContext(), productId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(product)
+}
+
+func (h *ProductHandler) GetDeletedProducts(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	deleted, err := h.productService.GetDeletedProducts(c.UserContext(), receptionId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(deleted)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
 )
 
@@ -21,17 +22,25 @@ type mockProductService struct{ mock.Mock }
 
 func (m *mockProductService) AddProduct(
 	ctx context.Context,
+	userID uuid.UUID,
 	req oapi.PostProductsJSONRequestBody) (oapi.Product, error) {
-	args := m.Called(ctx, req)
+	args := m.Called(ctx, userID, req)
 	return args.Get(0).(oapi.Product), args.Error(1)
 }
-func (m *mockProductService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
-	return m.Called(ctx, pvzID).Error(0)
+func (m *mockProductService) DeleteLastProduct(ctx context.Context, userID, pvzID uuid.UUID) error {
+	return m.Called(ctx, userID, pvzID).Error(0)
+}
+func (m *mockProductService) GetProductByBarcode(
+	ctx context.Context,
+	code string,
+	params oapi.GetProductsByBarcodeCodeParams) (oapi.Product, error) {
+	args := m.Called(ctx, code, params)
+	return args.Get(0).(oapi.Product), args.Error(1)
 }
 
 func TestPostProducts(t *testing.T) {
 	mockSvc := new(mockProductService)
-	h := NewProductHandler(mockSvc)
+	h := NewProductHandler(mockSvc, nil)
 	app := fiber.New()
 	app.Post("/products", h.PostProducts)
 
@@ -44,8 +53,8 @@ func TestPostProducts(t *testing.T) {
 	})
 
 	t.Run("service error", func(t *testing.T) {
-		body := oapi.PostProductsJSONRequestBody{PvzId: uuid.New(), Type: oapi.PostProductsJSONBodyType("X")}
-		mockSvc.On("AddProduct", mock.Anything, body).Return(oapi.Product{}, errors.New("boom"))
+		body := oapi.PostProductsJSONRequestBody{PvzId: uuid.New(), Type: "X"}
+		mockSvc.On("AddProduct", mock.Anything, mock.Anything, body).Return(oapi.Product{}, errors.New("boom"))
 		req := httptest.NewRequest(http.MethodPost, "/products", marshaled(t, body))
 		req.Header.Set("Content-Type", "application/json")
 		resp, _ := app.Test(req, -1)
@@ -55,9 +64,9 @@ func TestPostProducts(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		body := oapi.PostProductsJSONRequestBody{PvzId: uuid.New(), Type: oapi.PostProductsJSONBodyType("X")}
+		body := oapi.PostProductsJSONRequestBody{PvzId: uuid.New(), Type: "X"}
 		want := oapi.Product{Id: ptrUUID(uuid.New())}
-		mockSvc.On("AddProduct", mock.Anything, body).Return(want, nil)
+		mockSvc.On("AddProduct", mock.Anything, mock.Anything, body).Return(want, nil)
 		req := httptest.NewRequest(http.MethodPost, "/products", marshaled(t, body))
 		req.Header.Set("Content-Type", "application/json")
 		resp, _ := app.Test(req, -1)
@@ -71,9 +80,241 @@ func TestPostProducts(t *testing.T) {
 	})
 }
 
+func (m *mockProductService) AddProductBatch(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostProductsBatchJSONRequestBody) (oapi.ProductBatchResult, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.ProductBatchResult), args.Error(1)
+}
+
+func (m *mockProductService) DeleteProduct(
+	ctx context.Context,
+	userID, productID uuid.UUID) (oapi.DeletedProduct, error) {
+	args := m.Called(ctx, userID, productID)
+	return args.Get(0).(oapi.DeletedProduct), args.Error(1)
+}
+
+func (m *mockProductService) UpdateProduct(
+	ctx context.Context,
+	productID uuid.UUID,
+	req oapi.PatchProductsProductIdJSONRequestBody) (oapi.Product, error) {
+	args := m.Called(ctx, productID, req)
+	return args.Get(0).(oapi.Product), args.Error(1)
+}
+
+func (m *mockProductService) RestoreProduct(ctx context.Context, productID uuid.UUID) (oapi.Product, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).(oapi.Product), args.Error(1)
+}
+
+func (m *mockProductService) GetDeletedProducts(
+	ctx context.Context,
+	receptionID uuid.UUID) ([]oapi.DeletedProduct, error) {
+	args := m.Called(ctx, receptionID)
+	return args.Get(0).([]oapi.DeletedProduct), args.Error(1)
+}
+
+func TestProductTrashHandlers(t *testing.T) {
+	mockSvc := new(mockProductService)
+	h := NewProductHandler(mockSvc, nil)
+	userID := uuid.New()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		return c.Next()
+	})
+	app.Delete("/products/:productId", func(c *fiber.Ctx) error {
+		return h.DeleteProduct(c, uuid.MustParse(c.Params("productId")))
+	})
+	app.Patch("/products/:productId", func(c *fiber.Ctx) error {
+		return h.PatchProduct(c, uuid.MustParse(c.Params("productId")))
+	})
+	app.Post("/products/:productId/restore", func(c *fiber.Ctx) error {
+		return h.RestoreProduct(c, uuid.MustParse(c.Params("productId")))
+	})
+	app.Get("/receptions/:receptionId/deleted-products", func(c *fiber.Ctx) error {
+		return h.GetDeletedProducts(c, uuid.MustParse(c.Params("receptionId")))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		productID := uuid.New()
+		want := oapi.DeletedProduct{Product: oapi.Product{Id: &productID, Type: "X"}, DeletedBy: &userID}
+		mockSvc.On("DeleteProduct", mock.Anything, userID, productID).Return(want, nil)
+		req := httptest.NewRequest(http.MethodDelete, "/products/"+productID.String(), nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.DeletedProduct
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want.Product.Id, got.Product.Id)
+		require.Equal(t, want.DeletedBy, got.DeletedBy)
+	})
+
+	t.Run("delete not in open reception", func(t *testing.T) {
+		productID := uuid.New()
+		mockSvc.
+			On("DeleteProduct", mock.Anything, userID, productID).
+			Return(oapi.DeletedProduct{}, pvz_errors.ErrProductNotInOpenReception)
+		req := httptest.NewRequest(http.MethodDelete, "/products/"+productID.String(), nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("patch bad body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPatch, "/products/"+uuid.NewString(), bytes.NewBufferString(`{`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		productID := uuid.New()
+		typ := "Y"
+		body := oapi.PatchProductsProductIdJSONRequestBody{Type: &typ}
+		mockSvc.On("UpdateProduct", mock.Anything, productID, body).Return(oapi.Product{Id: &productID, Type: typ}, nil)
+		req := httptest.NewRequest(http.MethodPatch, "/products/"+productID.String(), marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("restore conflict", func(t *testing.T) {
+		productID := uuid.New()
+		mockSvc.On("RestoreProduct", mock.Anything, productID).Return(oapi.Product{}, pvz_errors.ErrDuplicateBarcodeScan)
+		req := httptest.NewRequest(http.MethodPost, "/products/"+productID.String()+"/restore", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("list trash", func(t *testing.T) {
+		receptionID := uuid.New()
+		mockSvc.On("GetDeletedProducts", mock.Anything, receptionID).Return([]oapi.DeletedProduct{}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/receptions/"+receptionID.String()+"/deleted-products", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestPostProductsBatch(t *testing.T) {
+	mockSvc := new(mockProductService)
+	h := NewProductHandler(mockSvc, nil)
+	app := fiber.New()
+	app.Post("/products/batch", h.PostProductsBatch)
+
+	t.Run("bad body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/products/batch", bytes.NewBufferString(`[`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("nothing accepted", func(t *testing.T) {
+		body := oapi.PostProductsBatchJSONRequestBody{
+			PvzId: uuid.New(),
+			Items: []oapi.ProductBatchItem{{Type: "X", Barcode: ptrString("A")}},
+		}
+		result := oapi.ProductBatchResult{
+			Mode:   oapi.Atomic,
+			Failed: 1,
+			Items: []oapi.ProductBatchItemResult{
+				{Index: 0, Status: oapi.Failed, Error: ptrString(pvz_errors.ErrDuplicateBarcodeScan.Error())},
+			},
+		}
+		mockSvc.On("AddProductBatch", mock.Anything, mock.Anything, body).Return(result, pvz_errors.ErrDuplicateBarcodeScan)
+		req := httptest.NewRequest(http.MethodPost, "/products/batch", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+		var got oapi.ProductBatchResult
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, result, got)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		body := oapi.PostProductsBatchJSONRequestBody{PvzId: uuid.New(), Items: []oapi.ProductBatchItem{{Type: "Y"}}}
+		result := oapi.ProductBatchResult{
+			Mode:    oapi.Atomic,
+			Created: 1,
+			Items:   []oapi.ProductBatchItemResult{{Index: 0, Status: oapi.Created}},
+		}
+		mockSvc.On("AddProductBatch", mock.Anything, mock.Anything, body).Return(result, nil)
+		req := httptest.NewRequest(http.MethodPost, "/products/batch", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestPostProductsDuplicateBarcode(t *testing.T) {
+	mockSvc := new(mockProductService)
+	h := NewProductHandler(mockSvc, nil)
+	app := fiber.New()
+	app.Post("/products", h.PostProducts)
+
+	body := oapi.PostProductsJSONRequestBody{PvzId: uuid.New(), Type: "X", Barcode: ptrString("4601234567890")}
+	mockSvc.On("AddProduct", mock.Anything, mock.Anything, body).Return(oapi.Product{}, pvz_errors.ErrDuplicateBarcodeScan)
+	req := httptest.NewRequest(http.MethodPost, "/products", marshaled(t, body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetProductByBarcode(t *testing.T) {
+	mockSvc := new(mockProductService)
+	h := NewProductHandler(mockSvc, nil)
+	app := fiber.New()
+	app.Get("/products/by-barcode/:code", func(c *fiber.Ctx) error {
+		return h.GetProductByBarcode(c, c.Params("code"), oapi.GetProductsByBarcodeCodeParams{})
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockSvc.
+			On("GetProductByBarcode", mock.Anything, "missing", oapi.GetProductsByBarcodeCodeParams{}).
+			Return(oapi.Product{}, pvz_errors.ErrProductNotFound)
+		req := httptest.NewRequest(http.MethodGet, "/products/by-barcode/missing", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		want := oapi.Product{Id: ptrUUID(uuid.New()), Type: "X", Barcode: ptrString("4601234567890")}
+		mockSvc.
+			On("GetProductByBarcode", mock.Anything, "4601234567890", oapi.GetProductsByBarcodeCodeParams{}).
+			Return(want, nil)
+		req := httptest.NewRequest(http.MethodGet, "/products/by-barcode/4601234567890", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.Product
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
 func TestDeleteLastProduct(t *testing.T) {
 	mockSvc := new(mockProductService)
-	h := NewProductHandler(mockSvc)
+	h := NewProductHandler(mockSvc, nil)
 	app := fiber.New()
 	app.Delete("/products/:pvzId", func(c *fiber.Ctx) error {
 		return h.PostPvzPvzIdDeleteLastProduct(c, uuid.MustParse((c.Params("pvzId"))))
@@ -81,7 +322,7 @@ func TestDeleteLastProduct(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		id := uuid.New()
-		mockSvc.On("DeleteLastProduct", mock.Anything, id).Return(errors.New("boom"))
+		mockSvc.On("DeleteLastProduct", mock.Anything, uuid.Nil, id).Return(errors.New("boom"))
 		req := httptest.NewRequest(http.MethodDelete, "/products/"+id.String(), nil)
 		resp, _ := app.Test(req, -1)
 		defer resp.Body.Close()
@@ -91,7 +332,7 @@ func TestDeleteLastProduct(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
-		mockSvc.On("DeleteLastProduct", mock.Anything, id).Return(nil)
+		mockSvc.On("DeleteLastProduct", mock.Anything, uuid.Nil, id).Return(nil)
 		req := httptest.NewRequest(http.MethodDelete, "/products/"+id.String(), nil)
 		resp, _ := app.Test(req, -1)
 		defer resp.Body.Close()
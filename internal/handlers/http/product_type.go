@@ -0,0 +1,64 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type productTypeService interface {
+	GetProductTypes(ctx context.Context, params oapi.GetProductTypesParams) ([]oapi.ProductTypeInfo, error)
+	CreateProductType(ctx context.Context, req oapi.PostProductTypesJSONRequestBody) (oapi.ProductTypeInfo, error)
+	UpdateProductType(
+		ctx context.Context,
+		code string,
+		req oapi.PutProductTypesCodeJSONRequestBody,
+	) (oapi.ProductTypeInfo, error)
+}
+
+type ProductTypeHandler struct {
+	productTypeService productTypeService
+}
+
+func NewProductTypeHandler(productTypeSvc productTypeService) *ProductTypeHandler {
+	return &ProductTypeHandler{productTypeService: productTypeSvc}
+}
+
+func (h *ProductTypeHandler) GetProductTypes(c *fiber.Ctx, params oapi.GetProductTypesParams) error {
+	types, err := h.productTypeService.GetProductTypes(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(types)
+}
+
+func (h *ProductTypeHandler) PostProductType(c *fiber.Ctx) error {
+	var req oapi.PostProductTypesJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	productType, err := h.productTypeService.CreateProductType(c.UserContext(), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(productType)
+}
+
+func (h *ProductTypeHandler) PutProductType(c *fiber.Ctx, code string) error {
+	var req oapi.PutProductTypesCodeJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	productType, err := h.productTypeService.UpdateProductType(c.UserContext(), code, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(productType)
+}
@@ -0,0 +1,119 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockProductTypeService struct{ mock.Mock }
+
+func (m *mockProductTypeService) GetProductTypes(
+	ctx context.Context,
+	params oapi.GetProductTypesParams) ([]oapi.ProductTypeInfo, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.ProductTypeInfo), args.Error(1)
+}
+
+func (m *mockProductTypeService) CreateProductType(
+	ctx context.Context,
+	req oapi.PostProductTypesJSONRequestBody) (oapi.ProductTypeInfo, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(oapi.ProductTypeInfo), args.Error(1)
+}
+
+func (m *mockProductTypeService) UpdateProductType(
+	ctx context.Context,
+	code string,
+	req oapi.PutProductTypesCodeJSONRequestBody) (oapi.ProductTypeInfo, error) {
+	args := m.Called(ctx, code, req)
+	return args.Get(0).(oapi.ProductTypeInfo), args.Error(1)
+}
+
+func TestProductTypeHandlers(t *testing.T) {
+	mockSvc := new(mockProductTypeService)
+	h := NewProductTypeHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/product-types", func(c *fiber.Ctx) error {
+		return h.GetProductTypes(c, oapi.GetProductTypesParams{})
+	})
+	app.Post("/product-types", h.PostProductType)
+	app.Put("/product-types/:code", func(c *fiber.Ctx) error {
+		code, err := url.PathUnescape(c.Params("code"))
+		require.NoError(t, err)
+		return h.PutProductType(c, code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		want := []oapi.ProductTypeInfo{
+			{Code: "обувь", DisplayNames: map[string]string{"ru": "Обувь"}, Active: true},
+		}
+		mockSvc.On("GetProductTypes", mock.Anything, oapi.GetProductTypesParams{}).Return(want, nil)
+		req := httptest.NewRequest(http.MethodGet, "/product-types", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got []oapi.ProductTypeInfo
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+	})
+
+	t.Run("create bad body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/product-types", bytes.NewBufferString(`{{`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("create duplicate", func(t *testing.T) {
+		body := oapi.PostProductTypesJSONRequestBody{Code: "обувь", DisplayNames: map[string]string{"ru": "Обувь"}}
+		mockSvc.
+			On("CreateProductType", mock.Anything, body).
+			Return(oapi.ProductTypeInfo{}, pvz_errors.ErrProductTypeAlreadyExists)
+		req := httptest.NewRequest(http.MethodPost, "/product-types", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("create success", func(t *testing.T) {
+		body := oapi.PostProductTypesJSONRequestBody{
+			Code:         "мебель",
+			DisplayNames: map[string]string{"ru": "Мебель"},
+		}
+		mockSvc.On("CreateProductType", mock.Anything, body).Return(body, nil)
+		req := httptest.NewRequest(http.MethodPost, "/product-types", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("update not found", func(t *testing.T) {
+		body := oapi.PutProductTypesCodeJSONRequestBody{DisplayNames: map[string]string{"ru": "Книги"}}
+		mockSvc.
+			On("UpdateProductType", mock.Anything, "книги", body).
+			Return(oapi.ProductTypeInfo{}, pvz_errors.ErrProductTypeNotFound)
+		req := httptest.NewRequest(http.MethodPut, "/product-types/"+url.PathEscape("книги"), marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
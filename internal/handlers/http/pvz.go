@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/whaleship/pvz/internal/dto"
 	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
@@ -12,6 +14,12 @@ import (
 type pvzService interface {
 	CreatePVZ(ctx context.Context, req oapi.PostPvzJSONRequestBody) (oapi.PVZ, error)
 	GetPVZ(ctx context.Context, params oapi.GetPvzParams) ([]dto.PVZWithReceptions, error)
+	SetLocation(ctx context.Context, pvzID uuid.UUID, req oapi.PutPvzPvzIdLocationJSONRequestBody) (oapi.PVZ, error)
+	SetIntakeCapacity(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		req oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody,
+	) (oapi.PVZ, error)
 }
 
 type PVZHandler struct {
@@ -51,3 +59,33 @@ func (h *PVZHandler) GetPvz(c *fiber.Ctx) error {
 
 	return c.JSON(response)
 }
+
+func (h *PVZHandler) PutPvzLocation(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	var req oapi.PutPvzPvzIdLocationJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	pvz, err := h.pvzService.SetLocation(c.UserContext(), pvzId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+
+	return c.JSON(pvz)
+}
+
+func (h *PVZHandler) PutPvzIntakeCapacity(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	var req oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	pvz, err := h.pvzService.SetIntakeCapacity(c.UserContext(), pvzId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+
+	return c.JSON(pvz)
+}
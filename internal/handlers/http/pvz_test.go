@@ -37,6 +37,24 @@ func (m *mockPVZService) GetPVZ(ctx context.Context, params oapi.GetPvzParams) (
 	return res, args.Error(1)
 }
 
+func (m *mockPVZService) SetLocation(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PutPvzPvzIdLocationJSONRequestBody,
+) (oapi.PVZ, error) {
+	args := m.Called(ctx, pvzID, req)
+	return args.Get(0).(oapi.PVZ), args.Error(1)
+}
+
+func (m *mockPVZService) SetIntakeCapacity(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody,
+) (oapi.PVZ, error) {
+	args := m.Called(ctx, pvzID, req)
+	return args.Get(0).(oapi.PVZ), args.Error(1)
+}
+
 func TestPostPvz(t *testing.T) {
 	t.Run("bad body", func(t *testing.T) {
 		mockSvc := new(mockPVZService)
@@ -154,3 +172,54 @@ func TestGetPvz(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestPutPvzLocation(t *testing.T) {
+	mockSvc := new(mockPVZService)
+	h := NewPVZHandler(mockSvc)
+	app := fiber.New()
+	app.Put("/pvz/:pvzId/location", func(c *fiber.Ctx) error {
+		return h.PutPvzLocation(c, uuid.MustParse(c.Params("pvzId")))
+	})
+
+	pvzID, regionID := uuid.New(), uuid.New()
+	body := oapi.PutPvzPvzIdLocationJSONRequestBody{Latitude: 55.75, Longitude: 37.62}
+	mockSvc.
+		On("SetLocation", mock.Anything, pvzID, body).
+		Return(oapi.PVZ{Id: &pvzID, City: oapi.Москва, RegionId: &regionID}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/pvz/"+pvzID.String()+"/location", marshaled(t, body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var got oapi.PVZ
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.Equal(t, regionID, *got.RegionId)
+}
+
+func TestPutPvzIntakeCapacity(t *testing.T) {
+	mockSvc := new(mockPVZService)
+	h := NewPVZHandler(mockSvc)
+	app := fiber.New()
+	app.Put("/pvz/:pvzId/intake-capacity", func(c *fiber.Ctx) error {
+		return h.PutPvzIntakeCapacity(c, uuid.MustParse(c.Params("pvzId")))
+	})
+
+	pvzID := uuid.New()
+	capacity := 300
+	body := oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody{DailyIntakeCapacity: &capacity}
+	mockSvc.
+		On("SetIntakeCapacity", mock.Anything, pvzID, body).
+		Return(oapi.PVZ{Id: &pvzID, City: oapi.Москва, DailyIntakeCapacity: &capacity}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/pvz/"+pvzID.String()+"/intake-capacity", marshaled(t, body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req, -1)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var got oapi.PVZ
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.Equal(t, capacity, *got.DailyIntakeCapacity)
+}
@@ -1,6 +1,8 @@
 package http_handlers
 
 import (
+	"context"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -9,15 +11,24 @@ import (
 )
 
 type receptionService interface {
-	CreateReception(req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error)
-	CloseLastReception(pvzID uuid.UUID) (oapi.Reception, error)
+	CreateReception(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error)
+	CloseLastReception(ctx context.Context, userID, pvzID uuid.UUID) (oapi.Reception, error)
+	SearchReceptions(ctx context.Context, params oapi.GetReceptionsSearchParams) ([]oapi.Reception, error)
+	GetReceptionSummary(ctx context.Context, receptionID uuid.UUID) (oapi.ReceptionSummary, error)
 }
 type ReceptionHandler struct {
 	receptionService receptionService
+	approvalGate     approvalGate
 }
 
-func NewReceptionHandler(receptSvc receptionService) *ReceptionHandler {
-	return &ReceptionHandler{receptionService: receptSvc}
+func NewReceptionHandler(receptSvc receptionService, approvals approvalGate) *ReceptionHandler {
+	return &ReceptionHandler{
+		receptionService: receptSvc,
+		approvalGate:     approvals,
+	}
 }
 
 func (h *ReceptionHandler) PostReception(c *fiber.Ctx) error {
@@ -26,7 +37,7 @@ func (h *ReceptionHandler) PostReception(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	result, err := h.receptionService.CreateReception(req)
+	result, err := h.receptionService.CreateReception(c.UserContext(), userIDFromLocals(c), req)
 	if err != nil {
 		status := pvz_errors.GetErrorStatusCode(err)
 		return fiber.NewError(status, err.Error())
@@ -35,7 +46,28 @@ func (h *ReceptionHandler) PostReception(c *fiber.Ctx) error {
 }
 
 func (h *ReceptionHandler) CloseReception(c *fiber.Ctx, pvzId openapi_types.UUID) error {
-	result, err := h.receptionService.CloseLastReception(pvzId)
+	if handled, err := requireApproval(c, h.approvalGate, oapi.CloseReception, pvzId); handled {
+		return err
+	}
+	result, err := h.receptionService.CloseLastReception(c.UserContext(), userIDFromLocals(c), pvzId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(result)
+}
+
+func (h *ReceptionHandler) SearchReceptions(c *fiber.Ctx, params oapi.GetReceptionsSearchParams) error {
+	result, err := h.receptionService.SearchReceptions(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(result)
+}
+
+func (h *ReceptionHandler) GetReceptionSummary(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	result, err := h.receptionService.GetReceptionSummary(c.UserContext(), receptionId)
 	if err != nil {
 		status := pvz_errors.GetErrorStatusCode(err)
 		return fiber.NewError(status, err.Error())
@@ -2,6 +2,7 @@ package http_handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -13,23 +14,43 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
 )
 
 type mockReceptionService struct{ mock.Mock }
 
-func (m *mockReceptionService) CreateReception(req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error) {
-	args := m.Called(req)
+func (m *mockReceptionService) CreateReception(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error) {
+	args := m.Called(ctx, userID, req)
 	return args.Get(0).(oapi.Reception), args.Error(1)
 }
-func (m *mockReceptionService) CloseLastReception(pvzID uuid.UUID) (oapi.Reception, error) {
-	args := m.Called(pvzID)
+func (m *mockReceptionService) CloseLastReception(
+	ctx context.Context,
+	userID, pvzID uuid.UUID) (oapi.Reception, error) {
+	args := m.Called(ctx, userID, pvzID)
 	return args.Get(0).(oapi.Reception), args.Error(1)
 }
 
+func (m *mockReceptionService) SearchReceptions(
+	ctx context.Context,
+	params oapi.GetReceptionsSearchParams) ([]oapi.Reception, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.Reception), args.Error(1)
+}
+
+func (m *mockReceptionService) GetReceptionSummary(
+	ctx context.Context,
+	receptionID uuid.UUID) (oapi.ReceptionSummary, error) {
+	args := m.Called(ctx, receptionID)
+	return args.Get(0).(oapi.ReceptionSummary), args.Error(1)
+}
+
 func TestPostReception(t *testing.T) {
 	mockSvc := new(mockReceptionService)
-	h := NewReceptionHandler(mockSvc)
+	h := NewReceptionHandler(mockSvc, nil)
 	app := fiber.New()
 	app.Post("/receptions", h.PostReception)
 
@@ -43,7 +64,7 @@ func TestPostReception(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		body := oapi.PostReceptionsJSONRequestBody{PvzId: uuid.New()}
-		mockSvc.On("CreateReception", body).Return(oapi.Reception{}, errors.New("boom"))
+		mockSvc.On("CreateReception", mock.Anything, uuid.Nil, body).Return(oapi.Reception{}, errors.New("boom"))
 		req := httptest.NewRequest(http.MethodPost, "/receptions", marshaled(t, body))
 		req.Header.Set("Content-Type", "application/json")
 		resp, _ := app.Test(req, -1)
@@ -55,7 +76,7 @@ func TestPostReception(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		body := oapi.PostReceptionsJSONRequestBody{PvzId: uuid.New()}
 		want := oapi.Reception{Id: ptrUUID(uuid.New())}
-		mockSvc.On("CreateReception", body).Return(want, nil)
+		mockSvc.On("CreateReception", mock.Anything, uuid.Nil, body).Return(want, nil)
 		req := httptest.NewRequest(http.MethodPost, "/receptions", marshaled(t, body))
 		req.Header.Set("Content-Type", "application/json")
 		resp, _ := app.Test(req, -1)
@@ -70,7 +91,7 @@ func TestPostReception(t *testing.T) {
 
 func TestCloseReception(t *testing.T) {
 	mockSvc := new(mockReceptionService)
-	h := NewReceptionHandler(mockSvc)
+	h := NewReceptionHandler(mockSvc, nil)
 	app := fiber.New()
 	app.Post("/receptions/:pvzId/close", func(c *fiber.Ctx) error {
 		return h.CloseReception(c, uuid.MustParse(c.Params("pvzId")))
@@ -78,7 +99,7 @@ func TestCloseReception(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		id := uuid.New()
-		mockSvc.On("CloseLastReception", id).Return(oapi.Reception{}, errors.New("boom"))
+		mockSvc.On("CloseLastReception", mock.Anything, mock.Anything, id).Return(oapi.Reception{}, errors.New("boom"))
 		req := httptest.NewRequest(http.MethodPost, "/receptions/"+id.String()+"/close", nil)
 		resp, _ := app.Test(req, -1)
 		defer resp.Body.Close()
@@ -89,7 +110,7 @@ func TestCloseReception(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		id := uuid.New()
 		want := oapi.Reception{Id: ptrUUID(uuid.New())}
-		mockSvc.On("CloseLastReception", id).Return(want, nil)
+		mockSvc.On("CloseLastReception", mock.Anything, mock.Anything, id).Return(want, nil)
 		req := httptest.NewRequest(http.MethodPost, "/receptions/"+id.String()+"/close", nil)
 		resp, _ := app.Test(req, -1)
 		defer resp.Body.Close()
@@ -100,3 +121,73 @@ func TestCloseReception(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestSearchReceptions(t *testing.T) {
+	mockSvc := new(mockReceptionService)
+	h := NewReceptionHandler(mockSvc, nil)
+	app := fiber.New()
+	app.Get("/receptions/search", func(c *fiber.Ctx) error {
+		return h.SearchReceptions(c, oapi.GetReceptionsSearchParams{WaybillNumber: c.Query("waybillNumber")})
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		params := oapi.GetReceptionsSearchParams{WaybillNumber: "bad"}
+		mockSvc.
+			On("SearchReceptions", mock.Anything, params).
+			Return([]oapi.Reception(nil), pvz_errors.ErrInvalidWaybill)
+		req := httptest.NewRequest(http.MethodGet, "/receptions/search?waybillNumber=bad", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		params := oapi.GetReceptionsSearchParams{WaybillNumber: "WB-1"}
+		want := []oapi.Reception{{Id: ptrUUID(uuid.New()), WaybillNumber: &params.WaybillNumber}}
+		mockSvc.On("SearchReceptions", mock.Anything, params).Return(want, nil)
+		req := httptest.NewRequest(http.MethodGet, "/receptions/search?waybillNumber=WB-1", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var got []oapi.Reception
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestGetReceptionSummary(t *testing.T) {
+	mockSvc := new(mockReceptionService)
+	h := NewReceptionHandler(mockSvc, nil)
+	app := fiber.New()
+	app.Get("/receptions/:receptionId/summary", func(c *fiber.Ctx) error {
+		return h.GetReceptionSummary(c, uuid.MustParse(c.Params("receptionId")))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.
+			On("GetReceptionSummary", mock.Anything, id).
+			Return(oapi.ReceptionSummary{}, pvz_errors.ErrReceptionSummaryNotFound)
+		req := httptest.NewRequest(http.MethodGet, "/receptions/"+id.String()+"/summary", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		id := uuid.New()
+		want := oapi.ReceptionSummary{ReceptionId: id, TotalItems: 1, CountsByType: map[string]int{"обувь": 1}}
+		mockSvc.On("GetReceptionSummary", mock.Anything, id).Return(want, nil)
+		req := httptest.NewRequest(http.MethodGet, "/receptions/"+id.String()+"/summary", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		var got oapi.ReceptionSummary
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, want, got)
+		mockSvc.AssertExpectations(t)
+	})
+}
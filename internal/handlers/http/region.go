@@ -0,0 +1,72 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const geoJSONContentType = "application/geo+json"
+
+type regionService interface {
+	UploadRegions(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.RegionFeatureCollection,
+	) (oapi.RegionFeatureCollection, error)
+	GetRegions(ctx context.Context) (oapi.RegionFeatureCollection, error)
+	DeleteRegion(ctx context.Context, regionID uuid.UUID) error
+	GetPVZGeoJSON(ctx context.Context, params oapi.GetPvzGeojsonParams) (oapi.PvzFeatureCollection, error)
+}
+
+type RegionHandler struct {
+	regionService regionService
+}
+
+func NewRegionHandler(regionSvc regionService) *RegionHandler {
+	return &RegionHandler{regionService: regionSvc}
+}
+
+func (h *RegionHandler) UploadRegions(c *fiber.Ctx) error {
+	var req oapi.PostRegionsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	regions, err := h.regionService.UploadRegions(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(regions, geoJSONContentType)
+}
+
+func (h *RegionHandler) GetRegions(c *fiber.Ctx) error {
+	regions, err := h.regionService.GetRegions(c.UserContext())
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(regions, geoJSONContentType)
+}
+
+func (h *RegionHandler) DeleteRegion(c *fiber.Ctx, regionId openapi_types.UUID) error {
+	if err := h.regionService.DeleteRegion(c.UserContext(), regionId); err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *RegionHandler) GetPVZGeoJSON(c *fiber.Ctx, params oapi.GetPvzGeojsonParams) error {
+	pvzs, err := h.regionService.GetPVZGeoJSON(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(pvzs, geoJSONContentType)
+}
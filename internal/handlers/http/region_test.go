@@ -0,0 +1,95 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockRegionService struct{ mock.Mock }
+
+func (m *mockRegionService) UploadRegions(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.RegionFeatureCollection,
+) (oapi.RegionFeatureCollection, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.RegionFeatureCollection), args.Error(1)
+}
+
+func (m *mockRegionService) GetRegions(ctx context.Context) (oapi.RegionFeatureCollection, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(oapi.RegionFeatureCollection), args.Error(1)
+}
+
+func (m *mockRegionService) DeleteRegion(ctx context.Context, regionID uuid.UUID) error {
+	args := m.Called(ctx, regionID)
+	return args.Error(0)
+}
+
+func (m *mockRegionService) GetPVZGeoJSON(
+	ctx context.Context,
+	params oapi.GetPvzGeojsonParams,
+) (oapi.PvzFeatureCollection, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(oapi.PvzFeatureCollection), args.Error(1)
+}
+
+func TestRegionHandlers(t *testing.T) {
+	mockSvc := new(mockRegionService)
+	h := NewRegionHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/pvz.geojson", func(c *fiber.Ctx) error {
+		return h.GetPVZGeoJSON(c, oapi.GetPvzGeojsonParams{})
+	})
+	app.Post("/regions", h.UploadRegions)
+
+	t.Run("pvz geojson", func(t *testing.T) {
+		pvzID := uuid.New()
+		mockSvc.
+			On("GetPVZGeoJSON", mock.Anything, oapi.GetPvzGeojsonParams{}).
+			Return(oapi.PvzFeatureCollection{Type: oapi.FeatureCollection, Features: []oapi.PvzFeature{{
+				Type:     oapi.Feature,
+				Id:       pvzID,
+				Geometry: oapi.PvzPoint{Type: oapi.Point, Coordinates: []float64{37.62, 55.75}},
+			}}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/pvz.geojson", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/geo+json", resp.Header.Get("Content-Type"))
+
+		var got oapi.PvzFeatureCollection
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, pvzID, got.Features[0].Id)
+		require.Equal(t, []float64{37.62, 55.75}, got.Features[0].Geometry.Coordinates)
+	})
+
+	t.Run("invalid polygon", func(t *testing.T) {
+		body := []byte(`{"type": "FeatureCollection", "features": [{"type": "Feature",
+			"geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 1]]]}, "properties": {"name": "Центр"}}]}`)
+		mockSvc.
+			On("UploadRegions", mock.Anything, uuid.Nil, mock.Anything).
+			Return(oapi.RegionFeatureCollection{}, pvz_errors.ErrInvalidRegion)
+		req := httptest.NewRequest(http.MethodPost, "/regions", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/geo+json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockSvc.AssertCalled(t, "UploadRegions", mock.Anything, uuid.Nil, mock.MatchedBy(
+			func(req oapi.RegionFeatureCollection) bool {
+				return len(req.Features) == 1 && req.Features[0].Properties.Name == "Центр"
+			}))
+	})
+}
@@ -0,0 +1,53 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type reportService interface {
+	GetIntakeReport(ctx context.Context, params oapi.GetReportsIntakeParams) (oapi.IntakeReport, error)
+	GetEmployeeProductivity(
+		ctx context.Context,
+		params oapi.GetReportsEmployeesParams,
+	) (oapi.EmployeeProductivityReport, error)
+	GetReceptionSLA(ctx context.Context, params oapi.GetReportsReceptionSlaParams) (oapi.ReceptionSlaReport, error)
+}
+
+type ReportHandler struct {
+	reportService reportService
+}
+
+func NewReportHandler(reportSvc reportService) *ReportHandler {
+	return &ReportHandler{reportService: reportSvc}
+}
+
+func (h *ReportHandler) GetIntake(c *fiber.Ctx, params oapi.GetReportsIntakeParams) error {
+	report, err := h.reportService.GetIntakeReport(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(report)
+}
+
+func (h *ReportHandler) GetEmployees(c *fiber.Ctx, params oapi.GetReportsEmployeesParams) error {
+	report, err := h.reportService.GetEmployeeProductivity(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(report)
+}
+
+func (h *ReportHandler) GetReceptionSLA(c *fiber.Ctx, params oapi.GetReportsReceptionSlaParams) error {
+	report, err := h.reportService.GetReceptionSLA(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,107 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type reportSubscriptionService interface {
+	CreateSubscription(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PostReportSubscriptionsJSONRequestBody,
+	) (oapi.ReportSubscription, error)
+	GetSubscriptions(ctx context.Context) ([]oapi.ReportSubscription, error)
+	GetSubscription(ctx context.Context, subscriptionID uuid.UUID) (oapi.ReportSubscription, error)
+	UpdateSubscription(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		req oapi.PutReportSubscriptionsSubscriptionIdJSONRequestBody,
+	) (oapi.ReportSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error
+	GetDeliveries(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		params oapi.GetReportSubscriptionsSubscriptionIdDeliveriesParams,
+	) ([]oapi.ReportDelivery, error)
+}
+
+type ReportSubscriptionHandler struct {
+	subscriptionService reportSubscriptionService
+}
+
+func NewReportSubscriptionHandler(subscriptionSvc reportSubscriptionService) *ReportSubscriptionHandler {
+	return &ReportSubscriptionHandler{subscriptionService: subscriptionSvc}
+}
+
+func (h *ReportSubscriptionHandler) CreateSubscription(c *fiber.Ctx) error {
+	var req oapi.PostReportSubscriptionsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	sub, err := h.subscriptionService.CreateSubscription(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(sub)
+}
+
+func (h *ReportSubscriptionHandler) GetSubscriptions(c *fiber.Ctx) error {
+	subs, err := h.subscriptionService.GetSubscriptions(c.UserContext())
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(subs)
+}
+
+func (h *ReportSubscriptionHandler) GetSubscription(c *fiber.Ctx, subscriptionId openapi_types.UUID) error {
+	sub, err := h.subscriptionService.GetSubscription(c.UserContext(), subscriptionId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(sub)
+}
+
+func (h *ReportSubscriptionHandler) UpdateSubscription(c *fiber.Ctx, subscriptionId openapi_types.UUID) error {
+	var req oapi.PutReportSubscriptionsSubscriptionIdJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	sub, err := h.subscriptionService.UpdateSubscription(c.UserContext(), subscriptionId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(sub)
+}
+
+func (h *ReportSubscriptionHandler) DeleteSubscription(c *fiber.Ctx, subscriptionId openapi_types.UUID) error {
+	if err := h.subscriptionService.DeleteSubscription(c.UserContext(), subscriptionId); err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *ReportSubscriptionHandler) GetDeliveries(
+	c *fiber.Ctx,
+	subscriptionId openapi_types.UUID,
+	params oapi.GetReportSubscriptionsSubscriptionIdDeliveriesParams,
+) error {
+	deliveries, err := h.subscriptionService.GetDeliveries(c.UserContext(), subscriptionId, params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(deliveries)
+}
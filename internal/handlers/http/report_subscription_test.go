@@ -0,0 +1,126 @@
+package http_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockReportSubscriptionService struct{ mock.Mock }
+
+func (m *mockReportSubscriptionService) CreateSubscription(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReportSubscriptionsJSONRequestBody,
+) (oapi.ReportSubscription, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionService) GetSubscriptions(ctx context.Context) ([]oapi.ReportSubscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionService) GetSubscription(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+) (oapi.ReportSubscription, error) {
+	args := m.Called(ctx, subscriptionID)
+	return args.Get(0).(oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionService) UpdateSubscription(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	req oapi.PutReportSubscriptionsSubscriptionIdJSONRequestBody,
+) (oapi.ReportSubscription, error) {
+	args := m.Called(ctx, subscriptionID, req)
+	return args.Get(0).(oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionService) DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *mockReportSubscriptionService) GetDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	params oapi.GetReportSubscriptionsSubscriptionIdDeliveriesParams,
+) ([]oapi.ReportDelivery, error) {
+	args := m.Called(ctx, subscriptionID, params)
+	return args.Get(0).([]oapi.ReportDelivery), args.Error(1)
+}
+
+func TestReportSubscriptionHandlers(t *testing.T) {
+	mockSvc := new(mockReportSubscriptionService)
+	h := NewReportSubscriptionHandler(mockSvc)
+	app := fiber.New()
+	app.Post("/report-subscriptions", h.CreateSubscription)
+	app.Delete("/report-subscriptions/:id", func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return err
+		}
+		return h.DeleteSubscription(c, id)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		body := oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "Daily intake", Report: oapi.Intake, Schedule: "0 7 * * *",
+			Targets: []oapi.DeliveryTarget{{Type: oapi.Directory, Address: "ops"}},
+		}
+		subID := uuid.New()
+		mockSvc.
+			On("CreateSubscription", mock.Anything, uuid.Nil, body).
+			Return(oapi.ReportSubscription{Id: subID, Name: body.Name}, nil)
+		req := httptest.NewRequest(http.MethodPost, "/report-subscriptions", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var got oapi.ReportSubscription
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, subID, got.Id)
+	})
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		body := oapi.PostReportSubscriptionsJSONRequestBody{Name: "Broken", Report: oapi.Intake, Schedule: "daily"}
+		mockSvc.
+			On("CreateSubscription", mock.Anything, uuid.Nil, body).
+			Return(oapi.ReportSubscription{}, pvz_errors.ErrInvalidReportSubscription)
+		req := httptest.NewRequest(http.MethodPost, "/report-subscriptions", marshaled(t, body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		subID, missingID := uuid.New(), uuid.New()
+		mockSvc.On("DeleteSubscription", mock.Anything, subID).Return(nil)
+		mockSvc.On("DeleteSubscription", mock.Anything, missingID).Return(pvz_errors.ErrReportSubscriptionNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/report-subscriptions/"+subID.String(), nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+
+		req = httptest.NewRequest(http.MethodDelete, "/report-subscriptions/"+missingID.String(), nil)
+		resp, _ = app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,117 @@
+package http_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockReportService struct{ mock.Mock }
+
+func (m *mockReportService) GetIntakeReport(
+	ctx context.Context,
+	params oapi.GetReportsIntakeParams,
+) (oapi.IntakeReport, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(oapi.IntakeReport), args.Error(1)
+}
+
+func (m *mockReportService) GetEmployeeProductivity(
+	ctx context.Context,
+	params oapi.GetReportsEmployeesParams,
+) (oapi.EmployeeProductivityReport, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(oapi.EmployeeProductivityReport), args.Error(1)
+}
+
+func (m *mockReportService) GetReceptionSLA(
+	ctx context.Context,
+	params oapi.GetReportsReceptionSlaParams,
+) (oapi.ReceptionSlaReport, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(oapi.ReceptionSlaReport), args.Error(1)
+}
+
+func TestReportHandlers(t *testing.T) {
+	mockSvc := new(mockReportService)
+	h := NewReportHandler(mockSvc)
+	app := fiber.New()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	app.Get("/reports/intake", func(c *fiber.Ctx) error {
+		params := oapi.GetReportsIntakeParams{From: from, To: from.AddDate(0, 1, 0)}
+		if c.Query("to") == "past" {
+			params.To = from.AddDate(0, -1, 0)
+		}
+		return h.GetIntake(c, params)
+	})
+	app.Get("/reports/employees", func(c *fiber.Ctx) error {
+		return h.GetEmployees(c, oapi.GetReportsEmployeesParams{From: from, To: from.AddDate(0, 0, 7)})
+	})
+	app.Get("/reports/reception-sla", func(c *fiber.Ctx) error {
+		return h.GetReceptionSLA(c, oapi.GetReportsReceptionSlaParams{From: from, To: from.AddDate(0, 0, 7)})
+	})
+
+	t.Run("success", func(t *testing.T) {
+		params := oapi.GetReportsIntakeParams{From: from, To: from.AddDate(0, 1, 0)}
+		mockSvc.
+			On("GetIntakeReport", mock.Anything, params).
+			Return(oapi.IntakeReport{Totals: oapi.IntakeCounts{Products: 42}, Rows: []oapi.IntakeReportRow{}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/reports/intake", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.IntakeReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, 42, got.Totals.Products)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		params := oapi.GetReportsIntakeParams{From: from, To: from.AddDate(0, -1, 0)}
+		mockSvc.
+			On("GetIntakeReport", mock.Anything, params).
+			Return(oapi.IntakeReport{}, pvz_errors.ErrInvalidReport)
+		req := httptest.NewRequest(http.MethodGet, "/reports/intake?to=past", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+	t.Run("employees", func(t *testing.T) {
+		params := oapi.GetReportsEmployeesParams{From: from, To: from.AddDate(0, 0, 7)}
+		mockSvc.
+			On("GetEmployeeProductivity", mock.Anything, params).
+			Return(oapi.EmployeeProductivityReport{Rows: []oapi.EmployeeProductivity{{ProductsScanned: 7}}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/reports/employees", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.EmployeeProductivityReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, 7, got.Rows[0].ProductsScanned)
+	})
+	t.Run("reception sla", func(t *testing.T) {
+		params := oapi.GetReportsReceptionSlaParams{From: from, To: from.AddDate(0, 0, 7)}
+		mockSvc.
+			On("GetReceptionSLA", mock.Anything, params).
+			Return(oapi.ReceptionSlaReport{Totals: oapi.ReceptionSlaStats{Receptions: 5, Breaches: 2}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/reports/reception-sla", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got oapi.ReceptionSlaReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, 2, got.Totals.Breaches)
+	})
+}
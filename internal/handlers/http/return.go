@@ -0,0 +1,75 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type returnService interface {
+	GetReturnReasons(ctx context.Context, params oapi.GetReturnReasonsParams) ([]oapi.ReturnReason, error)
+	CreateReturnReason(ctx context.Context, req oapi.PostReturnReasonsJSONRequestBody) (oapi.ReturnReason, error)
+	CreateReturn(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PostReturnsJSONRequestBody,
+	) (oapi.CustomerReturn, error)
+	GetReturns(ctx context.Context, params oapi.GetReturnsParams) ([]oapi.CustomerReturn, error)
+}
+
+type ReturnHandler struct {
+	returnService returnService
+}
+
+func NewReturnHandler(returnSvc returnService) *ReturnHandler {
+	return &ReturnHandler{returnService: returnSvc}
+}
+
+func (h *ReturnHandler) GetReturnReasons(c *fiber.Ctx, params oapi.GetReturnReasonsParams) error {
+	reasons, err := h.returnService.GetReturnReasons(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(reasons)
+}
+
+func (h *ReturnHandler) PostReturnReason(c *fiber.Ctx) error {
+	var req oapi.PostReturnReasonsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	reason, err := h.returnService.CreateReturnReason(c.UserContext(), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(reason)
+}
+
+func (h *ReturnHandler) PostReturn(c *fiber.Ctx) error {
+	var req oapi.PostReturnsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	ret, err := h.returnService.CreateReturn(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(ret)
+}
+
+func (h *ReturnHandler) GetReturns(c *fiber.Ctx, params oapi.GetReturnsParams) error {
+	returns, err := h.returnService.GetReturns(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(returns)
+}
@@ -0,0 +1,99 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type returnShipmentService interface {
+	CreateReturnShipment(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PostReturnShipmentsJSONRequestBody,
+	) (oapi.ReturnShipment, error)
+	GetReturnShipments(ctx context.Context, params oapi.GetReturnShipmentsParams) ([]oapi.ReturnShipment, error)
+	GetReturnShipment(ctx context.Context, shipmentID uuid.UUID) (oapi.ReturnShipment, error)
+	AddReturns(
+		ctx context.Context,
+		shipmentID uuid.UUID,
+		req oapi.PostReturnShipmentsShipmentIdReturnsJSONRequestBody,
+	) (oapi.ReturnShipment, error)
+	UpdateStatus(
+		ctx context.Context,
+		shipmentID uuid.UUID,
+		req oapi.PostReturnShipmentsShipmentIdStatusJSONRequestBody,
+	) (oapi.ReturnShipment, error)
+}
+
+type ReturnShipmentHandler struct {
+	shipmentService returnShipmentService
+}
+
+func NewReturnShipmentHandler(shipmentSvc returnShipmentService) *ReturnShipmentHandler {
+	return &ReturnShipmentHandler{shipmentService: shipmentSvc}
+}
+
+func (h *ReturnShipmentHandler) PostReturnShipment(c *fiber.Ctx) error {
+	var req oapi.PostReturnShipmentsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	shipment, err := h.shipmentService.CreateReturnShipment(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(shipment)
+}
+
+func (h *ReturnShipmentHandler) GetReturnShipments(c *fiber.Ctx, params oapi.GetReturnShipmentsParams) error {
+	shipments, err := h.shipmentService.GetReturnShipments(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(shipments)
+}
+
+func (h *ReturnShipmentHandler) GetReturnShipment(c *fiber.Ctx, shipmentId openapi_types.UUID) error {
+	shipment, err := h.shipmentService.GetReturnShipment(c.UserContext(), shipmentId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(shipment)
+}
+
+func (h *ReturnShipmentHandler) AddReturns(c *fiber.Ctx, shipmentId openapi_types.UUID) error {
+	var req oapi.PostReturnShipmentsShipmentIdReturnsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	shipment, err := h.shipmentService.AddReturns(c.UserContext(), shipmentId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(shipment)
+}
+
+func (h *ReturnShipmentHandler) UpdateStatus(c *fiber.Ctx, shipmentId openapi_types.UUID) error {
+	var req oapi.PostReturnShipmentsShipmentIdStatusJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	shipment, err := h.shipmentService.UpdateStatus(c.UserContext(), shipmentId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(shipment)
+}
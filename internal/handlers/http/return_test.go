@@ -0,0 +1,179 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockReturnService struct{ mock.Mock }
+
+func (m *mockReturnService) GetReturnReasons(
+	ctx context.Context,
+	params oapi.GetReturnReasonsParams,
+) ([]oapi.ReturnReason, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.ReturnReason), args.Error(1)
+}
+
+func (m *mockReturnService) CreateReturnReason(
+	ctx context.Context,
+	req oapi.PostReturnReasonsJSONRequestBody,
+) (oapi.ReturnReason, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(oapi.ReturnReason), args.Error(1)
+}
+
+func (m *mockReturnService) CreateReturn(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReturnsJSONRequestBody,
+) (oapi.CustomerReturn, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.CustomerReturn), args.Error(1)
+}
+
+func (m *mockReturnService) GetReturns(
+	ctx context.Context,
+	params oapi.GetReturnsParams,
+) ([]oapi.CustomerReturn, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.CustomerReturn), args.Error(1)
+}
+
+type mockReturnShipmentService struct{ mock.Mock }
+
+func (m *mockReturnShipmentService) CreateReturnShipment(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReturnShipmentsJSONRequestBody,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentService) GetReturnShipments(
+	ctx context.Context,
+	params oapi.GetReturnShipmentsParams,
+) ([]oapi.ReturnShipment, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentService) GetReturnShipment(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, shipmentID)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentService) AddReturns(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	req oapi.PostReturnShipmentsShipmentIdReturnsJSONRequestBody,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, shipmentID, req)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentService) UpdateStatus(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	req oapi.PostReturnShipmentsShipmentIdStatusJSONRequestBody,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, shipmentID, req)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func TestReturnHandlers(t *testing.T) {
+	mockSvc := new(mockReturnService)
+	h := NewReturnHandler(mockSvc)
+	userID := uuid.New()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		return c.Next()
+	})
+	app.Post("/returns", h.PostReturn)
+
+	t.Run("create", func(t *testing.T) {
+		productID := uuid.New()
+		body := oapi.PostReturnsJSONRequestBody{PvzId: uuid.New(), ProductId: &productID, ReasonCode: "defect"}
+		mockSvc.
+			On("CreateReturn", mock.Anything, userID, body).
+			Return(oapi.CustomerReturn{ProductId: &productID, ReasonCode: "defect", Status: oapi.Accepted}, nil)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/returns", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var got oapi.CustomerReturn
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, &productID, got.ProductId)
+	})
+
+	t.Run("already returned", func(t *testing.T) {
+		productID := uuid.New()
+		body := oapi.PostReturnsJSONRequestBody{PvzId: uuid.New(), ProductId: &productID, ReasonCode: "defect"}
+		mockSvc.
+			On("CreateReturn", mock.Anything, userID, body).
+			Return(oapi.CustomerReturn{}, pvz_errors.ErrProductAlreadyReturned)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/returns", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestReturnShipmentHandlers(t *testing.T) {
+	mockSvc := new(mockReturnShipmentService)
+	h := NewReturnShipmentHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/return-shipments/:shipmentId", func(c *fiber.Ctx) error {
+		return h.GetReturnShipment(c, uuid.MustParse(c.Params("shipmentId")))
+	})
+	app.Post("/return-shipments/:shipmentId/status", func(c *fiber.Ctx) error {
+		return h.UpdateStatus(c, uuid.MustParse(c.Params("shipmentId")))
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		shipmentID := uuid.New()
+		mockSvc.
+			On("GetReturnShipment", mock.Anything, shipmentID).
+			Return(oapi.ReturnShipment{}, pvz_errors.ErrReturnShipmentNotFound)
+		req := httptest.NewRequest(http.MethodGet, "/return-shipments/"+shipmentID.String(), nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		shipmentID := uuid.New()
+		body := oapi.PostReturnShipmentsShipmentIdStatusJSONRequestBody{Status: oapi.Delivered}
+		mockSvc.
+			On("UpdateStatus", mock.Anything, shipmentID, body).
+			Return(oapi.ReturnShipment{}, pvz_errors.ErrInvalidShipmentTransition)
+		req := httptest.NewRequest(http.MethodPost, "/return-shipments/"+shipmentID.String()+"/status",
+			bytes.NewBufferString(`{"status":"delivered"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
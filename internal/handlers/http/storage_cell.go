@@ -0,0 +1,80 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type storageCellService interface {
+	ConfigureCells(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		req oapi.PostPvzPvzIdCellsJSONRequestBody,
+	) ([]oapi.StorageCell, error)
+	GetCells(ctx context.Context, pvzID uuid.UUID) ([]oapi.StorageCell, error)
+	PlaceProduct(
+		ctx context.Context,
+		productID uuid.UUID,
+		req oapi.PutProductsProductIdCellJSONRequestBody,
+	) (oapi.Product, error)
+	GetProductLocations(ctx context.Context, params oapi.GetProductsLocationsParams) ([]oapi.ProductLocation, error)
+}
+
+type StorageCellHandler struct {
+	cellService storageCellService
+}
+
+func NewStorageCellHandler(cellSvc storageCellService) *StorageCellHandler {
+	return &StorageCellHandler{cellService: cellSvc}
+}
+
+func (h *StorageCellHandler) PostCells(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	var req oapi.PostPvzPvzIdCellsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	cells, err := h.cellService.ConfigureCells(c.UserContext(), pvzId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(cells)
+}
+
+func (h *StorageCellHandler) GetCells(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	cells, err := h.cellService.GetCells(c.UserContext(), pvzId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(cells)
+}
+
+func (h *StorageCellHandler) PutProductCell(c *fiber.Ctx, productId openapi_types.UUID) error {
+	var req oapi.PutProductsProductIdCellJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	product, err := h.cellService.PlaceProduct(c.UserContext(), productId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(product)
+}
+
+func (h *StorageCellHandler) GetProductLocations(c *fiber.Ctx, params oapi.GetProductsLocationsParams) error {
+	locations, err := h.cellService.GetProductLocations(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(locations)
+}
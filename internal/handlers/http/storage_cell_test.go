@@ -0,0 +1,92 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockStorageCellService struct{ mock.Mock }
+
+func (m *mockStorageCellService) ConfigureCells(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PostPvzPvzIdCellsJSONRequestBody,
+) ([]oapi.StorageCell, error) {
+	args := m.Called(ctx, pvzID, req)
+	return args.Get(0).([]oapi.StorageCell), args.Error(1)
+}
+
+func (m *mockStorageCellService) GetCells(ctx context.Context, pvzID uuid.UUID) ([]oapi.StorageCell, error) {
+	args := m.Called(ctx, pvzID)
+	return args.Get(0).([]oapi.StorageCell), args.Error(1)
+}
+
+func (m *mockStorageCellService) PlaceProduct(
+	ctx context.Context,
+	productID uuid.UUID,
+	req oapi.PutProductsProductIdCellJSONRequestBody,
+) (oapi.Product, error) {
+	args := m.Called(ctx, productID, req)
+	return args.Get(0).(oapi.Product), args.Error(1)
+}
+
+func (m *mockStorageCellService) GetProductLocations(
+	ctx context.Context,
+	params oapi.GetProductsLocationsParams,
+) ([]oapi.ProductLocation, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.ProductLocation), args.Error(1)
+}
+
+func TestStorageCellHandlers(t *testing.T) {
+	mockSvc := new(mockStorageCellService)
+	h := NewStorageCellHandler(mockSvc)
+	app := fiber.New()
+	app.Get("/pvz/:pvzId/cells", func(c *fiber.Ctx) error {
+		return h.GetCells(c, uuid.MustParse(c.Params("pvzId")))
+	})
+	app.Put("/products/:productId/cell", func(c *fiber.Ctx) error {
+		return h.PutProductCell(c, uuid.MustParse(c.Params("productId")))
+	})
+
+	t.Run("occupancy", func(t *testing.T) {
+		pvzID := uuid.New()
+		mockSvc.
+			On("GetCells", mock.Anything, pvzID).
+			Return([]oapi.StorageCell{{PvzId: pvzID, Capacity: 10, Occupied: 7}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/pvz/"+pvzID.String()+"/cells", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got []oapi.StorageCell
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, 7, got[0].Occupied)
+	})
+
+	t.Run("cell full", func(t *testing.T) {
+		productID := uuid.New()
+		body := oapi.PutProductsProductIdCellJSONRequestBody{CellId: uuid.New()}
+		mockSvc.
+			On("PlaceProduct", mock.Anything, productID, body).
+			Return(oapi.Product{}, pvz_errors.ErrCellFull)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPut, "/products/"+productID.String()+"/cell", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
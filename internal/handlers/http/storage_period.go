@@ -0,0 +1,60 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type storagePeriodService interface {
+	GetStoragePeriods(ctx context.Context) ([]oapi.StoragePeriod, error)
+	SetStoragePeriod(
+		ctx context.Context,
+		userID uuid.UUID,
+		req oapi.PutStoragePeriodsJSONRequestBody,
+	) (oapi.StoragePeriod, error)
+	GetUnclaimed(ctx context.Context, params oapi.GetUnclaimedParams) ([]oapi.UnclaimedItem, error)
+}
+
+type StoragePeriodHandler struct {
+	storageService storagePeriodService
+}
+
+func NewStoragePeriodHandler(storageSvc storagePeriodService) *StoragePeriodHandler {
+	return &StoragePeriodHandler{storageService: storageSvc}
+}
+
+func (h *StoragePeriodHandler) GetStoragePeriods(c *fiber.Ctx) error {
+	periods, err := h.storageService.GetStoragePeriods(c.UserContext())
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(periods)
+}
+
+func (h *StoragePeriodHandler) PutStoragePeriods(c *fiber.Ctx) error {
+	var req oapi.PutStoragePeriodsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	period, err := h.storageService.SetStoragePeriod(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(period)
+}
+
+func (h *StoragePeriodHandler) GetUnclaimed(c *fiber.Ctx, params oapi.GetUnclaimedParams) error {
+	items, err := h.storageService.GetUnclaimed(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(items)
+}
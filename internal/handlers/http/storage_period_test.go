@@ -0,0 +1,80 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockStoragePeriodService struct{ mock.Mock }
+
+func (m *mockStoragePeriodService) GetStoragePeriods(ctx context.Context) ([]oapi.StoragePeriod, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]oapi.StoragePeriod), args.Error(1)
+}
+
+func (m *mockStoragePeriodService) SetStoragePeriod(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PutStoragePeriodsJSONRequestBody,
+) (oapi.StoragePeriod, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.StoragePeriod), args.Error(1)
+}
+
+func (m *mockStoragePeriodService) GetUnclaimed(
+	ctx context.Context,
+	params oapi.GetUnclaimedParams,
+) ([]oapi.UnclaimedItem, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.UnclaimedItem), args.Error(1)
+}
+
+func TestStoragePeriodHandlers(t *testing.T) {
+	mockSvc := new(mockStoragePeriodService)
+	h := NewStoragePeriodHandler(mockSvc)
+	app := fiber.New()
+	app.Put("/storage-periods", h.PutStoragePeriods)
+	app.Get("/unclaimed", func(c *fiber.Ctx) error {
+		return h.GetUnclaimed(c, oapi.GetUnclaimedParams{})
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		body := oapi.PutStoragePeriodsJSONRequestBody{Days: 0}
+		mockSvc.
+			On("SetStoragePeriod", mock.Anything, uuid.Nil, body).
+			Return(oapi.StoragePeriod{}, pvz_errors.ErrInvalidStoragePeriod)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPut, "/storage-periods", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("worklist", func(t *testing.T) {
+		productID := uuid.New()
+		mockSvc.
+			On("GetUnclaimed", mock.Anything, oapi.GetUnclaimedParams{}).
+			Return([]oapi.UnclaimedItem{{ProductId: productID}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/unclaimed", nil)
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var got []oapi.UnclaimedItem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, productID, got[0].ProductId)
+	})
+}
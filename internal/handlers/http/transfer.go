@@ -0,0 +1,76 @@
+package http_handlers
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type transferService interface {
+	CreateTransfer(ctx context.Context, userID uuid.UUID, req oapi.PostTransfersJSONRequestBody) (oapi.Transfer, error)
+	GetTransfer(ctx context.Context, transferID uuid.UUID) (oapi.Transfer, error)
+	ReceiveTransfer(
+		ctx context.Context,
+		userID, transferID uuid.UUID,
+		req oapi.PostTransfersTransferIdReceiveJSONRequestBody,
+	) (oapi.Transfer, error)
+	GetOverdueTransfers(ctx context.Context, params oapi.GetTransfersOverdueParams) ([]oapi.Transfer, error)
+}
+
+type TransferHandler struct {
+	transferService transferService
+}
+
+func NewTransferHandler(transferSvc transferService) *TransferHandler {
+	return &TransferHandler{transferService: transferSvc}
+}
+
+func (h *TransferHandler) PostTransfer(c *fiber.Ctx) error {
+	var req oapi.PostTransfersJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	transfer, err := h.transferService.CreateTransfer(c.UserContext(), userIDFromLocals(c), req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(transfer)
+}
+
+func (h *TransferHandler) GetTransfer(c *fiber.Ctx, transferId openapi_types.UUID) error {
+	transfer, err := h.transferService.GetTransfer(c.UserContext(), transferId)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(transfer)
+}
+
+func (h *TransferHandler) ReceiveTransfer(c *fiber.Ctx, transferId openapi_types.UUID) error {
+	var req oapi.PostTransfersTransferIdReceiveJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	transfer, err := h.transferService.ReceiveTransfer(c.UserContext(), userIDFromLocals(c), transferId, req)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(transfer)
+}
+
+func (h *TransferHandler) GetOverdueTransfers(c *fiber.Ctx, params oapi.GetTransfersOverdueParams) error {
+	transfers, err := h.transferService.GetOverdueTransfers(c.UserContext(), params)
+	if err != nil {
+		status := pvz_errors.GetErrorStatusCode(err)
+		return fiber.NewError(status, err.Error())
+	}
+	return c.JSON(transfers)
+}
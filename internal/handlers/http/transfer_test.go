@@ -0,0 +1,102 @@
+package http_handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockTransferService struct{ mock.Mock }
+
+func (m *mockTransferService) CreateTransfer(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostTransfersJSONRequestBody,
+) (oapi.Transfer, error) {
+	args := m.Called(ctx, userID, req)
+	return args.Get(0).(oapi.Transfer), args.Error(1)
+}
+
+func (m *mockTransferService) GetTransfer(ctx context.Context, transferID uuid.UUID) (oapi.Transfer, error) {
+	args := m.Called(ctx, transferID)
+	return args.Get(0).(oapi.Transfer), args.Error(1)
+}
+
+func (m *mockTransferService) ReceiveTransfer(
+	ctx context.Context,
+	userID, transferID uuid.UUID,
+	req oapi.PostTransfersTransferIdReceiveJSONRequestBody,
+) (oapi.Transfer, error) {
+	args := m.Called(ctx, userID, transferID, req)
+	return args.Get(0).(oapi.Transfer), args.Error(1)
+}
+
+func (m *mockTransferService) GetOverdueTransfers(
+	ctx context.Context,
+	params oapi.GetTransfersOverdueParams,
+) ([]oapi.Transfer, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).([]oapi.Transfer), args.Error(1)
+}
+
+func TestTransferHandlers(t *testing.T) {
+	mockSvc := new(mockTransferService)
+	h := NewTransferHandler(mockSvc)
+	userID := uuid.New()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		return c.Next()
+	})
+	app.Post("/transfers", h.PostTransfer)
+	app.Post("/transfers/:transferId/receive", func(c *fiber.Ctx) error {
+		return h.ReceiveTransfer(c, uuid.MustParse(c.Params("transferId")))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		body := oapi.PostTransfersJSONRequestBody{
+			SourcePvzId:      uuid.New(),
+			DestinationPvzId: uuid.New(),
+			ProductIds:       []uuid.UUID{uuid.New()},
+		}
+		mockSvc.
+			On("CreateTransfer", mock.Anything, userID, body).
+			Return(oapi.Transfer{Status: oapi.AwaitingReceipt}, nil)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		var got oapi.Transfer
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		require.Equal(t, oapi.AwaitingReceipt, got.Status)
+	})
+
+	t.Run("receive unexpected product", func(t *testing.T) {
+		transferID := uuid.New()
+		productID := uuid.New()
+		body := oapi.PostTransfersTransferIdReceiveJSONRequestBody{ProductIds: []uuid.UUID{productID}}
+		mockSvc.
+			On("ReceiveTransfer", mock.Anything, userID, transferID, body).
+			Return(oapi.Transfer{}, pvz_errors.ErrInvalidTransferProducts)
+		b, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/transfers/"+transferID.String()+"/receive", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ := app.Test(req, -1)
+		defer resp.Body.Close()
+		require.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type LocalFileStorage struct {
+	dir string
+}
+
+func NewLocalFileStorage(dir string) *LocalFileStorage {
+	return &LocalFileStorage{dir: dir}
+}
+
+func (s *LocalFileStorage) Create(name string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return nil, err
+	}
+	return os.Create(s.path(name))
+}
+
+func (s *LocalFileStorage) Open(name string) (io.ReadCloser, error) {
+	return os.Open(s.path(name))
+}
+
+func (s *LocalFileStorage) Remove(name string) error {
+	if err := os.Remove(s.path(name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalFileStorage) path(name string) string {
+	return filepath.Join(s.dir, filepath.Base(name))
+}
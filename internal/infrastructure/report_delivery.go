@@ -0,0 +1,109 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/utils"
+)
+
+type EmailSender interface {
+	SendEmail(ctx context.Context, to string, file dto.ReportFile) error
+}
+
+type ReportDeliverer struct {
+	dir    string
+	mailer EmailSender
+	client *http.Client
+}
+
+// NewReportDeliverer delivers reports into subdirectories of dir, by email
+// through mailer and to webhooks. Email targets fail while mailer is nil.
+// Webhooks are only dialed at public addresses, checked after DNS resolution
+// and on every redirect.
+func NewReportDeliverer(dir string, mailer EmailSender) *ReportDeliverer {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: dialPublicOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &ReportDeliverer{
+		dir:    dir,
+		mailer: mailer,
+		client: &http.Client{Timeout: 30 * time.Second, Transport: transport},
+	}
+}
+
+func dialPublicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !utils.IsPublicAddr(addrPort.Addr()) {
+		return pvz_errors.ErrDeliveryAddressForbidden
+	}
+	return nil
+}
+
+func (d *ReportDeliverer) Deliver(ctx context.Context, target oapi.DeliveryTarget, file dto.ReportFile) error {
+	switch target.Type {
+	case oapi.Directory:
+		return d.writeFile(target.Address, file)
+	case oapi.Email:
+		if d.mailer == nil {
+			return pvz_errors.ErrDeliveryTargetUnavailable
+		}
+		return d.mailer.SendEmail(ctx, target.Address, file)
+	case oapi.Webhook:
+		return d.post(ctx, target.Address, file)
+	default:
+		return pvz_errors.ErrDeliveryTargetUnavailable
+	}
+}
+
+// writeFile writes through a temporary file so that a directory watcher
+// never picks up a partially written report.
+func (d *ReportDeliverer) writeFile(subdir string, file dto.ReportFile) error {
+	if !filepath.IsLocal(subdir) {
+		return pvz_errors.ErrInvalidReportSubscription
+	}
+	dir := filepath.Join(d.dir, subdir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, filepath.Base(file.Name))
+	if err := os.WriteFile(path+".part", file.Body, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(path+".part", path)
+}
+
+func (d *ReportDeliverer) post(ctx context.Context, url string, file dto.ReportFile) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(file.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", file.ContentType)
+	req.Header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("report webhook responded with %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package infrastructure
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var testReport = dto.ReportFile{
+	Name:        "intake.csv",
+	Subject:     "Приемка за 01.10.2026",
+	ContentType: "text/csv",
+	Body:        []byte("pvz,products\nМосква,12\n"),
+}
+
+func TestDeliverToDirectory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("written into subdirectory", func(t *testing.T) {
+		root := t.TempDir()
+		d := NewReportDeliverer(root, nil)
+
+		err := d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Directory, Address: "daily/intake"}, testReport)
+		require.NoError(t, err)
+
+		body, err := os.ReadFile(filepath.Join(root, "daily", "intake", "intake.csv"))
+		require.NoError(t, err)
+		require.Equal(t, testReport.Body, body)
+		_, err = os.Stat(filepath.Join(root, "daily", "intake", "intake.csv.part"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("file name cannot leave the directory", func(t *testing.T) {
+		root := t.TempDir()
+		d := NewReportDeliverer(root, nil)
+		file := testReport
+		file.Name = "../../intake.csv"
+
+		require.NoError(t, d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Directory, Address: "daily"}, file))
+		_, err := os.Stat(filepath.Join(root, "daily", "intake.csv"))
+		require.NoError(t, err)
+	})
+
+	t.Run("report only appears after a complete write", func(t *testing.T) {
+		root := t.TempDir()
+		d := NewReportDeliverer(root, nil)
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "daily", "intake.csv.part"), 0o750))
+
+		err := d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Directory, Address: "daily"}, testReport)
+		require.Error(t, err)
+		_, err = os.Stat(filepath.Join(root, "daily", "intake.csv"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	for name, address := range map[string]string{
+		"parent directory": "../outside",
+		"absolute path":    "/tmp/outside",
+		"empty":            "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			d := NewReportDeliverer(filepath.Join(root, "reports"), nil)
+
+			err := d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Directory, Address: address}, testReport)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidReportSubscription)
+			entries, err := os.ReadDir(root)
+			require.NoError(t, err)
+			require.Empty(t, entries)
+		})
+	}
+}
+
+func TestDeliverToWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("posts the report", func(t *testing.T) {
+		var got *http.Request
+		var body []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+		d := NewReportDeliverer(t.TempDir(), nil)
+		d.client = srv.Client()
+
+		err := d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Webhook, Address: srv.URL + "/reports"}, testReport)
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPost, got.Method)
+		require.Equal(t, "/reports", got.URL.Path)
+		require.Equal(t, "text/csv", got.Header.Get("Content-Type"))
+		require.Equal(t, "attachment; filename=intake.csv", got.Header.Get("Content-Disposition"))
+		require.Equal(t, testReport.Body, body)
+	})
+
+	for name, status := range map[string]int{
+		"redirect":     http.StatusFound,
+		"client error": http.StatusBadRequest,
+		"server error": http.StatusBadGateway,
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(status)
+			}))
+			defer srv.Close()
+			d := NewReportDeliverer(t.TempDir(), nil)
+			d.client = srv.Client()
+			d.client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+			err := d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Webhook, Address: srv.URL}, testReport)
+			require.ErrorContains(t, err, strconv.Itoa(status))
+		})
+	}
+
+	t.Run("internal address refused", func(t *testing.T) {
+		called := false
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			called = true
+		}))
+		defer srv.Close()
+		d := NewReportDeliverer(t.TempDir(), nil)
+
+		err := d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Webhook, Address: srv.URL}, testReport)
+		require.ErrorIs(t, err, pvz_errors.ErrDeliveryAddressForbidden)
+		require.False(t, called)
+	})
+
+	t.Run("redirect to internal address refused", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://127.0.0.1:1/", http.StatusTemporaryRedirect)
+		}))
+		defer srv.Close()
+		d := NewReportDeliverer(t.TempDir(), nil)
+		transport := d.client.Transport.(*http.Transport)
+		dial := transport.DialContext
+		transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == srv.Listener.Addr().String() {
+				return (&net.Dialer{}).DialContext(ctx, network, address)
+			}
+			return dial(ctx, network, address)
+		}
+
+		err := d.Deliver(ctx, oapi.DeliveryTarget{Type: oapi.Webhook, Address: srv.URL}, testReport)
+		require.ErrorIs(t, err, pvz_errors.ErrDeliveryAddressForbidden)
+	})
+}
+
+func TestDeliverWithoutChannel(t *testing.T) {
+	d := NewReportDeliverer(t.TempDir(), nil)
+
+	err := d.Deliver(context.Background(), oapi.DeliveryTarget{Type: oapi.Email, Address: "ops@example.com"}, testReport)
+	require.ErrorIs(t, err, pvz_errors.ErrDeliveryTargetUnavailable)
+	err = d.Deliver(context.Background(), oapi.DeliveryTarget{Type: "ftp", Address: "ftp://example.com"}, testReport)
+	require.ErrorIs(t, err, pvz_errors.ErrDeliveryTargetUnavailable)
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+
+	"github.com/whaleship/pvz/internal/dto"
+)
+
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPSender sends mail through the server at addr; authentication is
+// skipped when username is empty.
+func NewSMTPSender(addr, from, username, password string) *SMTPSender {
+	sender := &SMTPSender{addr: addr, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		sender.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return sender
+}
+
+func (s *SMTPSender) SendEmail(ctx context.Context, to string, file dto.ReportFile) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	msg, err := s.message(to, file)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{to}, msg)
+}
+
+func (s *SMTPSender) message(to string, file dto.ReportFile) ([]byte, error) {
+	var buf bytes.Buffer
+	body := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\n", s.from, to,
+		mime.QEncoding.Encode("utf-8", file.Subject))
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", body.Boundary())
+
+	text, err := body.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = fmt.Fprintf(text, "%s\r\n", file.Subject); err != nil {
+		return nil, err
+	}
+
+	attachment, err := body.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {file.ContentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": file.Name})},
+	})
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(file.Body)
+	for len(encoded) > 76 {
+		if _, err = fmt.Fprintf(attachment, "%s\r\n", encoded[:76]); err != nil {
+			return nil, err
+		}
+		encoded = encoded[76:]
+	}
+	if _, err = fmt.Fprintf(attachment, "%s\r\n", encoded); err != nil {
+		return nil, err
+	}
+	if err = body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
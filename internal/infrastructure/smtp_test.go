@@ -0,0 +1,81 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/whaleship/pvz/internal/dto"
+)
+
+func TestSMTPMessage(t *testing.T) {
+	s := NewSMTPSender("smtp.example.com:587", "reports@example.com", "", "")
+	file := dto.ReportFile{
+		Name:        "damage.xlsx",
+		Subject:     "Повреждения за неделю",
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		Body:        bytes.Repeat([]byte{0x00, 0xff, 0x10}, 100),
+	}
+
+	raw, err := s.message("ops@example.com", file)
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	require.Equal(t, "reports@example.com", msg.Header.Get("From"))
+	require.Equal(t, "ops@example.com", msg.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, file.Subject, subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+
+	text, err := parts.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, "text/plain; charset=utf-8", text.Header.Get("Content-Type"))
+	body, err := io.ReadAll(text)
+	require.NoError(t, err)
+	require.Equal(t, file.Subject+"\r\n", string(body))
+
+	attachment, err := parts.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, file.ContentType, attachment.Header.Get("Content-Type"))
+	require.Equal(t, "damage.xlsx", attachment.FileName())
+	encoded, err := io.ReadAll(attachment)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(encoded), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		require.LessOrEqual(t, len(line), 76)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+	require.NoError(t, err)
+	require.Equal(t, file.Body, decoded)
+
+	_, err = parts.NextPart()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestNewSMTPSender(t *testing.T) {
+	require.Nil(t, NewSMTPSender("smtp.example.com:587", "reports@example.com", "", "").auth)
+	require.NotNil(t, NewSMTPSender("smtp.example.com:587", "reports@example.com", "reports", "secret").auth)
+}
+
+func TestSendEmailCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := NewSMTPSender("127.0.0.1:1", "reports@example.com", "", "")
+
+	err := s.SendEmail(ctx, "ops@example.com", dto.ReportFile{Name: "intake.csv"})
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/whaleship/pvz/internal/dto"
+)
+
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *WebhookNotifier) NotifyStorageExpiring(ctx context.Context, reminders []dto.StorageReminder) error {
+	return n.post(ctx, map[string]any{"event": "storage_expiring", "items": reminders})
+}
+
+func (n *WebhookNotifier) NotifyPickupCode(ctx context.Context, notice dto.PickupCodeNotice) error {
+	return n.post(ctx, map[string]any{"event": "pickup_code_reset", "order": notice})
+}
+
+func (n *WebhookNotifier) post(ctx context.Context, payload map[string]any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s webhook responded with %d", payload["event"], resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package label
+
+import "errors"
+
+var ErrUnsupportedBarcode = errors.New("barcode contains characters that cannot be encoded")
+
+const (
+	code128CodeB  = 100
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+)
+
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+// encodeCode128 returns the widths of alternating bars and spaces, starting
+// with a bar. Digit-only values use code set C, everything else code set B.
+func encodeCode128(value string) ([]int, error) {
+	if value == "" {
+		return nil, ErrUnsupportedBarcode
+	}
+	digits := true
+	for i := 0; i < len(value); i++ {
+		if value[i] < ' ' || value[i] > '~' {
+			return nil, ErrUnsupportedBarcode
+		}
+		if value[i] < '0' || value[i] > '9' {
+			digits = false
+		}
+	}
+
+	var codes []int
+	if digits && len(value) > 1 {
+		codes = append(codes, code128StartC)
+		i := 0
+		for ; i+1 < len(value); i += 2 {
+			codes = append(codes, int(value[i]-'0')*10+int(value[i+1]-'0'))
+		}
+		if i < len(value) {
+			codes = append(codes, code128CodeB, int(value[i]-' '))
+		}
+	} else {
+		codes = append(codes, code128StartB)
+		for i := 0; i < len(value); i++ {
+			codes = append(codes, int(value[i]-' '))
+		}
+	}
+
+	checksum := codes[0]
+	for i, code := range codes[1:] {
+		checksum += (i + 1) * code
+	}
+	codes = append(codes, checksum%103, code128Stop)
+
+	widths := make([]int, 0, len(codes)*6+1)
+	for _, code := range codes {
+		for _, w := range code128Patterns[code] {
+			widths = append(widths, int(w-'0'))
+		}
+	}
+	return widths, nil
+}
+
+func code128Modules(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	return total
+}
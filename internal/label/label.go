@@ -0,0 +1,70 @@
+package label
+
+import (
+	"errors"
+	"strings"
+)
+
+type Symbology string
+
+const (
+	Code128 Symbology = "code128"
+	QR      Symbology = "qr"
+)
+
+type Label struct {
+	WidthMm   float64
+	HeightMm  float64
+	Dpi       int
+	Symbology Symbology
+	Barcode   string
+	Lines     []string
+}
+
+var ErrEmptyBatch = errors.New("no labels to render")
+
+const (
+	marginMm   = 2.0
+	textSizeMm = 3.0
+	lineGapMm  = 0.8
+	pointsInMm = 72 / 25.4
+
+	code128QuietZone = 10
+	qrQuietZone      = 4
+)
+
+func (l Label) barcodeHeightMm() float64 {
+	return l.HeightMm - 2*marginMm - float64(len(l.Lines))*(textSizeMm+lineGapMm)
+}
+
+var cyrillicLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// transliterate keeps PDF labels printable with the standard base fonts,
+// which have no Cyrillic glyphs.
+func transliterate(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= ' ' && r <= '~':
+			b.WriteRune(r)
+		case cyrillicLatin[r] != "":
+			b.WriteString(cyrillicLatin[r])
+		default:
+			lower := []rune(strings.ToLower(string(r)))[0]
+			latin, ok := cyrillicLatin[lower]
+			if !ok {
+				b.WriteByte('?')
+				continue
+			}
+			if latin != "" {
+				b.WriteString(strings.ToUpper(latin[:1]) + latin[1:])
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package label
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	seen := map[string]bool{}
+	for i, pattern := range code128Patterns[:code128Stop] {
+		sum := 0
+		for _, w := range pattern {
+			sum += int(w - '0')
+		}
+		require.Equal(t, 11, sum, "pattern %d", i)
+		require.False(t, seen[pattern], "pattern %d", i)
+		seen[pattern] = true
+	}
+}
+
+func TestEncodeCode128(t *testing.T) {
+	t.Run("digits use code set C", func(t *testing.T) {
+		widths, err := encodeCode128("4600")
+		require.NoError(t, err)
+		// start C, 46, 00, checksum, stop
+		require.Equal(t, 4*11+13, code128Modules(widths))
+		require.Equal(t, []int{2, 1, 1, 2, 3, 2}, widths[:6])
+	})
+
+	t.Run("odd digits end in code set B", func(t *testing.T) {
+		widths, err := encodeCode128("123")
+		require.NoError(t, err)
+		require.Equal(t, 5*11+13, code128Modules(widths))
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		// start B (104) + 1*'P'(48) + 2*'J'(42) = 236, 236 % 103 = 30
+		widths, err := encodeCode128("PJ")
+		require.NoError(t, err)
+		require.Equal(t, "212123", patternAt(widths, 3))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := encodeCode128("товар")
+		require.ErrorIs(t, err, ErrUnsupportedBarcode)
+	})
+}
+
+func patternAt(widths []int, symbol int) string {
+	var b strings.Builder
+	for _, w := range widths[symbol*6 : symbol*6+6] {
+		b.WriteByte(byte('0' + w))
+	}
+	return b.String()
+}
+
+func TestReedSolomon(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ec := reedSolomonRemainder(data, reedSolomonDivisor(10))
+	require.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ec)
+}
+
+func TestEncodeQR(t *testing.T) {
+	t.Run("version by length", func(t *testing.T) {
+		modules, err := encodeQR("4600000000017")
+		require.NoError(t, err)
+		require.Equal(t, 21, len(modules))
+
+		modules, err = encodeQR(strings.Repeat("x", 128))
+		require.NoError(t, err)
+		require.Equal(t, 4*8+17, len(modules))
+	})
+
+	t.Run("format bits", func(t *testing.T) {
+		m := newQRMatrix(21)
+		m.drawFormatBits(0)
+		var bits strings.Builder
+		for x := 20; x >= 13; x-- {
+			if m.modules[8][x] {
+				bits.WriteByte('1')
+			} else {
+				bits.WriteByte('0')
+			}
+		}
+		require.Equal(t, "01001000", bits.String())
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		_, err := encodeQR(strings.Repeat("x", 214))
+		require.ErrorIs(t, err, ErrUnsupportedBarcode)
+	})
+}
+
+func TestPDF(t *testing.T) {
+	labels := []Label{
+		{WidthMm: 58, HeightMm: 40, Symbology: Code128, Barcode: "4600000000017", Lines: []string{"ПВЗ Москва"}},
+		{WidthMm: 58, HeightMm: 40, Symbology: QR, Barcode: "a(b)", Lines: []string{"обувь"}},
+	}
+	pdf, err := PDF(labels)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	require.Contains(t, string(pdf), "/Count 2")
+	require.Contains(t, string(pdf), "(PVZ Moskva)")
+	require.Contains(t, string(pdf), `(obuv)`)
+
+	_, err = PDF(nil)
+	require.ErrorIs(t, err, ErrEmptyBatch)
+}
+
+func TestZPL(t *testing.T) {
+	zpl, err := ZPL([]Label{
+		{WidthMm: 58, HeightMm: 40, Dpi: 203, Symbology: Code128, Barcode: "A^B", Lines: []string{"ПВЗ Москва"}},
+		{WidthMm: 58, HeightMm: 40, Dpi: 203, Symbology: QR, Barcode: "4600000000017"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(zpl), "^XA"))
+	require.Contains(t, string(zpl), "^PW463^LL319")
+	require.Contains(t, string(zpl), "^FDПВЗ Москва^FS")
+	require.Contains(t, string(zpl), "^FDA_5EB^FS")
+	require.Contains(t, string(zpl), "^FDMA,4600000000017^FS")
+}
@@ -0,0 +1,115 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PDF renders every label on its own page sized to the label.
+func PDF(labels []Label) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, ErrEmptyBatch
+	}
+
+	const firstPageObject = 4
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	}
+	kids := make([]string, 0, len(labels))
+	for i, l := range labels {
+		content, err := pdfContent(l)
+		if err != nil {
+			return nil, err
+		}
+		pageObject := firstPageObject + 2*i
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+				"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfNumber(l.WidthMm*pointsInMm), pdfNumber(l.HeightMm*pointsInMm), pageObject+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(labels))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes(), nil
+}
+
+func pdfContent(l Label) (string, error) {
+	var b strings.Builder
+	pt := func(mm float64) string { return pdfNumber(mm * pointsInMm) }
+
+	fontSize := pdfNumber(textSizeMm * pointsInMm)
+	y := l.HeightMm - marginMm
+	for _, line := range l.Lines {
+		y -= textSizeMm
+		fmt.Fprintf(&b, "BT /F1 %s Tf %s %s Td (%s) Tj ET\n", fontSize, pt(marginMm), pt(y), pdfEscape(line))
+		y -= lineGapMm
+	}
+
+	width := l.WidthMm - 2*marginMm
+	height := l.barcodeHeightMm()
+	switch l.Symbology {
+	case QR:
+		modules, err := encodeQR(l.Barcode)
+		if err != nil {
+			return "", err
+		}
+		side := min(width, height)
+		module := side / float64(len(modules)+2*qrQuietZone)
+		left := marginMm + (width-side)/2 + qrQuietZone*module
+		top := marginMm + side - qrQuietZone*module
+		for row, cells := range modules {
+			for col, dark := range cells {
+				if dark {
+					fmt.Fprintf(&b, "%s %s %s %s re\n", pt(left+float64(col)*module),
+						pt(top-float64(row+1)*module), pt(module), pt(module))
+				}
+			}
+		}
+	default:
+		widths, err := encodeCode128(l.Barcode)
+		if err != nil {
+			return "", err
+		}
+		module := width / float64(code128Modules(widths)+2*code128QuietZone)
+		barHeight := height - textSizeMm - lineGapMm
+		x := marginMm + code128QuietZone*module
+		for i, w := range widths {
+			if i%2 == 0 {
+				fmt.Fprintf(&b, "%s %s %s %s re\n", pt(x), pt(marginMm+textSizeMm+lineGapMm),
+					pt(float64(w)*module), pt(barHeight))
+			}
+			x += float64(w) * module
+		}
+		fmt.Fprintf(&b, "BT /F1 %s Tf %s %s Td (%s) Tj ET\n", fontSize, pt(marginMm), pt(marginMm),
+			pdfEscape(l.Barcode))
+	}
+	b.WriteString("f")
+	return b.String(), nil
+}
+
+func pdfEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(transliterate(s))
+}
+
+func pdfNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
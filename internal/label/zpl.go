@@ -0,0 +1,57 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// ZPL renders labels for thermal printers; barcodes are drawn by the printer.
+func ZPL(labels []Label) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, ErrEmptyBatch
+	}
+
+	var buf bytes.Buffer
+	for _, l := range labels {
+		dots := func(mm float64) int { return int(mm * float64(l.Dpi) / 25.4) }
+		textDots := dots(textSizeMm)
+
+		fmt.Fprintf(&buf, "^XA^CI28^PW%d^LL%d\n", dots(l.WidthMm), dots(l.HeightMm))
+		y := marginMm
+		for _, line := range l.Lines {
+			fmt.Fprintf(&buf, "^FO%d,%d^A0N,%d,%d^FH_^FD%s^FS\n",
+				dots(marginMm), dots(y), textDots, textDots, zplEscape(line))
+			y += textSizeMm + lineGapMm
+		}
+
+		width := dots(l.WidthMm - 2*marginMm)
+		height := dots(l.barcodeHeightMm())
+		switch l.Symbology {
+		case QR:
+			modules, err := encodeQR(l.Barcode)
+			if err != nil {
+				return nil, err
+			}
+			magnification := max(1, min(10, min(width, height)/(len(modules)+2*qrQuietZone)))
+			left := dots(marginMm) + (width-magnification*len(modules))/2
+			fmt.Fprintf(&buf, "^FO%d,%d^BQN,2,%d^FH_^FDMA,%s^FS\n",
+				left, dots(y), magnification, zplEscape(l.Barcode))
+		default:
+			widths, err := encodeCode128(l.Barcode)
+			if err != nil {
+				return nil, err
+			}
+			module := max(1, min(10, width/(code128Modules(widths)+2*code128QuietZone)))
+			barHeight := max(1, height-textDots*3/2)
+			fmt.Fprintf(&buf, "^FO%d,%d^BY%d^BCN,%d,Y,N,N,A^FH_^FD%s^FS\n",
+				dots(marginMm)+module*code128QuietZone, dots(y), module, barHeight, zplEscape(l.Barcode))
+		}
+		buf.WriteString("^XZ\n")
+	}
+	return buf.Bytes(), nil
+}
+
+func zplEscape(s string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(s)
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type approvalRepository struct {
+	db database.PgxIface
+}
+
+func NewApprovalRepository(dbConn database.PgxIface) *approvalRepository {
+	return &approvalRepository{db: dbConn}
+}
+
+func (r *approvalRepository) GetApprovalRule(
+	ctx context.Context,
+	operation oapi.ApprovalOperation,
+) (oapi.ApprovalRule, error) {
+	var (
+		rule oapi.ApprovalRule
+		op   string
+	)
+	err := r.db.QueryRow(ctx, QueryGetApprovalRule, string(operation)).
+		Scan(&op, &rule.Enabled, &rule.UpdatedBy, &rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.ApprovalRule{}, pvz_errors.ErrInvalidApprovalOperation
+		}
+		return oapi.ApprovalRule{}, err
+	}
+	rule.Operation = oapi.ApprovalOperation(op)
+	return rule, nil
+}
+
+func (r *approvalRepository) SelectApprovalRules(ctx context.Context) ([]oapi.ApprovalRule, error) {
+	rows, err := r.db.Query(ctx, QuerySelectApprovalRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []oapi.ApprovalRule{}
+	for rows.Next() {
+		var (
+			rule oapi.ApprovalRule
+			op   string
+		)
+		if err := rows.Scan(&op, &rule.Enabled, &rule.UpdatedBy, &rule.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rule.Operation = oapi.ApprovalOperation(op)
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *approvalRepository) UpdateApprovalRule(
+	ctx context.Context,
+	rule oapi.ApprovalRule,
+) (oapi.ApprovalRule, error) {
+	cmdTag, err := r.db.Exec(ctx, QueryUpdateApprovalRule,
+		string(rule.Operation),
+		rule.Enabled,
+		rule.UpdatedBy,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return oapi.ApprovalRule{}, err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return oapi.ApprovalRule{}, pvz_errors.ErrInvalidApprovalOperation
+	}
+	return rule, nil
+}
+
+func (r *approvalRepository) HasReceptionDiscrepancies(ctx context.Context, pvzID uuid.UUID) (bool, error) {
+	var has bool
+	err := r.db.QueryRow(ctx, QueryOpenReceptionHasDiscrepancies, pvzID).Scan(&has)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return false, pvz_errors.ErrCloseReceptionFailed
+		}
+		return false, err
+	}
+	return has, nil
+}
+
+func (r *approvalRepository) InsertApproval(ctx context.Context, approval oapi.Approval) (oapi.Approval, error) {
+	query, subjectID, notFoundErr := QueryInsertDeleteProductApproval, approval.PvzId, pvz_errors.ErrDeletingProduct
+	switch approval.Operation {
+	case oapi.CloseReception:
+		query, notFoundErr = QueryInsertCloseReceptionApproval, pvz_errors.ErrCloseReceptionFailed
+	case oapi.DeleteProduct:
+		query, notFoundErr = QueryInsertDeleteProductByIDApproval, pvz_errors.ErrProductNotInOpenReception
+		subjectID = approval.TargetId
+	}
+
+	err := r.db.QueryRow(ctx, query,
+		approval.Id,
+		subjectID,
+		approval.RequestedBy,
+		approval.RequestedAt,
+	).Scan(&approval.PvzId, &approval.TargetId)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Approval{}, notFoundErr
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_approvals_pending_target" {
+			return oapi.Approval{}, pvz_errors.ErrApprovalAlreadyPending
+		}
+		return oapi.Approval{}, err
+	}
+
+	approval.Status = oapi.ApprovalStatusPending
+	return approval, nil
+}
+
+func (r *approvalRepository) SelectApprovals(
+	ctx context.Context,
+	status oapi.ApprovalStatus,
+	limit, offset int,
+) ([]oapi.Approval, error) {
+	rows, err := r.db.Query(ctx, QuerySelectApprovals, string(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectApprovalsFailed, err)
+	}
+	defer rows.Close()
+
+	approvals := []oapi.Approval{}
+	for rows.Next() {
+		a, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
+
+func (r *approvalRepository) ApproveRequest(
+	ctx context.Context,
+	approvalID, decidedBy uuid.UUID,
+	decidedAt time.Time,
+	comment *string,
+) (oapi.Approval, error) {
+	return r.decide(ctx, approvalID, decidedBy, decidedAt, comment, oapi.ApprovalStatusApproved)
+}
+
+func (r *approvalRepository) RejectRequest(
+	ctx context.Context,
+	approvalID, decidedBy uuid.UUID,
+	decidedAt time.Time,
+	comment *string,
+) (oapi.Approval, error) {
+	return r.decide(ctx, approvalID, decidedBy, decidedAt, comment, oapi.ApprovalStatusRejected)
+}
+
+func (r *approvalRepository) decide(
+	ctx context.Context,
+	approvalID, decidedBy uuid.UUID,
+	decidedAt time.Time,
+	comment *string,
+	status oapi.ApprovalStatus,
+) (oapi.Approval, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Approval{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var (
+		operation, current string
+		targetID           uuid.UUID
+		requestedBy        *uuid.UUID
+	)
+	err = tx.QueryRow(ctx, QueryLockApproval, approvalID).Scan(&operation, &current, &targetID, &requestedBy)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Approval{}, pvz_errors.ErrApprovalNotFound
+		}
+		return oapi.Approval{}, err
+	}
+	if oapi.ApprovalStatus(current) != oapi.ApprovalStatusPending {
+		err = pvz_errors.ErrApprovalAlreadyDecided
+		return oapi.Approval{}, err
+	}
+	if requestedBy != nil && *requestedBy == decidedBy {
+		err = pvz_errors.ErrApprovalSelfDecision
+		return oapi.Approval{}, err
+	}
+
+	if status == oapi.ApprovalStatusApproved {
+		err = r.applyApproval(ctx, tx, oapi.ApprovalOperation(operation), targetID, requestedBy, decidedAt)
+		if err != nil {
+			return oapi.Approval{}, err
+		}
+	}
+
+	row := tx.QueryRow(ctx, QueryDecideApproval, approvalID, string(status), decidedBy, decidedAt, comment)
+	approval, err := scanApproval(row)
+	if err != nil {
+		return oapi.Approval{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Approval{}, err
+	}
+	return approval, nil
+}
+
+func (r *approvalRepository) applyApproval(
+	ctx context.Context,
+	tx pgx.Tx,
+	operation oapi.ApprovalOperation,
+	targetID uuid.UUID,
+	requestedBy *uuid.UUID,
+	decidedAt time.Time,
+) error {
+	switch operation {
+	case oapi.DeleteLastProduct:
+		_, err := trashProduct(ctx, tx, targetID, requestedBy, decidedAt)
+		if errors.Is(err, pvz_errors.ErrProductNotInOpenReception) {
+			return pvz_errors.ErrDeletingProduct
+		}
+		return err
+	case oapi.DeleteProduct:
+		_, err := trashProduct(ctx, tx, targetID, requestedBy, decidedAt)
+		return err
+	case oapi.CloseReception:
+		var (
+			pvzID               uuid.UUID
+			openTime, closeTime time.Time
+		)
+		err := tx.QueryRow(ctx, QueryCloseReceptionByID, targetID, requestedBy).Scan(&pvzID, &openTime, &closeTime)
+		if err != nil {
+			if errors.Is(err, r.db.ErrNoRows()) {
+				return pvz_errors.ErrCloseReceptionFailed
+			}
+			return err
+		}
+		_, err = summarizeReception(ctx, tx, targetID, openTime, closeTime)
+		return err
+	default:
+		return pvz_errors.ErrInvalidApprovalOperation
+	}
+}
+
+func scanApproval(row pgx.Row) (oapi.Approval, error) {
+	var (
+		a                 oapi.Approval
+		id                uuid.UUID
+		operation, status string
+		requestedAt       time.Time
+	)
+	err := row.Scan(&id, &operation, &status, &a.PvzId, &a.TargetId,
+		&a.RequestedBy, &requestedAt, &a.DecidedBy, &a.DecidedAt, &a.Comment)
+	if err != nil {
+		return oapi.Approval{}, err
+	}
+	a.Id = &id
+	a.Operation = oapi.ApprovalOperation(operation)
+	a.Status = oapi.ApprovalStatus(status)
+	a.RequestedAt = &requestedAt
+	return a, nil
+}
@@ -0,0 +1,358 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var approvalColumns = []string{
+	"id", "operation", "status", "pvz_id", "target_id",
+	"requested_by", "requested_at", "decided_by", "decided_at", "comment",
+}
+
+func TestGetApprovalRule(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewApprovalRepository(db)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetApprovalRule).
+			WithArgs("close_reception").
+			WillReturnRows(pgxmock.NewRows([]string{"operation", "enabled", "updated_by", "updated_at"}).
+				AddRow("close_reception", true, nil, nil))
+
+		rule, err := repo.GetApprovalRule(ctx, oapi.CloseReception)
+		require.NoError(t, err)
+		require.True(t, rule.Enabled)
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetApprovalRule).
+			WithArgs("drop_pvz").
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.GetApprovalRule(ctx, "drop_pvz")
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidApprovalOperation)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestInsertApproval(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewApprovalRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+	approval := oapi.Approval{
+		Id:          uuidPtr(uuid.New()),
+		Operation:   oapi.DeleteLastProduct,
+		PvzId:       uuid.New(),
+		RequestedBy: uuidPtr(uuid.New()),
+		RequestedAt: &now,
+	}
+
+	t.Run("delete last product", func(t *testing.T) {
+		productID := uuid.New()
+		mockPool.
+			ExpectQuery(QueryInsertDeleteProductApproval).
+			WithArgs(approval.Id, approval.PvzId, approval.RequestedBy, approval.RequestedAt).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "target_id"}).AddRow(approval.PvzId, productID))
+
+		got, err := repo.InsertApproval(ctx, approval)
+		require.NoError(t, err)
+		require.Equal(t, productID, got.TargetId)
+		require.Equal(t, oapi.ApprovalStatusPending, got.Status)
+	})
+
+	t.Run("delete product by id", func(t *testing.T) {
+		pvzID, productID := uuid.New(), uuid.New()
+		byID := approval
+		byID.Operation = oapi.DeleteProduct
+		byID.PvzId = uuid.Nil
+		byID.TargetId = productID
+		mockPool.
+			ExpectQuery(QueryInsertDeleteProductByIDApproval).
+			WithArgs(byID.Id, productID, byID.RequestedBy, byID.RequestedAt).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "target_id"}).AddRow(pvzID, productID))
+
+		got, err := repo.InsertApproval(ctx, byID)
+		require.NoError(t, err)
+		require.Equal(t, pvzID, got.PvzId)
+		require.Equal(t, productID, got.TargetId)
+	})
+
+	t.Run("delete product outside open reception", func(t *testing.T) {
+		byID := approval
+		byID.Operation = oapi.DeleteProduct
+		byID.TargetId = uuid.New()
+		mockPool.
+			ExpectQuery(QueryInsertDeleteProductByIDApproval).
+			WithArgs(anyArgs(4)...).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.InsertApproval(ctx, byID)
+		require.ErrorIs(t, err, pvz_errors.ErrProductNotInOpenReception)
+	})
+
+	t.Run("no products", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryInsertDeleteProductApproval).
+			WithArgs(anyArgs(4)...).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.InsertApproval(ctx, approval)
+		require.ErrorIs(t, err, pvz_errors.ErrDeletingProduct)
+	})
+
+	t.Run("close reception already pending", func(t *testing.T) {
+		closing := approval
+		closing.Operation = oapi.CloseReception
+		mockPool.
+			ExpectQuery(QueryInsertCloseReceptionApproval).
+			WithArgs(anyArgs(4)...).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_approvals_pending_target"})
+
+		_, err := repo.InsertApproval(ctx, closing)
+		require.ErrorIs(t, err, pvz_errors.ErrApprovalAlreadyPending)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestApproveRequest(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewApprovalRepository(db)
+	ctx := context.Background()
+	approvalID := uuid.New()
+	moderatorID := uuid.New()
+	employeeID := uuid.New()
+	targetID := uuid.New()
+	now := time.Now()
+
+	lockRows := func(operation, status string, requestedBy uuid.UUID) *pgxmock.Rows {
+		return pgxmock.NewRows([]string{"operation", "status", "target_id", "requested_by"}).
+			AddRow(operation, status, targetID, &requestedBy)
+	}
+
+	t.Run("delete product applied", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockApproval).
+			WithArgs(approvalID).
+			WillReturnRows(lockRows("delete_last_product", "pending", employeeID))
+		mockPool.
+			ExpectQuery(QueryLockProductReception).
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id"}).AddRow(uuid.New()))
+		mockPool.
+			ExpectQuery(QueryTrashProduct).
+			WithArgs(targetID, &employeeID, now).
+			WillReturnRows(pgxmock.NewRows(deletedProductColumns).
+				AddRow(targetID, uuid.New(), now, "обувь", nil, nil, nil, nil, &employeeID, now))
+		mockPool.
+			ExpectQuery(QueryDecideApproval).
+			WithArgs(approvalID, "approved", moderatorID, now, (*string)(nil)).
+			WillReturnRows(pgxmock.NewRows(approvalColumns).
+				AddRow(approvalID, "delete_last_product", "approved", uuid.New(), targetID,
+					&employeeID, now, &moderatorID, &now, nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.ApproveRequest(ctx, approvalID, moderatorID, now, nil)
+		require.NoError(t, err)
+		require.Equal(t, oapi.ApprovalStatusApproved, got.Status)
+		require.Equal(t, moderatorID, *got.DecidedBy)
+	})
+
+	t.Run("delete product by id moved to trash", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockApproval).
+			WithArgs(approvalID).
+			WillReturnRows(lockRows("delete_product", "pending", employeeID))
+		mockPool.
+			ExpectQuery(QueryLockProductReception).
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id"}).AddRow(uuid.New()))
+		mockPool.
+			ExpectQuery(QueryTrashProduct).
+			WithArgs(targetID, &employeeID, now).
+			WillReturnRows(pgxmock.NewRows(deletedProductColumns).
+				AddRow(targetID, uuid.New(), now, "обувь", nil, nil, nil, nil, &employeeID, now))
+		mockPool.
+			ExpectQuery(QueryDecideApproval).
+			WithArgs(approvalID, "approved", moderatorID, now, (*string)(nil)).
+			WillReturnRows(pgxmock.NewRows(approvalColumns).
+				AddRow(approvalID, "delete_product", "approved", uuid.New(), targetID,
+					&employeeID, now, &moderatorID, &now, nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.ApproveRequest(ctx, approvalID, moderatorID, now, nil)
+		require.NoError(t, err)
+		require.Equal(t, oapi.DeleteProduct, got.Operation)
+	})
+
+	t.Run("close reception applied with summary", func(t *testing.T) {
+		openTime := now.Add(-time.Hour)
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockApproval).
+			WithArgs(approvalID).
+			WillReturnRows(lockRows("close_reception", "pending", employeeID))
+		mockPool.
+			ExpectQuery(QueryCloseReceptionByID).
+			WithArgs(targetID, &employeeID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "date_time", "close_date_time"}).
+				AddRow(uuid.New(), openTime, now))
+		mockPool.
+			ExpectQuery(QueryCountProductsByType).
+			WithArgs(targetID).
+			WillReturnRows(pgxmock.NewRows([]string{"type", "count", "min", "max"}))
+		mockPool.
+			ExpectExec(QueryInsertReceptionSummary).
+			WithArgs(anyArgs(7)...).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.
+			ExpectQuery(QueryDecideApproval).
+			WithArgs(anyArgs(5)...).
+			WillReturnRows(pgxmock.NewRows(approvalColumns).
+				AddRow(approvalID, "close_reception", "approved", uuid.New(), targetID,
+					&employeeID, now, &moderatorID, &now, nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.ApproveRequest(ctx, approvalID, moderatorID, now, nil)
+		require.NoError(t, err)
+		require.Equal(t, oapi.CloseReception, got.Operation)
+	})
+
+	t.Run("product already gone", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockApproval).
+			WithArgs(approvalID).
+			WillReturnRows(lockRows("delete_last_product", "pending", employeeID))
+		mockPool.
+			ExpectQuery(QueryLockProductReception).
+			WithArgs(targetID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.ApproveRequest(ctx, approvalID, moderatorID, now, nil)
+		require.ErrorIs(t, err, pvz_errors.ErrDeletingProduct)
+	})
+
+	t.Run("already decided", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockApproval).
+			WithArgs(approvalID).
+			WillReturnRows(lockRows("delete_last_product", "rejected", employeeID))
+		mockPool.ExpectRollback()
+
+		_, err := repo.ApproveRequest(ctx, approvalID, moderatorID, now, nil)
+		require.ErrorIs(t, err, pvz_errors.ErrApprovalAlreadyDecided)
+	})
+
+	t.Run("self decision", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockApproval).
+			WithArgs(approvalID).
+			WillReturnRows(lockRows("close_reception", "pending", moderatorID))
+		mockPool.ExpectRollback()
+
+		_, err := repo.ApproveRequest(ctx, approvalID, moderatorID, now, nil)
+		require.ErrorIs(t, err, pvz_errors.ErrApprovalSelfDecision)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockApproval).
+			WithArgs(approvalID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.ApproveRequest(ctx, approvalID, moderatorID, now, nil)
+		require.ErrorIs(t, err, pvz_errors.ErrApprovalNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestRejectRequest(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewApprovalRepository(db)
+	ctx := context.Background()
+	approvalID := uuid.New()
+	moderatorID := uuid.New()
+	now := time.Now()
+	comment := strPtr("нет оснований")
+
+	mockPool.ExpectBegin()
+	mockPool.
+		ExpectQuery(QueryLockApproval).
+		WithArgs(approvalID).
+		WillReturnRows(pgxmock.NewRows([]string{"operation", "status", "target_id", "requested_by"}).
+			AddRow("delete_last_product", "pending", uuid.New(), nil))
+	mockPool.
+		ExpectQuery(QueryDecideApproval).
+		WithArgs(approvalID, "rejected", moderatorID, now, comment).
+		WillReturnRows(pgxmock.NewRows(approvalColumns).
+			AddRow(approvalID, "delete_last_product", "rejected", uuid.New(), uuid.New(),
+				nil, now, &moderatorID, &now, comment))
+	mockPool.ExpectCommit()
+
+	got, err := repo.RejectRequest(ctx, approvalID, moderatorID, now, comment)
+	require.NoError(t, err)
+	require.Equal(t, oapi.ApprovalStatusRejected, got.Status)
+	require.Equal(t, comment, got.Comment)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectApprovals(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewApprovalRepository(db)
+	ctx := context.Background()
+
+	mockPool.
+		ExpectQuery(QuerySelectApprovals).
+		WithArgs("pending", 30, 0).
+		WillReturnError(errors.New("db down"))
+	_, err = repo.SelectApprovals(ctx, oapi.ApprovalStatusPending, 30, 0)
+	require.ErrorIs(t, err, pvz_errors.ErrSelectApprovalsFailed)
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type containerRepository struct {
+	db database.PgxIface
+}
+
+func NewContainerRepository(dbConn database.PgxIface) *containerRepository {
+	return &containerRepository{db: dbConn}
+}
+
+func (r *containerRepository) InsertContainer(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	container oapi.Container,
+	products []oapi.Product,
+) (oapi.Container, []oapi.Product, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Container{}, nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	types := make([]string, 0, len(products))
+	for _, p := range products {
+		types = append(types, p.Type)
+	}
+	if err = ensureActiveProductTypes(ctx, tx, types); err != nil {
+		return oapi.Container{}, nil, err
+	}
+
+	var receptionID uuid.UUID
+	err = tx.QueryRow(ctx, QueryInsertContainer,
+		pvzID,
+		container.Id,
+		container.Barcode,
+		string(container.Kind),
+		container.DeclaredItems,
+		container.CreatedAt,
+	).Scan(&receptionID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Container{}, nil, pvz_errors.ErrNoOpenRecetionOrPvz
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "containers_barcode_key" {
+			return oapi.Container{}, nil, pvz_errors.ErrContainerAlreadyExists
+		}
+		return oapi.Container{}, nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(products))
+	for i := range products {
+		products[i].ReceptionId = receptionID
+		ids = append(ids, *products[i].Id)
+	}
+
+	_, err = tx.Exec(ctx, QueryInsertContainerProducts, ids, receptionID, container.CreatedAt, container.Id, types)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_products_type" {
+			return oapi.Container{}, nil, pvz_errors.ErrInvalidProduct
+		}
+		return oapi.Container{}, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Container{}, nil, err
+	}
+
+	container.ReceptionId = receptionID
+	container.State = oapi.ContainerStateSealed
+	return container, products, nil
+}
+
+func (r *containerRepository) OpenContainer(
+	ctx context.Context,
+	containerID uuid.UUID,
+	openedAt time.Time,
+) (oapi.Container, error) {
+	cmdTag, err := r.db.Exec(ctx, QueryOpenContainer, containerID, openedAt)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+
+	container, err := r.GetContainer(ctx, containerID)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return oapi.Container{}, pvz_errors.ErrInvalidContainerState
+	}
+	return container, nil
+}
+
+func (r *containerRepository) VerifyContainerProducts(
+	ctx context.Context,
+	containerID uuid.UUID,
+	productIDs []uuid.UUID,
+	verifiedAt time.Time,
+) (oapi.Container, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var state string
+	err = tx.QueryRow(ctx, QueryLockContainerState, containerID).Scan(&state)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Container{}, pvz_errors.ErrContainerNotFound
+		}
+		return oapi.Container{}, err
+	}
+	if oapi.ContainerState(state) != oapi.ContainerStateOpened {
+		err = pvz_errors.ErrInvalidContainerState
+		return oapi.Container{}, err
+	}
+
+	cmdTag, err := tx.Exec(ctx, QueryVerifyContainerProducts, containerID, productIDs, verifiedAt)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+	if cmdTag.RowsAffected() != int64(len(productIDs)) {
+		err = pvz_errors.ErrInvalidContainerProducts
+		return oapi.Container{}, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryCompleteContainerVerification, containerID, verifiedAt); err != nil {
+		return oapi.Container{}, err
+	}
+
+	rows, err := tx.Query(ctx, QueryGetContainer, containerID)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+	containers, err := scanContainers(rows)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+	if len(containers) == 0 {
+		err = pvz_errors.ErrContainerNotFound
+		return oapi.Container{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Container{}, err
+	}
+	return containers[0], nil
+}
+
+func (r *containerRepository) GetContainer(ctx context.Context, containerID uuid.UUID) (oapi.Container, error) {
+	rows, err := r.db.Query(ctx, QueryGetContainer, containerID)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+	containers, err := scanContainers(rows)
+	if err != nil {
+		return oapi.Container{}, err
+	}
+	if len(containers) == 0 {
+		return oapi.Container{}, pvz_errors.ErrContainerNotFound
+	}
+	return containers[0], nil
+}
+
+func (r *containerRepository) GetContainersByReceptionIDs(
+	ctx context.Context,
+	receptionIDs []*uuid.UUID,
+) ([]oapi.Container, error) {
+	rows, err := r.db.Query(ctx, QueryGetContainersByReceptions, receptionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectContainersFailed, err)
+	}
+	return scanContainers(rows)
+}
+
+func scanContainers(rows pgx.Rows) ([]oapi.Container, error) {
+	defer rows.Close()
+
+	containers := []oapi.Container{}
+	for rows.Next() {
+		var (
+			c         oapi.Container
+			id        uuid.UUID
+			kind      string
+			state     string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &c.ReceptionId, &c.Barcode, &kind, &state, &c.DeclaredItems,
+			&c.VerifiedItems, &createdAt, &c.OpenedAt, &c.VerifiedAt); err != nil {
+			return nil, err
+		}
+		c.Id = &id
+		c.Kind = oapi.ContainerKind(kind)
+		c.State = oapi.ContainerState(state)
+		c.CreatedAt = &createdAt
+		containers = append(containers, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var containerColumns = []string{
+	"id", "reception_id", "barcode", "kind", "state", "declared_items",
+	"verified_items", "created_at", "opened_at", "verified_at",
+}
+
+func TestInsertContainer(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewContainerRepository(db)
+
+	ctx := context.Background()
+	pvzID := uuid.New()
+	now := time.Now()
+	container := oapi.Container{
+		Id:            uuidPtr(uuid.New()),
+		Barcode:       "PAL-001",
+		Kind:          oapi.ContainerKindPallet,
+		DeclaredItems: 2,
+		CreatedAt:     &now,
+	}
+	products := []oapi.Product{
+		{Id: uuidPtr(uuid.New()), Type: oapi.ProductType("обувь"), ContainerId: container.Id},
+		{Id: uuidPtr(uuid.New()), Type: oapi.ProductType("одежда"), ContainerId: container.Id},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		receptionID := uuid.New()
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{"обувь", "одежда"}, 2)
+		mockPool.
+			ExpectQuery(QueryInsertContainer).
+			WithArgs(pvzID, container.Id, "PAL-001", "pallet", 2, container.CreatedAt).
+			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(receptionID))
+		mockPool.
+			ExpectExec(QueryInsertContainerProducts).
+			WithArgs(
+				[]uuid.UUID{*products[0].Id, *products[1].Id},
+				receptionID, container.CreatedAt, container.Id,
+				[]string{"обувь", "одежда"},
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+		mockPool.ExpectCommit()
+
+		got, gotProducts, err := repo.InsertContainer(ctx, pvzID, container, products)
+		require.NoError(t, err)
+		require.Equal(t, receptionID, got.ReceptionId)
+		require.Equal(t, oapi.ContainerStateSealed, got.State)
+		require.Len(t, gotProducts, 2)
+		require.Equal(t, receptionID, gotProducts[1].ReceptionId)
+	})
+
+	t.Run("no open reception", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{"обувь", "одежда"}, 2)
+		mockPool.
+			ExpectQuery(QueryInsertContainer).
+			WithArgs(anyArgs(6)...).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, _, err := repo.InsertContainer(ctx, pvzID, container, products)
+		require.ErrorIs(t, err, pvz_errors.ErrNoOpenRecetionOrPvz)
+	})
+
+	t.Run("duplicate barcode", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{"обувь", "одежда"}, 2)
+		mockPool.
+			ExpectQuery(QueryInsertContainer).
+			WithArgs(anyArgs(6)...).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "containers_barcode_key"})
+		mockPool.ExpectRollback()
+
+		_, _, err := repo.InsertContainer(ctx, pvzID, container, products)
+		require.ErrorIs(t, err, pvz_errors.ErrContainerAlreadyExists)
+	})
+
+	t.Run("invalid product type", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{"обувь", "одежда"}, 2)
+		mockPool.
+			ExpectQuery(QueryInsertContainer).
+			WithArgs(anyArgs(6)...).
+			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(uuid.New()))
+		mockPool.
+			ExpectExec(QueryInsertContainerProducts).
+			WithArgs(anyArgs(5)...).
+			WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_products_type"})
+		mockPool.ExpectRollback()
+
+		_, _, err := repo.InsertContainer(ctx, pvzID, container, products)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidProduct)
+	})
+
+	t.Run("inactive product type", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{"обувь", "одежда"}, 1)
+		mockPool.ExpectRollback()
+
+		_, _, err := repo.InsertContainer(ctx, pvzID, container, products)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidProduct)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestOpenContainer(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewContainerRepository(db)
+
+	ctx := context.Background()
+	id := uuid.New()
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryOpenContainer).
+			WithArgs(id, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectQuery(QueryGetContainer).
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(containerColumns).
+				AddRow(id, uuid.New(), "C-1", "container", "opened", 3, 0, now, &now, nil))
+
+		got, err := repo.OpenContainer(ctx, id, now)
+		require.NoError(t, err)
+		require.Equal(t, oapi.ContainerStateOpened, got.State)
+		require.Equal(t, 3, got.DeclaredItems)
+	})
+
+	t.Run("already opened", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryOpenContainer).
+			WithArgs(id, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mockPool.
+			ExpectQuery(QueryGetContainer).
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(containerColumns).
+				AddRow(id, uuid.New(), "C-1", "container", "verified", 3, 3, now, &now, &now))
+
+		_, err := repo.OpenContainer(ctx, id, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidContainerState)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryOpenContainer).
+			WithArgs(id, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mockPool.
+			ExpectQuery(QueryGetContainer).
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(containerColumns))
+
+		_, err := repo.OpenContainer(ctx, id, now)
+		require.ErrorIs(t, err, pvz_errors.ErrContainerNotFound)
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryOpenContainer).
+			WithArgs(id, now).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.OpenContainer(ctx, id, now)
+		require.Error(t, err)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestVerifyContainerProducts(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewContainerRepository(db)
+
+	ctx := context.Background()
+	id := uuid.New()
+	productIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	now := time.Now()
+
+	t.Run("success completes verification", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockContainerState).
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"state"}).AddRow("opened"))
+		mockPool.
+			ExpectExec(QueryVerifyContainerProducts).
+			WithArgs(id, productIDs, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		mockPool.
+			ExpectExec(QueryCompleteContainerVerification).
+			WithArgs(id, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectQuery(QueryGetContainer).
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows(containerColumns).
+				AddRow(id, uuid.New(), "C-1", "container", "verified", 2, 2, now, &now, &now))
+		mockPool.ExpectCommit()
+
+		got, err := repo.VerifyContainerProducts(ctx, id, productIDs, now)
+		require.NoError(t, err)
+		require.Equal(t, oapi.ContainerStateVerified, got.State)
+		require.Equal(t, 2, got.VerifiedItems)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockContainerState).
+			WithArgs(id).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.VerifyContainerProducts(ctx, id, productIDs, now)
+		require.ErrorIs(t, err, pvz_errors.ErrContainerNotFound)
+	})
+
+	t.Run("sealed container", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockContainerState).
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"state"}).AddRow("sealed"))
+		mockPool.ExpectRollback()
+
+		_, err := repo.VerifyContainerProducts(ctx, id, productIDs, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidContainerState)
+	})
+
+	t.Run("foreign products", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockContainerState).
+			WithArgs(id).
+			WillReturnRows(pgxmock.NewRows([]string{"state"}).AddRow("opened"))
+		mockPool.
+			ExpectExec(QueryVerifyContainerProducts).
+			WithArgs(id, productIDs, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectRollback()
+
+		_, err := repo.VerifyContainerProducts(ctx, id, productIDs, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidContainerProducts)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestGetContainersByReceptionIDs(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewContainerRepository(db)
+
+	ctx := context.Background()
+	ids := []*uuid.UUID{uuidPtr(uuid.New())}
+
+	t.Run("success", func(t *testing.T) {
+		rows := pgxmock.NewRows(containerColumns).
+			AddRow(uuid.New(), *ids[0], "C-1", "container", "sealed", 5, 0, time.Now(), nil, nil)
+		mockPool.
+			ExpectQuery(QueryGetContainersByReceptions).
+			WithArgs(ids).
+			WillReturnRows(rows)
+
+		got, err := repo.GetContainersByReceptionIDs(ctx, ids)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, oapi.ContainerKindContainer, got[0].Kind)
+		require.Nil(t, got[0].OpenedAt)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetContainersByReceptions).
+			WithArgs(ids).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.GetContainersByReceptionIDs(ctx, ids)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectContainersFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type damageRepository struct {
+	db database.PgxIface
+}
+
+func NewDamageRepository(dbConn database.PgxIface) *damageRepository {
+	return &damageRepository{db: dbConn}
+}
+
+func (r *damageRepository) UpsertProductDamage(
+	ctx context.Context,
+	damage oapi.ProductDamage,
+) (oapi.ProductDamage, error) {
+	var (
+		id          uuid.UUID
+		receptionID uuid.UUID
+	)
+	err := r.db.QueryRow(ctx, QueryUpsertProductDamage,
+		damage.Id,
+		damage.ProductId,
+		string(damage.Kind),
+		string(damage.Severity),
+		damage.Comment,
+		damage.ReportedBy,
+		damage.CreatedAt,
+	).Scan(&id, &receptionID)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.ProductDamage{}, pvz_errors.ErrProductNotFound
+		}
+		return oapi.ProductDamage{}, err
+	}
+
+	damage.Id = &id
+	damage.ReceptionId = receptionID
+	return damage, nil
+}
+
+func (r *damageRepository) GetReceptionDamage(
+	ctx context.Context,
+	receptionID uuid.UUID,
+) ([]oapi.ProductDamage, error) {
+	rows, err := r.db.Query(ctx, QueryGetReceptionDamage, receptionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectDamageFailed, err)
+	}
+	return scanDamages(rows)
+}
+
+func (r *damageRepository) SelectDamageIncidents(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	pvzID *uuid.UUID,
+	carrier *string,
+	limit, offset int,
+) ([]oapi.ProductDamage, error) {
+	rows, err := r.db.Query(ctx, QuerySelectDamageIncidents,
+		startDate, endDate,
+		pvzID, carrier,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectDamageFailed, err)
+	}
+	return scanDamages(rows)
+}
+
+func scanDamages(rows pgx.Rows) ([]oapi.ProductDamage, error) {
+	defer rows.Close()
+
+	damages := []oapi.ProductDamage{}
+	for rows.Next() {
+		var (
+			d         oapi.ProductDamage
+			pvzID     uuid.UUID
+			city      string
+			kind      string
+			severity  string
+			createdAt time.Time
+		)
+		if err := rows.Scan(&d.Id, &d.ProductId, &d.ReceptionId, &pvzID, &city,
+			&d.Carrier, &d.WaybillNumber, &kind, &severity, &d.Comment, &d.ReportedBy, &createdAt); err != nil {
+			return nil, err
+		}
+		d.PvzId = &pvzID
+		d.City = &city
+		d.Kind = oapi.ProductDamageKind(kind)
+		d.Severity = oapi.ProductDamageSeverity(severity)
+		d.CreatedAt = &createdAt
+		damages = append(damages, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return damages, nil
+}
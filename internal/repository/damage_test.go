@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var damageColumns = []string{
+	"id", "product_id", "reception_id", "pvz_id", "city",
+	"carrier", "waybill_number", "kind", "severity", "comment", "reported_by", "created_at",
+}
+
+func TestUpsertProductDamage(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewDamageRepository(db)
+
+	ctx := context.Background()
+	now := time.Now()
+	damage := oapi.ProductDamage{
+		Id:        uuidPtr(uuid.New()),
+		ProductId: uuid.New(),
+		Kind:      oapi.ProductDamageKindWet,
+		Severity:  oapi.ProductDamageSeverityHigh,
+		CreatedAt: &now,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		receptionID := uuid.New()
+		mockPool.
+			ExpectQuery(QueryUpsertProductDamage).
+			WithArgs(damage.Id, damage.ProductId, "wet", "high", damage.Comment, damage.ReportedBy, damage.CreatedAt).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "reception_id"}).AddRow(*damage.Id, receptionID))
+
+		got, err := repo.UpsertProductDamage(ctx, damage)
+		require.NoError(t, err)
+		require.Equal(t, receptionID, got.ReceptionId)
+		require.Equal(t, damage.ProductId, got.ProductId)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryUpsertProductDamage).
+			WithArgs(anyArgs(7)...).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.UpsertProductDamage(ctx, damage)
+		require.ErrorIs(t, err, pvz_errors.ErrProductNotFound)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryUpsertProductDamage).
+			WithArgs(anyArgs(7)...).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.UpsertProductDamage(ctx, damage)
+		require.Error(t, err)
+	})
+}
+
+func TestGetReceptionDamage(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewDamageRepository(db)
+
+	ctx := context.Background()
+	receptionID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		rows := pgxmock.NewRows(damageColumns).
+			AddRow(uuidPtr(uuid.New()), uuid.New(), receptionID, uuid.New(), "Москва",
+				strPtr("СДЭК"), strPtr("WB-1"), "opened", "low", nil, nil, time.Now())
+		mockPool.
+			ExpectQuery(QueryGetReceptionDamage).
+			WithArgs(receptionID).
+			WillReturnRows(rows)
+
+		out, err := repo.GetReceptionDamage(ctx, receptionID)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		require.Equal(t, oapi.ProductDamageKindOpened, out[0].Kind)
+		require.Equal(t, "Москва", *out[0].City)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetReceptionDamage).
+			WithArgs(receptionID).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.GetReceptionDamage(ctx, receptionID)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectDamageFailed)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		rows := pgxmock.NewRows(damageColumns).
+			AddRow(uuidPtr(uuid.New()), "bad-uuid", receptionID, uuid.New(), "Москва",
+				nil, nil, "opened", "low", nil, nil, time.Now())
+		mockPool.
+			ExpectQuery(QueryGetReceptionDamage).
+			WithArgs(receptionID).
+			WillReturnRows(rows)
+
+		_, err := repo.GetReceptionDamage(ctx, receptionID)
+		require.Error(t, err)
+	})
+}
+
+func TestSelectDamageIncidents(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewDamageRepository(db)
+
+	ctx := context.Background()
+	start, end := time.Now().Add(-time.Hour), time.Now()
+	carrier := "СДЭК"
+
+	t.Run("success", func(t *testing.T) {
+		rows := pgxmock.NewRows(damageColumns).
+			AddRow(uuidPtr(uuid.New()), uuid.New(), uuid.New(), uuid.New(), "Казань",
+				&carrier, nil, "damaged", "medium", strPtr("вмятина"), nil, time.Now()).
+			AddRow(uuidPtr(uuid.New()), uuid.New(), uuid.New(), uuid.New(), "Казань",
+				&carrier, nil, "wet", "low", nil, nil, time.Now())
+		mockPool.
+			ExpectQuery(QuerySelectDamageIncidents).
+			WithArgs(start, end, (*uuid.UUID)(nil), &carrier, 30, 0).
+			WillReturnRows(rows)
+
+		out, err := repo.SelectDamageIncidents(ctx, start, end, nil, &carrier, 30, 0)
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+	})
+
+	t.Run("rows error", func(t *testing.T) {
+		rows := pgxmock.NewRows(damageColumns).RowError(0, errors.New("row fail"))
+		mockPool.
+			ExpectQuery(QuerySelectDamageIncidents).
+			WithArgs(start, end, (*uuid.UUID)(nil), &carrier, 30, 0).
+			WillReturnRows(rows)
+
+		_, err := repo.SelectDamageIncidents(ctx, start, end, nil, &carrier, 30, 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+)
+
+type exportRepository struct {
+	db database.PgxIface
+}
+
+func NewExportRepository(dbConn database.PgxIface) *exportRepository {
+	return &exportRepository{db: dbConn}
+}
+
+func (r *exportRepository) ExportPVZs(ctx context.Context, filter dto.ExportFilter, w export.Writer) error {
+	return r.streamRows(ctx, w, QueryExportPVZs, filter.From, filter.To, filter.RegionID)
+}
+
+func (r *exportRepository) ExportReceptions(ctx context.Context, filter dto.ExportFilter, w export.Writer) error {
+	return r.streamRows(ctx, w, QueryExportReceptions, filter.From, filter.To, filter.PvzID)
+}
+
+func (r *exportRepository) ExportProducts(ctx context.Context, filter dto.ExportFilter, w export.Writer) error {
+	return r.streamRows(ctx, w, QueryExportProducts, filter.From, filter.To, filter.PvzID, filter.ProductType)
+}
+
+// streamRows writes rows as they are read from the connection, using the
+// query's column names as the header.
+func (r *exportRepository) streamRows(ctx context.Context, w export.Writer, query string, args ...any) error {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", pvz_errors.ErrSelectExportFailed, err)
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, field.Name)
+	}
+	if err = w.WriteHeader(columns); err != nil {
+		return err
+	}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return err
+		}
+		if err = w.WriteRow(values); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
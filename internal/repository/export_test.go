@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+)
+
+func TestExportPVZs(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewExportRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	regionID := uuid.New()
+	filter := dto.ExportFilter{From: time.Time{}, To: time.Now(), RegionID: &regionID}
+
+	t.Run("streams rows", func(t *testing.T) {
+		id := uuid.New()
+		registered := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockPool.
+			ExpectQuery(QueryExportPVZs).
+			WithArgs(filter.From, filter.To, filter.RegionID).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "city", "registrationDate", "latitude", "longitude", "regionId"}).
+				AddRow(id, "Москва", registered, 55.75, 37.62, regionID))
+
+		var buf bytes.Buffer
+		w, err := export.NewWriter(export.CSV, &buf)
+		require.NoError(t, err)
+		require.NoError(t, repo.ExportPVZs(ctx, filter, w))
+		require.NoError(t, w.Close())
+		require.Contains(t, buf.String(), "id,city,registrationDate,latitude,longitude,regionId\n")
+		require.Contains(t, buf.String(), id.String()+",Москва,2025-01-02T03:04:05Z,55.75,37.62,"+regionID.String())
+	})
+
+	t.Run("query failure", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryExportPVZs).
+			WithArgs(filter.From, filter.To, filter.RegionID).
+			WillReturnError(errors.New("connection reset"))
+
+		w, err := export.NewWriter(export.NDJSON, &strings.Builder{})
+		require.NoError(t, err)
+		require.ErrorIs(t, repo.ExportPVZs(ctx, filter, w), pvz_errors.ErrSelectExportFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
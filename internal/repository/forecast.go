@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+)
+
+type forecastRepository struct {
+	db database.PgxIface
+}
+
+func NewForecastRepository(dbConn database.PgxIface) *forecastRepository {
+	return &forecastRepository{db: dbConn}
+}
+
+func (r *forecastRepository) GetForecastPVZ(ctx context.Context, pvzID uuid.UUID) (dto.ForecastPVZ, error) {
+	var pvz dto.ForecastPVZ
+	err := r.db.QueryRow(ctx, QueryGetForecastPVZ, pvzID).Scan(&pvz.RegisteredAt, &pvz.IntakeCapacity)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return dto.ForecastPVZ{}, pvz_errors.ErrPVZNotFound
+		}
+		return dto.ForecastPVZ{}, fmt.Errorf("%w: %w", pvz_errors.ErrSelectForecastDataFailed, err)
+	}
+	return pvz, nil
+}
+
+func (r *forecastRepository) SelectHourlyIntake(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	from, to time.Time,
+	timezone string,
+) ([]dto.HourlyIntake, error) {
+	rows, err := r.db.Query(ctx, QuerySelectHourlyIntake, pvzID, from, to, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectForecastDataFailed, err)
+	}
+	defer rows.Close()
+
+	result := []dto.HourlyIntake{}
+	for rows.Next() {
+		var intake dto.HourlyIntake
+		if err = rows.Scan(&intake.Day, &intake.Hour, &intake.Products); err != nil {
+			return nil, err
+		}
+		result = append(result, intake)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+)
+
+func TestForecastRepository(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewForecastRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	pvzID := uuid.New()
+	registered := time.Date(2024, 11, 5, 9, 30, 0, 0, time.UTC)
+
+	t.Run("pvz capacity", func(t *testing.T) {
+		capacity := 480
+		mockPool.
+			ExpectQuery(QueryGetForecastPVZ).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"registration_date", "daily_intake_capacity"}).
+				AddRow(registered, &capacity))
+
+		pvz, err := repo.GetForecastPVZ(ctx, pvzID)
+		require.NoError(t, err)
+		require.Equal(t, dto.ForecastPVZ{RegisteredAt: registered, IntakeCapacity: &capacity}, pvz)
+	})
+
+	t.Run("capacity not set", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetForecastPVZ).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"registration_date", "daily_intake_capacity"}).
+				AddRow(registered, nil))
+
+		pvz, err := repo.GetForecastPVZ(ctx, pvzID)
+		require.NoError(t, err)
+		require.Nil(t, pvz.IntakeCapacity)
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetForecastPVZ).
+			WithArgs(pvzID).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.GetForecastPVZ(ctx, pvzID)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	t.Run("hourly intake", func(t *testing.T) {
+		from := time.Date(2025, 3, 2, 21, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, 14)
+		day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+		mockPool.
+			ExpectQuery(QuerySelectHourlyIntake).
+			WithArgs(pvzID, from, to, "Europe/Moscow").
+			WillReturnRows(pgxmock.NewRows([]string{"day", "hour", "count"}).
+				AddRow(day, 9, 40).
+				AddRow(day, 10, 25))
+
+		intake, err := repo.SelectHourlyIntake(ctx, pvzID, from, to, "Europe/Moscow")
+		require.NoError(t, err)
+		require.Equal(t, []dto.HourlyIntake{
+			{Day: day, Hour: 9, Products: 40},
+			{Day: day, Hour: 10, Products: 25},
+		}, intake)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
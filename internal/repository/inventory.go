@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type inventoryRepository struct {
+	db database.PgxIface
+}
+
+func NewInventoryRepository(dbConn database.PgxIface) *inventoryRepository {
+	return &inventoryRepository{db: dbConn}
+}
+
+func (r *inventoryRepository) InsertInventorySession(
+	ctx context.Context,
+	session oapi.InventorySession,
+) (oapi.InventorySession, error) {
+	_, err := r.db.Exec(ctx, QueryInsertInventorySession,
+		session.Id, session.PvzId, session.StartedBy, session.StartedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_inventory_sessions_counting" {
+			return oapi.InventorySession{}, pvz_errors.ErrInventoryInProgress
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_inventory_sessions_pvz" {
+			return oapi.InventorySession{}, pvz_errors.ErrPVZNotFound
+		}
+		return oapi.InventorySession{}, err
+	}
+	return session, nil
+}
+
+func (r *inventoryRepository) GetInventorySession(
+	ctx context.Context,
+	sessionID uuid.UUID,
+) (oapi.InventorySession, error) {
+	return r.getInventorySession(ctx, r.db, sessionID)
+}
+
+func (r *inventoryRepository) UpsertInventoryScan(
+	ctx context.Context,
+	sessionID uuid.UUID,
+	scan oapi.InventoryScan,
+) (oapi.InventoryScan, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.InventoryScan{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	pvzID, err := r.lockCountingSession(ctx, tx, QueryShareInventorySession, sessionID)
+	if err != nil {
+		return oapi.InventoryScan{}, err
+	}
+	if scan.CellId != nil {
+		var exists bool
+		if err = tx.QueryRow(ctx, QueryCheckStorageCell, *scan.CellId, pvzID).Scan(&exists); err != nil {
+			return oapi.InventoryScan{}, err
+		}
+		if !exists {
+			err = pvz_errors.ErrCellNotFound
+			return oapi.InventoryScan{}, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, QueryUpsertInventoryScan,
+		sessionID, scan.Barcode, scan.CellId, scan.ScannedBy, scan.ScannedAt)
+	if err != nil {
+		return oapi.InventoryScan{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.InventoryScan{}, err
+	}
+	return scan, nil
+}
+
+func (r *inventoryRepository) CloseInventorySession(
+	ctx context.Context,
+	sessionID uuid.UUID,
+	closedBy *uuid.UUID,
+	closedAt time.Time,
+) (oapi.InventorySession, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	pvzID, err := r.lockCountingSession(ctx, tx, QueryLockInventorySession, sessionID)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+	if _, err = tx.Exec(ctx, QueryInsertInventoryDiscrepancies, sessionID, pvzID); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	if _, err = tx.Exec(ctx, QueryCloseInventorySession, sessionID, closedBy, closedAt); err != nil {
+		return oapi.InventorySession{}, err
+	}
+
+	session, err := r.getInventorySession(ctx, tx, sessionID)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	return session, nil
+}
+
+func (r *inventoryRepository) AcceptInventoryAdjustments(
+	ctx context.Context,
+	sessionID uuid.UUID,
+	barcodes []string,
+	acceptedBy *uuid.UUID,
+	acceptedAt time.Time,
+) (oapi.InventorySession, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var pvzID uuid.UUID
+	var status string
+	if err = tx.QueryRow(ctx, QueryLockInventorySession, sessionID).Scan(&pvzID, &status); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrInventoryNotFound
+		}
+		return oapi.InventorySession{}, err
+	}
+	if oapi.InventorySessionStatus(status) != oapi.Counted {
+		err = pvz_errors.ErrInventoryNotCounted
+		return oapi.InventorySession{}, err
+	}
+
+	rows, err := tx.Query(ctx, QueryLockInventoryDiscrepancies, sessionID, barcodes)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+	pending := 0
+	for rows.Next() {
+		pending++
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	if pending != len(barcodes) {
+		err = pvz_errors.ErrInvalidInventoryAdjustment
+		return oapi.InventorySession{}, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryWriteOffMissingProducts, sessionID, barcodes); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	if _, err = tx.Exec(ctx, QueryRelocateMisplacedProducts, sessionID, barcodes); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	if _, err = tx.Exec(ctx, QueryClaimUnexpectedProducts, sessionID, barcodes, pvzID); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	_, err = tx.Exec(ctx, QueryAcceptInventoryDiscrepancies, sessionID, barcodes, acceptedBy, acceptedAt)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+
+	session, err := r.getInventorySession(ctx, tx, sessionID)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	return session, nil
+}
+
+func (r *inventoryRepository) lockCountingSession(
+	ctx context.Context,
+	tx pgx.Tx,
+	query string,
+	sessionID uuid.UUID,
+) (uuid.UUID, error) {
+	var pvzID uuid.UUID
+	var status string
+	if err := tx.QueryRow(ctx, query, sessionID).Scan(&pvzID, &status); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return uuid.Nil, pvz_errors.ErrInventoryNotFound
+		}
+		return uuid.Nil, err
+	}
+	if oapi.InventorySessionStatus(status) != oapi.Counting {
+		return uuid.Nil, pvz_errors.ErrInventoryAlreadyCounted
+	}
+	return pvzID, nil
+}
+
+func (r *inventoryRepository) getInventorySession(
+	ctx context.Context,
+	q querier,
+	sessionID uuid.UUID,
+) (oapi.InventorySession, error) {
+	var session oapi.InventorySession
+	var status string
+	err := q.QueryRow(ctx, QueryGetInventorySession, sessionID).Scan(
+		&session.Id,
+		&session.PvzId,
+		&status,
+		&session.StartedBy,
+		&session.StartedAt,
+		&session.ClosedBy,
+		&session.ClosedAt,
+		&session.ScannedCount,
+	)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.InventorySession{}, pvz_errors.ErrInventoryNotFound
+		}
+		return oapi.InventorySession{}, err
+	}
+	session.Status = oapi.InventorySessionStatus(status)
+
+	rows, err := q.Query(ctx, QueryGetInventoryDiscrepancies, sessionID)
+	if err != nil {
+		return oapi.InventorySession{}, err
+	}
+	defer rows.Close()
+
+	discrepancies := []oapi.InventoryDiscrepancy{}
+	for rows.Next() {
+		var discrepancy oapi.InventoryDiscrepancy
+		var kind string
+		err = rows.Scan(
+			&kind,
+			&discrepancy.Barcode,
+			&discrepancy.ProductId,
+			&discrepancy.ExpectedCellId,
+			&discrepancy.ActualCellId,
+			&discrepancy.AcceptedBy,
+			&discrepancy.AcceptedAt,
+		)
+		if err != nil {
+			return oapi.InventorySession{}, err
+		}
+		discrepancy.Kind = oapi.InventoryDiscrepancyKind(kind)
+		discrepancies = append(discrepancies, discrepancy)
+	}
+	if err = rows.Err(); err != nil {
+		return oapi.InventorySession{}, err
+	}
+	session.Discrepancies = &discrepancies
+	return session, nil
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var (
+	inventorySessionColumns = []string{
+		"id", "pvz_id", "status", "started_by", "started_at", "closed_by", "closed_at", "scanned",
+	}
+	inventoryDiscrepancyColumns = []string{
+		"kind", "barcode", "product_id", "expected_cell_id", "actual_cell_id", "accepted_by", "accepted_at",
+	}
+)
+
+func expectGetInventorySession(
+	mockPool pgxmock.PgxPoolIface,
+	sessionID uuid.UUID,
+	status string,
+	discrepancies *pgxmock.Rows,
+) {
+	mockPool.
+		ExpectQuery(QueryGetInventorySession).
+		WithArgs(sessionID).
+		WillReturnRows(pgxmock.NewRows(inventorySessionColumns).
+			AddRow(sessionID, uuid.New(), status, nil, time.Now(), nil, nil, 2))
+	mockPool.
+		ExpectQuery(QueryGetInventoryDiscrepancies).
+		WithArgs(sessionID).
+		WillReturnRows(discrepancies)
+}
+
+func TestInsertInventorySession(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+	session := oapi.InventorySession{Id: uuid.New(), PvzId: uuid.New(), Status: oapi.Counting, StartedAt: time.Now()}
+
+	t.Run("success", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryInsertInventorySession).
+			WithArgs(session.Id, session.PvzId, session.StartedBy, session.StartedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		got, err := repo.InsertInventorySession(ctx, session)
+		require.NoError(t, err)
+		require.Equal(t, session.Id, got.Id)
+	})
+
+	t.Run("already counting", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryInsertInventorySession).
+			WithArgs(session.Id, session.PvzId, session.StartedBy, session.StartedAt).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_inventory_sessions_counting"})
+
+		_, err := repo.InsertInventorySession(ctx, session)
+		require.ErrorIs(t, err, pvz_errors.ErrInventoryInProgress)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestUpsertInventoryScan(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+	sessionID, pvzID, cellID := uuid.New(), uuid.New(), uuid.New()
+	scan := oapi.InventoryScan{Barcode: "4601234567890", CellId: &cellID, ScannedAt: time.Now()}
+
+	expectSession := func(status string) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryShareInventorySession).
+			WithArgs(sessionID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "status"}).AddRow(pvzID, status))
+	}
+	expectCell := func(exists bool) {
+		mockPool.
+			ExpectQuery(QueryCheckStorageCell).
+			WithArgs(cellID, pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(exists))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectSession("counting")
+		expectCell(true)
+		mockPool.
+			ExpectExec(QueryUpsertInventoryScan).
+			WithArgs(sessionID, scan.Barcode, scan.CellId, scan.ScannedBy, scan.ScannedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.ExpectCommit()
+
+		_, err := repo.UpsertInventoryScan(ctx, sessionID, scan)
+		require.NoError(t, err)
+	})
+
+	t.Run("cell of another pvz", func(t *testing.T) {
+		expectSession("counting")
+		expectCell(false)
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpsertInventoryScan(ctx, sessionID, scan)
+		require.ErrorIs(t, err, pvz_errors.ErrCellNotFound)
+	})
+
+	t.Run("session counted", func(t *testing.T) {
+		expectSession("counted")
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpsertInventoryScan(ctx, sessionID, scan)
+		require.ErrorIs(t, err, pvz_errors.ErrInventoryAlreadyCounted)
+	})
+
+	t.Run("session not found", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryShareInventorySession).
+			WithArgs(sessionID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpsertInventoryScan(ctx, sessionID, scan)
+		require.ErrorIs(t, err, pvz_errors.ErrInventoryNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestCloseInventorySession(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+	sessionID, pvzID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mockPool.ExpectBegin()
+	mockPool.
+		ExpectQuery(QueryLockInventorySession).
+		WithArgs(sessionID).
+		WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "status"}).AddRow(pvzID, "counting"))
+	mockPool.
+		ExpectExec(QueryInsertInventoryDiscrepancies).
+		WithArgs(sessionID, pvzID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	mockPool.
+		ExpectExec(QueryCloseInventorySession).
+		WithArgs(sessionID, (*uuid.UUID)(nil), now).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	expectGetInventorySession(mockPool, sessionID, "counted", pgxmock.NewRows(inventoryDiscrepancyColumns).
+		AddRow("missing", "A-1", uuidPtr(uuid.New()), nil, nil, nil, nil).
+		AddRow("misplaced", "A-2", uuidPtr(uuid.New()), uuidPtr(uuid.New()), uuidPtr(uuid.New()), nil, nil))
+	mockPool.ExpectCommit()
+
+	got, err := repo.CloseInventorySession(ctx, sessionID, nil, now)
+	require.NoError(t, err)
+	require.Equal(t, oapi.Counted, got.Status)
+	require.Len(t, *got.Discrepancies, 2)
+	require.Equal(t, oapi.Missing, (*got.Discrepancies)[0].Kind)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestAcceptInventoryAdjustments(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewInventoryRepository(db)
+	ctx := context.Background()
+	sessionID, pvzID, userID := uuid.New(), uuid.New(), uuid.New()
+	barcodes := []string{"A-1", "A-2"}
+	now := time.Now()
+
+	expectSession := func(status string) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockInventorySession).
+			WithArgs(sessionID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "status"}).AddRow(pvzID, status))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectSession("counted")
+		mockPool.
+			ExpectQuery(QueryLockInventoryDiscrepancies).
+			WithArgs(sessionID, barcodes).
+			WillReturnRows(pgxmock.NewRows([]string{"barcode"}).AddRow("A-1").AddRow("A-2"))
+		mockPool.
+			ExpectExec(QueryWriteOffMissingProducts).
+			WithArgs(sessionID, barcodes).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryRelocateMisplacedProducts).
+			WithArgs(sessionID, barcodes).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryClaimUnexpectedProducts).
+			WithArgs(sessionID, barcodes, pvzID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mockPool.
+			ExpectExec(QueryAcceptInventoryDiscrepancies).
+			WithArgs(sessionID, barcodes, &userID, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		expectGetInventorySession(mockPool, sessionID, "counted", pgxmock.NewRows(inventoryDiscrepancyColumns).
+			AddRow("missing", "A-1", uuidPtr(uuid.New()), nil, nil, &userID, &now))
+		mockPool.ExpectCommit()
+
+		got, err := repo.AcceptInventoryAdjustments(ctx, sessionID, barcodes, &userID, now)
+		require.NoError(t, err)
+		require.Equal(t, userID, *(*got.Discrepancies)[0].AcceptedBy)
+	})
+
+	t.Run("already accepted", func(t *testing.T) {
+		expectSession("counted")
+		mockPool.
+			ExpectQuery(QueryLockInventoryDiscrepancies).
+			WithArgs(sessionID, barcodes).
+			WillReturnRows(pgxmock.NewRows([]string{"barcode"}).AddRow("A-2"))
+		mockPool.ExpectRollback()
+
+		_, err := repo.AcceptInventoryAdjustments(ctx, sessionID, barcodes, &userID, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidInventoryAdjustment)
+	})
+
+	t.Run("still counting", func(t *testing.T) {
+		expectSession("counting")
+		mockPool.ExpectRollback()
+
+		_, err := repo.AcceptInventoryAdjustments(ctx, sessionID, barcodes, &userID, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInventoryNotCounted)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type jobRepository struct {
+	db database.PgxIface
+}
+
+func NewJobRepository(dbConn database.PgxIface) *jobRepository {
+	return &jobRepository{db: dbConn}
+}
+
+func (r *jobRepository) InsertJob(ctx context.Context, job oapi.Job) (oapi.Job, error) {
+	params, err := json.Marshal(job.Params)
+	if err != nil {
+		return oapi.Job{}, err
+	}
+	_, err = r.db.Exec(ctx, QueryInsertJob,
+		job.Id, job.Kind, job.Format, params, job.CreatedBy, job.CreatedAt)
+	if err != nil {
+		return oapi.Job{}, err
+	}
+	return job, nil
+}
+
+func (r *jobRepository) GetJob(ctx context.Context, jobID uuid.UUID) (oapi.Job, error) {
+	job, err := scanJob(r.db.QueryRow(ctx, QueryGetJob, jobID))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Job{}, pvz_errors.ErrJobNotFound
+		}
+		return oapi.Job{}, err
+	}
+	return job, nil
+}
+
+func (r *jobRepository) ClaimJob(ctx context.Context, startedAt time.Time) (oapi.Job, bool, error) {
+	job, err := scanJob(r.db.QueryRow(ctx, QueryClaimJob, startedAt))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Job{}, false, nil
+		}
+		return oapi.Job{}, false, err
+	}
+	return job, true, nil
+}
+
+func (r *jobRepository) UpdateJobProgress(ctx context.Context, jobID uuid.UUID, progress int) error {
+	tag, err := r.db.Exec(ctx, QueryUpdateJobProgress, jobID, progress)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz_errors.ErrJobCancelled
+	}
+	return nil
+}
+
+func (r *jobRepository) FinishJob(
+	ctx context.Context,
+	jobID uuid.UUID,
+	status oapi.JobStatus,
+	progress int,
+	reason *string,
+	finishedAt, expiresAt time.Time,
+) error {
+	tag, err := r.db.Exec(ctx, QueryFinishJob, jobID, status, progress, reason, finishedAt, expiresAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz_errors.ErrJobCancelled
+	}
+	return nil
+}
+
+func (r *jobRepository) CancelJob(
+	ctx context.Context,
+	jobID uuid.UUID,
+	cancelledAt, expiresAt time.Time,
+) (oapi.Job, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Job{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var status string
+	if err = tx.QueryRow(ctx, QueryLockJob, jobID).Scan(&status); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrJobNotFound
+		}
+		return oapi.Job{}, err
+	}
+	switch oapi.JobStatus(status) {
+	case oapi.Queued, oapi.Running:
+	default:
+		err = pvz_errors.ErrJobFinished
+		return oapi.Job{}, err
+	}
+
+	job, err := scanJob(tx.QueryRow(ctx, QueryCancelJob, jobID, cancelledAt, expiresAt))
+	if err != nil {
+		return oapi.Job{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Job{}, err
+	}
+	return job, nil
+}
+
+func (r *jobRepository) FailStaleJobs(
+	ctx context.Context,
+	startedBefore, finishedAt, expiresAt time.Time,
+	reason string,
+) ([]dto.JobFile, error) {
+	return r.selectJobFiles(ctx, QueryFailStaleJobs, finishedAt, expiresAt, reason, startedBefore)
+}
+
+func (r *jobRepository) ExpireJobs(ctx context.Context, now time.Time) ([]dto.JobFile, error) {
+	return r.selectJobFiles(ctx, QueryExpireJobs, now)
+}
+
+func (r *jobRepository) selectJobFiles(ctx context.Context, query string, args ...any) ([]dto.JobFile, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectJobsFailed, err)
+	}
+	defer rows.Close()
+
+	files := []dto.JobFile{}
+	for rows.Next() {
+		var file dto.JobFile
+		if err = rows.Scan(&file.ID, &file.Format); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func scanJob(row pgx.Row) (oapi.Job, error) {
+	var job oapi.Job
+	var kind, format, status string
+	var params []byte
+	err := row.Scan(
+		&job.Id,
+		&kind,
+		&format,
+		&params,
+		&status,
+		&job.Progress,
+		&job.Error,
+		&job.CreatedBy,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ExpiresAt,
+	)
+	if err != nil {
+		return oapi.Job{}, err
+	}
+	if err = json.Unmarshal(params, &job.Params); err != nil {
+		return oapi.Job{}, err
+	}
+	job.Kind = oapi.JobKind(kind)
+	job.Format = oapi.ExportFormat(format)
+	job.Status = oapi.JobStatus(status)
+	return job, nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var jobRowColumns = []string{
+	"id", "kind", "format", "params", "status", "progress", "error", "created_by",
+	"created_at", "started_at", "finished_at", "expires_at",
+}
+
+func jobRow(jobID uuid.UUID, status string, createdAt time.Time) *pgxmock.Rows {
+	return pgxmock.NewRows(jobRowColumns).AddRow(
+		jobID, "export_products", "csv", []byte(`{"productType":"обувь"}`), status, 0, nil, nil,
+		createdAt, nil, nil, nil,
+	)
+}
+
+func TestJobRepository(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewJobRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	jobID := uuid.New()
+	now := time.Now()
+
+	t.Run("claim decodes params", func(t *testing.T) {
+		mockPool.ExpectQuery(QueryClaimJob).WithArgs(now).WillReturnRows(jobRow(jobID, "running", now))
+
+		job, ok, err := repo.ClaimJob(ctx, now)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, oapi.ExportProducts, job.Kind)
+		require.Equal(t, oapi.Running, job.Status)
+		require.Equal(t, "обувь", *job.Params.ProductType)
+	})
+
+	t.Run("claim on empty queue", func(t *testing.T) {
+		mockPool.ExpectQuery(QueryClaimJob).WithArgs(now).WillReturnRows(pgxmock.NewRows(jobRowColumns))
+
+		_, ok, err := repo.ClaimJob(ctx, now)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("progress on cancelled job", func(t *testing.T) {
+		mockPool.ExpectExec(QueryUpdateJobProgress).WithArgs(jobID, 1000).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		require.ErrorIs(t, repo.UpdateJobProgress(ctx, jobID, 1000), pvz_errors.ErrJobCancelled)
+	})
+
+	t.Run("cancel queued job", func(t *testing.T) {
+		expires := now.Add(time.Hour)
+		mockPool.ExpectBegin()
+		mockPool.ExpectQuery(QueryLockJob).WithArgs(jobID).
+			WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow("queued"))
+		mockPool.ExpectQuery(QueryCancelJob).WithArgs(jobID, now, expires).
+			WillReturnRows(jobRow(jobID, "cancelled", now))
+		mockPool.ExpectCommit()
+
+		job, err := repo.CancelJob(ctx, jobID, now, expires)
+		require.NoError(t, err)
+		require.Equal(t, oapi.Cancelled, job.Status)
+	})
+
+	t.Run("cancel finished job", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.ExpectQuery(QueryLockJob).WithArgs(jobID).
+			WillReturnRows(pgxmock.NewRows([]string{"status"}).AddRow("succeeded"))
+		mockPool.ExpectRollback()
+
+		_, err := repo.CancelJob(ctx, jobID, now, now)
+		require.ErrorIs(t, err, pvz_errors.ErrJobFinished)
+	})
+
+	t.Run("expire returns files", func(t *testing.T) {
+		mockPool.ExpectQuery(QueryExpireJobs).WithArgs(now).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "format"}).AddRow(jobID, "xlsx"))
+
+		files, err := repo.ExpireJobs(ctx, now)
+		require.NoError(t, err)
+		require.Equal(t, []dto.JobFile{{ID: jobID, Format: "xlsx"}}, files)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type labelRepository struct {
+	db database.PgxIface
+}
+
+func NewLabelRepository(dbConn database.PgxIface) *labelRepository {
+	return &labelRepository{db: dbConn}
+}
+
+type labelTemplateRow struct {
+	symbology *string
+	widthMm   *int
+	heightMm  *int
+	dpi       *int
+	header    *string
+	updatedAt *time.Time
+}
+
+func (t labelTemplateRow) template(pvzID uuid.UUID) *oapi.LabelTemplate {
+	if t.symbology == nil {
+		return nil
+	}
+	return &oapi.LabelTemplate{
+		PvzId:     pvzID,
+		Symbology: oapi.LabelSymbology(*t.symbology),
+		WidthMm:   *t.widthMm,
+		HeightMm:  *t.heightMm,
+		Dpi:       *t.dpi,
+		Header:    t.header,
+		UpdatedAt: t.updatedAt,
+	}
+}
+
+func (r *labelRepository) SelectLabelData(ctx context.Context, productIDs []uuid.UUID) ([]dto.LabelData, error) {
+	rows, err := r.db.Query(ctx, QuerySelectLabelData, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectLabelsFailed, err)
+	}
+	defer rows.Close()
+
+	labels := []dto.LabelData{}
+	for rows.Next() {
+		var data dto.LabelData
+		var tmpl labelTemplateRow
+		err = rows.Scan(
+			&data.ProductID,
+			&data.PvzID,
+			&data.City,
+			&data.Type,
+			&data.Barcode,
+			&data.ReceivedAt,
+			&data.Zone,
+			&data.Rack,
+			&data.Shelf,
+			&tmpl.symbology,
+			&tmpl.widthMm,
+			&tmpl.heightMm,
+			&tmpl.dpi,
+			&tmpl.header,
+			&tmpl.updatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data.Template = tmpl.template(data.PvzID)
+		labels = append(labels, data)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (r *labelRepository) GetLabelTemplate(ctx context.Context, pvzID uuid.UUID) (*oapi.LabelTemplate, error) {
+	var tmpl labelTemplateRow
+	err := r.db.QueryRow(ctx, QueryGetLabelTemplate, pvzID).Scan(
+		&pvzID,
+		&tmpl.symbology,
+		&tmpl.widthMm,
+		&tmpl.heightMm,
+		&tmpl.dpi,
+		&tmpl.header,
+		&tmpl.updatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return nil, pvz_errors.ErrPVZNotFound
+		}
+		return nil, err
+	}
+	return tmpl.template(pvzID), nil
+}
+
+func (r *labelRepository) UpsertLabelTemplate(
+	ctx context.Context,
+	tmpl oapi.LabelTemplate,
+) (oapi.LabelTemplate, error) {
+	_, err := r.db.Exec(ctx, QueryUpsertLabelTemplate,
+		tmpl.PvzId, tmpl.Symbology, tmpl.WidthMm, tmpl.HeightMm, tmpl.Dpi, tmpl.Header, tmpl.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_label_templates_pvz" {
+			return oapi.LabelTemplate{}, pvz_errors.ErrPVZNotFound
+		}
+		return oapi.LabelTemplate{}, err
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func TestSelectLabelData(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewLabelRepository(&database.PgxMockAdapter{Pool: mockPool})
+	now := time.Now()
+	plain, templated := uuid.New(), uuid.New()
+	pvzID := uuid.New()
+	symbology, width, height, dpi := "qr", 58, 40, 300
+	mockPool.
+		ExpectQuery(QuerySelectLabelData).
+		WithArgs([]uuid.UUID{plain, templated}).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "pvz_id", "city", "type", "barcode", "date_time", "zone", "rack", "shelf",
+			"symbology", "width_mm", "height_mm", "dpi", "header", "updated_at",
+		}).
+			AddRow(plain, pvzID, "Москва", "обувь", nil, now, nil, nil, nil, nil, nil, nil, nil, nil, nil).
+			AddRow(templated, pvzID, "Москва", "обувь", nil, now, nil, nil, nil,
+				&symbology, &width, &height, &dpi, nil, &now))
+
+	labels, err := repo.SelectLabelData(context.Background(), []uuid.UUID{plain, templated})
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	require.Nil(t, labels[0].Template)
+	require.Equal(t, oapi.Qr, labels[1].Template.Symbology)
+	require.Equal(t, 300, labels[1].Template.Dpi)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestLabelTemplate(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewLabelRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	pvzID := uuid.New()
+	columns := []string{"id", "symbology", "width_mm", "height_mm", "dpi", "header", "updated_at"}
+
+	t.Run("not configured", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetLabelTemplate).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows(columns).AddRow(pvzID, nil, nil, nil, nil, nil, nil))
+
+		tmpl, err := repo.GetLabelTemplate(ctx, pvzID)
+		require.NoError(t, err)
+		require.Nil(t, tmpl)
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetLabelTemplate).
+			WithArgs(pvzID).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.GetLabelTemplate(ctx, pvzID)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	t.Run("upsert for unknown pvz", func(t *testing.T) {
+		now := time.Now()
+		tmpl := oapi.LabelTemplate{
+			PvzId: pvzID, Symbology: oapi.Code128, WidthMm: 58, HeightMm: 40, Dpi: 203, UpdatedAt: &now,
+		}
+		mockPool.
+			ExpectExec(QueryUpsertLabelTemplate).
+			WithArgs(pvzID, oapi.Code128, 58, 40, 203, tmpl.Header, &now).
+			WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_label_templates_pvz"})
+
+		_, err := repo.UpsertLabelTemplate(ctx, tmpl)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
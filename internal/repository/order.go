@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const maxPickupAttempts = 5
+
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+type orderRepository struct {
+	db database.PgxIface
+}
+
+func NewOrderRepository(dbConn database.PgxIface) *orderRepository {
+	return &orderRepository{db: dbConn}
+}
+
+func (r *orderRepository) InsertOrder(
+	ctx context.Context,
+	order oapi.Order,
+	productIDs []uuid.UUID,
+	codeHash string,
+) (oapi.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, QueryInsertOrder,
+		order.Id, order.PvzId, order.CustomerRef, codeHash, order.CreatedBy, order.CreatedAt)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+
+	cmdTag, err := tx.Exec(ctx, QueryReserveOrderProducts, productIDs, order.PvzId)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if cmdTag.RowsAffected() != int64(len(productIDs)) {
+		err = pvz_errors.ErrInvalidOrderProducts
+		return oapi.Order{}, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryInsertOrderItems, order.Id, productIDs); err != nil {
+		return oapi.Order{}, err
+	}
+
+	created, err := r.getOrder(ctx, tx, order.Id)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Order{}, err
+	}
+	return created, nil
+}
+
+func (r *orderRepository) GetOrder(ctx context.Context, orderID uuid.UUID) (oapi.Order, error) {
+	return r.getOrder(ctx, r.db, orderID)
+}
+
+// IssueOrder replaces the pickup code with nextCodeHash once the code matches
+// and rolls the issue back if beforeCommit fails.
+func (r *orderRepository) IssueOrder(
+	ctx context.Context,
+	orderID uuid.UUID,
+	codeHash string,
+	productIDs []uuid.UUID,
+	issuedBy uuid.UUID,
+	issuedAt time.Time,
+	nextCodeHash string,
+	beforeCommit func(oapi.Order) error,
+) (oapi.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	lock, err := r.lockOrder(ctx, tx, orderID)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if lock.attempts >= maxPickupAttempts {
+		err = pvz_errors.ErrPickupCodeLocked
+		return oapi.Order{}, err
+	}
+	if lock.codeHash != codeHash {
+		if _, err = tx.Exec(ctx, QueryRegisterFailedPickup, orderID); err != nil {
+			return oapi.Order{}, err
+		}
+		if err = tx.Commit(ctx); err != nil {
+			return oapi.Order{}, err
+		}
+		return oapi.Order{}, pvz_errors.ErrInvalidPickupCode
+	}
+
+	cmdTag, err := tx.Exec(ctx, QueryIssueOrderItems, orderID, productIDs, issuedAt, issuedBy)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if cmdTag.RowsAffected() == 0 || (len(productIDs) > 0 && cmdTag.RowsAffected() != int64(len(productIDs))) {
+		err = pvz_errors.ErrInvalidOrderProducts
+		return oapi.Order{}, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryFinishOrderIssue, orderID, issuedAt, nextCodeHash); err != nil {
+		return oapi.Order{}, err
+	}
+
+	order, err := r.getOrder(ctx, tx, orderID)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if err = beforeCommit(order); err != nil {
+		return oapi.Order{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Order{}, err
+	}
+	return order, nil
+}
+
+// ResetPickupCode replaces the pickup code and keeps the order locked until
+// beforeCommit returns, so that the old code stays valid if it fails.
+func (r *orderRepository) ResetPickupCode(
+	ctx context.Context,
+	orderID uuid.UUID,
+	codeHash string,
+	unlock bool,
+	beforeCommit func(oapi.Order) error,
+) (oapi.Order, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	lock, err := r.lockOrder(ctx, tx, orderID)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if lock.attempts >= maxPickupAttempts && !unlock {
+		err = pvz_errors.ErrPickupCodeLocked
+		return oapi.Order{}, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryResetPickupCode, orderID, codeHash, unlock); err != nil {
+		return oapi.Order{}, err
+	}
+
+	order, err := r.getOrder(ctx, tx, orderID)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if err = beforeCommit(order); err != nil {
+		return oapi.Order{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Order{}, err
+	}
+	return order, nil
+}
+
+type orderLock struct {
+	codeHash string
+	attempts int
+}
+
+func (r *orderRepository) lockOrder(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) (orderLock, error) {
+	var lock orderLock
+	var status string
+	if err := tx.QueryRow(ctx, QueryLockOrder, orderID).Scan(&status, &lock.codeHash, &lock.attempts); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return orderLock{}, pvz_errors.ErrOrderNotFound
+		}
+		return orderLock{}, err
+	}
+	if status == string(oapi.Completed) {
+		return orderLock{}, pvz_errors.ErrOrderAlreadyIssued
+	}
+	return lock, nil
+}
+
+func (r *orderRepository) getOrder(ctx context.Context, q querier, orderID uuid.UUID) (oapi.Order, error) {
+	var order oapi.Order
+	var status string
+	err := q.QueryRow(ctx, QueryGetOrder, orderID).Scan(
+		&order.Id,
+		&order.PvzId,
+		&order.CustomerRef,
+		&status,
+		&order.CreatedBy,
+		&order.CreatedAt,
+		&order.IssuedAt,
+	)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Order{}, pvz_errors.ErrOrderNotFound
+		}
+		return oapi.Order{}, err
+	}
+	order.Status = oapi.OrderStatus(status)
+
+	rows, err := q.Query(ctx, QueryGetOrderItems, orderID)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	defer rows.Close()
+
+	order.Items = make([]oapi.OrderItem, 0)
+	for rows.Next() {
+		var item oapi.OrderItem
+		var itemStatus string
+		if err = rows.Scan(&item.ProductId, &itemStatus, &item.IssuedAt, &item.IssuedBy); err != nil {
+			return oapi.Order{}, err
+		}
+		item.Status = oapi.ProductStatus(itemStatus)
+		order.Items = append(order.Items, item)
+	}
+	return order, rows.Err()
+}
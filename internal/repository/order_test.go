@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var (
+	orderColumns     = []string{"id", "pvz_id", "customer_ref", "status", "created_by", "created_at", "issued_at"}
+	orderItemColumns = []string{"product_id", "status", "issued_at", "issued_by"}
+	orderLockColumns = []string{"status", "pickup_code_hash", "failed_attempts"}
+)
+
+func expectGetOrder(mockPool pgxmock.PgxPoolIface, orderID uuid.UUID, status string, items *pgxmock.Rows) {
+	mockPool.
+		ExpectQuery(QueryGetOrder).
+		WithArgs(orderID).
+		WillReturnRows(pgxmock.NewRows(orderColumns).
+			AddRow(orderID, uuid.New(), "ORD-1", status, nil, time.Now(), nil))
+	mockPool.
+		ExpectQuery(QueryGetOrderItems).
+		WithArgs(orderID).
+		WillReturnRows(items)
+}
+
+func TestInsertOrder(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewOrderRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	order := oapi.Order{
+		Id:          uuid.New(),
+		PvzId:       uuid.New(),
+		CustomerRef: "ORD-1",
+		CreatedBy:   &userID,
+		CreatedAt:   time.Now(),
+	}
+	productIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	expectInsert := func() {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectExec(QueryInsertOrder).
+			WithArgs(order.Id, order.PvzId, "ORD-1", "hash", order.CreatedBy, order.CreatedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectInsert()
+		mockPool.
+			ExpectExec(QueryReserveOrderProducts).
+			WithArgs(productIDs, order.PvzId).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		mockPool.
+			ExpectExec(QueryInsertOrderItems).
+			WithArgs(order.Id, productIDs).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+		expectGetOrder(mockPool, order.Id, "awaiting_pickup", pgxmock.NewRows(orderItemColumns).
+			AddRow(productIDs[0], "ready", nil, nil).
+			AddRow(productIDs[1], "ready", nil, nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.InsertOrder(ctx, order, productIDs, "hash")
+		require.NoError(t, err)
+		require.Equal(t, oapi.AwaitingPickup, got.Status)
+		require.Len(t, got.Items, 2)
+		require.Equal(t, oapi.Ready, got.Items[0].Status)
+	})
+
+	t.Run("product not available", func(t *testing.T) {
+		expectInsert()
+		mockPool.
+			ExpectExec(QueryReserveOrderProducts).
+			WithArgs(productIDs, order.PvzId).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertOrder(ctx, order, productIDs, "hash")
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidOrderProducts)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestIssueOrder(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewOrderRepository(db)
+	ctx := context.Background()
+	orderID := uuid.New()
+	userID := uuid.New()
+	productID := uuid.New()
+	now := time.Now()
+	sent := func(oapi.Order) error { return nil }
+
+	expectLock := func(status string, attempts int) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockOrder).
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderLockColumns).AddRow(status, "hash", attempts))
+	}
+
+	t.Run("partial issue", func(t *testing.T) {
+		ids := []uuid.UUID{productID}
+		expectLock("awaiting_pickup", 0)
+		mockPool.
+			ExpectExec(QueryIssueOrderItems).
+			WithArgs(orderID, ids, now, userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryFinishOrderIssue).
+			WithArgs(orderID, now, "next").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectGetOrder(mockPool, orderID, "partially_issued", pgxmock.NewRows(orderItemColumns).
+			AddRow(productID, "issued", &now, &userID).
+			AddRow(uuid.New(), "ready", nil, nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.IssueOrder(ctx, orderID, "hash", ids, userID, now, "next", sent)
+		require.NoError(t, err)
+		require.Equal(t, oapi.PartiallyIssued, got.Status)
+		require.Equal(t, oapi.Issued, got.Items[0].Status)
+		require.Equal(t, &userID, got.Items[0].IssuedBy)
+	})
+
+	t.Run("unsent code rolls the issue back", func(t *testing.T) {
+		ids := []uuid.UUID{productID}
+		expectLock("awaiting_pickup", 0)
+		mockPool.
+			ExpectExec(QueryIssueOrderItems).
+			WithArgs(orderID, ids, now, userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryFinishOrderIssue).
+			WithArgs(orderID, now, "next").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectGetOrder(mockPool, orderID, "partially_issued", pgxmock.NewRows(orderItemColumns).
+			AddRow(productID, "issued", &now, &userID).
+			AddRow(uuid.New(), "ready", nil, nil))
+		mockPool.ExpectRollback()
+
+		_, err := repo.IssueOrder(ctx, orderID, "hash", ids, userID, now, "next", func(oapi.Order) error {
+			return pvz_errors.ErrPickupCodeNotSent
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeNotSent)
+	})
+
+	t.Run("wrong code is counted", func(t *testing.T) {
+		expectLock("awaiting_pickup", 1)
+		mockPool.
+			ExpectExec(QueryRegisterFailedPickup).
+			WithArgs(orderID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectCommit()
+
+		_, err := repo.IssueOrder(ctx, orderID, "wrong", nil, userID, now, "next", sent)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidPickupCode)
+	})
+
+	t.Run("locked after failed attempts", func(t *testing.T) {
+		expectLock("awaiting_pickup", maxPickupAttempts)
+		mockPool.ExpectRollback()
+
+		_, err := repo.IssueOrder(ctx, orderID, "hash", nil, userID, now, "next", sent)
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeLocked)
+	})
+
+	t.Run("already completed", func(t *testing.T) {
+		expectLock("completed", 0)
+		mockPool.ExpectRollback()
+
+		_, err := repo.IssueOrder(ctx, orderID, "hash", nil, userID, now, "next", sent)
+		require.ErrorIs(t, err, pvz_errors.ErrOrderAlreadyIssued)
+	})
+
+	t.Run("product not in order", func(t *testing.T) {
+		ids := []uuid.UUID{uuid.New()}
+		expectLock("partially_issued", 0)
+		mockPool.
+			ExpectExec(QueryIssueOrderItems).
+			WithArgs(orderID, ids, now, userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mockPool.ExpectRollback()
+
+		_, err := repo.IssueOrder(ctx, orderID, "hash", ids, userID, now, "next", sent)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidOrderProducts)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockOrder).
+			WithArgs(orderID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.IssueOrder(ctx, orderID, "hash", nil, userID, now, "next", sent)
+		require.ErrorIs(t, err, pvz_errors.ErrOrderNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestResetPickupCode(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewOrderRepository(db)
+	ctx := context.Background()
+	orderID := uuid.New()
+	sent := func(oapi.Order) error { return nil }
+
+	t.Run("moderator unlocks a blocked code", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockOrder).
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderLockColumns).AddRow("awaiting_pickup", "old", maxPickupAttempts))
+		mockPool.
+			ExpectExec(QueryResetPickupCode).
+			WithArgs(orderID, "new", true).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectGetOrder(mockPool, orderID, "awaiting_pickup", pgxmock.NewRows(orderItemColumns))
+		mockPool.ExpectCommit()
+
+		got, err := repo.ResetPickupCode(ctx, orderID, "new", true, sent)
+		require.NoError(t, err)
+		require.Empty(t, got.Items)
+	})
+
+	t.Run("blocked code stays locked after employee reset", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockOrder).
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderLockColumns).AddRow("awaiting_pickup", "old", maxPickupAttempts))
+		mockPool.ExpectRollback()
+
+		_, err := repo.ResetPickupCode(ctx, orderID, "new", false, sent)
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeLocked)
+
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockOrder).
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderLockColumns).AddRow("awaiting_pickup", "old", maxPickupAttempts))
+		mockPool.ExpectRollback()
+
+		_, err = repo.IssueOrder(ctx, orderID, "new", nil, uuid.New(), time.Now(), "next", sent)
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeLocked)
+	})
+
+	t.Run("employee reset keeps failed attempts", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockOrder).
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderLockColumns).AddRow("awaiting_pickup", "old", maxPickupAttempts-1))
+		mockPool.
+			ExpectExec(QueryResetPickupCode).
+			WithArgs(orderID, "new", false).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectGetOrder(mockPool, orderID, "awaiting_pickup", pgxmock.NewRows(orderItemColumns))
+		mockPool.ExpectCommit()
+
+		_, err := repo.ResetPickupCode(ctx, orderID, "new", false, sent)
+		require.NoError(t, err)
+	})
+
+	t.Run("unsent code rolled back", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockOrder).
+			WithArgs(orderID).
+			WillReturnRows(pgxmock.NewRows(orderLockColumns).AddRow("awaiting_pickup", "old", 0))
+		mockPool.
+			ExpectExec(QueryResetPickupCode).
+			WithArgs(orderID, "new", false).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectGetOrder(mockPool, orderID, "awaiting_pickup", pgxmock.NewRows(orderItemColumns))
+		mockPool.ExpectRollback()
+
+		_, err := repo.ResetPickupCode(ctx, orderID, "new", false, func(oapi.Order) error {
+			return pvz_errors.ErrPickupCodeNotSent
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeNotSent)
+	})
+
+	t.Run("get missing order", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetOrder).
+			WithArgs(orderID).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.GetOrder(ctx, orderID)
+		require.ErrorIs(t, err, pvz_errors.ErrOrderNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
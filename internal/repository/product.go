@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/whaleship/pvz/internal/database"
 	pvz_errors "github.com/whaleship/pvz/internal/errors"
@@ -22,9 +23,8 @@ func NewProductRepository(dbConn database.PgxIface) *productRepository {
 
 func (r *productRepository) InsertProduct(
 	ctx context.Context,
-	pvzID, productID uuid.UUID,
-	dateTime time.Time,
-	productType string,
+	pvzID uuid.UUID,
+	product oapi.Product,
 ) (uuid.UUID, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -36,17 +36,37 @@ func (r *productRepository) InsertProduct(
 		}
 	}()
 
+	if err = ensureActiveProductTypes(ctx, tx, []string{product.Type}); err != nil {
+		return uuid.Nil, err
+	}
+	if product.Barcode != nil {
+		if err = r.ensureBarcodeFree(ctx, tx, pvzID, *product.Id, *product.Barcode); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	if product.CellId != nil {
+		if err = ensureCellAvailable(ctx, tx, pvzID, *product.CellId); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
 	var receptionID uuid.UUID
-	err = tx.QueryRow(ctx, QueryInsertProduct, pvzID, productID, dateTime, productType).Scan(&receptionID)
+	err = tx.QueryRow(ctx, QueryInsertProduct,
+		pvzID, product.Id, product.DateTime, product.Type, product.Barcode, product.Sku, product.CellId,
+		product.CreatedBy,
+	).Scan(&receptionID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.Is(err, r.db.ErrNoRows()) {
 			return uuid.Nil, pvz_errors.ErrNoOpenRecetionOrPvz
 		}
 
-		if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_products_type" {
 			return uuid.Nil, pvz_errors.ErrInvalidProduct
 		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_products_reception_barcode" {
+			return uuid.Nil, pvz_errors.ErrDuplicateBarcodeScan
+		}
 		return uuid.Nil, err
 	}
 
@@ -56,7 +76,72 @@ func (r *productRepository) InsertProduct(
 	return receptionID, nil
 }
 
-func (r *productRepository) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
+func (r *productRepository) ensureBarcodeFree(
+	ctx context.Context,
+	tx pgx.Tx,
+	pvzID, productID uuid.UUID,
+	barcode string,
+) error {
+	if err := lockPVZBarcodes(ctx, tx, pvzID); err != nil {
+		return err
+	}
+
+	var activeReceptionID uuid.UUID
+	if err := tx.QueryRow(ctx, QueryLockActiveReception, pvzID).Scan(&activeReceptionID); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return pvz_errors.ErrNoOpenRecetionOrPvz
+		}
+		return err
+	}
+
+	var receptionID uuid.UUID
+	err := tx.QueryRow(ctx, QueryFindBarcodeAtPVZ, pvzID, barcode, productID).Scan(&receptionID)
+	switch {
+	case errors.Is(err, r.db.ErrNoRows()):
+		return nil
+	case err != nil:
+		return err
+	case receptionID == activeReceptionID:
+		return pvz_errors.ErrDuplicateBarcodeScan
+	default:
+		return pvz_errors.ErrBarcodeAlreadyAtPVZ
+	}
+}
+
+// lockPVZBarcodes serializes everything that brings a barcode to the PVZ:
+// scans, batches, restores and transfer receipts. The unique index only
+// covers a single reception, so the PVZ-wide check relies on this lock.
+func lockPVZBarcodes(ctx context.Context, tx pgx.Tx, pvzID uuid.UUID) error {
+	var id uuid.UUID
+	if err := tx.QueryRow(ctx, QueryLockPVZBarcodes, pvzID).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pvz_errors.ErrNoOpenRecetionOrPvz
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *productRepository) GetProductByBarcode(
+	ctx context.Context,
+	barcode string,
+	pvzID *uuid.UUID,
+) (oapi.Product, error) {
+	product, err := scanProduct(r.db.QueryRow(ctx, QueryGetProductByBarcode, barcode, pvzID))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Product{}, pvz_errors.ErrProductNotFound
+		}
+		return oapi.Product{}, err
+	}
+	return product, nil
+}
+
+func (r *productRepository) DeleteLastProduct(
+	ctx context.Context,
+	pvzID, deletedBy uuid.UUID,
+	deletedAt time.Time,
+) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return err
@@ -67,19 +152,24 @@ func (r *productRepository) DeleteLastProduct(ctx context.Context, pvzID uuid.UU
 		}
 	}()
 
-	cmdTag, err := tx.Exec(ctx, QueryDeleteLastProduct, pvzID)
-	if err != nil {
-		_ = tx.Rollback(ctx)
+	var receptionID, productID uuid.UUID
+	if err = tx.QueryRow(ctx, QueryLockActiveReception, pvzID).Scan(&receptionID); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrDeletingProduct
+		}
 		return err
 	}
-	if cmdTag.RowsAffected() == 0 {
-		_ = tx.Rollback(ctx)
-		return pvz_errors.ErrDeletingProduct
+	if err = tx.QueryRow(ctx, QuerySelectLastProduct, receptionID).Scan(&productID); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrDeletingProduct
+		}
+		return err
 	}
-	if err = tx.Commit(ctx); err != nil {
+
+	if _, err = trashProduct(ctx, tx, productID, &deletedBy, deletedAt); err != nil {
 		return err
 	}
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (r *productRepository) GetProductsByReceptionIDs(ctx context.Context,
@@ -99,7 +189,10 @@ func (r *productRepository) GetProductsByReceptionIDs(ctx context.Context,
 		var receptionId uuid.UUID
 		var dt time.Time
 		var typ string
-		if err := rows.Scan(&id, &receptionId, &dt, &typ); err != nil {
+		var containerID, cellID *uuid.UUID
+		var barcode, sku *string
+		var status string
+		if err := rows.Scan(&id, &receptionId, &dt, &typ, &containerID, &barcode, &sku, &status, &cellID); err != nil {
 			if errors.Is(err, r.db.ErrNoRows()) {
 				return nil, pvz_errors.ErrSelectProductsFailed
 			}
@@ -109,7 +202,12 @@ func (r *productRepository) GetProductsByReceptionIDs(ctx context.Context,
 			Id:          &id,
 			ReceptionId: receptionId,
 			DateTime:    &dt,
-			Type:        oapi.ProductType(typ),
+			Type:        typ,
+			ContainerId: containerID,
+			Barcode:     barcode,
+			Sku:         sku,
+			Status:      productStatusPtr(status),
+			CellId:      cellID,
 		})
 	}
 	if err = rows.Err(); err != nil {
@@ -120,3 +218,8 @@ func (r *productRepository) GetProductsByReceptionIDs(ctx context.Context,
 	}
 	return products, nil
 }
+
+func productStatusPtr(status string) *oapi.ProductStatus {
+	productStatus := oapi.ProductStatus(status)
+	return &productStatus
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var productCopyColumns = []string{"id", "reception_id", "date_time", "type", "barcode", "sku", "cell_id", "created_by"}
+
+func (r *productRepository) InsertProductBatch(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	products []oapi.Product,
+	atomic bool,
+) (uuid.UUID, []error, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = lockPVZBarcodes(ctx, tx, pvzID); err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	var receptionID uuid.UUID
+	if err = tx.QueryRow(ctx, QueryLockActiveReception, pvzID).Scan(&receptionID); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrNoOpenRecetionOrPvz
+		}
+		return uuid.Nil, nil, err
+	}
+
+	failures, err := r.batchFailures(ctx, tx, pvzID, receptionID, products)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	rows := make([][]any, 0, len(products))
+	for i, p := range products {
+		if failures[i] != nil {
+			continue
+		}
+		products[i].ReceptionId = receptionID
+		rows = append(rows, productCopyRow(p, receptionID))
+	}
+	if len(rows) == 0 || (atomic && len(rows) < len(products)) {
+		_ = tx.Rollback(ctx)
+		return receptionID, failures, nil
+	}
+
+	if _, err = tx.CopyFrom(ctx, pgx.Identifier{"products"}, productCopyColumns, pgx.CopyFromRows(rows)); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_products_reception_barcode" {
+			err = pvz_errors.ErrDuplicateBarcodeScan
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_products_type" {
+			err = pvz_errors.ErrInvalidProduct
+		}
+		return uuid.Nil, nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, nil, err
+	}
+	return receptionID, failures, nil
+}
+
+func productCopyRow(p oapi.Product, receptionID uuid.UUID) []any {
+	return []any{p.Id, receptionID, p.DateTime, p.Type, p.Barcode, p.Sku, p.CellId, p.CreatedBy}
+}
+
+func (r *productRepository) batchFailures(
+	ctx context.Context,
+	tx pgx.Tx,
+	pvzID, receptionID uuid.UUID,
+	products []oapi.Product,
+) ([]error, error) {
+	types := make([]string, 0, len(products))
+	barcodes := make([]string, 0, len(products))
+	cells := make([]uuid.UUID, 0, len(products))
+	for _, p := range products {
+		types = append(types, p.Type)
+		if p.Barcode != nil {
+			barcodes = append(barcodes, *p.Barcode)
+		}
+		if p.CellId != nil {
+			cells = append(cells, *p.CellId)
+		}
+	}
+
+	active, err := selectActiveProductTypes(ctx, tx, types)
+	if err != nil {
+		return nil, err
+	}
+	held, err := r.selectBarcodesAtPVZ(ctx, tx, pvzID, barcodes)
+	if err != nil {
+		return nil, err
+	}
+	room, err := selectCellRoom(ctx, tx, pvzID, cells)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := make([]error, len(products))
+	seen := make(map[string]struct{}, len(barcodes))
+	for i, p := range products {
+		if _, ok := active[p.Type]; !ok {
+			failures[i] = pvz_errors.ErrInvalidProduct
+			continue
+		}
+		if p.Barcode != nil {
+			if heldBy, ok := held[*p.Barcode]; ok {
+				failures[i] = pvz_errors.ErrBarcodeAlreadyAtPVZ
+				if heldBy == receptionID {
+					failures[i] = pvz_errors.ErrDuplicateBarcodeScan
+				}
+				continue
+			}
+			if _, ok := seen[*p.Barcode]; ok {
+				failures[i] = pvz_errors.ErrDuplicateBarcodeScan
+				continue
+			}
+		}
+		if p.CellId != nil {
+			left, ok := room[*p.CellId]
+			if !ok {
+				failures[i] = pvz_errors.ErrCellNotFound
+				continue
+			}
+			if left <= 0 {
+				failures[i] = pvz_errors.ErrCellFull
+				continue
+			}
+			room[*p.CellId] = left - 1
+		}
+		if p.Barcode != nil {
+			seen[*p.Barcode] = struct{}{}
+		}
+	}
+	return failures, nil
+}
+
+// selectCellRoom locks the requested cells and returns how many more products
+// each of them can take. Unknown or inactive cells are left out of the map.
+func selectCellRoom(ctx context.Context, tx pgx.Tx, pvzID uuid.UUID, cells []uuid.UUID) (map[uuid.UUID]int, error) {
+	room := make(map[uuid.UUID]int, len(cells))
+	locked := make(map[uuid.UUID]struct{}, len(cells))
+	for _, cellID := range cells {
+		if _, ok := locked[cellID]; ok {
+			continue
+		}
+		locked[cellID] = struct{}{}
+
+		var capacity, occupied int
+		err := tx.QueryRow(ctx, QueryLockStorageCell, cellID, pvzID).Scan(&capacity, &occupied)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		room[cellID] = capacity - occupied
+	}
+	return room, nil
+}
+
+func (r *productRepository) selectBarcodesAtPVZ(
+	ctx context.Context,
+	tx pgx.Tx,
+	pvzID uuid.UUID,
+	barcodes []string,
+) (map[string]uuid.UUID, error) {
+	held := make(map[string]uuid.UUID)
+	if len(barcodes) == 0 {
+		return held, nil
+	}
+
+	rows, err := tx.Query(ctx, QuerySelectBarcodesAtPVZ, pvzID, barcodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var barcode string
+		var receptionID uuid.UUID
+		if err = rows.Scan(&barcode, &receptionID); err != nil {
+			return nil, err
+		}
+		held[barcode] = receptionID
+	}
+	return held, rows.Err()
+}
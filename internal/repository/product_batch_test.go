@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func TestInsertProductBatch(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductRepository(db)
+
+	ctx := context.Background()
+	pvzID := uuid.New()
+	openID := uuid.New()
+	now := time.Now()
+	newBatch := func() []oapi.Product {
+		return []oapi.Product{
+			{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "обувь", Barcode: strPtr("A")},
+			{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "мебель"},
+			{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "обувь", Barcode: strPtr("A")},
+			{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "одежда", Barcode: strPtr("B")},
+		}
+	}
+	expectChecks := func() {
+		mockPool.ExpectBegin()
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(openID))
+		mockPool.
+			ExpectQuery(QuerySelectActiveProductTypeCodes).
+			WithArgs([]string{"обувь", "мебель", "обувь", "одежда"}).
+			WillReturnRows(pgxmock.NewRows([]string{"code"}).AddRow("обувь").AddRow("одежда"))
+		mockPool.
+			ExpectQuery(QuerySelectBarcodesAtPVZ).
+			WithArgs(pvzID, []string{"A", "A", "B"}).
+			WillReturnRows(pgxmock.NewRows([]string{"barcode", "reception_id"}).AddRow("B", uuid.New()))
+	}
+
+	t.Run("partial", func(t *testing.T) {
+		expectChecks()
+		mockPool.
+			ExpectCopyFrom(pgx.Identifier{"products"}, productCopyColumns).
+			WillReturnResult(1)
+		mockPool.ExpectCommit()
+
+		got, failures, err := repo.InsertProductBatch(ctx, pvzID, newBatch(), false)
+		require.NoError(t, err)
+		require.Equal(t, openID, got)
+		require.NoError(t, failures[0])
+		require.ErrorIs(t, failures[1], pvz_errors.ErrInvalidProduct)
+		require.ErrorIs(t, failures[2], pvz_errors.ErrDuplicateBarcodeScan)
+		require.ErrorIs(t, failures[3], pvz_errors.ErrBarcodeAlreadyAtPVZ)
+	})
+
+	t.Run("atomic rolls back on failures", func(t *testing.T) {
+		expectChecks()
+		mockPool.ExpectRollback()
+
+		_, failures, err := repo.InsertProductBatch(ctx, pvzID, newBatch(), true)
+		require.NoError(t, err)
+		require.Len(t, failures, 4)
+		require.NoError(t, failures[0])
+	})
+
+	t.Run("no open reception", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, _, err := repo.InsertProductBatch(ctx, pvzID, newBatch(), true)
+		require.ErrorIs(t, err, pvz_errors.ErrNoOpenRecetionOrPvz)
+	})
+
+	t.Run("copy conflict", func(t *testing.T) {
+		products := []oapi.Product{{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "обувь", Barcode: strPtr("C")}}
+		mockPool.ExpectBegin()
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(openID))
+		mockPool.
+			ExpectQuery(QuerySelectActiveProductTypeCodes).
+			WithArgs([]string{"обувь"}).
+			WillReturnRows(pgxmock.NewRows([]string{"code"}).AddRow("обувь"))
+		mockPool.
+			ExpectQuery(QuerySelectBarcodesAtPVZ).
+			WithArgs(pvzID, []string{"C"}).
+			WillReturnRows(pgxmock.NewRows([]string{"barcode", "reception_id"}))
+		mockPool.
+			ExpectCopyFrom(pgx.Identifier{"products"}, productCopyColumns).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_products_reception_barcode"})
+		mockPool.ExpectRollback()
+
+		_, _, err := repo.InsertProductBatch(ctx, pvzID, products, true)
+		require.ErrorIs(t, err, pvz_errors.ErrDuplicateBarcodeScan)
+	})
+
+	t.Run("cells", func(t *testing.T) {
+		cellID, missingID := uuid.New(), uuid.New()
+		products := []oapi.Product{
+			{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "обувь", CellId: &cellID},
+			{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "обувь", CellId: &cellID},
+			{Id: uuidPtr(uuid.New()), DateTime: &now, Type: "обувь", CellId: &missingID},
+		}
+		mockPool.ExpectBegin()
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(openID))
+		mockPool.
+			ExpectQuery(QuerySelectActiveProductTypeCodes).
+			WithArgs([]string{"обувь", "обувь", "обувь"}).
+			WillReturnRows(pgxmock.NewRows([]string{"code"}).AddRow("обувь"))
+		mockPool.
+			ExpectQuery(QueryLockStorageCell).
+			WithArgs(cellID, pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"capacity", "count"}).AddRow(5, 4))
+		mockPool.
+			ExpectQuery(QueryLockStorageCell).
+			WithArgs(missingID, pvzID).
+			WillReturnError(pgx.ErrNoRows)
+		mockPool.
+			ExpectCopyFrom(pgx.Identifier{"products"}, productCopyColumns).
+			WillReturnResult(1)
+		mockPool.ExpectCommit()
+
+		_, failures, err := repo.InsertProductBatch(ctx, pvzID, products, false)
+		require.NoError(t, err)
+		require.NoError(t, failures[0])
+		require.ErrorIs(t, failures[1], pvz_errors.ErrCellFull)
+		require.ErrorIs(t, failures[2], pvz_errors.ErrCellNotFound)
+
+		row := productCopyRow(products[0], openID)
+		require.Len(t, row, len(productCopyColumns))
+		for i, column := range productCopyColumns {
+			if column == "cell_id" {
+				require.Equal(t, &cellID, row[i])
+			}
+		}
+	})
+
+	t.Run("type lookup error", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(openID))
+		mockPool.
+			ExpectQuery(QuerySelectActiveProductTypeCodes).
+			WithArgs(anyArgs(1)...).
+			WillReturnError(errors.New("db down"))
+		mockPool.ExpectRollback()
+
+		_, _, err := repo.InsertProductBatch(ctx, pvzID, newBatch(), false)
+		require.Error(t, err)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/whaleship/pvz/internal/database"
 	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
 )
 
 func TestInsertProduct(t *testing.T) {
@@ -29,63 +30,83 @@ func TestInsertProduct(t *testing.T) {
 	now := time.Now()
 	typ := "standard"
 	newRecv := uuid.New()
+	product := oapi.Product{Id: &productID, DateTime: &now, Type: typ}
 
 	t.Run("success", func(t *testing.T) {
 		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
 		mockPool.
 			ExpectQuery(QueryInsertProduct).
-			WithArgs(pvzID, productID, now, typ).
+			WithArgs(pvzID, product.Id, product.DateTime, typ, product.Barcode, product.Sku, product.CellId,
+				product.CreatedBy).
 			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(newRecv))
 		mockPool.ExpectCommit()
 
-		got, err := repo.InsertProduct(ctx, pvzID, productID, now, typ)
+		got, err := repo.InsertProduct(ctx, pvzID, product)
 		require.NoError(t, err)
 		require.Equal(t, newRecv, got)
 	})
 
 	t.Run("no open reception or pvz", func(t *testing.T) {
 		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
 		mockPool.
 			ExpectQuery(QueryInsertProduct).
-			WithArgs(pvzID, productID, now, typ).
+			WithArgs(pvzID, product.Id, product.DateTime, typ, product.Barcode, product.Sku, product.CellId,
+				product.CreatedBy).
 			WillReturnError(db.ErrNoRows())
 
-		_, err := repo.InsertProduct(ctx, pvzID, productID, now, typ)
+		_, err := repo.InsertProduct(ctx, pvzID, product)
 		require.ErrorIs(t, err, pvz_errors.ErrNoOpenRecetionOrPvz)
 	})
 
+	t.Run("inactive product type", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 0)
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertProduct(ctx, pvzID, product)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidProduct)
+	})
+
 	t.Run("invalid product constraint", func(t *testing.T) {
-		pgErr := &pgconn.PgError{Code: "23514"}
+		pgErr := &pgconn.PgError{Code: "23503", ConstraintName: "fk_products_type"}
 		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
 		mockPool.
 			ExpectQuery(QueryInsertProduct).
-			WithArgs(pvzID, productID, now, typ).
+			WithArgs(pvzID, product.Id, product.DateTime, typ, product.Barcode, product.Sku, product.CellId,
+				product.CreatedBy).
 			WillReturnError(pgErr)
 
-		_, err := repo.InsertProduct(ctx, pvzID, productID, now, typ)
+		_, err := repo.InsertProduct(ctx, pvzID, product)
 		require.ErrorIs(t, err, pvz_errors.ErrInvalidProduct)
 	})
 
 	t.Run("other db error", func(t *testing.T) {
 		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
 		mockPool.
 			ExpectQuery(QueryInsertProduct).
-			WithArgs(pvzID, productID, now, typ).
+			WithArgs(pvzID, product.Id, product.DateTime, typ, product.Barcode, product.Sku, product.CellId,
+				product.CreatedBy).
 			WillReturnError(errors.New("some db error"))
 
-		_, err := repo.InsertProduct(ctx, pvzID, productID, now, typ)
+		_, err := repo.InsertProduct(ctx, pvzID, product)
 		require.Error(t, err)
 	})
 
 	t.Run("commit error", func(t *testing.T) {
 		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
 		mockPool.
 			ExpectQuery(QueryInsertProduct).
-			WithArgs(pvzID, productID, now, typ).
+			WithArgs(pvzID, product.Id, product.DateTime, typ, product.Barcode, product.Sku, product.CellId,
+				product.CreatedBy).
 			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(newRecv))
 		mockPool.ExpectCommit().WillReturnError(errors.New("commit failed"))
 
-		_, err := repo.InsertProduct(ctx, pvzID, productID, now, typ)
+		_, err := repo.InsertProduct(ctx, pvzID, product)
 		require.Error(t, err)
 	})
 
@@ -96,11 +117,159 @@ func TestInsertProduct(t *testing.T) {
 		badRepo := NewProductRepository(badDb)
 
 		badPool.ExpectBegin().WillReturnError(errors.New("begin failed"))
-		_, err := badRepo.InsertProduct(ctx, pvzID, productID, now, typ)
+		_, err := badRepo.InsertProduct(ctx, pvzID, product)
 		require.Error(t, err)
 	})
 }
 
+func TestInsertProductWithBarcode(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductRepository(db)
+
+	ctx := context.Background()
+	pvzID := uuid.New()
+	productID := uuid.New()
+	openID := uuid.New()
+	now := time.Now()
+	typ := "обувь"
+	product := oapi.Product{
+		Id:       &productID,
+		DateTime: &now,
+		Type:     typ,
+		Barcode:  strPtr("4601234567890"),
+		Sku:      strPtr("SKU-1"),
+	}
+	insertArgs := []any{
+		pvzID, product.Id, product.DateTime, typ, product.Barcode, product.Sku, product.CellId, product.CreatedBy,
+	}
+
+	expectLock := func() {
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(openID))
+	}
+
+	t.Run("free barcode", func(t *testing.T) {
+		expectLock()
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "4601234567890", productID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.
+			ExpectQuery(QueryInsertProduct).
+			WithArgs(insertArgs...).
+			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(openID))
+		mockPool.ExpectCommit()
+
+		got, err := repo.InsertProduct(ctx, pvzID, product)
+		require.NoError(t, err)
+		require.Equal(t, openID, got)
+	})
+
+	t.Run("scanned twice into open reception", func(t *testing.T) {
+		expectLock()
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "4601234567890", productID).
+			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(openID))
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertProduct(ctx, pvzID, product)
+		require.ErrorIs(t, err, pvz_errors.ErrDuplicateBarcodeScan)
+	})
+
+	t.Run("already at pvz", func(t *testing.T) {
+		expectLock()
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "4601234567890", productID).
+			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(uuid.New()))
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertProduct(ctx, pvzID, product)
+		require.ErrorIs(t, err, pvz_errors.ErrBarcodeAlreadyAtPVZ)
+	})
+
+	t.Run("no open reception", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertProduct(ctx, pvzID, product)
+		require.ErrorIs(t, err, pvz_errors.ErrNoOpenRecetionOrPvz)
+	})
+
+	t.Run("unique index violation", func(t *testing.T) {
+		expectLock()
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "4601234567890", productID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.
+			ExpectQuery(QueryInsertProduct).
+			WithArgs(insertArgs...).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_products_reception_barcode"})
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertProduct(ctx, pvzID, product)
+		require.ErrorIs(t, err, pvz_errors.ErrDuplicateBarcodeScan)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestGetProductByBarcode(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	pvzID := uuidPtr(uuid.New())
+
+	t.Run("found", func(t *testing.T) {
+		productID := uuid.New()
+		rows := pgxmock.NewRows(productColumns).
+			AddRow(productID, uuid.New(), time.Now(), "обувь", nil, strPtr("4601234567890"), strPtr("SKU-1"),
+				"received", nil)
+		mockPool.
+			ExpectQuery(QueryGetProductByBarcode).
+			WithArgs("4601234567890", pvzID).
+			WillReturnRows(rows)
+
+		got, err := repo.GetProductByBarcode(ctx, "4601234567890", pvzID)
+		require.NoError(t, err)
+		require.Equal(t, &productID, got.Id)
+		require.Equal(t, "SKU-1", *got.Sku)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetProductByBarcode).
+			WithArgs("missing", (*uuid.UUID)(nil)).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.GetProductByBarcode(ctx, "missing", nil)
+		require.ErrorIs(t, err, pvz_errors.ErrProductNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
 func TestDeleteLastProduct(t *testing.T) {
 	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
 	require.NoError(t, err)
@@ -110,50 +279,90 @@ func TestDeleteLastProduct(t *testing.T) {
 	repo := NewProductRepository(db)
 
 	pvzID := uuid.New()
+	receptionID := uuid.New()
+	productID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
 	ctx := context.Background()
 
-	t.Run("success", func(t *testing.T) {
+	expectLastProduct := func() {
 		mockPool.ExpectBegin()
 		mockPool.
-			ExpectExec(QueryDeleteLastProduct).
+			ExpectQuery(QueryLockActiveReception).
 			WithArgs(pvzID).
-			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(receptionID))
+		mockPool.
+			ExpectQuery(QuerySelectLastProduct).
+			WithArgs(receptionID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(productID))
+		mockPool.
+			ExpectQuery(QueryLockProductReception).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id"}).AddRow(pvzID))
+	}
+
+	t.Run("moved to trash", func(t *testing.T) {
+		expectLastProduct()
+		mockPool.
+			ExpectQuery(QueryTrashProduct).
+			WithArgs(productID, &userID, now).
+			WillReturnRows(pgxmock.NewRows(deletedProductColumns).
+				AddRow(productID, receptionID, now, "обувь", nil, nil, nil, nil, &userID, now))
 		mockPool.ExpectCommit()
 
-		require.NoError(t, repo.DeleteLastProduct(ctx, pvzID))
+		require.NoError(t, repo.DeleteLastProduct(ctx, pvzID, userID, now))
 	})
 
-	t.Run("nothing to delete", func(t *testing.T) {
+	t.Run("no open reception", func(t *testing.T) {
 		mockPool.ExpectBegin()
 		mockPool.
-			ExpectExec(QueryDeleteLastProduct).
+			ExpectQuery(QueryLockActiveReception).
 			WithArgs(pvzID).
-			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
 
-		err := repo.DeleteLastProduct(ctx, pvzID)
+		err := repo.DeleteLastProduct(ctx, pvzID, userID, now)
 		require.ErrorIs(t, err, pvz_errors.ErrDeletingProduct)
 	})
 
-	t.Run("exec error", func(t *testing.T) {
+	t.Run("nothing to delete", func(t *testing.T) {
 		mockPool.ExpectBegin()
 		mockPool.
-			ExpectExec(QueryDeleteLastProduct).
+			ExpectQuery(QueryLockActiveReception).
 			WithArgs(pvzID).
-			WillReturnError(errors.New("exec failed"))
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(receptionID))
+		mockPool.
+			ExpectQuery(QuerySelectLastProduct).
+			WithArgs(receptionID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
 
-		err := repo.DeleteLastProduct(ctx, pvzID)
+		err := repo.DeleteLastProduct(ctx, pvzID, userID, now)
+		require.ErrorIs(t, err, pvz_errors.ErrDeletingProduct)
+	})
+
+	t.Run("trash error", func(t *testing.T) {
+		expectLastProduct()
+		mockPool.
+			ExpectQuery(QueryTrashProduct).
+			WithArgs(productID, &userID, now).
+			WillReturnError(errors.New("insert failed"))
+		mockPool.ExpectRollback()
+
+		err := repo.DeleteLastProduct(ctx, pvzID, userID, now)
 		require.Error(t, err)
 	})
 
 	t.Run("commit error", func(t *testing.T) {
-		mockPool.ExpectBegin()
+		expectLastProduct()
 		mockPool.
-			ExpectExec(QueryDeleteLastProduct).
-			WithArgs(pvzID).
-			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+			ExpectQuery(QueryTrashProduct).
+			WithArgs(productID, &userID, now).
+			WillReturnRows(pgxmock.NewRows(deletedProductColumns).
+				AddRow(productID, receptionID, now, "обувь", nil, nil, nil, nil, &userID, now))
 		mockPool.ExpectCommit().WillReturnError(errors.New("commit failed"))
 
-		err := repo.DeleteLastProduct(ctx, pvzID)
+		err := repo.DeleteLastProduct(ctx, pvzID, userID, now)
 		require.Error(t, err)
 	})
 
@@ -164,9 +373,11 @@ func TestDeleteLastProduct(t *testing.T) {
 		badRepo := NewProductRepository(badDb)
 
 		badPool.ExpectBegin().WillReturnError(errors.New("begin failed"))
-		err := badRepo.DeleteLastProduct(ctx, pvzID)
+		err := badRepo.DeleteLastProduct(ctx, pvzID, userID, now)
 		require.Error(t, err)
 	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
 }
 
 func TestGetProductsByReceptionIDs(t *testing.T) {
@@ -181,9 +392,10 @@ func TestGetProductsByReceptionIDs(t *testing.T) {
 	ids := []*uuid.UUID{uuidPtr(uuid.New()), uuidPtr(uuid.New())}
 
 	t.Run("success multiple products", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type"}).
-			AddRow(uuid.New(), *ids[0], time.Now(), "A").
-			AddRow(uuid.New(), *ids[1], time.Now(), "B")
+		rows := pgxmock.NewRows(productColumns).
+			AddRow(uuid.New(), *ids[0], time.Now(), "A", nil, nil, nil, "received", nil).
+			AddRow(uuid.New(), *ids[1], time.Now(), "B", uuidPtr(uuid.New()), strPtr("4601234567890"), strPtr("SKU-1"),
+				"issued", nil)
 		mockPool.
 			ExpectQuery(QueryGetProductsByReceptions).
 			WithArgs(ids).
@@ -215,8 +427,8 @@ func TestGetProductsByReceptionIDs(t *testing.T) {
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type"}).
-			AddRow("bad-uuid", *ids[0], time.Now(), "A")
+		rows := pgxmock.NewRows(productColumns).
+			AddRow("bad-uuid", *ids[0], time.Now(), "A", nil, nil, nil, "received", nil)
 		mockPool.
 			ExpectQuery(QueryGetProductsByReceptions).
 			WithArgs(ids).
@@ -228,8 +440,8 @@ func TestGetProductsByReceptionIDs(t *testing.T) {
 
 	t.Run("scan no rows", func(t *testing.T) {
 		validID := uuid.New()
-		rows := pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type"}).
-			AddRow(validID, *ids[0], time.Now(), "A").
+		rows := pgxmock.NewRows(productColumns).
+			AddRow(validID, *ids[0], time.Now(), "A", nil, nil, nil, "received", nil).
 			RowError(0, db.ErrNoRows())
 		mockPool.
 			ExpectQuery(QueryGetProductsByReceptions).
@@ -241,7 +453,7 @@ func TestGetProductsByReceptionIDs(t *testing.T) {
 	})
 
 	t.Run("rows error after next", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type"}).
+		rows := pgxmock.NewRows(productColumns).
 			RowError(0, errors.New("row fail"))
 		mockPool.
 			ExpectQuery(QueryGetProductsByReceptions).
@@ -253,7 +465,7 @@ func TestGetProductsByReceptionIDs(t *testing.T) {
 	})
 
 	t.Run("rows Err no rows", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "reception_id", "date_time", "type"}).
+		rows := pgxmock.NewRows(productColumns).
 			RowError(0, db.ErrNoRows())
 		mockPool.
 			ExpectQuery(QueryGetProductsByReceptions).
@@ -265,4 +477,22 @@ func TestGetProductsByReceptionIDs(t *testing.T) {
 	})
 }
 
+func expectActiveProductTypes(mockPool pgxmock.PgxPoolIface, codes []string, active int) {
+	mockPool.
+		ExpectQuery(QueryCountActiveProductTypes).
+		WithArgs(codes).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(active))
+}
+
+func expectPVZBarcodeLock(mockPool pgxmock.PgxPoolIface, pvzID uuid.UUID) {
+	mockPool.
+		ExpectQuery(QueryLockPVZBarcodes).
+		WithArgs(pvzID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(pvzID))
+}
+
+var productColumns = []string{
+	"id", "reception_id", "date_time", "type", "container_id", "barcode", "sku", "status", "cell_id",
+}
+
 func uuidPtr(u uuid.UUID) *uuid.UUID { return &u }
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func (r *productRepository) TrashProduct(
+	ctx context.Context,
+	productID, deletedBy uuid.UUID,
+	deletedAt time.Time,
+) (oapi.DeletedProduct, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.DeletedProduct{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	deleted, err := trashProduct(ctx, tx, productID, &deletedBy, deletedAt)
+	if err != nil {
+		return oapi.DeletedProduct{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.DeletedProduct{}, err
+	}
+	return deleted, nil
+}
+
+// trashProduct moves a product of an open reception to deleted_products. It is
+// shared by direct deletion and by approved deletion requests.
+func trashProduct(
+	ctx context.Context,
+	tx pgx.Tx,
+	productID uuid.UUID,
+	deletedBy *uuid.UUID,
+	deletedAt time.Time,
+) (oapi.DeletedProduct, error) {
+	var pvzID uuid.UUID
+	if err := tx.QueryRow(ctx, QueryLockProductReception, productID).Scan(&pvzID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return oapi.DeletedProduct{}, pvz_errors.ErrProductNotInOpenReception
+		}
+		return oapi.DeletedProduct{}, err
+	}
+	return scanDeletedProduct(tx.QueryRow(ctx, QueryTrashProduct, productID, deletedBy, deletedAt))
+}
+
+func (r *productRepository) UpdateProduct(
+	ctx context.Context,
+	productID uuid.UUID,
+	patch oapi.PatchProductsProductIdJSONRequestBody,
+) (oapi.Product, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Product{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	pvzID, err := r.lockProductReception(ctx, tx, productID)
+	if err != nil {
+		return oapi.Product{}, err
+	}
+	if patch.Type != nil {
+		if err = ensureActiveProductTypes(ctx, tx, []string{*patch.Type}); err != nil {
+			return oapi.Product{}, err
+		}
+	}
+	if patch.Barcode != nil {
+		if err = r.ensureBarcodeFree(ctx, tx, pvzID, productID, *patch.Barcode); err != nil {
+			return oapi.Product{}, err
+		}
+	}
+
+	product, err := scanProduct(tx.QueryRow(ctx, QueryUpdateProduct, productID, patch.Type, patch.Barcode, patch.Sku))
+	if err != nil {
+		err = mapProductWriteError(err)
+		return oapi.Product{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Product{}, err
+	}
+	return product, nil
+}
+
+func (r *productRepository) RestoreProduct(ctx context.Context, productID uuid.UUID) (oapi.Product, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Product{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var pvzID uuid.UUID
+	var barcode *string
+	var cellID *uuid.UUID
+	err = tx.QueryRow(ctx, QueryLockDeletedProductReception, productID).Scan(&pvzID, &barcode, &cellID)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrDeletedProductNotFound
+		}
+		return oapi.Product{}, err
+	}
+	if barcode != nil {
+		if err = r.ensureBarcodeFree(ctx, tx, pvzID, productID, *barcode); err != nil {
+			return oapi.Product{}, err
+		}
+	}
+	// the cell may have been filled or deactivated while the product was in
+	// the trash; the product then comes back without a cell
+	if cellID != nil {
+		switch err = ensureCellAvailable(ctx, tx, pvzID, *cellID); {
+		case errors.Is(err, pvz_errors.ErrCellFull), errors.Is(err, pvz_errors.ErrCellNotFound):
+			cellID = nil
+		case err != nil:
+			return oapi.Product{}, err
+		}
+	}
+
+	product, err := scanProduct(tx.QueryRow(ctx, QueryRestoreProduct, productID, cellID))
+	if err != nil {
+		err = mapProductWriteError(err)
+		return oapi.Product{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Product{}, err
+	}
+	return product, nil
+}
+
+func (r *productRepository) SelectDeletedProducts(
+	ctx context.Context,
+	receptionID uuid.UUID,
+) ([]oapi.DeletedProduct, error) {
+	rows, err := r.db.Query(ctx, QuerySelectDeletedProducts, receptionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectProductsFailed, err)
+	}
+	defer rows.Close()
+
+	deleted := make([]oapi.DeletedProduct, 0)
+	for rows.Next() {
+		item, err := scanDeletedProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectProductsFailed, err)
+		}
+		deleted = append(deleted, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectProductsFailed, err)
+	}
+	return deleted, nil
+}
+
+func (r *productRepository) lockProductReception(
+	ctx context.Context,
+	tx pgx.Tx,
+	productID uuid.UUID,
+) (uuid.UUID, error) {
+	var pvzID uuid.UUID
+	if err := tx.QueryRow(ctx, QueryLockProductReception, productID).Scan(&pvzID); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return uuid.Nil, pvz_errors.ErrProductNotInOpenReception
+		}
+		return uuid.Nil, err
+	}
+	return pvzID, nil
+}
+
+func mapProductWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch {
+	case pgErr.Code == "23505" && pgErr.ConstraintName == "idx_products_reception_barcode":
+		return pvz_errors.ErrDuplicateBarcodeScan
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "fk_products_type":
+		return pvz_errors.ErrInvalidProduct
+	}
+	return err
+}
+
+func scanProduct(row pgx.Row) (oapi.Product, error) {
+	var id uuid.UUID
+	var dt time.Time
+	var status string
+	product := oapi.Product{Id: &id, DateTime: &dt}
+	err := row.Scan(
+		&id,
+		&product.ReceptionId,
+		&dt,
+		&product.Type,
+		&product.ContainerId,
+		&product.Barcode,
+		&product.Sku,
+		&status,
+		&product.CellId,
+	)
+	product.Status = productStatusPtr(status)
+	return product, err
+}
+
+func scanDeletedProduct(row pgx.Row) (oapi.DeletedProduct, error) {
+	var id uuid.UUID
+	var dt time.Time
+	deleted := oapi.DeletedProduct{Product: oapi.Product{Id: &id, DateTime: &dt}}
+	err := row.Scan(
+		&id,
+		&deleted.Product.ReceptionId,
+		&dt,
+		&deleted.Product.Type,
+		&deleted.Product.ContainerId,
+		&deleted.Product.Barcode,
+		&deleted.Product.Sku,
+		&deleted.Product.CellId,
+		&deleted.DeletedBy,
+		&deleted.DeletedAt,
+	)
+	return deleted, err
+}
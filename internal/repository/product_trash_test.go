@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var deletedProductColumns = []string{
+	"id", "reception_id", "date_time", "type", "container_id", "barcode", "sku", "cell_id", "deleted_by", "deleted_at",
+}
+
+func TestTrashProduct(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	productID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockProductReception).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id"}).AddRow(uuid.New()))
+		mockPool.
+			ExpectQuery(QueryTrashProduct).
+			WithArgs(productID, &userID, now).
+			WillReturnRows(pgxmock.NewRows(deletedProductColumns).
+				AddRow(productID, uuid.New(), now, "обувь", nil, strPtr("A"), nil, nil, &userID, now))
+		mockPool.ExpectCommit()
+
+		got, err := repo.TrashProduct(ctx, productID, userID, now)
+		require.NoError(t, err)
+		require.Equal(t, &productID, got.Product.Id)
+		require.Equal(t, &userID, got.DeletedBy)
+	})
+
+	t.Run("not in open reception", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockProductReception).
+			WithArgs(productID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.TrashProduct(ctx, productID, userID, now)
+		require.ErrorIs(t, err, pvz_errors.ErrProductNotInOpenReception)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestUpdateProduct(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	productID := uuid.New()
+	pvzID := uuid.New()
+	openID := uuid.New()
+	typ := "одежда"
+	patch := oapi.PatchProductsProductIdJSONRequestBody{Type: &typ, Barcode: strPtr("B")}
+
+	expectLockAndChecks := func() {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockProductReception).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id"}).AddRow(pvzID))
+		expectActiveProductTypes(mockPool, []string{typ}, 1)
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(openID))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectLockAndChecks()
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "B", productID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.
+			ExpectQuery(QueryUpdateProduct).
+			WithArgs(productID, patch.Type, patch.Barcode, patch.Sku).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(productID, openID, time.Now(), typ, nil, strPtr("B"), nil, "received", nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.UpdateProduct(ctx, productID, patch)
+		require.NoError(t, err)
+		require.Equal(t, typ, got.Type)
+		require.Equal(t, "B", *got.Barcode)
+	})
+
+	t.Run("barcode taken in reception", func(t *testing.T) {
+		expectLockAndChecks()
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "B", productID).
+			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(openID))
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpdateProduct(ctx, productID, patch)
+		require.ErrorIs(t, err, pvz_errors.ErrDuplicateBarcodeScan)
+	})
+
+	t.Run("type constraint", func(t *testing.T) {
+		expectLockAndChecks()
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "B", productID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.
+			ExpectQuery(QueryUpdateProduct).
+			WithArgs(productID, patch.Type, patch.Barcode, patch.Sku).
+			WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_products_type"})
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpdateProduct(ctx, productID, patch)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidProduct)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestRestoreProduct(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	productID := uuid.New()
+	pvzID := uuid.New()
+	openID := uuid.New()
+
+	t.Run("success without barcode", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockDeletedProductReception).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "barcode", "cell_id"}).AddRow(pvzID, nil, nil))
+		mockPool.
+			ExpectQuery(QueryRestoreProduct).
+			WithArgs(productID, (*uuid.UUID)(nil)).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(productID, openID, time.Now(), "обувь", nil, nil, nil, "received", nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.RestoreProduct(ctx, productID)
+		require.NoError(t, err)
+		require.Equal(t, openID, got.ReceptionId)
+	})
+
+	t.Run("cell kept while it has room", func(t *testing.T) {
+		cellID := uuid.New()
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockDeletedProductReception).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "barcode", "cell_id"}).AddRow(pvzID, nil, &cellID))
+		mockPool.
+			ExpectQuery(QueryLockStorageCell).
+			WithArgs(cellID, pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"capacity", "count"}).AddRow(2, 1))
+		mockPool.
+			ExpectQuery(QueryRestoreProduct).
+			WithArgs(productID, &cellID).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(productID, openID, time.Now(), "обувь", nil, nil, nil, "received", &cellID))
+		mockPool.ExpectCommit()
+
+		got, err := repo.RestoreProduct(ctx, productID)
+		require.NoError(t, err)
+		require.Equal(t, &cellID, got.CellId)
+	})
+
+	t.Run("full cell dropped", func(t *testing.T) {
+		cellID := uuid.New()
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockDeletedProductReception).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "barcode", "cell_id"}).AddRow(pvzID, nil, &cellID))
+		mockPool.
+			ExpectQuery(QueryLockStorageCell).
+			WithArgs(cellID, pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"capacity", "count"}).AddRow(1, 1))
+		mockPool.
+			ExpectQuery(QueryRestoreProduct).
+			WithArgs(productID, (*uuid.UUID)(nil)).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(productID, openID, time.Now(), "обувь", nil, nil, nil, "received", nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.RestoreProduct(ctx, productID)
+		require.NoError(t, err)
+		require.Nil(t, got.CellId)
+	})
+
+	t.Run("barcode rescanned meanwhile", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockDeletedProductReception).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "barcode", "cell_id"}).AddRow(pvzID, strPtr("A"), nil))
+		expectPVZBarcodeLock(mockPool, pvzID)
+		mockPool.
+			ExpectQuery(QueryLockActiveReception).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(openID))
+		mockPool.
+			ExpectQuery(QueryFindBarcodeAtPVZ).
+			WithArgs(pvzID, "A", productID).
+			WillReturnRows(pgxmock.NewRows([]string{"reception_id"}).AddRow(openID))
+		mockPool.ExpectRollback()
+
+		_, err := repo.RestoreProduct(ctx, productID)
+		require.ErrorIs(t, err, pvz_errors.ErrDuplicateBarcodeScan)
+	})
+
+	t.Run("reception closed or not in trash", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockDeletedProductReception).
+			WithArgs(productID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.RestoreProduct(ctx, productID)
+		require.ErrorIs(t, err, pvz_errors.ErrDeletedProductNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectDeletedProducts(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductRepository(db)
+	ctx := context.Background()
+	receptionID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		mockPool.
+			ExpectQuery(QuerySelectDeletedProducts).
+			WithArgs(receptionID).
+			WillReturnRows(pgxmock.NewRows(deletedProductColumns).
+				AddRow(uuid.New(), receptionID, now, "обувь", nil, nil, nil, nil, nil, now))
+
+		got, err := repo.SelectDeletedProducts(ctx, receptionID)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Nil(t, got[0].DeletedBy)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectDeletedProducts).
+			WithArgs(receptionID).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.SelectDeletedProducts(ctx, receptionID)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectProductsFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type productTypeRepository struct {
+	db database.PgxIface
+}
+
+func NewProductTypeRepository(dbConn database.PgxIface) *productTypeRepository {
+	return &productTypeRepository{db: dbConn}
+}
+
+func (r *productTypeRepository) SelectProductTypes(
+	ctx context.Context,
+	includeInactive bool,
+) ([]oapi.ProductTypeInfo, error) {
+	rows, err := r.db.Query(ctx, QuerySelectProductTypes, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectProductTypesFailed, err)
+	}
+	defer rows.Close()
+
+	types := []oapi.ProductTypeInfo{}
+	for rows.Next() {
+		var t oapi.ProductTypeInfo
+		if err := rows.Scan(&t.Code, &t.DisplayNames, &t.Fragile, &t.HighValue, &t.Oversized,
+			&t.Active, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func (r *productTypeRepository) InsertProductType(
+	ctx context.Context,
+	productType oapi.ProductTypeInfo,
+) (oapi.ProductTypeInfo, error) {
+	err := r.db.QueryRow(ctx, QueryInsertProductType,
+		productType.Code,
+		productType.DisplayNames,
+		productType.Fragile,
+		productType.HighValue,
+		productType.Oversized,
+		productType.Active,
+		productType.UpdatedAt,
+	).Scan(&productType.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return oapi.ProductTypeInfo{}, pvz_errors.ErrProductTypeAlreadyExists
+		}
+		return oapi.ProductTypeInfo{}, err
+	}
+	return productType, nil
+}
+
+func (r *productTypeRepository) UpdateProductType(
+	ctx context.Context,
+	productType oapi.ProductTypeInfo,
+) (oapi.ProductTypeInfo, error) {
+	err := r.db.QueryRow(ctx, QueryUpdateProductType,
+		productType.Code,
+		productType.DisplayNames,
+		productType.Fragile,
+		productType.HighValue,
+		productType.Oversized,
+		productType.Active,
+		productType.UpdatedAt,
+	).Scan(&productType.CreatedAt)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.ProductTypeInfo{}, pvz_errors.ErrProductTypeNotFound
+		}
+		return oapi.ProductTypeInfo{}, err
+	}
+	return productType, nil
+}
+
+func ensureActiveProductTypes(ctx context.Context, tx pgx.Tx, types []string) error {
+	unique := make(map[string]struct{}, len(types))
+	codes := make([]string, 0, len(types))
+	for _, t := range types {
+		if _, ok := unique[t]; ok {
+			continue
+		}
+		unique[t] = struct{}{}
+		codes = append(codes, t)
+	}
+
+	var active int
+	if err := tx.QueryRow(ctx, QueryCountActiveProductTypes, codes).Scan(&active); err != nil {
+		return err
+	}
+	if active != len(codes) {
+		return pvz_errors.ErrInvalidProduct
+	}
+	return nil
+}
+
+func selectActiveProductTypes(ctx context.Context, tx pgx.Tx, types []string) (map[string]struct{}, error) {
+	rows, err := tx.Query(ctx, QuerySelectActiveProductTypeCodes, types)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	active := make(map[string]struct{}, len(types))
+	for rows.Next() {
+		var code string
+		if err = rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		active[code] = struct{}{}
+	}
+	return active, rows.Err()
+}
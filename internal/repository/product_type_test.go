@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func TestSelectProductTypes(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductTypeRepository(db)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		rows := pgxmock.NewRows([]string{
+			"code", "display_names", "fragile", "high_value", "oversized", "active", "created_at", "updated_at",
+		}).AddRow(
+			"электроника", map[string]string{"ru": "Электроника"}, true, true, false, true, &now, &now,
+		)
+		mockPool.
+			ExpectQuery(QuerySelectProductTypes).
+			WithArgs(false).
+			WillReturnRows(rows)
+
+		got, err := repo.SelectProductTypes(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, "Электроника", got[0].DisplayNames["ru"])
+		require.True(t, got[0].HighValue)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectProductTypes).
+			WithArgs(true).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.SelectProductTypes(ctx, true)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectProductTypesFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestInsertAndUpdateProductType(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewProductTypeRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+	productType := oapi.ProductTypeInfo{
+		Code:         "мебель",
+		DisplayNames: map[string]string{"ru": "Мебель"},
+		Oversized:    true,
+		Active:       true,
+		UpdatedAt:    &now,
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryInsertProductType).
+			WithArgs("мебель", productType.DisplayNames, false, false, true, true, productType.UpdatedAt).
+			WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(&now))
+
+		got, err := repo.InsertProductType(ctx, productType)
+		require.NoError(t, err)
+		require.Equal(t, &now, got.CreatedAt)
+	})
+
+	t.Run("insert duplicate", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryInsertProductType).
+			WithArgs(anyArgs(7)...).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		_, err := repo.InsertProductType(ctx, productType)
+		require.ErrorIs(t, err, pvz_errors.ErrProductTypeAlreadyExists)
+	})
+
+	t.Run("update missing", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryUpdateProductType).
+			WithArgs(anyArgs(7)...).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.UpdateProductType(ctx, productType)
+		require.ErrorIs(t, err, pvz_errors.ErrProductTypeNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
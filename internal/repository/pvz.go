@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/whaleship/pvz/internal/database"
 	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
@@ -22,40 +23,59 @@ func NewPVZRepository(dbConn database.PgxIface) *pvzRepository {
 	return &pvzRepository{db: dbConn}
 }
 
-func (r *pvzRepository) InsertPVZ(
-	ctx context.Context,
-	city oapi.PVZCity,
-	registrationDate time.Time) (oapi.PVZ, error) {
+func (r *pvzRepository) InsertPVZ(ctx context.Context, pvz oapi.PVZ) (oapi.PVZ, error) {
 	newPvzID := uuid.New()
-	var id uuid.UUID
-	var outCity string
-	var regDate time.Time
-
-	err := r.db.QueryRow(ctx, QueryInsertPVZ, newPvzID, string(city), registrationDate).
-		Scan(&id, &outCity, &regDate)
+	out, err := scanPVZ(r.db.QueryRow(ctx, QueryInsertPVZ,
+		newPvzID, string(pvz.City), pvz.RegistrationDate,
+		pvz.Latitude, pvz.Longitude, pvz.RegionId,
+	))
 	if err != nil {
 		if errors.Is(err, r.db.ErrNoRows()) {
 			return oapi.PVZ{}, pvz_errors.ErrInsertPVZFailed
 		}
 		return oapi.PVZ{}, err
 	}
+	return out, nil
+}
 
-	return oapi.PVZ{
-		Id:               &id,
-		City:             oapi.PVZCity(outCity),
-		RegistrationDate: &regDate,
-	}, nil
+func (r *pvzRepository) UpdatePVZLocation(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	latitude, longitude float64,
+	regionID *uuid.UUID,
+) (oapi.PVZ, error) {
+	pvz, err := scanPVZ(r.db.QueryRow(ctx, QueryUpdatePVZLocation, pvzID, latitude, longitude, regionID))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.PVZ{}, pvz_errors.ErrPVZNotFound
+		}
+		return oapi.PVZ{}, err
+	}
+	return pvz, nil
+}
+
+func (r *pvzRepository) UpdatePVZIntakeCapacity(ctx context.Context, pvzID uuid.UUID, capacity *int) (oapi.PVZ, error) {
+	pvz, err := scanPVZ(r.db.QueryRow(ctx, QueryUpdatePVZIntakeCapacity, pvzID, capacity))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.PVZ{}, pvz_errors.ErrPVZNotFound
+		}
+		return oapi.PVZ{}, err
+	}
+	return pvz, nil
 }
 
 func (r *pvzRepository) SelectPVZByOpenReceptions(
 	ctx context.Context,
 	startDate, endDate time.Time,
+	regionID *uuid.UUID,
 	limit, offset int,
 ) ([]oapi.PVZ, error) {
 	rows, err := r.db.Query(ctx,
 		QuerySelectPVZByOpenReceptions,
 		startDate, endDate,
 		limit, offset,
+		regionID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectPVZFailed, err)
@@ -64,21 +84,30 @@ func (r *pvzRepository) SelectPVZByOpenReceptions(
 
 	var list []oapi.PVZ
 	for rows.Next() {
-		var id uuid.UUID
-		var city string
-		var regDate time.Time
-		if err := rows.Scan(&id, &city, &regDate); err != nil {
+		pvz, err := scanPVZ(rows)
+		if err != nil {
 			return nil, err
 		}
-		list = append(list, oapi.PVZ{
-			Id:               &id,
-			City:             oapi.PVZCity(city),
-			RegistrationDate: &regDate,
-		})
+		list = append(list, pvz)
 	}
 	return list, rows.Err()
 }
 
+func scanPVZ(row pgx.Row) (oapi.PVZ, error) {
+	var id uuid.UUID
+	var city string
+	var regDate time.Time
+	var pvz oapi.PVZ
+	err := row.Scan(&id, &city, &regDate, &pvz.Latitude, &pvz.Longitude, &pvz.RegionId, &pvz.DailyIntakeCapacity)
+	if err != nil {
+		return oapi.PVZ{}, err
+	}
+	pvz.Id = &id
+	pvz.City = oapi.PVZCity(city)
+	pvz.RegistrationDate = &regDate
+	return pvz, nil
+}
+
 func (r *pvzRepository) SelectAllPVZs(ctx context.Context) ([]*proto.PVZ, error) {
 	rows, err := r.db.Query(ctx, QuerySelectAllPVZs)
 	if err != nil {
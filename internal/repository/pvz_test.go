@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/require"
 	pvz_errors "github.com/whaleship/pvz/internal/errors"
@@ -16,6 +17,13 @@ import (
 	"github.com/whaleship/pvz/internal/gen/oapi"
 )
 
+var (
+	pvzColumns = []string{
+		"id", "city", "registration_date", "latitude", "longitude", "region_id", "daily_intake_capacity",
+	}
+	noRegion *uuid.UUID
+)
+
 func TestInsertPVZ(t *testing.T) {
 	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
 	require.NoError(t, err)
@@ -35,13 +43,16 @@ func TestInsertPVZ(t *testing.T) {
 				pgxmock.AnyArg(),
 				string(city),
 				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
 			).
 			WillReturnRows(
-				pgxmock.NewRows([]string{"id", "city", "registration_date"}).
-					AddRow(uuid.New(), string(city), reg),
+				pgxmock.NewRows(pvzColumns).
+					AddRow(uuid.New(), string(city), reg, nil, nil, nil, nil),
 			)
 
-		pvz, err := repo.InsertPVZ(ctx, city, reg)
+		pvz, err := repo.InsertPVZ(ctx, oapi.PVZ{City: city, RegistrationDate: &reg})
 		require.NoError(t, err)
 		require.Equal(t, string(city), string(pvz.City))
 	})
@@ -53,10 +64,13 @@ func TestInsertPVZ(t *testing.T) {
 				pgxmock.AnyArg(),
 				string(city),
 				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
 			).
 			WillReturnError(db.ErrNoRows())
 
-		_, err := repo.InsertPVZ(ctx, city, reg)
+		_, err := repo.InsertPVZ(ctx, oapi.PVZ{City: city, RegistrationDate: &reg})
 		require.ErrorIs(t, err, pvz_errors.ErrInsertPVZFailed)
 	})
 
@@ -67,25 +81,31 @@ func TestInsertPVZ(t *testing.T) {
 				pgxmock.AnyArg(),
 				string(city),
 				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
 			).
 			WillReturnError(errors.New("db"))
 
-		_, err := repo.InsertPVZ(ctx, city, reg)
+		_, err := repo.InsertPVZ(ctx, oapi.PVZ{City: city, RegistrationDate: &reg})
 		require.Error(t, err)
 	})
 	t.Run("scan error", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "city", "registration_date"}).
-			AddRow("not-a-uuid", string(city), reg)
+		rows := pgxmock.NewRows(pvzColumns).
+			AddRow("not-a-uuid", string(city), reg, nil, nil, nil, nil)
 		mockPool.
 			ExpectQuery(QueryInsertPVZ).
 			WithArgs(
 				pgxmock.AnyArg(),
 				string(city),
 				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
+				pgxmock.AnyArg(),
 			).
 			WillReturnRows(rows)
 
-		_, err := repo.InsertPVZ(ctx, city, reg)
+		_, err := repo.InsertPVZ(ctx, oapi.PVZ{City: city, RegistrationDate: &reg})
 		require.Error(t, err)
 	})
 
@@ -94,7 +114,7 @@ func TestInsertPVZ(t *testing.T) {
 			ExpectQuery(QueryInsertPVZ).
 			WillReturnError(errors.New("some failure"))
 
-		_, err := repo.InsertPVZ(ctx, city, reg)
+		_, err := repo.InsertPVZ(ctx, oapi.PVZ{City: city, RegistrationDate: &reg})
 		require.Error(t, err)
 	})
 }
@@ -110,15 +130,15 @@ func TestSelectPVZByOpenReceptions(t *testing.T) {
 	start, end := time.Now(), time.Now().Add(time.Hour)
 
 	t.Run("success", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "city", "registration_date"}).
-			AddRow(uuid.New(), "X", start).
-			AddRow(uuid.New(), "Y", end)
+		rows := pgxmock.NewRows(pvzColumns).
+			AddRow(uuid.New(), "X", start, nil, nil, nil, nil).
+			AddRow(uuid.New(), "Y", end, nil, nil, nil, nil)
 		mockPool.
 			ExpectQuery(QuerySelectPVZByOpenReceptions).
-			WithArgs(start, end, 10, 0).
+			WithArgs(start, end, 10, 0, noRegion).
 			WillReturnRows(rows)
 
-		list, err := repo.SelectPVZByOpenReceptions(ctx, start, end, 10, 0)
+		list, err := repo.SelectPVZByOpenReceptions(ctx, start, end, nil, 10, 0)
 		require.NoError(t, err)
 		require.Len(t, list, 2)
 	})
@@ -128,34 +148,106 @@ func TestSelectPVZByOpenReceptions(t *testing.T) {
 			ExpectQuery(QuerySelectPVZByOpenReceptions).
 			WillReturnError(fmt.Errorf("err"))
 
-		_, err := repo.SelectPVZByOpenReceptions(ctx, start, end, 1, 0)
+		_, err := repo.SelectPVZByOpenReceptions(ctx, start, end, nil, 1, 0)
 		require.Error(t, err)
 	})
 	t.Run("scan error", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "city", "registration_date"}).
-			AddRow("bad-uuid", "X", start)
+		rows := pgxmock.NewRows(pvzColumns).
+			AddRow("bad-uuid", "X", start, nil, nil, nil, nil)
 		mockPool.
 			ExpectQuery(QuerySelectPVZByOpenReceptions).
-			WithArgs(start, end, 10, 0).
+			WithArgs(start, end, 10, 0, noRegion).
 			WillReturnRows(rows)
 
-		_, err := repo.SelectPVZByOpenReceptions(ctx, start, end, 10, 0)
+		_, err := repo.SelectPVZByOpenReceptions(ctx, start, end, nil, 10, 0)
 		require.Error(t, err)
 	})
 
 	t.Run("rows.Err", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "city", "registration_date"}).
+		rows := pgxmock.NewRows(pvzColumns).
 			RowError(0, errors.New("row failure"))
 		mockPool.
 			ExpectQuery(QuerySelectPVZByOpenReceptions).
-			WithArgs(start, end, 5, 1).
+			WithArgs(start, end, 5, 1, noRegion).
 			WillReturnRows(rows)
 
-		_, err := repo.SelectPVZByOpenReceptions(ctx, start, end, 5, 1)
+		_, err := repo.SelectPVZByOpenReceptions(ctx, start, end, nil, 5, 1)
 		require.Error(t, err)
 	})
 }
 
+func TestUpdatePVZLocation(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewPVZRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	pvzID, regionID := uuid.New(), uuid.New()
+	reg := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	lat, lon := 55.75, 37.62
+
+	t.Run("success", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryUpdatePVZLocation).
+			WithArgs(pvzID, lat, lon, &regionID).
+			WillReturnRows(pgxmock.NewRows(pvzColumns).AddRow(pvzID, "Москва", reg, &lat, &lon, &regionID, nil))
+
+		pvz, err := repo.UpdatePVZLocation(ctx, pvzID, lat, lon, &regionID)
+		require.NoError(t, err)
+		require.Equal(t, lat, *pvz.Latitude)
+		require.Equal(t, lon, *pvz.Longitude)
+		require.Equal(t, regionID, *pvz.RegionId)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryUpdatePVZLocation).
+			WithArgs(pvzID, lat, lon, noRegion).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.UpdatePVZLocation(ctx, pvzID, lat, lon, nil)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestUpdatePVZIntakeCapacity(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewPVZRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	pvzID := uuid.New()
+	reg := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	capacity := 300
+
+	t.Run("success", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryUpdatePVZIntakeCapacity).
+			WithArgs(pvzID, &capacity).
+			WillReturnRows(pgxmock.NewRows(pvzColumns).AddRow(pvzID, "Москва", reg, nil, nil, nil, &capacity))
+
+		pvz, err := repo.UpdatePVZIntakeCapacity(ctx, pvzID, &capacity)
+		require.NoError(t, err)
+		require.Equal(t, capacity, *pvz.DailyIntakeCapacity)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryUpdatePVZIntakeCapacity).
+			WithArgs(pvzID, (*int)(nil)).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.UpdatePVZIntakeCapacity(ctx, pvzID, nil)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
 func TestSelectAllPVZs(t *testing.T) {
 	mockPool, _ := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
 	defer mockPool.Close()
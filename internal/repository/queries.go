@@ -11,9 +11,9 @@ const (
                          WHERE email = $1`
 
 	// pvz
-	QueryInsertPVZ = `INSERT INTO pvz (id, city, registration_date)
-							VALUES ($1, $2, $3)
-							RETURNING id, city, registration_date;`
+	QueryInsertPVZ = `INSERT INTO pvz (id, city, registration_date, latitude, longitude, region_id)
+							VALUES ($1, $2, $3, $4, $5, $6)
+							RETURNING id, city, registration_date, latitude, longitude, region_id, daily_intake_capacity;`
 
 	QuerySelectPVZByOpenReceptions = `WITH qualified_pvzs AS (
 										SELECT DISTINCT pvz_id
@@ -21,14 +21,26 @@ const (
 										WHERE date_time <= $2
 										AND (close_date_time >= $1 OR close_date_time IS NULL)
 									)
-									SELECT id, city, registration_date
+									SELECT id, city, registration_date, latitude, longitude, region_id, daily_intake_capacity
 									FROM pvz
 									WHERE id IN (SELECT pvz_id FROM qualified_pvzs)
+									AND ($5::uuid IS NULL OR region_id = $5)
 									ORDER BY registration_date DESC
 									LIMIT $3 OFFSET $4;`
 
 	QuerySelectAllPVZs = `SELECT id, city, registration_date FROM pvz`
 
+	QueryUpdatePVZLocation = `UPDATE pvz
+								SET latitude = $2, longitude = $3, region_id = $4
+								WHERE id = $1
+								RETURNING id, city, registration_date, latitude, longitude, region_id, daily_intake_capacity`
+
+	QueryUpdatePVZIntakeCapacity = `UPDATE pvz
+									SET daily_intake_capacity = $2
+									WHERE id = $1
+									RETURNING id, city, registration_date, latitude, longitude, region_id,
+										daily_intake_capacity`
+
 	// recepiton
 	QueryInsertReception = `WITH locked AS (
 								SELECT id
@@ -36,8 +48,11 @@ const (
 								WHERE id = $1
 								FOR UPDATE
 							)
-							INSERT INTO receptions (id, pvz_id, date_time, status)
-							SELECT $2, locked.id, $3, 'in_progress'
+							INSERT INTO receptions (
+								id, pvz_id, date_time, status,
+								carrier, vehicle_plate, waybill_number, seal_number, comment, created_by
+							)
+							SELECT $2, locked.id, $3, 'in_progress', $4, $5, $6, $7, $8, $9
 							FROM locked
 							RETURNING id`
 
@@ -52,15 +67,40 @@ const (
 								UPDATE receptions
 								SET 
 									status = 'close',
-									close_date_time = NOW()
+									close_date_time = NOW(),
+									closed_by = $2
 								WHERE id IN (SELECT id FROM active)
-								RETURNING id, date_time;`
+								RETURNING id, date_time, close_date_time;`
+
+	QueryCountProductsByType = `SELECT type, COUNT(*), MIN(date_time), MAX(date_time)
+								FROM products
+								WHERE reception_id = $1
+								GROUP BY type`
+
+	QueryInsertReceptionSummary = `INSERT INTO reception_summaries (
+										reception_id, total_items, counts_by_type,
+										first_scan_at, last_scan_at, open_duration_seconds, items_per_minute
+									)
+									VALUES ($1, $2, $3, $4, $5, $6, $7)`
 
-	QueryGetReceptionsByPVZs = `SELECT id, pvz_id, date_time, status
+	QueryGetReceptionSummary = `SELECT reception_id, total_items, counts_by_type,
+									first_scan_at, last_scan_at, open_duration_seconds, items_per_minute
+								FROM reception_summaries
+								WHERE reception_id = $1`
+
+	QueryGetReceptionsByPVZs = `SELECT id, pvz_id, date_time, status,
+									carrier, vehicle_plate, waybill_number, seal_number, comment, created_by, closed_by
 								FROM receptions
 								WHERE pvz_id = $1
 								ORDER BY date_time DESC`
 
+	QuerySearchReceptionsByWaybill = `SELECT id, pvz_id, date_time, status,
+										carrier, vehicle_plate, waybill_number, seal_number, comment, created_by,
+										closed_by
+									FROM receptions
+									WHERE waybill_number = $1
+									ORDER BY date_time DESC`
+
 	// products
 	QueryInsertProduct = `WITH active_reception AS (
 								SELECT id FROM receptions 
@@ -69,26 +109,1212 @@ const (
 								LIMIT 1 
 								FOR UPDATE
 							)
-							INSERT INTO products (id, reception_id, date_time, type)
-							SELECT $2, id, $3, $4
+							INSERT INTO products (id, reception_id, date_time, type, barcode, sku, cell_id, created_by)
+							SELECT $2, id, $3, $4, $5, $6, $7, $8
 							FROM active_reception
-							RETURNING id;`
+							RETURNING reception_id;`
 
-	QueryDeleteLastProduct = `WITH last AS (
-								SELECT p.id
-								FROM receptions r
-								JOIN products p ON p.reception_id = r.id
-								WHERE r.pvz_id = $1 AND r.status = 'in_progress'
-								ORDER BY r.date_time DESC, p.date_time DESC
+	QueryLockActiveReception = `SELECT id FROM receptions
+								WHERE pvz_id = $1 AND status = 'in_progress'
+								ORDER BY date_time DESC
 								LIMIT 1
-								FOR UPDATE SKIP LOCKED
-							)
-							DELETE FROM products
-							WHERE id = (SELECT id FROM last)
-							RETURNING *;`
+								FOR UPDATE`
 
-	QueryGetProductsByReceptions = `SELECT id, reception_id, date_time, type
+	QueryLockPVZBarcodes = `SELECT id FROM pvz WHERE id = $1 FOR NO KEY UPDATE`
+
+	QueryFindBarcodeAtPVZ = `SELECT p.reception_id
+							FROM products p
+							JOIN receptions r ON r.id = p.reception_id
+							WHERE COALESCE(p.current_pvz_id, r.pvz_id) = $1
+							AND p.barcode = $2 AND p.id <> $3
+							AND p.status NOT IN ('issued', 'in_transit', 'lost', 'unclaimed')
+							LIMIT 1`
+
+	QuerySelectBarcodesAtPVZ = `SELECT p.barcode, p.reception_id
+								FROM products p
+								JOIN receptions r ON r.id = p.reception_id
+								WHERE COALESCE(p.current_pvz_id, r.pvz_id) = $1
+								AND p.barcode = ANY($2)
+								AND p.status NOT IN ('issued', 'in_transit', 'lost', 'unclaimed')`
+
+	QueryGetProductByBarcode = `SELECT p.id, p.reception_id, p.date_time, p.type, p.container_id, p.barcode, p.sku,
+									p.status, p.cell_id
+								FROM products p
+								JOIN receptions r ON r.id = p.reception_id
+								WHERE p.barcode = $1 AND ($2::uuid IS NULL OR COALESCE(p.current_pvz_id, r.pvz_id) = $2)
+								ORDER BY p.date_time DESC
+								LIMIT 1`
+
+	QuerySelectLastProduct = `SELECT id
+								FROM products
+								WHERE reception_id = $1
+								ORDER BY date_time DESC
+								LIMIT 1`
+
+	QueryGetProductsByReceptions = `SELECT id, reception_id, date_time, type, container_id, barcode, sku, status,
+								cell_id
 							FROM products
 							WHERE reception_id = ANY($1)
 							ORDER BY date_time DESC`
+
+	// product trash
+	QueryLockProductReception = `SELECT r.pvz_id
+								FROM products p
+								JOIN receptions r ON r.id = p.reception_id
+								WHERE p.id = $1 AND r.status = 'in_progress'
+								FOR UPDATE OF r`
+
+	QueryTrashProduct = `WITH damage AS (
+							SELECT to_jsonb(d) AS damage
+							FROM product_damages d
+							WHERE d.product_id = $1
+						),
+						removed AS (
+							DELETE FROM products
+							WHERE id = $1
+							RETURNING id, reception_id, date_time, type, container_id, verified_at, barcode, sku,
+								cell_id, created_by
+						),
+						stale AS (
+							UPDATE rollup_days
+							SET dirty = TRUE
+							WHERE day IN (
+								SELECT date_time::date FROM removed
+								UNION
+								SELECT $3::timestamp::date
+							)
+						)
+						INSERT INTO deleted_products (
+							id, reception_id, date_time, type, container_id, verified_at, barcode, sku,
+							cell_id, damage, created_by, deleted_by, deleted_at
+						)
+						SELECT id, reception_id, date_time, type, container_id, verified_at, barcode, sku,
+							cell_id, (SELECT damage FROM damage), created_by, $2, $3
+						FROM removed
+						RETURNING id, reception_id, date_time, type, container_id, barcode, sku, cell_id,
+							deleted_by, deleted_at`
+
+	QueryUpdateProduct = `WITH updated AS (
+							UPDATE products
+							SET type = COALESCE($2, type),
+								barcode = COALESCE($3, barcode),
+								sku = COALESCE($4, sku)
+							WHERE id = $1
+							RETURNING id, reception_id, date_time, type, container_id, barcode, sku, status, cell_id
+						),
+						stale AS (
+							UPDATE rollup_days
+							SET dirty = TRUE
+							WHERE $2 IS NOT NULL AND day IN (SELECT date_time::date FROM updated)
+						)
+						SELECT id, reception_id, date_time, type, container_id, barcode, sku, status, cell_id
+						FROM updated`
+
+	QueryLockDeletedProductReception = `SELECT r.pvz_id, d.barcode, d.cell_id
+										FROM deleted_products d
+										JOIN receptions r ON r.id = d.reception_id
+										WHERE d.id = $1 AND r.status = 'in_progress'
+										FOR UPDATE OF r`
+
+	QueryRestoreProduct = `WITH restored AS (
+								DELETE FROM deleted_products
+								WHERE id = $1
+								RETURNING id, reception_id, date_time, type, container_id, verified_at, barcode, sku,
+									damage, created_by, deleted_at
+							),
+							stale AS (
+								UPDATE rollup_days
+								SET dirty = TRUE
+								WHERE day IN (
+									SELECT date_time::date FROM restored
+									UNION
+									SELECT deleted_at::date FROM restored
+								)
+							),
+							product AS (
+								INSERT INTO products (
+									id, reception_id, date_time, type, container_id, verified_at, barcode, sku,
+									cell_id, created_by
+								)
+								SELECT id, reception_id, date_time, type, container_id, verified_at, barcode, sku,
+									$2, created_by
+								FROM restored
+								RETURNING id, reception_id, date_time, type, container_id, barcode, sku, status, cell_id
+							),
+							damage AS (
+								INSERT INTO product_damages
+								SELECT (jsonb_populate_record(NULL::product_damages, r.damage)).*
+								FROM restored r
+								JOIN product p ON p.id = r.id
+								WHERE r.damage IS NOT NULL
+							)
+							SELECT id, reception_id, date_time, type, container_id, barcode, sku, status, cell_id
+							FROM product`
+
+	QuerySelectDeletedProducts = `SELECT id, reception_id, date_time, type, container_id, barcode, sku, cell_id,
+										deleted_by, deleted_at
+									FROM deleted_products
+									WHERE reception_id = $1
+									ORDER BY deleted_at DESC`
+
+	// product types
+	QueryCountActiveProductTypes = `SELECT COUNT(*)
+									FROM product_types
+									WHERE active AND code = ANY($1)`
+
+	QuerySelectActiveProductTypeCodes = `SELECT code
+										FROM product_types
+										WHERE active AND code = ANY($1)`
+
+	QuerySelectProductTypes = `SELECT code, display_names, fragile, high_value, oversized, active, created_at, updated_at
+								FROM product_types
+								WHERE $1 OR active
+								ORDER BY code`
+
+	QueryInsertProductType = `INSERT INTO product_types (
+									code, display_names, fragile, high_value, oversized, active, created_at, updated_at
+								)
+								VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+								RETURNING created_at`
+
+	QueryUpdateProductType = `UPDATE product_types
+								SET display_names = $2, fragile = $3, high_value = $4, oversized = $5,
+									active = $6, updated_at = $7
+								WHERE code = $1
+								RETURNING created_at`
+
+	// containers
+	QueryInsertContainer = `WITH active_reception AS (
+								SELECT id FROM receptions
+								WHERE pvz_id = $1 AND status = 'in_progress'
+								ORDER BY date_time DESC
+								LIMIT 1
+								FOR UPDATE
+							)
+							INSERT INTO containers (id, reception_id, barcode, kind, state, declared_items, created_at)
+							SELECT $2, id, $3, $4, 'sealed', $5, $6
+							FROM active_reception
+							RETURNING reception_id;`
+
+	QueryInsertContainerProducts = `INSERT INTO products (id, reception_id, date_time, type, container_id)
+									SELECT item.id, $2, $3, item.type, $4
+									FROM unnest($1::uuid[], $5::text[]) AS item(id, type)`
+
+	QueryOpenContainer = `UPDATE containers
+							SET state = 'opened', opened_at = $2
+							WHERE id = $1 AND state = 'sealed'`
+
+	QueryLockContainerState = `SELECT state
+								FROM containers
+								WHERE id = $1
+								FOR UPDATE`
+
+	QueryVerifyContainerProducts = `UPDATE products
+									SET verified_at = $3
+									WHERE container_id = $1 AND id = ANY($2)`
+
+	QueryCompleteContainerVerification = `UPDATE containers c
+											SET state = 'verified', verified_at = $2
+											WHERE c.id = $1
+											AND NOT EXISTS (
+												SELECT 1 FROM products p
+												WHERE p.container_id = c.id AND p.verified_at IS NULL
+											)`
+
+	QueryGetContainer = `SELECT c.id, c.reception_id, c.barcode, c.kind, c.state, c.declared_items,
+							(SELECT COUNT(*) FROM products p WHERE p.container_id = c.id AND p.verified_at IS NOT NULL),
+							c.created_at, c.opened_at, c.verified_at
+						FROM containers c
+						WHERE c.id = $1`
+
+	QueryGetContainersByReceptions = `SELECT c.id, c.reception_id, c.barcode, c.kind, c.state, c.declared_items,
+										(SELECT COUNT(*) FROM products p
+											WHERE p.container_id = c.id AND p.verified_at IS NOT NULL),
+										c.created_at, c.opened_at, c.verified_at
+									FROM containers c
+									WHERE c.reception_id = ANY($1)
+									ORDER BY c.created_at`
+
+	// orders
+	QueryInsertOrder = `INSERT INTO orders (id, pvz_id, customer_ref, pickup_code_hash, created_by, created_at)
+						VALUES ($1, $2, $3, $4, $5, $6)`
+
+	QueryReserveOrderProducts = `UPDATE products p
+								SET status = 'ready'
+								FROM receptions r
+								WHERE p.id = ANY($1)
+								AND r.id = p.reception_id
+								AND COALESCE(p.current_pvz_id, r.pvz_id) = $2
+								AND r.status = 'close'
+								AND p.status = 'received'`
+
+	QueryInsertOrderItems = `INSERT INTO order_items (order_id, product_id)
+							SELECT $1, unnest($2::uuid[])`
+
+	QueryGetOrder = `SELECT id, pvz_id, customer_ref, status, created_by, created_at, issued_at
+					FROM orders
+					WHERE id = $1`
+
+	QueryGetOrderItems = `SELECT oi.product_id, p.status, oi.issued_at, oi.issued_by
+						FROM order_items oi
+						JOIN products p ON p.id = oi.product_id
+						WHERE oi.order_id = $1
+						ORDER BY p.date_time`
+
+	QueryLockOrder = `SELECT status, pickup_code_hash, failed_attempts
+					FROM orders
+					WHERE id = $1
+					FOR UPDATE`
+
+	QueryRegisterFailedPickup = `UPDATE orders
+								SET failed_attempts = failed_attempts + 1
+								WHERE id = $1`
+
+	QueryIssueOrderItems = `WITH issued AS (
+								UPDATE order_items
+								SET issued_at = $3, issued_by = $4
+								WHERE order_id = $1
+								AND issued_at IS NULL
+								AND (COALESCE(cardinality($2::uuid[]), 0) = 0 OR product_id = ANY($2))
+								RETURNING product_id
+							)
+							UPDATE products
+							SET status = 'issued', issued_at = $3, cell_id = NULL
+							WHERE id IN (SELECT product_id FROM issued)`
+
+	QueryFinishOrderIssue = `UPDATE orders o
+							SET status = CASE WHEN remaining.cnt > 0 THEN 'partially_issued' ELSE 'completed' END,
+								issued_at = CASE WHEN remaining.cnt > 0 THEN NULL ELSE $2 END,
+								pickup_code_hash = $3,
+								failed_attempts = 0
+							FROM (
+								SELECT COUNT(*) AS cnt FROM order_items
+								WHERE order_id = $1 AND issued_at IS NULL
+							) remaining
+							WHERE o.id = $1`
+
+	QueryResetPickupCode = `UPDATE orders
+							SET pickup_code_hash = $2,
+								failed_attempts = CASE WHEN $3 THEN 0 ELSE failed_attempts END
+							WHERE id = $1`
+
+	// returns
+	QuerySelectReturnReasons = `SELECT code, display_names, active, created_at
+								FROM return_reasons
+								WHERE $1 OR active
+								ORDER BY code`
+
+	QueryInsertReturnReason = `INSERT INTO return_reasons (code, display_names, active, created_at)
+								VALUES ($1, $2, $3, $4)`
+
+	QueryFindIssuedProduct = `SELECT p.id, oi.order_id, p.barcode
+							FROM products p
+							LEFT JOIN order_items oi ON oi.product_id = p.id
+							WHERE p.status = 'issued'
+							AND (p.id = $1 OR ($1::uuid IS NULL AND p.barcode = $2))
+							ORDER BY p.issued_at DESC
+							LIMIT 1`
+
+	QueryInsertReturn = `INSERT INTO customer_returns (
+							id, pvz_id, product_id, order_id, barcode, reason_code, comment, received_by, received_at
+						)
+						SELECT $1, $2, $3, $4, $5, rr.code, $7, $8, $9
+						FROM return_reasons rr
+						WHERE rr.code = $6 AND rr.active`
+
+	QuerySelectReturns = `SELECT id, pvz_id, product_id, order_id, barcode, reason_code, comment,
+							status, shipment_id, received_by, received_at
+						FROM customer_returns
+						WHERE ($1::uuid IS NULL OR pvz_id = $1)
+						AND ($2::text IS NULL OR status = $2)
+						ORDER BY received_at DESC
+						LIMIT $3 OFFSET $4`
+
+	QuerySelectShipmentReturns = `SELECT id, pvz_id, product_id, order_id, barcode, reason_code, comment,
+									status, shipment_id, received_by, received_at
+								FROM customer_returns
+								WHERE shipment_id = $1
+								ORDER BY received_at`
+
+	// return shipments
+	QueryInsertReturnShipment = `INSERT INTO return_shipments (id, pvz_id, created_by, created_at)
+								VALUES ($1, $2, $3, $4)`
+
+	QuerySelectReturnShipments = `SELECT id, pvz_id, status, created_by, created_at, dispatched_at, delivered_at
+								FROM return_shipments
+								WHERE ($1::uuid IS NULL OR pvz_id = $1)
+								AND ($2::text IS NULL OR status = $2)
+								ORDER BY created_at DESC
+								LIMIT $3 OFFSET $4`
+
+	QueryGetReturnShipment = `SELECT id, pvz_id, status, created_by, created_at, dispatched_at, delivered_at
+							FROM return_shipments
+							WHERE id = $1`
+
+	QueryLockReturnShipment = `SELECT pvz_id, status
+								FROM return_shipments
+								WHERE id = $1
+								FOR UPDATE`
+
+	QueryAttachShipmentReturns = `UPDATE customer_returns
+								SET shipment_id = $1, status = 'in_shipment'
+								WHERE id = ANY($2)
+								AND pvz_id = $3
+								AND status = 'accepted'`
+
+	QueryShipShipmentReturns = `UPDATE customer_returns
+								SET status = 'shipped'
+								WHERE shipment_id = $1`
+
+	QueryReleaseShipmentCells = `UPDATE products p
+								SET cell_id = NULL
+								FROM customer_returns cr
+								WHERE cr.shipment_id = $1 AND p.id = cr.product_id`
+
+	QueryUpdateReturnShipmentStatus = `UPDATE return_shipments
+										SET status = $2,
+											dispatched_at = CASE WHEN $2 = 'dispatched' THEN $3::timestamp ELSE dispatched_at END,
+											delivered_at = CASE WHEN $2 = 'delivered' THEN $3::timestamp ELSE delivered_at END
+										WHERE id = $1`
+
+	// transfers
+	QueryInsertTransfer = `INSERT INTO transfers (
+								id, source_pvz_id, destination_pvz_id, created_by, created_at, expected_at
+							)
+							VALUES ($1, $2, $3, $4, $5, $6)`
+
+	QueryDispatchTransferProducts = `UPDATE products p
+									SET status = 'in_transit', transfer_id = $1, cell_id = NULL
+									FROM receptions r
+									WHERE p.id = ANY($2)
+									AND r.id = p.reception_id
+									AND COALESCE(p.current_pvz_id, r.pvz_id) = $3
+									AND r.status = 'close'
+									AND p.status = 'received'`
+
+	QueryInsertTransferItems = `INSERT INTO transfer_items (transfer_id, product_id)
+								SELECT $1, unnest($2::uuid[])`
+
+	QueryGetTransfer = `SELECT id, source_pvz_id, destination_pvz_id, status,
+							created_by, created_at, expected_at, completed_at
+						FROM transfers
+						WHERE id = $1`
+
+	QueryGetTransferItems = `SELECT product_id, received_at, received_by
+							FROM transfer_items
+							WHERE transfer_id = $1
+							ORDER BY product_id`
+
+	QueryLockTransfer = `SELECT destination_pvz_id, status
+						FROM transfers
+						WHERE id = $1
+						FOR UPDATE`
+
+	QueryReceiveTransferItems = `WITH received AS (
+									UPDATE transfer_items
+									SET received_at = $3, received_by = $4
+									WHERE transfer_id = $1
+									AND received_at IS NULL
+									AND product_id = ANY($2)
+									RETURNING product_id
+								)
+								UPDATE products
+								SET status = 'received', transfer_id = NULL, current_pvz_id = $5
+								WHERE id IN (SELECT product_id FROM received)`
+
+	QueryFindTransferBarcodeConflict = `SELECT 1
+										FROM products t
+										JOIN products p ON p.barcode = t.barcode AND p.id <> t.id
+										JOIN receptions r ON r.id = p.reception_id
+										WHERE t.id = ANY($2)
+										AND COALESCE(p.current_pvz_id, r.pvz_id) = $1
+										AND p.status NOT IN ('issued', 'in_transit', 'lost', 'unclaimed')
+										LIMIT 1`
+
+	QueryFinishTransferReceipt = `UPDATE transfers t
+								SET status = CASE WHEN remaining.cnt > 0
+										THEN 'partially_received' ELSE 'fully_received' END,
+									completed_at = CASE WHEN remaining.cnt > 0 THEN NULL ELSE $2 END
+								FROM (
+									SELECT COUNT(*) AS cnt FROM transfer_items
+									WHERE transfer_id = $1 AND received_at IS NULL
+								) remaining
+								WHERE t.id = $1`
+
+	QuerySelectOverdueTransfers = `SELECT id, source_pvz_id, destination_pvz_id, status,
+										created_by, created_at, expected_at, completed_at
+									FROM transfers
+									WHERE status <> 'fully_received'
+									AND expected_at < $2
+									AND ($1::uuid IS NULL OR source_pvz_id = $1 OR destination_pvz_id = $1)
+									ORDER BY expected_at
+									LIMIT $3 OFFSET $4`
+
+	// storage cells
+	QueryUpsertStorageCells = `INSERT INTO storage_cells (id, pvz_id, zone, rack, shelf, capacity, active, created_at)
+								SELECT item.id, $1, item.zone, item.rack, item.shelf, item.capacity, item.active, $8
+								FROM unnest($2::uuid[], $3::text[], $4::text[], $5::text[], $6::int[], $7::bool[])
+									AS item(id, zone, rack, shelf, capacity, active)
+								ON CONFLICT ON CONSTRAINT uq_storage_cells_location DO UPDATE
+								SET capacity = EXCLUDED.capacity, active = EXCLUDED.active`
+
+	QuerySelectStorageCells = `SELECT c.id, c.pvz_id, c.zone, c.rack, c.shelf, c.capacity, c.active, COUNT(p.id)
+								FROM storage_cells c
+								LEFT JOIN products p ON p.cell_id = c.id
+								WHERE c.pvz_id = $1
+								GROUP BY c.id
+								ORDER BY c.zone, c.rack, c.shelf`
+
+	QueryLockStorageCell = `SELECT c.capacity, (SELECT COUNT(*) FROM products p WHERE p.cell_id = c.id)
+							FROM storage_cells c
+							WHERE c.id = $1 AND c.pvz_id = $2 AND c.active
+							FOR UPDATE OF c`
+
+	QueryLockProductLocation = `SELECT COALESCE(p.current_pvz_id, r.pvz_id), p.status, p.cell_id
+								FROM products p
+								JOIN receptions r ON r.id = p.reception_id
+								WHERE p.id = $1
+								FOR UPDATE OF p`
+
+	QueryMoveProductToCell = `UPDATE products
+							SET cell_id = $2
+							WHERE id = $1
+							RETURNING id, reception_id, date_time, type, container_id, barcode, sku, status, cell_id`
+
+	QuerySelectProductLocations = `SELECT DISTINCT p.id, p.barcode, p.status, c.id, c.zone, c.rack, c.shelf
+									FROM products p
+									JOIN receptions r ON r.id = p.reception_id
+									LEFT JOIN storage_cells c ON c.id = p.cell_id
+									LEFT JOIN order_items oi ON oi.product_id = p.id
+									WHERE (p.barcode = $1 OR oi.order_id = $2)
+									AND ($3::uuid IS NULL OR COALESCE(p.current_pvz_id, r.pvz_id) = $3)
+									AND p.status NOT IN ('issued', 'in_transit', 'lost', 'unclaimed')
+									ORDER BY c.zone, c.rack, c.shelf`
+
+	// inventory
+	QueryInsertInventorySession = `INSERT INTO inventory_sessions (id, pvz_id, started_by, started_at)
+									VALUES ($1, $2, $3, $4)`
+
+	QueryGetInventorySession = `SELECT s.id, s.pvz_id, s.status, s.started_by, s.started_at, s.closed_by, s.closed_at,
+									(SELECT COUNT(*) FROM inventory_scans sc WHERE sc.session_id = s.id)
+								FROM inventory_sessions s
+								WHERE s.id = $1`
+
+	QueryGetInventoryDiscrepancies = `SELECT kind, barcode, product_id, expected_cell_id, actual_cell_id,
+											accepted_by, accepted_at
+										FROM inventory_discrepancies
+										WHERE session_id = $1
+										ORDER BY kind, barcode`
+
+	QueryLockInventorySession = `SELECT pvz_id, status
+								FROM inventory_sessions
+								WHERE id = $1
+								FOR UPDATE`
+
+	QueryShareInventorySession = `SELECT pvz_id, status
+								FROM inventory_sessions
+								WHERE id = $1
+								FOR SHARE`
+
+	QueryCheckStorageCell = `SELECT EXISTS (SELECT 1 FROM storage_cells WHERE id = $1 AND pvz_id = $2)`
+
+	QueryUpsertInventoryScan = `INSERT INTO inventory_scans (session_id, barcode, cell_id, scanned_by, scanned_at)
+								VALUES ($1, $2, $3, $4, $5)
+								ON CONFLICT (session_id, barcode) DO UPDATE
+								SET cell_id = EXCLUDED.cell_id,
+									scanned_by = EXCLUDED.scanned_by,
+									scanned_at = EXCLUDED.scanned_at`
+
+	QueryInsertInventoryDiscrepancies = `WITH expected AS (
+											SELECT DISTINCT ON (p.barcode) p.id, p.barcode, p.cell_id
+											FROM products p
+											JOIN receptions r ON r.id = p.reception_id
+											WHERE COALESCE(p.current_pvz_id, r.pvz_id) = $2
+											AND p.barcode IS NOT NULL
+											AND p.status IN ('received', 'ready')
+											ORDER BY p.barcode, p.date_time DESC
+										), scanned AS (
+											SELECT barcode, cell_id
+											FROM inventory_scans
+											WHERE session_id = $1
+										)
+										INSERT INTO inventory_discrepancies (
+											session_id, kind, barcode, product_id, expected_cell_id, actual_cell_id
+										)
+										SELECT $1, 'missing', e.barcode, e.id, e.cell_id, NULL::uuid
+										FROM expected e
+										WHERE NOT EXISTS (SELECT 1 FROM scanned s WHERE s.barcode = e.barcode)
+										UNION ALL
+										SELECT $1, 'misplaced', e.barcode, e.id, e.cell_id, s.cell_id
+										FROM expected e
+										JOIN scanned s ON s.barcode = e.barcode
+										WHERE s.cell_id IS NOT NULL AND e.cell_id IS DISTINCT FROM s.cell_id
+										UNION ALL
+										SELECT $1, 'unexpected', s.barcode, known.id, NULL::uuid, s.cell_id
+										FROM scanned s
+										LEFT JOIN LATERAL (
+											SELECT p.id
+											FROM products p
+											WHERE p.barcode = s.barcode AND p.status <> 'issued'
+											ORDER BY p.date_time DESC
+											LIMIT 1
+										) known ON TRUE
+										WHERE NOT EXISTS (SELECT 1 FROM expected e WHERE e.barcode = s.barcode)`
+
+	QueryCloseInventorySession = `UPDATE inventory_sessions
+								SET status = 'counted', closed_by = $2, closed_at = $3
+								WHERE id = $1`
+
+	QueryLockInventoryDiscrepancies = `SELECT barcode
+										FROM inventory_discrepancies
+										WHERE session_id = $1 AND barcode = ANY($2) AND accepted_at IS NULL
+										FOR UPDATE`
+
+	QueryWriteOffMissingProducts = `UPDATE products p
+									SET status = 'lost', cell_id = NULL
+									FROM inventory_discrepancies d
+									WHERE d.session_id = $1 AND d.barcode = ANY($2)
+									AND d.kind = 'missing'
+									AND p.id = d.product_id
+									AND p.status IN ('received', 'ready')`
+
+	QueryRelocateMisplacedProducts = `UPDATE products p
+									SET cell_id = d.actual_cell_id
+									FROM inventory_discrepancies d
+									WHERE d.session_id = $1 AND d.barcode = ANY($2)
+									AND d.kind = 'misplaced'
+									AND p.id = d.product_id
+									AND p.status IN ('received', 'ready')`
+
+	QueryClaimUnexpectedProducts = `UPDATE products p
+									SET status = 'received', current_pvz_id = $3, cell_id = d.actual_cell_id
+									FROM inventory_discrepancies d
+									WHERE d.session_id = $1 AND d.barcode = ANY($2)
+									AND d.kind = 'unexpected'
+									AND p.id = d.product_id
+									AND p.status IN ('received', 'lost')`
+
+	QueryAcceptInventoryDiscrepancies = `UPDATE inventory_discrepancies
+										SET accepted_by = $3, accepted_at = $4
+										WHERE session_id = $1 AND barcode = ANY($2)`
+
+	// storage periods
+	QuerySelectStoragePeriods = `SELECT pvz_id, product_type, days, updated_by, updated_at
+								FROM storage_periods
+								ORDER BY pvz_id NULLS FIRST, product_type NULLS FIRST`
+
+	QueryUpsertStoragePeriod = `INSERT INTO storage_periods (pvz_id, product_type, days, updated_by, updated_at)
+								VALUES ($1, $2, $3, $4, $5)
+								ON CONFLICT ON CONSTRAINT uq_storage_periods_scope DO UPDATE
+								SET days = EXCLUDED.days,
+									updated_by = EXCLUDED.updated_by,
+									updated_at = EXCLUDED.updated_at`
+
+	storageDeadline = `COALESCE(
+							(SELECT MAX(ti.received_at) FROM transfer_items ti WHERE ti.product_id = p.id),
+							p.date_time
+						) + make_interval(days => COALESCE(
+							(SELECT sp.days
+							FROM storage_periods sp
+							WHERE (sp.pvz_id IS NULL OR sp.pvz_id = COALESCE(p.current_pvz_id, r.pvz_id))
+							AND (sp.product_type IS NULL OR sp.product_type = p.type)
+							ORDER BY sp.product_type IS NULL, sp.pvz_id IS NULL
+							LIMIT 1),
+							$2::int
+						))`
+
+	QuerySelectOverdueProducts = `SELECT p.id, COALESCE(p.current_pvz_id, r.pvz_id), oi.order_id, p.barcode
+								FROM products p
+								JOIN receptions r ON r.id = p.reception_id
+								LEFT JOIN order_items oi ON oi.product_id = p.id
+								WHERE p.status IN ('received', 'ready')
+								AND r.status = 'close'
+								AND ` + storageDeadline + ` <= $1
+								LIMIT $3
+								FOR UPDATE OF p SKIP LOCKED`
+
+	QueryMarkProductsUnclaimed = `UPDATE products
+								SET status = 'unclaimed'
+								WHERE id = ANY($1)`
+
+	QueryInsertUnclaimedReturns = `INSERT INTO customer_returns (
+										id, pvz_id, product_id, order_id, barcode, reason_code, received_at
+									)
+									SELECT item.id, item.pvz_id, item.product_id, item.order_id, item.barcode,
+										'unclaimed', $6
+									FROM unnest($1::uuid[], $2::uuid[], $3::uuid[], $4::uuid[], $5::text[])
+										AS item(id, pvz_id, product_id, order_id, barcode)`
+
+	QuerySelectExpiringProducts = `SELECT d.id, d.order_id, d.customer_ref, d.pvz_id, d.barcode, d.deadline
+									FROM (
+										SELECT p.id, o.id AS order_id, o.customer_ref,
+											COALESCE(p.current_pvz_id, r.pvz_id) AS pvz_id, p.barcode,
+											` + storageDeadline + ` AS deadline
+										FROM products p
+										JOIN receptions r ON r.id = p.reception_id
+										JOIN order_items oi ON oi.product_id = p.id
+										JOIN orders o ON o.id = oi.order_id
+										WHERE p.status = 'ready' AND p.storage_reminded_at IS NULL
+									) d
+									WHERE d.deadline > $1 AND d.deadline <= $3
+									ORDER BY d.deadline
+									LIMIT $4`
+
+	QueryMarkStorageReminded = `UPDATE products
+								SET storage_reminded_at = $2
+								WHERE id = ANY($1)`
+
+	QuerySelectUnclaimedItems = `SELECT cr.id, cr.product_id, cr.pvz_id, cr.order_id, cr.barcode,
+									c.id, c.zone, c.rack, c.shelf, cr.received_at
+								FROM customer_returns cr
+								JOIN products p ON p.id = cr.product_id
+								LEFT JOIN storage_cells c ON c.id = p.cell_id
+								WHERE cr.reason_code = 'unclaimed' AND cr.status = 'accepted'
+								AND ($1::uuid IS NULL OR cr.pvz_id = $1)
+								ORDER BY cr.received_at
+								LIMIT $2 OFFSET $3`
+
+	// labels
+	QuerySelectLabelData = `SELECT p.id, v.id, v.city, p.type, p.barcode, p.date_time, c.zone, c.rack, c.shelf,
+								t.symbology, t.width_mm, t.height_mm, t.dpi, t.header, t.updated_at
+							FROM products p
+							JOIN receptions r ON r.id = p.reception_id
+							JOIN pvz v ON v.id = COALESCE(p.current_pvz_id, r.pvz_id)
+							LEFT JOIN storage_cells c ON c.id = p.cell_id
+							LEFT JOIN label_templates t ON t.pvz_id = v.id
+							WHERE p.id = ANY($1)`
+
+	QueryGetLabelTemplate = `SELECT v.id, t.symbology, t.width_mm, t.height_mm, t.dpi, t.header, t.updated_at
+							FROM pvz v
+							LEFT JOIN label_templates t ON t.pvz_id = v.id
+							WHERE v.id = $1`
+
+	QueryUpsertLabelTemplate = `INSERT INTO label_templates (
+									pvz_id, symbology, width_mm, height_mm, dpi, header, updated_at
+								)
+								VALUES ($1, $2, $3, $4, $5, $6, $7)
+								ON CONFLICT (pvz_id) DO UPDATE
+								SET symbology = EXCLUDED.symbology,
+									width_mm = EXCLUDED.width_mm,
+									height_mm = EXCLUDED.height_mm,
+									dpi = EXCLUDED.dpi,
+									header = EXCLUDED.header,
+									updated_at = EXCLUDED.updated_at`
+
+	// rollups
+	rollupSettled = `NOT d.dirty AND d.computed_at >= d.day + 2`
+
+	QuerySelectStaleRollupDays = `SELECT g.day::date
+									FROM generate_series($1::date, $2::date, interval '1 day') AS g(day)
+									LEFT JOIN rollup_days d ON d.day = g.day::date
+									WHERE d.day IS NULL OR NOT (` + rollupSettled + `)
+									ORDER BY g.day DESC
+									LIMIT $3`
+
+	QueryCountSettledRollupDays = `SELECT COUNT(*)
+									FROM rollup_days d
+									WHERE d.day >= $1::date AND d.day < $2::date AND ` + rollupSettled
+
+	QueryLockRollupDay = `INSERT INTO rollup_days (day, computed_at, dirty)
+							VALUES ($1, $2, FALSE)
+							ON CONFLICT (day) DO UPDATE
+							SET computed_at = EXCLUDED.computed_at, dirty = FALSE`
+
+	QueryDeleteProductRollups = `DELETE FROM daily_product_rollups WHERE day = $1`
+
+	QueryDeletePVZRollups = `DELETE FROM daily_pvz_rollups WHERE day = $1`
+
+	QueryInsertProductRollups = `INSERT INTO daily_product_rollups (
+									day, pvz_id, product_type, received, deleted, issued, receptions
+								)
+								WITH events AS (
+									SELECT r.pvz_id, p.type, p.reception_id, 1 AS received, 0 AS deleted, 0 AS issued
+									FROM products p
+									JOIN receptions r ON r.id = p.reception_id
+									WHERE p.date_time >= $1::date AND p.date_time < $1::date + 1
+									UNION ALL
+									SELECT r.pvz_id, d.type, NULL, 0, 1, 0
+									FROM deleted_products d
+									JOIN receptions r ON r.id = d.reception_id
+									WHERE d.deleted_at >= $1::date AND d.deleted_at < $1::date + 1
+									UNION ALL
+									SELECT COALESCE(p.current_pvz_id, r.pvz_id), p.type, NULL, 0, 0, 1
+									FROM products p
+									JOIN receptions r ON r.id = p.reception_id
+									WHERE p.issued_at >= $1::date AND p.issued_at < $1::date + 1
+								)
+								SELECT $1::date, pvz_id, type, SUM(received), SUM(deleted), SUM(issued),
+									COUNT(DISTINCT reception_id)
+								FROM events
+								GROUP BY pvz_id, type`
+
+	QueryInsertPVZRollups = `INSERT INTO daily_pvz_rollups (
+								day, pvz_id, receptions_opened, scanned_receptions, open_seconds
+							)
+							WITH opened AS (
+								SELECT r.pvz_id,
+									COUNT(*) FILTER (WHERE r.date_time >= $1::date) AS receptions,
+									SUM(EXTRACT(EPOCH FROM LEAST(COALESCE(r.close_date_time, $2), $1::date + 1)
+										- GREATEST(r.date_time, $1::date))) AS seconds
+								FROM receptions r
+								WHERE r.date_time < $1::date + 1 AND COALESCE(r.close_date_time, $2) > $1::date
+								GROUP BY r.pvz_id
+							),
+							scanned AS (
+								SELECT r.pvz_id, COUNT(DISTINCT p.reception_id) AS receptions
+								FROM products p
+								JOIN receptions r ON r.id = p.reception_id
+								WHERE p.date_time >= $1::date AND p.date_time < $1::date + 1
+								GROUP BY r.pvz_id
+							)
+							SELECT $1::date, COALESCE(o.pvz_id, s.pvz_id), COALESCE(o.receptions, 0),
+								COALESCE(s.receptions, 0), GREATEST(COALESCE(o.seconds, 0), 0)::bigint
+							FROM opened o
+							FULL JOIN scanned s ON s.pvz_id = o.pvz_id`
+
+	// reports
+	intakeFilter = `p.date_time >= $1 AND p.date_time < $2
+					AND ($3::text IS NULL OR v.city = $3)
+					AND ($4::uuid IS NULL OR v.id = $4)
+					AND ($5::text IS NULL OR p.type = $5)`
+
+	QuerySelectIntakeRows = `SELECT date_trunc($6, p.date_time) AS period,
+								CASE $7::text
+									WHEN 'city' THEN v.city
+									WHEN 'pvz' THEN v.id::text
+									WHEN 'productType' THEN p.type
+									ELSE ''
+								END AS key,
+								COUNT(DISTINCT p.reception_id), COUNT(*)
+							FROM products p
+							JOIN receptions r ON r.id = p.reception_id
+							JOIN pvz v ON v.id = r.pvz_id
+							WHERE ` + intakeFilter + `
+							GROUP BY period, key
+							ORDER BY key, period`
+
+	QuerySelectIntakeTotals = `SELECT COUNT(DISTINCT p.reception_id), COUNT(*)
+								FROM products p
+								JOIN receptions r ON r.id = p.reception_id
+								JOIN pvz v ON v.id = r.pvz_id
+								WHERE ` + intakeFilter
+
+	// without a type filter or grouping the rollups are merged across product
+	// types and take the reception count from the PVZ rollup, so that a
+	// reception with several product types is counted once per day
+	intakeRollupsTail = `SUM(d.received) AS products, SUM(d.receptions) AS receptions
+							FROM daily_product_rollups d
+							WHERE d.day >= $1::date AND d.day < $2::date
+							AND ($5::text IS NULL OR d.product_type = $5)
+							GROUP BY 1, 2, 3
+							HAVING SUM(d.received) > 0
+						)`
+
+	intakeRollupFrom = `FROM rollups ru
+						JOIN daily_pvz_rollups pr ON pr.day = ru.day AND pr.pvz_id = ru.pvz_id
+						JOIN pvz v ON v.id = ru.pvz_id
+						WHERE ($3::text IS NULL OR v.city = $3)
+						AND ($4::uuid IS NULL OR v.id = $4)`
+
+	intakeRollupReceptions = `CASE WHEN ru.product_type = '' THEN pr.scanned_receptions ELSE ru.receptions END`
+
+	QuerySelectIntakeRollupRows = `WITH rollups AS (
+									SELECT d.day, d.pvz_id,
+										CASE WHEN $7::text = 'productType' OR $5::text IS NOT NULL
+											THEN d.product_type ELSE '' END AS product_type,
+									` + intakeRollupsTail + `
+								SELECT date_trunc($6, ru.day::timestamp) AS period,
+									CASE $7::text
+										WHEN 'city' THEN v.city
+										WHEN 'pvz' THEN v.id::text
+										WHEN 'productType' THEN ru.product_type
+										ELSE ''
+									END AS key,
+									SUM(` + intakeRollupReceptions + `), SUM(ru.products)
+								` + intakeRollupFrom + `
+								GROUP BY period, key
+								ORDER BY key, period`
+
+	QuerySelectOpenReceptions = `SELECT r.id, r.pvz_id, v.city, r.date_time, r.carrier, r.waybill_number,
+									COUNT(p.id)
+								FROM receptions r
+								JOIN pvz v ON v.id = r.pvz_id
+								LEFT JOIN products p ON p.reception_id = r.id
+								WHERE r.status = 'in_progress'
+								AND ($1::uuid IS NULL OR r.pvz_id = $1)
+								AND ($2::text IS NULL OR v.city = $2)
+								GROUP BY r.id, v.city
+								ORDER BY r.date_time`
+
+	receptionSLAWindow = `WITH window_receptions AS (
+							SELECT r.id, r.pvz_id, v.city, r.date_time,
+								EXTRACT(EPOCH FROM r.close_date_time - r.date_time)::float8 AS duration
+							FROM receptions r
+							JOIN pvz v ON v.id = r.pvz_id
+							WHERE r.status = 'close' AND r.close_date_time IS NOT NULL
+							AND r.date_time >= $1 AND r.date_time < $2
+							AND ($3::uuid IS NULL OR r.pvz_id = $3)
+							AND ($4::text IS NULL OR v.city = $4)
+						)`
+
+	QuerySelectReceptionSLA = receptionSLAWindow + `,
+								gaps AS (
+									SELECT p.reception_id, p.date_time,
+										EXTRACT(EPOCH FROM p.date_time - LAG(p.date_time) OVER (
+											PARTITION BY p.reception_id ORDER BY p.date_time
+										))::float8 AS gap
+									FROM products p
+									JOIN window_receptions w ON w.id = p.reception_id
+								),
+								scans AS (
+									SELECT reception_id,
+										EXTRACT(EPOCH FROM MAX(date_time) - MIN(date_time))::float8 AS span,
+										MAX(gap) AS max_gap
+									FROM gaps
+									GROUP BY reception_id
+								)
+								SELECT w.pvz_id, w.city, COUNT(*) AS receptions,
+									COUNT(*) FILTER (WHERE w.duration > $5) AS breaches,
+									percentile_cont(ARRAY[0.5, 0.9, 0.99]) WITHIN GROUP (ORDER BY w.duration),
+									percentile_cont(ARRAY[0.5, 0.9]) WITHIN GROUP (ORDER BY s.span),
+									percentile_cont(ARRAY[0.5, 0.9]) WITHIN GROUP (ORDER BY s.max_gap)
+								FROM window_receptions w
+								LEFT JOIN scans s ON s.reception_id = w.id
+								GROUP BY GROUPING SETS ((w.pvz_id, w.city), (w.city), ())
+								ORDER BY GROUPING(w.pvz_id, w.city), breaches DESC, w.city, w.pvz_id`
+
+	QuerySelectReceptionSLAHours = receptionSLAWindow + `
+									SELECT EXTRACT(ISODOW FROM local_time)::int, EXTRACT(HOUR FROM local_time)::int,
+										COUNT(*), COUNT(*) FILTER (WHERE duration > $5),
+										percentile_cont(0.5) WITHIN GROUP (ORDER BY duration)
+									FROM (
+										SELECT (date_time AT TIME ZONE 'UTC') AT TIME ZONE $6 AS local_time, duration
+										FROM window_receptions
+									) local_receptions
+									GROUP BY 1, 2
+									ORDER BY 1, 2`
+
+	QuerySelectEmployeeStats = `WITH scans AS (
+									SELECT p.created_by AS user_id, p.reception_id, p.date_time, false AS deleted
+									FROM products p
+									JOIN receptions r ON r.id = p.reception_id
+									WHERE p.created_by IS NOT NULL
+									AND p.date_time >= $1 AND p.date_time < $2
+									AND ($3::uuid IS NULL OR r.pvz_id = $3)
+									UNION ALL
+									SELECT d.created_by, d.reception_id, d.date_time, true
+									FROM deleted_products d
+									JOIN receptions r ON r.id = d.reception_id
+									WHERE d.created_by IS NOT NULL
+									AND d.date_time >= $1 AND d.date_time < $2
+									AND ($3::uuid IS NULL OR r.pvz_id = $3)
+								),
+								scan_stats AS (
+									SELECT user_id, COUNT(*) AS scanned, COUNT(*) FILTER (WHERE deleted) AS deleted,
+										COUNT(DISTINCT date_trunc('hour', date_time)) AS active_hours
+									FROM scans
+									GROUP BY user_id
+								),
+								window_receptions AS (
+									SELECT r.id, r.created_by, r.closed_by, r.date_time, r.close_date_time
+									FROM receptions r
+									WHERE r.date_time < $2 AND (r.close_date_time >= $1 OR r.close_date_time IS NULL)
+									AND ($3::uuid IS NULL OR r.pvz_id = $3)
+								),
+								handled AS (
+									SELECT created_by AS user_id, id FROM window_receptions WHERE created_by IS NOT NULL
+									UNION
+									SELECT closed_by, id FROM window_receptions WHERE closed_by IS NOT NULL
+									UNION
+									SELECT user_id, reception_id FROM scans
+								),
+								handled_stats AS (
+									SELECT user_id, COUNT(*) AS receptions FROM handled GROUP BY user_id
+								),
+								duration_stats AS (
+									SELECT created_by AS user_id,
+										AVG(EXTRACT(EPOCH FROM close_date_time - date_time))::float8 AS avg_seconds
+									FROM window_receptions
+									WHERE created_by IS NOT NULL AND close_date_time IS NOT NULL
+									GROUP BY created_by
+								)
+								SELECT h.user_id, u.email, COALESCE(s.scanned, 0), COALESCE(s.deleted, 0),
+									COALESCE(s.active_hours, 0), h.receptions, d.avg_seconds
+								FROM handled_stats h
+								LEFT JOIN users u ON u.id = h.user_id
+								LEFT JOIN scan_stats s ON s.user_id = h.user_id
+								LEFT JOIN duration_stats d ON d.user_id = h.user_id
+								ORDER BY COALESCE(s.scanned, 0) DESC, h.user_id`
+
+	// export
+	exportReceptionWindow = `r.date_time <= $2 AND (r.close_date_time >= $1 OR r.close_date_time IS NULL)`
+
+	QueryExportPVZs = `SELECT v.id, v.city, v.registration_date AS "registrationDate",
+							v.latitude, v.longitude, v.region_id AS "regionId"
+						FROM pvz v
+						WHERE EXISTS (
+							SELECT 1 FROM receptions r
+							WHERE r.pvz_id = v.id AND ` + exportReceptionWindow + `
+						)
+						AND ($3::uuid IS NULL OR v.region_id = $3)
+						ORDER BY v.registration_date DESC`
+
+	QueryExportReceptions = `SELECT r.id, r.pvz_id AS "pvzId", r.date_time AS "dateTime",
+								r.close_date_time AS "closeDateTime", r.status, r.carrier,
+								r.vehicle_plate AS "vehiclePlate", r.waybill_number AS "waybillNumber",
+								r.seal_number AS "sealNumber", r.comment
+							FROM receptions r
+							WHERE ` + exportReceptionWindow + `
+							AND ($3::uuid IS NULL OR r.pvz_id = $3)
+							ORDER BY r.date_time DESC`
+
+	QueryExportProducts = `SELECT p.id, p.reception_id AS "receptionId", r.pvz_id AS "pvzId",
+								p.date_time AS "dateTime", p.type, p.barcode, p.sku, p.status
+							FROM products p
+							JOIN receptions r ON r.id = p.reception_id
+							WHERE ` + exportReceptionWindow + `
+							AND ($3::uuid IS NULL OR r.pvz_id = $3)
+							AND ($4::text IS NULL OR p.type = $4)
+							ORDER BY r.date_time DESC, p.date_time`
+
+	// jobs
+	jobColumns = `id, kind, format, params, status, progress, error, created_by,
+					created_at, started_at, finished_at, expires_at`
+
+	QueryInsertJob = `INSERT INTO jobs (id, kind, format, params, status, created_by, created_at)
+						VALUES ($1, $2, $3, $4, 'queued', $5, $6)`
+
+	QueryGetJob = `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	QueryClaimJob = `UPDATE jobs SET status = 'running', started_at = $1
+						WHERE id = (
+							SELECT id FROM jobs
+							WHERE status = 'queued'
+							ORDER BY created_at
+							FOR UPDATE SKIP LOCKED
+							LIMIT 1
+						)
+						RETURNING ` + jobColumns
+
+	QueryUpdateJobProgress = `UPDATE jobs SET progress = $2 WHERE id = $1 AND status = 'running'`
+
+	QueryFinishJob = `UPDATE jobs
+						SET status = $2, progress = $3, error = $4, finished_at = $5, expires_at = $6
+						WHERE id = $1 AND status = 'running'`
+
+	QueryLockJob = `SELECT status FROM jobs WHERE id = $1 FOR UPDATE`
+
+	QueryCancelJob = `UPDATE jobs SET status = 'cancelled', finished_at = $2, expires_at = $3
+						WHERE id = $1
+						RETURNING ` + jobColumns
+
+	QueryFailStaleJobs = `UPDATE jobs
+							SET status = 'errored', error = $3, finished_at = $1, expires_at = $2
+							WHERE status = 'running' AND started_at < $4
+							RETURNING id, format`
+
+	QueryExpireJobs = `UPDATE jobs SET status = 'expired'
+						WHERE status IN ('succeeded', 'errored', 'cancelled') AND expires_at <= $1
+						RETURNING id, format`
+
+	// report subscriptions
+	reportSubscriptionColumns = `id, name, report, params, schedule, timezone, format, targets, active,
+									created_by, created_at, next_run_at, last_run_at`
+
+	QueryInsertReportSubscription = `INSERT INTO report_subscriptions (
+										id, name, report, params, schedule, timezone, format, targets, active,
+										created_by, created_at, next_run_at
+									)
+									VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+
+	QuerySelectReportSubscriptions = `SELECT ` + reportSubscriptionColumns + `
+										FROM report_subscriptions
+										ORDER BY created_at`
+
+	QueryGetReportSubscription = `SELECT ` + reportSubscriptionColumns + ` FROM report_subscriptions WHERE id = $1`
+
+	QueryUpdateReportSubscription = `UPDATE report_subscriptions
+										SET name = $2, report = $3, params = $4, schedule = $5, timezone = $6,
+											format = $7, targets = $8, active = $9, next_run_at = $10
+										WHERE id = $1
+										RETURNING ` + reportSubscriptionColumns
+
+	QueryDeleteReportSubscription = `DELETE FROM report_subscriptions WHERE id = $1`
+
+	QuerySelectDueReportSubscriptions = `SELECT ` + reportSubscriptionColumns + `
+											FROM report_subscriptions
+											WHERE active AND next_run_at <= $1
+											ORDER BY next_run_at
+											LIMIT $2`
+
+	QueryClaimReportSubscriptionRun = `UPDATE report_subscriptions
+										SET last_run_at = $2, next_run_at = $3
+										WHERE id = $1 AND active AND next_run_at = $2`
+
+	QueryInsertReportDelivery = `INSERT INTO report_deliveries (
+									id, subscription_id, scheduled_at, target_type, target_address, status,
+									file_name, error, created_at
+								)
+								VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	QuerySelectReportDeliveries = `SELECT id, subscription_id, scheduled_at, target_type, target_address, status,
+										file_name, error, created_at
+									FROM report_deliveries
+									WHERE subscription_id = $1
+									ORDER BY created_at DESC
+									LIMIT $2 OFFSET $3`
+
+	// forecasts
+	QueryGetForecastPVZ = `SELECT registration_date, daily_intake_capacity FROM pvz WHERE id = $1`
+
+	QuerySelectHourlyIntake = `SELECT local_time::date, EXTRACT(HOUR FROM local_time)::int, COUNT(*)
+								FROM (
+									SELECT (p.date_time AT TIME ZONE 'UTC') AT TIME ZONE $4 AS local_time
+									FROM products p
+									JOIN receptions r ON r.id = p.reception_id
+									WHERE r.pvz_id = $1 AND p.date_time >= $2 AND p.date_time < $3
+								) intake
+								GROUP BY 1, 2
+								ORDER BY 1, 2`
+
+	// regions
+	QueryUpsertRegions = `INSERT INTO regions (id, name, geometry, created_by)
+							SELECT u.id, u.name, jsonb_build_object('type', u.type, 'coordinates', u.coordinates::jsonb), $5
+							FROM UNNEST($1::uuid[], $2::text[], $3::text[], $4::text[]) AS u(id, name, type, coordinates)
+							ON CONFLICT (name) DO UPDATE SET geometry = EXCLUDED.geometry`
+
+	QuerySelectRegions = `SELECT g.id, g.name, g.geometry->>'type', g.geometry->'coordinates', COUNT(v.id)
+							FROM regions g
+							LEFT JOIN pvz v ON v.region_id = g.id
+							GROUP BY g.id
+							ORDER BY g.name`
+
+	QueryDeleteRegion = `DELETE FROM regions WHERE id = $1`
+
+	QuerySelectPVZLocations = `SELECT id, latitude, longitude
+								FROM pvz
+								WHERE latitude IS NOT NULL AND longitude IS NOT NULL`
+
+	QueryAssignPVZRegions = `UPDATE pvz v
+								SET region_id = a.region_id
+								FROM UNNEST($1::uuid[], $2::uuid[]) AS a(id, region_id)
+								WHERE v.id = a.id AND v.region_id IS DISTINCT FROM a.region_id`
+
+	QuerySelectPVZPoints = `SELECT v.id, v.latitude, v.longitude, v.city, v.registration_date, v.region_id, g.name
+							FROM pvz v
+							LEFT JOIN regions g ON g.id = v.region_id
+							WHERE v.latitude IS NOT NULL AND v.longitude IS NOT NULL
+							AND ($1::text IS NULL OR v.city = $1)
+							AND ($2::uuid IS NULL OR v.region_id = $2)
+							ORDER BY v.registration_date`
+	// approvals
+	QueryGetApprovalRule = `SELECT operation, enabled, updated_by, updated_at
+							FROM approval_rules
+							WHERE operation = $1`
+
+	QuerySelectApprovalRules = `SELECT operation, enabled, updated_by, updated_at
+								FROM approval_rules
+								ORDER BY operation`
+
+	QueryUpdateApprovalRule = `UPDATE approval_rules
+								SET enabled = $2, updated_by = $3, updated_at = $4
+								WHERE operation = $1`
+
+	QueryOpenReceptionHasDiscrepancies = `SELECT EXISTS (
+												SELECT 1 FROM product_damages d WHERE d.reception_id = r.id
+											) OR EXISTS (
+												SELECT 1 FROM containers c
+												WHERE c.reception_id = r.id AND c.state <> 'verified'
+											)
+											FROM receptions r
+											WHERE r.pvz_id = $1 AND r.status = 'in_progress'`
+
+	QueryInsertDeleteProductApproval = `WITH last AS (
+											SELECT p.id
+											FROM receptions r
+											JOIN products p ON p.reception_id = r.id
+											WHERE r.pvz_id = $2 AND r.status = 'in_progress'
+											ORDER BY r.date_time DESC, p.date_time DESC
+											LIMIT 1
+										)
+										INSERT INTO approvals (
+											id, operation, status, pvz_id, target_id, requested_by, requested_at
+										)
+										SELECT $1, 'delete_last_product', 'pending', $2, last.id, $3, $4
+										FROM last
+										RETURNING pvz_id, target_id`
+
+	QueryInsertDeleteProductByIDApproval = `INSERT INTO approvals (
+												id, operation, status, pvz_id, target_id, requested_by, requested_at
+											)
+											SELECT $1, 'delete_product', 'pending', r.pvz_id, p.id, $3, $4
+											FROM products p
+											JOIN receptions r ON r.id = p.reception_id
+											WHERE p.id = $2 AND r.status = 'in_progress'
+											RETURNING pvz_id, target_id`
+
+	QueryInsertCloseReceptionApproval = `INSERT INTO approvals (
+											id, operation, status, pvz_id, target_id, requested_by, requested_at
+										)
+										SELECT $1, 'close_reception', 'pending', $2, r.id, $3, $4
+										FROM receptions r
+										WHERE r.pvz_id = $2 AND r.status = 'in_progress'
+										RETURNING pvz_id, target_id`
+
+	QuerySelectApprovals = `SELECT id, operation, status, pvz_id, target_id,
+								requested_by, requested_at, decided_by, decided_at, comment
+							FROM approvals
+							WHERE status = $1
+							ORDER BY requested_at
+							LIMIT $2 OFFSET $3`
+
+	QueryLockApproval = `SELECT operation, status, target_id, requested_by
+							FROM approvals
+							WHERE id = $1
+							FOR UPDATE`
+
+	QueryDecideApproval = `UPDATE approvals
+							SET status = $2, decided_by = $3, decided_at = $4, comment = $5
+							WHERE id = $1
+							RETURNING id, operation, status, pvz_id, target_id,
+								requested_by, requested_at, decided_by, decided_at, comment`
+
+	QueryCloseReceptionByID = `UPDATE receptions
+								SET status = 'close', close_date_time = NOW(), closed_by = $2
+								WHERE id = $1 AND status = 'in_progress'
+								RETURNING pvz_id, date_time, close_date_time`
+
+	// damage
+	QueryUpsertProductDamage = `INSERT INTO product_damages (
+									id, product_id, reception_id, kind, severity, comment, reported_by, created_at
+								)
+								SELECT $1, p.id, p.reception_id, $3, $4, $5, $6, $7
+								FROM products p
+								WHERE p.id = $2
+								ON CONFLICT (product_id) DO UPDATE
+								SET kind = EXCLUDED.kind,
+									severity = EXCLUDED.severity,
+									comment = EXCLUDED.comment,
+									reported_by = EXCLUDED.reported_by,
+									created_at = EXCLUDED.created_at
+								RETURNING id, reception_id;`
+
+	QueryGetReceptionDamage = `SELECT d.id, d.product_id, d.reception_id, r.pvz_id, pvz.city,
+									r.carrier, r.waybill_number, d.kind, d.severity, d.comment, d.reported_by, d.created_at
+								FROM product_damages d
+								JOIN receptions r ON r.id = d.reception_id
+								JOIN pvz ON pvz.id = r.pvz_id
+								WHERE d.reception_id = $1
+								ORDER BY d.created_at`
+
+	QuerySelectDamageIncidents = `SELECT d.id, d.product_id, d.reception_id, r.pvz_id, pvz.city,
+									r.carrier, r.waybill_number, d.kind, d.severity, d.comment, d.reported_by, d.created_at
+								FROM product_damages d
+								JOIN receptions r ON r.id = d.reception_id
+								JOIN pvz ON pvz.id = r.pvz_id
+								WHERE d.created_at BETWEEN $1 AND $2
+								AND ($3::uuid IS NULL OR r.pvz_id = $3)
+								AND ($4::text IS NULL OR r.carrier = $4)
+								ORDER BY r.carrier NULLS LAST, r.pvz_id, d.created_at DESC
+								LIMIT $5 OFFSET $6`
 )
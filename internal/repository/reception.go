@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/whaleship/pvz/internal/database"
 	"github.com/whaleship/pvz/internal/dto"
@@ -23,6 +26,7 @@ func NewReceptionRepository(dbConn database.PgxIface) *receptionRepository {
 
 func (r *receptionRepository) CreateReception(
 	ctx context.Context,
+	createdBy uuid.UUID,
 	req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -42,6 +46,12 @@ func (r *receptionRepository) CreateReception(
 		req.PvzId,
 		newReceptionID,
 		now,
+		req.Carrier,
+		req.VehiclePlate,
+		req.WaybillNumber,
+		req.SealNumber,
+		req.Comment,
+		nullableUUID(createdBy),
 	).Scan(&insertedID)
 	if err != nil {
 		if errors.Is(err, r.db.ErrNoRows()) {
@@ -66,14 +76,23 @@ func (r *receptionRepository) CreateReception(
 	}
 
 	return oapi.Reception{
-		Id:       &insertedID,
-		DateTime: now,
-		PvzId:    req.PvzId,
-		Status:   oapi.ReceptionStatus("in_progress"),
+		Id:            &insertedID,
+		DateTime:      now,
+		PvzId:         req.PvzId,
+		Status:        oapi.ReceptionStatus("in_progress"),
+		Carrier:       req.Carrier,
+		VehiclePlate:  req.VehiclePlate,
+		WaybillNumber: req.WaybillNumber,
+		SealNumber:    req.SealNumber,
+		Comment:       req.Comment,
+		CreatedBy:     nullableUUID(createdBy),
 	}, nil
 }
 
-func (r *receptionRepository) CloseLastReception(ctx context.Context, pvzID uuid.UUID) (oapi.Reception, error) {
+func (r *receptionRepository) CloseLastReception(
+	ctx context.Context,
+	pvzID, closedBy uuid.UUID,
+) (oapi.Reception, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return oapi.Reception{}, err
@@ -88,9 +107,10 @@ func (r *receptionRepository) CloseLastReception(ctx context.Context, pvzID uuid
 	var (
 		receptionID uuid.UUID
 		openTime    time.Time
+		closeTime   time.Time
 	)
-	err = tx.QueryRow(ctx, QueryCloseActiveReception, pvzID).
-		Scan(&receptionID, &openTime)
+	err = tx.QueryRow(ctx, QueryCloseActiveReception, pvzID, nullableUUID(closedBy)).
+		Scan(&receptionID, &openTime, &closeTime)
 	if err != nil {
 		if errors.Is(err, r.db.ErrNoRows()) {
 			return oapi.Reception{}, pvz_errors.ErrCloseReceptionFailed
@@ -98,18 +118,108 @@ func (r *receptionRepository) CloseLastReception(ctx context.Context, pvzID uuid
 		return oapi.Reception{}, err
 	}
 
+	summary, err := summarizeReception(ctx, tx, receptionID, openTime, closeTime)
+	if err != nil {
+		return oapi.Reception{}, err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return oapi.Reception{}, err
 	}
 
 	return oapi.Reception{
-		Id:       &receptionID,
-		PvzId:    pvzID,
-		DateTime: openTime,
-		Status:   oapi.ReceptionStatus("close"),
+		Id:            &receptionID,
+		PvzId:         pvzID,
+		DateTime:      openTime,
+		CloseDateTime: &closeTime,
+		Status:        oapi.ReceptionStatus("close"),
+		ClosedBy:      nullableUUID(closedBy),
+		Summary:       &summary,
 	}, nil
 }
 
+func summarizeReception(
+	ctx context.Context,
+	tx pgx.Tx,
+	receptionID uuid.UUID,
+	openTime, closeTime time.Time,
+) (oapi.ReceptionSummary, error) {
+	rows, err := tx.Query(ctx, QueryCountProductsByType, receptionID)
+	if err != nil {
+		return oapi.ReceptionSummary{}, err
+	}
+	defer rows.Close()
+
+	summary := oapi.ReceptionSummary{
+		ReceptionId:  receptionID,
+		CountsByType: make(map[string]int),
+	}
+	for rows.Next() {
+		var (
+			typ         string
+			count       int
+			first, last time.Time
+		)
+		if err := rows.Scan(&typ, &count, &first, &last); err != nil {
+			return oapi.ReceptionSummary{}, err
+		}
+		summary.CountsByType[typ] = count
+		summary.TotalItems += count
+		if summary.FirstScanAt == nil || first.Before(*summary.FirstScanAt) {
+			summary.FirstScanAt = &first
+		}
+		if summary.LastScanAt == nil || last.After(*summary.LastScanAt) {
+			summary.LastScanAt = &last
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return oapi.ReceptionSummary{}, err
+	}
+	rows.Close()
+
+	openDuration := closeTime.Sub(openTime)
+	summary.OpenDurationSeconds = int64(openDuration.Seconds())
+	if minutes := openDuration.Minutes(); minutes > 0 {
+		summary.ItemsPerMinute = math.Round(float64(summary.TotalItems)/minutes*100) / 100
+	}
+
+	_, err = tx.Exec(ctx, QueryInsertReceptionSummary,
+		receptionID,
+		summary.TotalItems,
+		summary.CountsByType,
+		summary.FirstScanAt,
+		summary.LastScanAt,
+		summary.OpenDurationSeconds,
+		summary.ItemsPerMinute,
+	)
+	if err != nil {
+		return oapi.ReceptionSummary{}, err
+	}
+	return summary, nil
+}
+
+func (r *receptionRepository) GetReceptionSummary(
+	ctx context.Context,
+	receptionID uuid.UUID) (oapi.ReceptionSummary, error) {
+	var summary oapi.ReceptionSummary
+	err := r.db.QueryRow(ctx, QueryGetReceptionSummary, receptionID).Scan(
+		&summary.ReceptionId,
+		&summary.TotalItems,
+		&summary.CountsByType,
+		&summary.FirstScanAt,
+		&summary.LastScanAt,
+		&summary.OpenDurationSeconds,
+		&summary.ItemsPerMinute,
+	)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.ReceptionSummary{}, pvz_errors.ErrReceptionSummaryNotFound
+		}
+		return oapi.ReceptionSummary{}, err
+	}
+	return summary, nil
+}
+
 func (r *receptionRepository) GetReceptionsByPVZ(ctx context.Context, pvzID uuid.UUID) ([]dto.Reception, error) {
 	rows, err := r.db.Query(ctx, QueryGetReceptionsByPVZs, pvzID)
 	if err != nil {
@@ -128,26 +238,80 @@ func (r *receptionRepository) GetReceptionsByPVZ(ctx context.Context, pvzID uuid
 			openTime  time.Time
 			closeTime *time.Time
 			status    string
+			waybill   dto.ReceptionWaybill
 		)
-		if err := rows.Scan(&id, &pvzId, &openTime, &status); err != nil {
+		if err := rows.Scan(&id, &pvzId, &openTime, &status,
+			&waybill.Carrier, &waybill.VehiclePlate, &waybill.WaybillNumber,
+			&waybill.SealNumber, &waybill.Comment, &waybill.CreatedBy, &waybill.ClosedBy); err != nil {
 			if errors.Is(err, r.db.ErrNoRows()) {
 				return nil, pvz_errors.ErrSelectReceptionsFailed
 			}
 			return nil, err
 		}
 		receptions = append(receptions, dto.Reception{
+			Id:               &id,
+			PvzId:            pvzId,
+			DateTime:         openTime,
+			CloseDateTime:    closeTime,
+			Status:           oapi.ReceptionStatus(status),
+			ReceptionWaybill: waybill,
+		})
+	}
+	if err = rows.Err(); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return nil, pvz_errors.ErrSelectReceptionsFailed
+		}
+		return nil, err
+	}
+	return receptions, nil
+}
+
+func (r *receptionRepository) SearchReceptionsByWaybill(
+	ctx context.Context,
+	waybillNumber string) ([]oapi.Reception, error) {
+	rows, err := r.db.Query(ctx, QuerySearchReceptionsByWaybill, waybillNumber)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReceptionsFailed, err)
+	}
+	defer rows.Close()
+
+	receptions := []oapi.Reception{}
+	for rows.Next() {
+		var (
+			id       uuid.UUID
+			pvzId    uuid.UUID
+			openTime time.Time
+			status   string
+			waybill  dto.ReceptionWaybill
+		)
+		if err := rows.Scan(&id, &pvzId, &openTime, &status,
+			&waybill.Carrier, &waybill.VehiclePlate, &waybill.WaybillNumber,
+			&waybill.SealNumber, &waybill.Comment, &waybill.CreatedBy, &waybill.ClosedBy); err != nil {
+			return nil, err
+		}
+		receptions = append(receptions, oapi.Reception{
 			Id:            &id,
 			PvzId:         pvzId,
 			DateTime:      openTime,
-			CloseDateTime: closeTime,
 			Status:        oapi.ReceptionStatus(status),
+			Carrier:       waybill.Carrier,
+			VehiclePlate:  waybill.VehiclePlate,
+			WaybillNumber: waybill.WaybillNumber,
+			SealNumber:    waybill.SealNumber,
+			Comment:       waybill.Comment,
+			CreatedBy:     waybill.CreatedBy,
+			ClosedBy:      waybill.ClosedBy,
 		})
 	}
 	if err = rows.Err(); err != nil {
-		if errors.Is(err, r.db.ErrNoRows()) {
-			return nil, pvz_errors.ErrSelectReceptionsFailed
-		}
 		return nil, err
 	}
 	return receptions, nil
 }
+
+func nullableUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
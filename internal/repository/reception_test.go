@@ -25,7 +25,8 @@ func TestCreateReception(t *testing.T) {
 	repo := NewReceptionRepository(db)
 
 	ctx := context.Background()
-	req := oapi.PostReceptionsJSONRequestBody{PvzId: uuid.New()}
+	req := oapi.PostReceptionsJSONRequestBody{PvzId: uuid.New(), WaybillNumber: strPtr("WB-1")}
+	userID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
 		mockPool.ExpectBegin()
@@ -35,13 +36,21 @@ func TestCreateReception(t *testing.T) {
 				req.PvzId,
 				pgxmock.AnyArg(),
 				pgxmock.AnyArg(),
+				req.Carrier,
+				req.VehiclePlate,
+				req.WaybillNumber,
+				req.SealNumber,
+				req.Comment,
+				&userID,
 			).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 		mockPool.ExpectCommit()
 
-		got, err := repo.CreateReception(ctx, req)
+		got, err := repo.CreateReception(ctx, userID, req)
 		require.NoError(t, err)
 		require.Equal(t, oapi.ReceptionStatus("in_progress"), got.Status)
+		require.Equal(t, req.WaybillNumber, got.WaybillNumber)
+		require.Equal(t, &userID, got.CreatedBy)
 	})
 
 	t.Run("pvz not found", func(t *testing.T) {
@@ -52,9 +61,15 @@ func TestCreateReception(t *testing.T) {
 				req.PvzId,
 				pgxmock.AnyArg(),
 				pgxmock.AnyArg(),
+				req.Carrier,
+				req.VehiclePlate,
+				req.WaybillNumber,
+				req.SealNumber,
+				req.Comment,
+				&userID,
 			).
 			WillReturnError(db.ErrNoRows())
-		_, err := repo.CreateReception(ctx, req)
+		_, err := repo.CreateReception(ctx, userID, req)
 		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
 	})
 
@@ -66,12 +81,18 @@ func TestCreateReception(t *testing.T) {
 				req.PvzId,
 				pgxmock.AnyArg(),
 				pgxmock.AnyArg(),
+				req.Carrier,
+				req.VehiclePlate,
+				req.WaybillNumber,
+				req.SealNumber,
+				req.Comment,
+				&userID,
 			).
 			WillReturnError(&pgconn.PgError{
 				Code:           "23505",
 				ConstraintName: "idx_unique_open_reception",
 			})
-		_, err := repo.CreateReception(ctx, req)
+		_, err := repo.CreateReception(ctx, userID, req)
 		require.ErrorIs(t, err, pvz_errors.ErrOpenReceptionExists)
 	})
 
@@ -80,10 +101,11 @@ func TestCreateReception(t *testing.T) {
 		mockPool.ExpectBegin()
 		mockPool.
 			ExpectQuery(QueryInsertReception).
-			WithArgs(req.PvzId, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WithArgs(req.PvzId, pgxmock.AnyArg(), pgxmock.AnyArg(),
+				req.Carrier, req.VehiclePlate, req.WaybillNumber, req.SealNumber, req.Comment, &userID).
 			WillReturnError(pgErr)
 
-		_, err := repo.CreateReception(ctx, req)
+		_, err := repo.CreateReception(ctx, userID, req)
 		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
 	})
 
@@ -95,9 +117,15 @@ func TestCreateReception(t *testing.T) {
 				req.PvzId,
 				pgxmock.AnyArg(),
 				pgxmock.AnyArg(),
+				req.Carrier,
+				req.VehiclePlate,
+				req.WaybillNumber,
+				req.SealNumber,
+				req.Comment,
+				&userID,
 			).
 			WillReturnError(errors.New("some db failure"))
-		_, err := repo.CreateReception(ctx, req)
+		_, err := repo.CreateReception(ctx, userID, req)
 		require.Error(t, err)
 	})
 
@@ -108,7 +136,7 @@ func TestCreateReception(t *testing.T) {
 		badRepo := NewReceptionRepository(badDb)
 
 		badPool.ExpectBegin().WillReturnError(errors.New("no tx"))
-		_, err := badRepo.CreateReception(ctx, req)
+		_, err := badRepo.CreateReception(ctx, userID, req)
 		require.Error(t, err)
 	})
 
@@ -120,11 +148,17 @@ func TestCreateReception(t *testing.T) {
 				req.PvzId,
 				pgxmock.AnyArg(),
 				pgxmock.AnyArg(),
+				req.Carrier,
+				req.VehiclePlate,
+				req.WaybillNumber,
+				req.SealNumber,
+				req.Comment,
+				&userID,
 			).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 		mockPool.ExpectCommit().WillReturnError(errors.New("cannot commit"))
 
-		_, err := repo.CreateReception(ctx, req)
+		_, err := repo.CreateReception(ctx, userID, req)
 		require.Error(t, err)
 	})
 }
@@ -141,33 +175,102 @@ func TestCloseLastReception(t *testing.T) {
 
 	ctx := context.Background()
 	pvzID := uuid.New()
+	closerID := uuid.New()
+	receptionID := uuid.New()
+	openTime := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	closeTime := openTime.Add(10 * time.Minute)
+	closeColumns := []string{"id", "open_time", "close_time"}
+	countColumns := []string{"type", "count", "first", "last"}
 
 	t.Run("success", func(t *testing.T) {
 		mockPool.ExpectBegin()
 		mockPool.
 			ExpectQuery(QueryCloseActiveReception).
-			WithArgs(pvzID).
+			WithArgs(pvzID, &closerID).
+			WillReturnRows(
+				pgxmock.NewRows(closeColumns).
+					AddRow(receptionID, openTime, closeTime),
+			)
+		mockPool.
+			ExpectQuery(QueryCountProductsByType).
+			WithArgs(receptionID).
 			WillReturnRows(
-				pgxmock.NewRows([]string{"id", "open_time"}).
-					AddRow(uuid.New(), time.Now()),
+				pgxmock.NewRows(countColumns).
+					AddRow("обувь", 5, openTime.Add(time.Minute), openTime.Add(4*time.Minute)).
+					AddRow("одежда", 10, openTime.Add(2*time.Minute), openTime.Add(9*time.Minute)),
 			)
+		mockPool.
+			ExpectExec(QueryInsertReceptionSummary).
+			WithArgs(receptionID, 15, map[string]int{"обувь": 5, "одежда": 10},
+				pgxmock.AnyArg(), pgxmock.AnyArg(), int64(600), 1.5).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 		mockPool.ExpectCommit()
 
-		got, err := repo.CloseLastReception(ctx, pvzID)
+		got, err := repo.CloseLastReception(ctx, pvzID, closerID)
 		require.NoError(t, err)
 		require.Equal(t, oapi.ReceptionStatus("close"), got.Status)
+		require.Equal(t, closerID, *got.ClosedBy)
+		require.NotNil(t, got.Summary)
+		require.Equal(t, 15, got.Summary.TotalItems)
+		require.Equal(t, openTime.Add(time.Minute), *got.Summary.FirstScanAt)
+		require.Equal(t, openTime.Add(9*time.Minute), *got.Summary.LastScanAt)
+		require.InDelta(t, 1.5, got.Summary.ItemsPerMinute, 0.001)
+	})
+
+	t.Run("empty reception", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryCloseActiveReception).
+			WithArgs(pvzID, &closerID).
+			WillReturnRows(pgxmock.NewRows(closeColumns).AddRow(receptionID, openTime, openTime))
+		mockPool.
+			ExpectQuery(QueryCountProductsByType).
+			WithArgs(receptionID).
+			WillReturnRows(pgxmock.NewRows(countColumns))
+		mockPool.
+			ExpectExec(QueryInsertReceptionSummary).
+			WithArgs(receptionID, 0, map[string]int{}, pgxmock.AnyArg(), pgxmock.AnyArg(), int64(0), 0.0).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.ExpectCommit()
+
+		got, err := repo.CloseLastReception(ctx, pvzID, closerID)
+		require.NoError(t, err)
+		require.Zero(t, got.Summary.TotalItems)
+		require.Nil(t, got.Summary.FirstScanAt)
 	})
 
 	t.Run("nothing to close", func(t *testing.T) {
 		mockPool.ExpectBegin()
 		mockPool.
 			ExpectQuery(QueryCloseActiveReception).
-			WithArgs(pvzID).
+			WithArgs(pvzID, &closerID).
 			WillReturnError(db.ErrNoRows())
-		_, err := repo.CloseLastReception(ctx, pvzID)
+		mockPool.ExpectRollback()
+		_, err := repo.CloseLastReception(ctx, pvzID, closerID)
 		require.ErrorIs(t, err, pvz_errors.ErrCloseReceptionFailed)
 	})
 
+	t.Run("summary insert error", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryCloseActiveReception).
+			WithArgs(pvzID, &closerID).
+			WillReturnRows(pgxmock.NewRows(closeColumns).AddRow(receptionID, openTime, closeTime))
+		mockPool.
+			ExpectQuery(QueryCountProductsByType).
+			WithArgs(receptionID).
+			WillReturnRows(pgxmock.NewRows(countColumns))
+		mockPool.
+			ExpectExec(QueryInsertReceptionSummary).
+			WithArgs(anyArgs(7)...).
+			WillReturnError(errors.New("insert failed"))
+		mockPool.ExpectRollback()
+
+		_, err := repo.CloseLastReception(ctx, pvzID, closerID)
+		require.Error(t, err)
+		require.NoError(t, mockPool.ExpectationsWereMet())
+	})
+
 	t.Run("begin error", func(t *testing.T) {
 		badPool, _ := pgxmock.NewPool()
 		defer badPool.Close()
@@ -175,7 +278,7 @@ func TestCloseLastReception(t *testing.T) {
 		badRepo := NewReceptionRepository(badDb)
 
 		badPool.ExpectBegin().WillReturnError(errors.New("no tx"))
-		_, err := badRepo.CloseLastReception(ctx, pvzID)
+		_, err := badRepo.CloseLastReception(ctx, pvzID, closerID)
 		require.Error(t, err)
 	})
 
@@ -183,17 +286,79 @@ func TestCloseLastReception(t *testing.T) {
 		mockPool.ExpectBegin()
 		mockPool.
 			ExpectQuery(QueryCloseActiveReception).
-			WithArgs(pvzID).
-			WillReturnRows(
-				pgxmock.NewRows([]string{"id", "open_time"}).AddRow(uuid.New(), time.Now()),
-			)
+			WithArgs(pvzID, &closerID).
+			WillReturnRows(pgxmock.NewRows(closeColumns).AddRow(receptionID, openTime, closeTime))
+		mockPool.
+			ExpectQuery(QueryCountProductsByType).
+			WithArgs(receptionID).
+			WillReturnRows(pgxmock.NewRows(countColumns))
+		mockPool.
+			ExpectExec(QueryInsertReceptionSummary).
+			WithArgs(anyArgs(7)...).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 		mockPool.ExpectCommit().WillReturnError(errors.New("oops commit"))
 
-		_, err := repo.CloseLastReception(ctx, pvzID)
+		_, err := repo.CloseLastReception(ctx, pvzID, closerID)
 		require.Error(t, err)
 	})
 }
 
+func TestGetReceptionSummary(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewReceptionRepository(db)
+
+	ctx := context.Background()
+	receptionID := uuid.New()
+	columns := []string{
+		"reception_id", "total_items", "counts_by_type",
+		"first_scan_at", "last_scan_at", "open_duration_seconds", "items_per_minute",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		first := time.Now().Add(-time.Hour)
+		last := time.Now()
+		mockPool.
+			ExpectQuery(QueryGetReceptionSummary).
+			WithArgs(receptionID).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow(receptionID, 3, map[string]int{"обувь": 3}, &first, &last, int64(3600), 0.05))
+
+		got, err := repo.GetReceptionSummary(ctx, receptionID)
+		require.NoError(t, err)
+		require.Equal(t, 3, got.TotalItems)
+		require.Equal(t, 3, got.CountsByType["обувь"])
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetReceptionSummary).
+			WithArgs(receptionID).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.GetReceptionSummary(ctx, receptionID)
+		require.ErrorIs(t, err, pvz_errors.ErrReceptionSummaryNotFound)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetReceptionSummary).
+			WithArgs(receptionID).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.GetReceptionSummary(ctx, receptionID)
+		require.Error(t, err)
+	})
+}
+
+var receptionColumns = []string{
+	"id", "pvz_id", "open_time", "status",
+	"carrier", "vehicle_plate", "waybill_number", "seal_number", "comment", "created_by", "closed_by",
+}
+
 func TestGetReceptionsByPVZ(t *testing.T) {
 	mockPool, _ := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
 	defer mockPool.Close()
@@ -205,9 +370,9 @@ func TestGetReceptionsByPVZ(t *testing.T) {
 	pvzID := uuid.New()
 
 	t.Run("success multiple statuses", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "pvz_id", "open_time", "status"}).
-			AddRow(uuid.New(), pvzID, time.Now(), "in_progress").
-			AddRow(uuid.New(), pvzID, time.Now(), "close")
+		rows := pgxmock.NewRows(receptionColumns).
+			AddRow(uuid.New(), pvzID, time.Now(), "in_progress", nil, nil, nil, nil, nil, nil, nil).
+			AddRow(uuid.New(), pvzID, time.Now(), "close", strPtr("СДЭК"), nil, strPtr("WB-1"), nil, nil, nil, nil)
 		mockPool.
 			ExpectQuery(QueryGetReceptionsByPVZs).
 			WithArgs(pvzID).
@@ -218,6 +383,7 @@ func TestGetReceptionsByPVZ(t *testing.T) {
 		require.Len(t, out, 2)
 		require.Equal(t, oapi.InProgress, out[0].Status)
 		require.Equal(t, oapi.Close, out[1].Status)
+		require.Equal(t, "WB-1", *out[1].WaybillNumber)
 	})
 
 	t.Run("no receptions", func(t *testing.T) {
@@ -231,8 +397,8 @@ func TestGetReceptionsByPVZ(t *testing.T) {
 	})
 
 	t.Run("scan error", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "pvz_id", "open_time", "status"}).
-			AddRow("bad-uuid", pvzID, time.Now(), "in_progress")
+		rows := pgxmock.NewRows(receptionColumns).
+			AddRow("bad-uuid", pvzID, time.Now(), "in_progress", nil, nil, nil, nil, nil, nil, nil)
 		mockPool.
 			ExpectQuery(QueryGetReceptionsByPVZs).
 			WithArgs(pvzID).
@@ -243,7 +409,7 @@ func TestGetReceptionsByPVZ(t *testing.T) {
 	})
 
 	t.Run("rows error after next", func(t *testing.T) {
-		rows := pgxmock.NewRows([]string{"id", "pvz_id", "open_time", "status"}).
+		rows := pgxmock.NewRows(receptionColumns).
 			RowError(0, errors.New("row-fail"))
 		mockPool.
 			ExpectQuery(QueryGetReceptionsByPVZs).
@@ -254,3 +420,77 @@ func TestGetReceptionsByPVZ(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestSearchReceptionsByWaybill(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewReceptionRepository(db)
+
+	ctx := context.Background()
+	waybill := "WB-42"
+
+	t.Run("success", func(t *testing.T) {
+		employee, closer := uuid.New(), uuid.New()
+		rows := pgxmock.NewRows(receptionColumns).
+			AddRow(uuid.New(), uuid.New(), time.Now(), "close",
+				strPtr("СДЭК"), strPtr("А123ВС77"), &waybill, strPtr("S-1"), nil, &employee, &closer)
+		mockPool.
+			ExpectQuery(QuerySearchReceptionsByWaybill).
+			WithArgs(waybill).
+			WillReturnRows(rows)
+
+		out, err := repo.SearchReceptionsByWaybill(ctx, waybill)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		require.Equal(t, waybill, *out[0].WaybillNumber)
+		require.Equal(t, employee, *out[0].CreatedBy)
+		require.Equal(t, closer, *out[0].ClosedBy)
+		require.Nil(t, out[0].Comment)
+	})
+
+	t.Run("nothing found", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySearchReceptionsByWaybill).
+			WithArgs(waybill).
+			WillReturnRows(pgxmock.NewRows(receptionColumns))
+
+		out, err := repo.SearchReceptionsByWaybill(ctx, waybill)
+		require.NoError(t, err)
+		require.Empty(t, out)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySearchReceptionsByWaybill).
+			WithArgs(waybill).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.SearchReceptionsByWaybill(ctx, waybill)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectReceptionsFailed)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		rows := pgxmock.NewRows(receptionColumns).
+			AddRow("bad-uuid", uuid.New(), time.Now(), "close", nil, nil, &waybill, nil, nil, nil, nil)
+		mockPool.
+			ExpectQuery(QuerySearchReceptionsByWaybill).
+			WithArgs(waybill).
+			WillReturnRows(rows)
+
+		_, err := repo.SearchReceptionsByWaybill(ctx, waybill)
+		require.Error(t, err)
+	})
+}
+
+func strPtr(s string) *string { return &s }
+
+func anyArgs(n int) []any {
+	args := make([]any, n)
+	for i := range args {
+		args[i] = pgxmock.AnyArg()
+	}
+	return args
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+)
+
+type regionRepository struct {
+	db database.PgxIface
+}
+
+func NewRegionRepository(dbConn database.PgxIface) *regionRepository {
+	return &regionRepository{db: dbConn}
+}
+
+func (r *regionRepository) UpsertRegions(ctx context.Context, regions []dto.Region, createdBy *uuid.UUID) error {
+	ids := make([]uuid.UUID, 0, len(regions))
+	names := make([]string, 0, len(regions))
+	types := make([]string, 0, len(regions))
+	coordinates := make([]string, 0, len(regions))
+	for _, region := range regions {
+		ids = append(ids, region.ID)
+		names = append(names, region.Name)
+		types = append(types, region.GeometryType)
+		coordinates = append(coordinates, string(region.Coordinates))
+	}
+	_, err := r.db.Exec(ctx, QueryUpsertRegions, ids, names, types, coordinates, createdBy)
+	return err
+}
+
+func (r *regionRepository) SelectRegions(ctx context.Context) ([]dto.Region, error) {
+	rows, err := r.db.Query(ctx, QuerySelectRegions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectRegionsFailed, err)
+	}
+	defer rows.Close()
+
+	regions := []dto.Region{}
+	for rows.Next() {
+		var region dto.Region
+		err = rows.Scan(&region.ID, &region.Name, &region.GeometryType, &region.Coordinates, &region.PvzCount)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, region)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
+func (r *regionRepository) DeleteRegion(ctx context.Context, regionID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, QueryDeleteRegion, regionID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz_errors.ErrRegionNotFound
+	}
+	return nil
+}
+
+func (r *regionRepository) SelectPVZLocations(ctx context.Context) ([]dto.PVZLocation, error) {
+	rows, err := r.db.Query(ctx, QuerySelectPVZLocations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectPVZFailed, err)
+	}
+	defer rows.Close()
+
+	locations := []dto.PVZLocation{}
+	for rows.Next() {
+		var location dto.PVZLocation
+		if err = rows.Scan(&location.ID, &location.Latitude, &location.Longitude); err != nil {
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+func (r *regionRepository) AssignPVZRegions(ctx context.Context, pvzIDs []uuid.UUID, regionIDs []*uuid.UUID) error {
+	if _, err := r.db.Exec(ctx, QueryAssignPVZRegions, pvzIDs, regionIDs); err != nil {
+		return fmt.Errorf("%w: %w", pvz_errors.ErrAssignRegionsFailed, err)
+	}
+	return nil
+}
+
+func (r *regionRepository) SelectPVZPoints(ctx context.Context, filter dto.PVZPointFilter) ([]dto.PVZPoint, error) {
+	rows, err := r.db.Query(ctx, QuerySelectPVZPoints, filter.City, filter.RegionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectPVZFailed, err)
+	}
+	defer rows.Close()
+
+	points := []dto.PVZPoint{}
+	for rows.Next() {
+		var p dto.PVZPoint
+		err = rows.Scan(&p.ID, &p.Latitude, &p.Longitude, &p.City, &p.RegisteredAt, &p.RegionID, &p.RegionName)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
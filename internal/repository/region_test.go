@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+)
+
+func TestRegionRepository(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewRegionRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	regionID, pvzID, userID := uuid.New(), uuid.New(), uuid.New()
+	polygon := `[[[37.3, 55.5], [37.9, 55.5], [37.9, 55.9], [37.3, 55.5]]]`
+
+	t.Run("upsert", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryUpsertRegions).
+			WithArgs([]uuid.UUID{regionID}, []string{"Москва"}, []string{"Polygon"}, []string{polygon}, &userID).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err := repo.UpsertRegions(ctx, []dto.Region{{
+			ID: regionID, Name: "Москва", GeometryType: "Polygon", Coordinates: []byte(polygon),
+		}}, &userID)
+		require.NoError(t, err)
+	})
+
+	t.Run("select", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectRegions).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "name", "type", "coordinates", "count"}).
+				AddRow(regionID, "Москва", "Polygon", []byte(polygon), 3))
+
+		regions, err := repo.SelectRegions(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []dto.Region{{
+			ID: regionID, Name: "Москва", GeometryType: "Polygon", Coordinates: []byte(polygon), PvzCount: 3,
+		}}, regions)
+	})
+
+	t.Run("delete missing", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryDeleteRegion).
+			WithArgs(regionID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		require.ErrorIs(t, repo.DeleteRegion(ctx, regionID), pvz_errors.ErrRegionNotFound)
+	})
+
+	t.Run("assign", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryAssignPVZRegions).
+			WithArgs([]uuid.UUID{pvzID}, []*uuid.UUID{nil}).
+			WillReturnError(errors.New("deadlock detected"))
+
+		err := repo.AssignPVZRegions(ctx, []uuid.UUID{pvzID}, []*uuid.UUID{nil})
+		require.ErrorIs(t, err, pvz_errors.ErrAssignRegionsFailed)
+	})
+
+	t.Run("points", func(t *testing.T) {
+		city := "Москва"
+		registered := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockPool.
+			ExpectQuery(QuerySelectPVZPoints).
+			WithArgs(&city, noRegion).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "latitude", "longitude", "city", "registration_date", "region_id", "name",
+			}).AddRow(pvzID, 55.75, 37.62, city, registered, &regionID, &city))
+
+		points, err := repo.SelectPVZPoints(ctx, dto.PVZPointFilter{City: &city})
+		require.NoError(t, err)
+		require.Len(t, points, 1)
+		require.Equal(t, dto.PVZLocation{ID: pvzID, Latitude: 55.75, Longitude: 37.62}, points[0].PVZLocation)
+		require.Equal(t, regionID, *points[0].RegionID)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
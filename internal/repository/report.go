@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type reportRepository struct {
+	db database.PgxIface
+}
+
+func NewReportRepository(dbConn database.PgxIface) *reportRepository {
+	return &reportRepository{db: dbConn}
+}
+
+func (r *reportRepository) SelectIntakeRows(ctx context.Context, filter dto.IntakeFilter) ([]dto.IntakeRow, error) {
+	query := QuerySelectIntakeRows
+	if filter.Rollups {
+		query = QuerySelectIntakeRollupRows
+	}
+	rows, err := r.db.Query(ctx, query,
+		filter.From, filter.To, filter.City, filter.PvzID, filter.ProductType, filter.Granularity, filter.GroupBy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReportFailed, err)
+	}
+	defer rows.Close()
+
+	result := []dto.IntakeRow{}
+	for rows.Next() {
+		var row dto.IntakeRow
+		if err = rows.Scan(&row.PeriodStart, &row.Key, &row.Receptions, &row.Products); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *reportRepository) SelectIntakeTotals(ctx context.Context, filter dto.IntakeFilter) (oapi.IntakeCounts, error) {
+	var totals oapi.IntakeCounts
+	err := r.db.QueryRow(ctx, QuerySelectIntakeTotals,
+		filter.From, filter.To, filter.City, filter.PvzID, filter.ProductType,
+	).Scan(&totals.Receptions, &totals.Products)
+	if err != nil {
+		return oapi.IntakeCounts{}, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReportFailed, err)
+	}
+	return totals, nil
+}
+
+func (r *reportRepository) CountSettledRollupDays(ctx context.Context, from, to time.Time) (int, error) {
+	var days int
+	if err := r.db.QueryRow(ctx, QueryCountSettledRollupDays, from, to).Scan(&days); err != nil {
+		return 0, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReportFailed, err)
+	}
+	return days, nil
+}
+
+func (r *reportRepository) SelectEmployeeStats(
+	ctx context.Context,
+	filter dto.ProductivityFilter,
+) ([]dto.EmployeeStats, error) {
+	rows, err := r.db.Query(ctx, QuerySelectEmployeeStats, filter.From, filter.To, filter.PvzID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReportFailed, err)
+	}
+	defer rows.Close()
+
+	result := []dto.EmployeeStats{}
+	for rows.Next() {
+		var stats dto.EmployeeStats
+		err = rows.Scan(
+			&stats.UserID,
+			&stats.Email,
+			&stats.ProductsScanned,
+			&stats.ProductsDeleted,
+			&stats.ActiveHours,
+			&stats.ReceptionsHandled,
+			&stats.AvgReceptionSeconds,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *reportRepository) SelectReceptionSLA(
+	ctx context.Context,
+	filter dto.ReceptionSLAFilter,
+) ([]dto.ReceptionSLAGroup, error) {
+	rows, err := r.db.Query(ctx, QuerySelectReceptionSLA,
+		filter.From, filter.To, filter.PvzID, filter.City, filter.SLASeconds)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReportFailed, err)
+	}
+	defer rows.Close()
+
+	result := []dto.ReceptionSLAGroup{}
+	for rows.Next() {
+		var group dto.ReceptionSLAGroup
+		err = rows.Scan(
+			&group.PvzID,
+			&group.City,
+			&group.Receptions,
+			&group.Breaches,
+			&group.Durations,
+			&group.ScanSpans,
+			&group.MaxIdleGaps,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, group)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *reportRepository) SelectReceptionSLAHours(
+	ctx context.Context,
+	filter dto.ReceptionSLAFilter,
+) ([]dto.ReceptionSLAHour, error) {
+	rows, err := r.db.Query(ctx, QuerySelectReceptionSLAHours,
+		filter.From, filter.To, filter.PvzID, filter.City, filter.SLASeconds, filter.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReportFailed, err)
+	}
+	defer rows.Close()
+
+	result := []dto.ReceptionSLAHour{}
+	for rows.Next() {
+		var hour dto.ReceptionSLAHour
+		err = rows.Scan(&hour.Weekday, &hour.Hour, &hour.Receptions, &hour.Breaches, &hour.DurationP50)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, hour)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *reportRepository) SelectOpenReceptions(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	city *string,
+) ([]dto.OpenReception, error) {
+	rows, err := r.db.Query(ctx, QuerySelectOpenReceptions, pvzID, city)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReportFailed, err)
+	}
+	defer rows.Close()
+
+	result := []dto.OpenReception{}
+	for rows.Next() {
+		var reception dto.OpenReception
+		err = rows.Scan(
+			&reception.ReceptionID,
+			&reception.PvzID,
+			&reception.City,
+			&reception.OpenedAt,
+			&reception.Carrier,
+			&reception.WaybillNumber,
+			&reception.Products,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, reception)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type reportSubscriptionRepository struct {
+	db database.PgxIface
+}
+
+func NewReportSubscriptionRepository(dbConn database.PgxIface) *reportSubscriptionRepository {
+	return &reportSubscriptionRepository{db: dbConn}
+}
+
+func (r *reportSubscriptionRepository) InsertReportSubscription(
+	ctx context.Context,
+	sub oapi.ReportSubscription,
+) (oapi.ReportSubscription, error) {
+	params, targets, err := marshalSubscription(sub)
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	_, err = r.db.Exec(ctx, QueryInsertReportSubscription,
+		sub.Id, sub.Name, sub.Report, params, sub.Schedule, sub.Timezone, sub.Format, targets, sub.Active,
+		sub.CreatedBy, sub.CreatedAt, sub.NextRunAt)
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	return sub, nil
+}
+
+func (r *reportSubscriptionRepository) SelectReportSubscriptions(
+	ctx context.Context,
+) ([]oapi.ReportSubscription, error) {
+	return r.selectReportSubscriptions(ctx, QuerySelectReportSubscriptions)
+}
+
+func (r *reportSubscriptionRepository) GetReportSubscription(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+) (oapi.ReportSubscription, error) {
+	sub, err := scanReportSubscription(r.db.QueryRow(ctx, QueryGetReportSubscription, subscriptionID))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.ReportSubscription{}, pvz_errors.ErrReportSubscriptionNotFound
+		}
+		return oapi.ReportSubscription{}, err
+	}
+	return sub, nil
+}
+
+func (r *reportSubscriptionRepository) UpdateReportSubscription(
+	ctx context.Context,
+	sub oapi.ReportSubscription,
+) (oapi.ReportSubscription, error) {
+	params, targets, err := marshalSubscription(sub)
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	updated, err := scanReportSubscription(r.db.QueryRow(ctx, QueryUpdateReportSubscription,
+		sub.Id, sub.Name, sub.Report, params, sub.Schedule, sub.Timezone, sub.Format, targets, sub.Active,
+		sub.NextRunAt))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.ReportSubscription{}, pvz_errors.ErrReportSubscriptionNotFound
+		}
+		return oapi.ReportSubscription{}, err
+	}
+	return updated, nil
+}
+
+func (r *reportSubscriptionRepository) DeleteReportSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, QueryDeleteReportSubscription, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz_errors.ErrReportSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *reportSubscriptionRepository) SelectDueReportSubscriptions(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]oapi.ReportSubscription, error) {
+	return r.selectReportSubscriptions(ctx, QuerySelectDueReportSubscriptions, now, limit)
+}
+
+// ClaimReportSubscriptionRun moves the subscription to its next run. It
+// reports false when another process has already claimed the scheduled run.
+func (r *reportSubscriptionRepository) ClaimReportSubscriptionRun(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	scheduledAt, nextRunAt time.Time,
+) (bool, error) {
+	tag, err := r.db.Exec(ctx, QueryClaimReportSubscriptionRun, subscriptionID, scheduledAt, nextRunAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *reportSubscriptionRepository) InsertReportDelivery(ctx context.Context, delivery oapi.ReportDelivery) error {
+	_, err := r.db.Exec(ctx, QueryInsertReportDelivery,
+		delivery.Id, delivery.SubscriptionId, delivery.ScheduledAt, delivery.Target.Type, delivery.Target.Address,
+		delivery.Status, delivery.FileName, delivery.Error, delivery.CreatedAt)
+	return err
+}
+
+func (r *reportSubscriptionRepository) SelectReportDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	limit, offset int,
+) ([]oapi.ReportDelivery, error) {
+	rows, err := r.db.Query(ctx, QuerySelectReportDeliveries, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []oapi.ReportDelivery{}
+	for rows.Next() {
+		var delivery oapi.ReportDelivery
+		var targetType, status string
+		err = rows.Scan(
+			&delivery.Id,
+			&delivery.SubscriptionId,
+			&delivery.ScheduledAt,
+			&targetType,
+			&delivery.Target.Address,
+			&status,
+			&delivery.FileName,
+			&delivery.Error,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		delivery.Target.Type = oapi.DeliveryTargetType(targetType)
+		delivery.Status = oapi.ReportDeliveryStatus(status)
+		deliveries = append(deliveries, delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *reportSubscriptionRepository) selectReportSubscriptions(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]oapi.ReportSubscription, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subs := []oapi.ReportSubscription{}
+	for rows.Next() {
+		sub, err := scanReportSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func marshalSubscription(sub oapi.ReportSubscription) ([]byte, []byte, error) {
+	params, err := json.Marshal(sub.Params)
+	if err != nil {
+		return nil, nil, err
+	}
+	targets, err := json.Marshal(sub.Targets)
+	if err != nil {
+		return nil, nil, err
+	}
+	return params, targets, nil
+}
+
+func scanReportSubscription(row pgx.Row) (oapi.ReportSubscription, error) {
+	var sub oapi.ReportSubscription
+	var report, format string
+	var params, targets []byte
+	err := row.Scan(
+		&sub.Id,
+		&sub.Name,
+		&report,
+		&params,
+		&sub.Schedule,
+		&sub.Timezone,
+		&format,
+		&targets,
+		&sub.Active,
+		&sub.CreatedBy,
+		&sub.CreatedAt,
+		&sub.NextRunAt,
+		&sub.LastRunAt,
+	)
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	if err = json.Unmarshal(params, &sub.Params); err != nil {
+		return oapi.ReportSubscription{}, fmt.Errorf("subscription %s params: %w", sub.Id, err)
+	}
+	if err = json.Unmarshal(targets, &sub.Targets); err != nil {
+		return oapi.ReportSubscription{}, fmt.Errorf("subscription %s targets: %w", sub.Id, err)
+	}
+	sub.Report = oapi.ReportSubscriptionKind(report)
+	sub.Format = oapi.ExportFormat(format)
+	return sub, nil
+}
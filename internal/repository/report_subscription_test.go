@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var reportSubscriptionRowColumns = []string{
+	"id", "name", "report", "params", "schedule", "timezone", "format", "targets", "active",
+	"created_by", "created_at", "next_run_at", "last_run_at",
+}
+
+func TestReportSubscriptionRepository(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewReportSubscriptionRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	subID := uuid.New()
+	now := time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC)
+	next := now.AddDate(0, 0, 1)
+
+	t.Run("due subscriptions decode params and targets", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectDueReportSubscriptions).
+			WithArgs(now, 20).
+			WillReturnRows(pgxmock.NewRows(reportSubscriptionRowColumns).AddRow(
+				subID, "Daily intake", "intake", []byte(`{"city":"Москва","lookbackDays":7}`), "0 7 * * *",
+				"Europe/Moscow", "xlsx", []byte(`[{"type":"email","address":"ops@example.com"}]`), true,
+				nil, now, &now, nil,
+			))
+
+		subs, err := repo.SelectDueReportSubscriptions(ctx, now, 20)
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		require.Equal(t, oapi.Intake, subs[0].Report)
+		require.Equal(t, oapi.Xlsx, subs[0].Format)
+		require.Equal(t, "Москва", *subs[0].Params.City)
+		require.Equal(t, 7, *subs[0].Params.LookbackDays)
+		require.Equal(t, []oapi.DeliveryTarget{{Type: oapi.Email, Address: "ops@example.com"}}, subs[0].Targets)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryGetReportSubscription).
+			WithArgs(subID).
+			WillReturnRows(pgxmock.NewRows(reportSubscriptionRowColumns))
+
+		_, err := repo.GetReportSubscription(ctx, subID)
+		require.ErrorIs(t, err, pvz_errors.ErrReportSubscriptionNotFound)
+	})
+
+	t.Run("claim", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryClaimReportSubscriptionRun).
+			WithArgs(subID, now, next).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryClaimReportSubscriptionRun).
+			WithArgs(subID, now, next).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		claimed, err := repo.ClaimReportSubscriptionRun(ctx, subID, now, next)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		claimed, err = repo.ClaimReportSubscriptionRun(ctx, subID, now, next)
+		require.NoError(t, err)
+		require.False(t, claimed)
+	})
+
+	t.Run("delete missing", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryDeleteReportSubscription).
+			WithArgs(subID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		err := repo.DeleteReportSubscription(ctx, subID)
+		require.ErrorIs(t, err, pvz_errors.ErrReportSubscriptionNotFound)
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		deliveryID := uuid.New()
+		message := "smtp: connection refused"
+		mockPool.
+			ExpectQuery(QuerySelectReportDeliveries).
+			WithArgs(subID, 30, 0).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "subscription_id", "scheduled_at", "target_type", "target_address", "status",
+				"file_name", "error", "created_at",
+			}).AddRow(deliveryID, subID, now, "email", "ops@example.com", "undelivered", nil, &message, now))
+
+		deliveries, err := repo.SelectReportDeliveries(ctx, subID, 30, 0)
+		require.NoError(t, err)
+		require.Equal(t, []oapi.ReportDelivery{{
+			Id:             deliveryID,
+			SubscriptionId: subID,
+			ScheduledAt:    now,
+			Target:         oapi.DeliveryTarget{Type: oapi.Email, Address: "ops@example.com"},
+			Status:         oapi.Undelivered,
+			Error:          &message,
+			CreatedAt:      now,
+		}}, deliveries)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+)
+
+func TestSelectIntake(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewReportRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	city := "Москва"
+	filter := dto.IntakeFilter{From: from, To: from.AddDate(0, 1, 0), Granularity: "week", GroupBy: "city", City: &city}
+
+	t.Run("rows", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectIntakeRows).
+			WithArgs(filter.From, filter.To, &city, filter.PvzID, filter.ProductType, "week", "city").
+			WillReturnRows(pgxmock.NewRows([]string{"period", "key", "receptions", "products"}).
+				AddRow(from, city, 2, 15))
+
+		rows, err := repo.SelectIntakeRows(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, []dto.IntakeRow{{PeriodStart: from, Key: city, Receptions: 2, Products: 15}}, rows)
+	})
+
+	t.Run("totals failure", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectIntakeTotals).
+			WithArgs(filter.From, filter.To, &city, filter.PvzID, filter.ProductType).
+			WillReturnError(errors.New("timeout"))
+
+		_, err := repo.SelectIntakeTotals(ctx, filter)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectReportFailed)
+	})
+
+	t.Run("rollup rows", func(t *testing.T) {
+		rollupFilter := filter
+		rollupFilter.Rollups = true
+		mockPool.
+			ExpectQuery(QuerySelectIntakeRollupRows).
+			WithArgs(filter.From, filter.To, &city, filter.PvzID, filter.ProductType, "week", "city").
+			WillReturnRows(pgxmock.NewRows([]string{"period", "key", "receptions", "products"}).
+				AddRow(from, city, 3, 20))
+
+		rows, err := repo.SelectIntakeRows(ctx, rollupFilter)
+		require.NoError(t, err)
+		require.Equal(t, 20, rows[0].Products)
+	})
+
+	t.Run("settled rollup days", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QueryCountSettledRollupDays).
+			WithArgs(filter.From, filter.To).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(31))
+
+		days, err := repo.CountSettledRollupDays(ctx, filter.From, filter.To)
+		require.NoError(t, err)
+		require.Equal(t, 31, days)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectEmployeeStats(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewReportRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	pvzID := uuid.New()
+	filter := dto.ProductivityFilter{From: from, To: from.AddDate(0, 0, 7), PvzID: &pvzID}
+
+	t.Run("rows", func(t *testing.T) {
+		userID := uuid.New()
+		email := "employee@example.com"
+		avg := 1200.5
+		mockPool.
+			ExpectQuery(QuerySelectEmployeeStats).
+			WithArgs(filter.From, filter.To, &pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"user_id", "email", "scanned", "deleted", "active_hours", "receptions", "avg_seconds",
+			}).AddRow(userID, &email, 40, 2, 4, 3, &avg))
+
+		stats, err := repo.SelectEmployeeStats(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, []dto.EmployeeStats{{
+			UserID:              userID,
+			Email:               &email,
+			ProductsScanned:     40,
+			ProductsDeleted:     2,
+			ActiveHours:         4,
+			ReceptionsHandled:   3,
+			AvgReceptionSeconds: &avg,
+		}}, stats)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectEmployeeStats).
+			WithArgs(filter.From, filter.To, &pvzID).
+			WillReturnError(errors.New("timeout"))
+
+		_, err := repo.SelectEmployeeStats(ctx, filter)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectReportFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectOpenReceptions(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewReportRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	city := "Казань"
+	receptionID, pvzID := uuid.New(), uuid.New()
+	openedAt := time.Date(2025, 3, 10, 6, 30, 0, 0, time.UTC)
+	carrier := "СДЭК"
+
+	mockPool.
+		ExpectQuery(QuerySelectOpenReceptions).
+		WithArgs((*uuid.UUID)(nil), &city).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "pvz_id", "city", "date_time", "carrier", "waybill_number", "count",
+		}).AddRow(receptionID, pvzID, city, openedAt, &carrier, nil, 12))
+
+	receptions, err := repo.SelectOpenReceptions(ctx, nil, &city)
+	require.NoError(t, err)
+	require.Equal(t, []dto.OpenReception{{
+		ReceptionID: receptionID,
+		PvzID:       pvzID,
+		City:        city,
+		OpenedAt:    openedAt,
+		Carrier:     &carrier,
+		Products:    12,
+	}}, receptions)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectReceptionSLA(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewReportRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	from := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	filter := dto.ReceptionSLAFilter{From: from, To: from.AddDate(0, 0, 7), SLASeconds: 7200, Timezone: "Asia/Omsk"}
+	pvzID, city := uuid.New(), "Омск"
+
+	t.Run("groups", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectReceptionSLA).
+			WithArgs(filter.From, filter.To, (*uuid.UUID)(nil), (*string)(nil), 7200.0).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"pvz_id", "city", "receptions", "breaches", "durations", "spans", "gaps",
+			}).
+				AddRow(&pvzID, &city, 2, 1, []float64{3600, 7500, 7800}, []float64{3000, 3100}, nil).
+				AddRow(nil, &city, 2, 1, []float64{3600, 7500, 7800}, []float64{3000, 3100}, nil).
+				AddRow(nil, nil, 2, 1, []float64{3600, 7500, 7800}, []float64{3000, 3100}, nil))
+
+		groups, err := repo.SelectReceptionSLA(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+		require.Equal(t, pvzID, *groups[0].PvzID)
+		require.Nil(t, groups[1].PvzID)
+		require.Nil(t, groups[2].City)
+		require.Equal(t, []float64{3600, 7500, 7800}, groups[2].Durations)
+		require.Nil(t, groups[2].MaxIdleGaps)
+	})
+
+	t.Run("hours", func(t *testing.T) {
+		p50 := 3600.0
+		mockPool.
+			ExpectQuery(QuerySelectReceptionSLAHours).
+			WithArgs(filter.From, filter.To, (*uuid.UUID)(nil), (*string)(nil), 7200.0, "Asia/Omsk").
+			WillReturnRows(pgxmock.NewRows([]string{"weekday", "hour", "receptions", "breaches", "p50"}).
+				AddRow(1, 9, 2, 1, &p50))
+
+		hours, err := repo.SelectReceptionSLAHours(ctx, filter)
+		require.NoError(t, err)
+		require.Equal(t, []dto.ReceptionSLAHour{
+			{Weekday: 1, Hour: 9, Receptions: 2, Breaches: 1, DurationP50: &p50},
+		}, hours)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectReceptionSLA).
+			WithArgs(filter.From, filter.To, (*uuid.UUID)(nil), (*string)(nil), 7200.0).
+			WillReturnError(errors.New("timeout"))
+
+		_, err := repo.SelectReceptionSLA(ctx, filter)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectReportFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
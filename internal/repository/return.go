@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type returnRepository struct {
+	db database.PgxIface
+}
+
+func NewReturnRepository(dbConn database.PgxIface) *returnRepository {
+	return &returnRepository{db: dbConn}
+}
+
+func (r *returnRepository) SelectReturnReasons(ctx context.Context, includeInactive bool) ([]oapi.ReturnReason, error) {
+	rows, err := r.db.Query(ctx, QuerySelectReturnReasons, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReturnsFailed, err)
+	}
+	defer rows.Close()
+
+	reasons := []oapi.ReturnReason{}
+	for rows.Next() {
+		var reason oapi.ReturnReason
+		if err := rows.Scan(&reason.Code, &reason.DisplayNames, &reason.Active, &reason.CreatedAt); err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, reason)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reasons, nil
+}
+
+func (r *returnRepository) InsertReturnReason(
+	ctx context.Context,
+	reason oapi.ReturnReason,
+) (oapi.ReturnReason, error) {
+	_, err := r.db.Exec(ctx, QueryInsertReturnReason, reason.Code, reason.DisplayNames, reason.Active, reason.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return oapi.ReturnReason{}, pvz_errors.ErrReturnReasonAlreadyExists
+		}
+		return oapi.ReturnReason{}, err
+	}
+	return reason, nil
+}
+
+func (r *returnRepository) InsertReturn(ctx context.Context, ret oapi.CustomerReturn) (oapi.CustomerReturn, error) {
+	if ret.ProductId != nil || ret.Barcode != nil {
+		var productID, orderID *uuid.UUID
+		var barcode *string
+		err := r.db.QueryRow(ctx, QueryFindIssuedProduct, ret.ProductId, ret.Barcode).Scan(&productID, &orderID, &barcode)
+		switch {
+		case errors.Is(err, r.db.ErrNoRows()):
+			if ret.ProductId != nil {
+				return oapi.CustomerReturn{}, pvz_errors.ErrReturnProductNotIssued
+			}
+		case err != nil:
+			return oapi.CustomerReturn{}, err
+		default:
+			ret.ProductId, ret.OrderId = productID, orderID
+			if ret.Barcode == nil {
+				ret.Barcode = barcode
+			}
+		}
+	}
+
+	cmdTag, err := r.db.Exec(ctx, QueryInsertReturn,
+		ret.Id, ret.PvzId, ret.ProductId, ret.OrderId, ret.Barcode,
+		ret.ReasonCode, ret.Comment, ret.ReceivedBy, ret.ReceivedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch {
+			case pgErr.Code == "23505" && pgErr.ConstraintName == "idx_customer_returns_product":
+				return oapi.CustomerReturn{}, pvz_errors.ErrProductAlreadyReturned
+			case pgErr.Code == "23503" && pgErr.ConstraintName == "fk_customer_returns_pvz":
+				return oapi.CustomerReturn{}, pvz_errors.ErrPVZNotFound
+			}
+		}
+		return oapi.CustomerReturn{}, err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return oapi.CustomerReturn{}, pvz_errors.ErrInvalidReturnReason
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) SelectReturns(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	status *oapi.CustomerReturnStatus,
+	limit, offset int,
+) ([]oapi.CustomerReturn, error) {
+	rows, err := r.db.Query(ctx, QuerySelectReturns, pvzID, stringFilter(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReturnsFailed, err)
+	}
+	return collectReturns(rows)
+}
+
+func collectReturns(rows pgx.Rows) ([]oapi.CustomerReturn, error) {
+	defer rows.Close()
+
+	returns := []oapi.CustomerReturn{}
+	for rows.Next() {
+		var ret oapi.CustomerReturn
+		var status string
+		if err := rows.Scan(
+			&ret.Id,
+			&ret.PvzId,
+			&ret.ProductId,
+			&ret.OrderId,
+			&ret.Barcode,
+			&ret.ReasonCode,
+			&ret.Comment,
+			&status,
+			&ret.ShipmentId,
+			&ret.ReceivedBy,
+			&ret.ReceivedAt,
+		); err != nil {
+			return nil, err
+		}
+		ret.Status = oapi.CustomerReturnStatus(status)
+		returns = append(returns, ret)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
+
+func stringFilter[T ~string](v *T) *string {
+	if v == nil {
+		return nil
+	}
+	s := string(*v)
+	return &s
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var returnShipmentTransitions = map[oapi.ReturnShipmentStatus]oapi.ReturnShipmentStatus{
+	oapi.Open:       oapi.Dispatched,
+	oapi.Dispatched: oapi.Delivered,
+}
+
+type returnShipmentRepository struct {
+	db database.PgxIface
+}
+
+func NewReturnShipmentRepository(dbConn database.PgxIface) *returnShipmentRepository {
+	return &returnShipmentRepository{db: dbConn}
+}
+
+func (r *returnShipmentRepository) InsertReturnShipment(
+	ctx context.Context,
+	shipment oapi.ReturnShipment,
+) (oapi.ReturnShipment, error) {
+	_, err := r.db.Exec(ctx, QueryInsertReturnShipment,
+		shipment.Id, shipment.PvzId, shipment.CreatedBy, shipment.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return oapi.ReturnShipment{}, pvz_errors.ErrPVZNotFound
+		}
+		return oapi.ReturnShipment{}, err
+	}
+	shipment.Returns = &[]oapi.CustomerReturn{}
+	return shipment, nil
+}
+
+func (r *returnShipmentRepository) SelectReturnShipments(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	status *oapi.ReturnShipmentStatus,
+	limit, offset int,
+) ([]oapi.ReturnShipment, error) {
+	rows, err := r.db.Query(ctx, QuerySelectReturnShipments, pvzID, stringFilter(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectReturnsFailed, err)
+	}
+	defer rows.Close()
+
+	shipments := []oapi.ReturnShipment{}
+	for rows.Next() {
+		shipment, err := scanReturnShipment(rows)
+		if err != nil {
+			return nil, err
+		}
+		shipments = append(shipments, shipment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+func (r *returnShipmentRepository) GetReturnShipment(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+) (oapi.ReturnShipment, error) {
+	return r.getReturnShipment(ctx, r.db, shipmentID)
+}
+
+func (r *returnShipmentRepository) AttachReturns(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	returnIDs []uuid.UUID,
+) (oapi.ReturnShipment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var pvzID uuid.UUID
+	var status string
+	if err = tx.QueryRow(ctx, QueryLockReturnShipment, shipmentID).Scan(&pvzID, &status); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrReturnShipmentNotFound
+		}
+		return oapi.ReturnShipment{}, err
+	}
+	if oapi.ReturnShipmentStatus(status) != oapi.Open {
+		err = pvz_errors.ErrReturnShipmentNotOpen
+		return oapi.ReturnShipment{}, err
+	}
+
+	cmdTag, err := tx.Exec(ctx, QueryAttachShipmentReturns, shipmentID, returnIDs, pvzID)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	if cmdTag.RowsAffected() != int64(len(returnIDs)) {
+		err = pvz_errors.ErrInvalidShipmentReturns
+		return oapi.ReturnShipment{}, err
+	}
+
+	shipment, err := r.getReturnShipment(ctx, tx, shipmentID)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	return shipment, nil
+}
+
+func (r *returnShipmentRepository) UpdateReturnShipmentStatus(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	status oapi.ReturnShipmentStatus,
+	changedAt time.Time,
+) (oapi.ReturnShipment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var pvzID uuid.UUID
+	var current string
+	if err = tx.QueryRow(ctx, QueryLockReturnShipment, shipmentID).Scan(&pvzID, &current); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrReturnShipmentNotFound
+		}
+		return oapi.ReturnShipment{}, err
+	}
+	if next, ok := returnShipmentTransitions[oapi.ReturnShipmentStatus(current)]; !ok || next != status {
+		err = pvz_errors.ErrInvalidShipmentTransition
+		return oapi.ReturnShipment{}, err
+	}
+
+	if status == oapi.Dispatched {
+		var cmdTag pgconn.CommandTag
+		cmdTag, err = tx.Exec(ctx, QueryShipShipmentReturns, shipmentID)
+		if err != nil {
+			return oapi.ReturnShipment{}, err
+		}
+		if cmdTag.RowsAffected() == 0 {
+			err = pvz_errors.ErrEmptyReturnShipment
+			return oapi.ReturnShipment{}, err
+		}
+		if _, err = tx.Exec(ctx, QueryReleaseShipmentCells, shipmentID); err != nil {
+			return oapi.ReturnShipment{}, err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, QueryUpdateReturnShipmentStatus, shipmentID, string(status), changedAt); err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+
+	shipment, err := r.getReturnShipment(ctx, tx, shipmentID)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	return shipment, nil
+}
+
+func (r *returnShipmentRepository) getReturnShipment(
+	ctx context.Context,
+	q querier,
+	shipmentID uuid.UUID,
+) (oapi.ReturnShipment, error) {
+	shipment, err := scanReturnShipment(q.QueryRow(ctx, QueryGetReturnShipment, shipmentID))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.ReturnShipment{}, pvz_errors.ErrReturnShipmentNotFound
+		}
+		return oapi.ReturnShipment{}, err
+	}
+
+	rows, err := q.Query(ctx, QuerySelectShipmentReturns, shipmentID)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	returns, err := collectReturns(rows)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	shipment.Returns = &returns
+	return shipment, nil
+}
+
+func scanReturnShipment(row pgx.Row) (oapi.ReturnShipment, error) {
+	var shipment oapi.ReturnShipment
+	var status string
+	err := row.Scan(
+		&shipment.Id,
+		&shipment.PvzId,
+		&status,
+		&shipment.CreatedBy,
+		&shipment.CreatedAt,
+		&shipment.DispatchedAt,
+		&shipment.DeliveredAt,
+	)
+	if err != nil {
+		return oapi.ReturnShipment{}, err
+	}
+	shipment.Status = oapi.ReturnShipmentStatus(status)
+	return shipment, nil
+}
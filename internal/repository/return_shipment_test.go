@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var returnShipmentColumns = []string{
+	"id", "pvz_id", "status", "created_by", "created_at", "dispatched_at", "delivered_at",
+}
+
+func TestAttachReturns(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewReturnShipmentRepository(db)
+	ctx := context.Background()
+	shipmentID, pvzID := uuid.New(), uuid.New()
+	returnIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	expectLock := func(status string) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockReturnShipment).
+			WithArgs(shipmentID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "status"}).AddRow(pvzID, status))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectLock("open")
+		mockPool.
+			ExpectExec(QueryAttachShipmentReturns).
+			WithArgs(shipmentID, returnIDs, pvzID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		mockPool.
+			ExpectQuery(QueryGetReturnShipment).
+			WithArgs(shipmentID).
+			WillReturnRows(pgxmock.NewRows(returnShipmentColumns).
+				AddRow(shipmentID, pvzID, "open", nil, time.Now(), nil, nil))
+		mockPool.
+			ExpectQuery(QuerySelectShipmentReturns).
+			WithArgs(shipmentID).
+			WillReturnRows(pgxmock.NewRows(returnColumns).
+				AddRow(returnIDs[0], pvzID, nil, nil, nil, "defect", nil, "in_shipment",
+					uuidPtr(shipmentID), nil, time.Now()).
+				AddRow(returnIDs[1], pvzID, nil, nil, nil, "not_fit", nil, "in_shipment",
+					uuidPtr(shipmentID), nil, time.Now()))
+		mockPool.ExpectCommit()
+
+		got, err := repo.AttachReturns(ctx, shipmentID, returnIDs)
+		require.NoError(t, err)
+		require.Len(t, *got.Returns, 2)
+		require.Equal(t, oapi.InShipment, (*got.Returns)[0].Status)
+	})
+
+	t.Run("shipment already dispatched", func(t *testing.T) {
+		expectLock("dispatched")
+		mockPool.ExpectRollback()
+
+		_, err := repo.AttachReturns(ctx, shipmentID, returnIDs)
+		require.ErrorIs(t, err, pvz_errors.ErrReturnShipmentNotOpen)
+	})
+
+	t.Run("return from another pvz", func(t *testing.T) {
+		expectLock("open")
+		mockPool.
+			ExpectExec(QueryAttachShipmentReturns).
+			WithArgs(shipmentID, returnIDs, pvzID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectRollback()
+
+		_, err := repo.AttachReturns(ctx, shipmentID, returnIDs)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidShipmentReturns)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockReturnShipment).
+			WithArgs(shipmentID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.AttachReturns(ctx, shipmentID, returnIDs)
+		require.ErrorIs(t, err, pvz_errors.ErrReturnShipmentNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestUpdateReturnShipmentStatus(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewReturnShipmentRepository(db)
+	ctx := context.Background()
+	shipmentID, pvzID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	expectLock := func(status string) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockReturnShipment).
+			WithArgs(shipmentID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "status"}).AddRow(pvzID, status))
+	}
+
+	t.Run("dispatch", func(t *testing.T) {
+		expectLock("open")
+		mockPool.
+			ExpectExec(QueryShipShipmentReturns).
+			WithArgs(shipmentID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+		mockPool.
+			ExpectExec(QueryReleaseShipmentCells).
+			WithArgs(shipmentID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryUpdateReturnShipmentStatus).
+			WithArgs(shipmentID, "dispatched", now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectQuery(QueryGetReturnShipment).
+			WithArgs(shipmentID).
+			WillReturnRows(pgxmock.NewRows(returnShipmentColumns).
+				AddRow(shipmentID, pvzID, "dispatched", nil, now, &now, nil))
+		mockPool.
+			ExpectQuery(QuerySelectShipmentReturns).
+			WithArgs(shipmentID).
+			WillReturnRows(pgxmock.NewRows(returnColumns))
+		mockPool.ExpectCommit()
+
+		got, err := repo.UpdateReturnShipmentStatus(ctx, shipmentID, oapi.Dispatched, now)
+		require.NoError(t, err)
+		require.Equal(t, oapi.Dispatched, got.Status)
+		require.NotNil(t, got.DispatchedAt)
+	})
+
+	t.Run("empty shipment", func(t *testing.T) {
+		expectLock("open")
+		mockPool.
+			ExpectExec(QueryShipShipmentReturns).
+			WithArgs(shipmentID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpdateReturnShipmentStatus(ctx, shipmentID, oapi.Dispatched, now)
+		require.ErrorIs(t, err, pvz_errors.ErrEmptyReturnShipment)
+	})
+
+	t.Run("skipping dispatch", func(t *testing.T) {
+		expectLock("open")
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpdateReturnShipmentStatus(ctx, shipmentID, oapi.Delivered, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidShipmentTransition)
+	})
+
+	t.Run("already delivered", func(t *testing.T) {
+		expectLock("delivered")
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpdateReturnShipmentStatus(ctx, shipmentID, oapi.Delivered, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidShipmentTransition)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
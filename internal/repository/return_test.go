@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var returnColumns = []string{
+	"id", "pvz_id", "product_id", "order_id", "barcode", "reason_code", "comment",
+	"status", "shipment_id", "received_by", "received_at",
+}
+
+func TestInsertReturn(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewReturnRepository(db)
+	ctx := context.Background()
+	productID, orderID := uuid.New(), uuid.New()
+
+	newReturn := func() oapi.CustomerReturn {
+		return oapi.CustomerReturn{
+			Id:         uuid.New(),
+			PvzId:      uuid.New(),
+			ReasonCode: "defect",
+			Status:     oapi.Accepted,
+			ReceivedAt: time.Now(),
+		}
+	}
+	expectInsert := func(ret oapi.CustomerReturn, product, order *uuid.UUID, barcode *string) *pgxmock.ExpectedExec {
+		return mockPool.
+			ExpectExec(QueryInsertReturn).
+			WithArgs(ret.Id, ret.PvzId, product, order, barcode, "defect", ret.Comment, ret.ReceivedBy, ret.ReceivedAt)
+	}
+
+	t.Run("links product and order by barcode", func(t *testing.T) {
+		ret := newReturn()
+		ret.Barcode = strPtr("4600000000001")
+		mockPool.
+			ExpectQuery(QueryFindIssuedProduct).
+			WithArgs(ret.ProductId, ret.Barcode).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "barcode"}).
+				AddRow(uuidPtr(productID), uuidPtr(orderID), strPtr("4600000000001")))
+		expectInsert(ret, &productID, &orderID, ret.Barcode).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		got, err := repo.InsertReturn(ctx, ret)
+		require.NoError(t, err)
+		require.Equal(t, &productID, got.ProductId)
+		require.Equal(t, &orderID, got.OrderId)
+	})
+
+	t.Run("unknown barcode is accepted unlinked", func(t *testing.T) {
+		ret := newReturn()
+		ret.Barcode = strPtr("unknown")
+		mockPool.
+			ExpectQuery(QueryFindIssuedProduct).
+			WithArgs(ret.ProductId, ret.Barcode).
+			WillReturnError(db.ErrNoRows())
+		expectInsert(ret, nil, nil, ret.Barcode).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		got, err := repo.InsertReturn(ctx, ret)
+		require.NoError(t, err)
+		require.Nil(t, got.ProductId)
+	})
+
+	t.Run("product was not issued", func(t *testing.T) {
+		ret := newReturn()
+		ret.ProductId = &productID
+		mockPool.
+			ExpectQuery(QueryFindIssuedProduct).
+			WithArgs(ret.ProductId, ret.Barcode).
+			WillReturnError(db.ErrNoRows())
+
+		_, err := repo.InsertReturn(ctx, ret)
+		require.ErrorIs(t, err, pvz_errors.ErrReturnProductNotIssued)
+	})
+
+	t.Run("inactive reason", func(t *testing.T) {
+		ret := newReturn()
+		ret.Barcode = strPtr("unknown")
+		mockPool.
+			ExpectQuery(QueryFindIssuedProduct).
+			WithArgs(ret.ProductId, ret.Barcode).
+			WillReturnError(db.ErrNoRows())
+		expectInsert(ret, nil, nil, ret.Barcode).WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+		_, err := repo.InsertReturn(ctx, ret)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidReturnReason)
+	})
+
+	t.Run("already returned", func(t *testing.T) {
+		ret := newReturn()
+		ret.ProductId = &productID
+		mockPool.
+			ExpectQuery(QueryFindIssuedProduct).
+			WithArgs(ret.ProductId, ret.Barcode).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "order_id", "barcode"}).
+				AddRow(uuidPtr(productID), nil, nil))
+		expectInsert(ret, &productID, nil, nil).
+			WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_customer_returns_product"})
+
+		_, err := repo.InsertReturn(ctx, ret)
+		require.ErrorIs(t, err, pvz_errors.ErrProductAlreadyReturned)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectReturns(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewReturnRepository(db)
+	pvzID := uuid.New()
+	status := oapi.Accepted
+
+	mockPool.
+		ExpectQuery(QuerySelectReturns).
+		WithArgs(&pvzID, strPtr("accepted"), 30, 0).
+		WillReturnRows(pgxmock.NewRows(returnColumns).
+			AddRow(uuid.New(), pvzID, nil, nil, strPtr("B1"), "defect", nil, "accepted", nil, nil, time.Now()))
+
+	got, err := repo.SelectReturns(context.Background(), &pvzID, &status, 30, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, oapi.Accepted, got[0].Status)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
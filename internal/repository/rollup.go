@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+)
+
+type rollupRepository struct {
+	db database.PgxIface
+}
+
+func NewRollupRepository(dbConn database.PgxIface) *rollupRepository {
+	return &rollupRepository{db: dbConn}
+}
+
+func (r *rollupRepository) SelectStaleRollupDays(
+	ctx context.Context,
+	from, to time.Time,
+	limit int,
+) ([]time.Time, error) {
+	rows, err := r.db.Query(ctx, QuerySelectStaleRollupDays, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrRefreshRollupsFailed, err)
+	}
+	defer rows.Close()
+
+	days := []time.Time{}
+	for rows.Next() {
+		var day time.Time
+		if err = rows.Scan(&day); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// RefreshRollupDay recomputes the rollups of a single day. The day row is
+// locked first, so a correction committed while the day is recomputed marks
+// it dirty again instead of being lost.
+func (r *rollupRepository) RefreshRollupDay(ctx context.Context, day, computedAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, QueryLockRollupDay, day, computedAt); err != nil {
+		err = fmt.Errorf("%w: %w", pvz_errors.ErrRefreshRollupsFailed, err)
+		return err
+	}
+	if _, err = tx.Exec(ctx, QueryDeleteProductRollups, day); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, QueryDeletePVZRollups, day); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, QueryInsertProductRollups, day); err != nil {
+		err = fmt.Errorf("%w: %w", pvz_errors.ErrRefreshRollupsFailed, err)
+		return err
+	}
+	if _, err = tx.Exec(ctx, QueryInsertPVZRollups, day, computedAt); err != nil {
+		err = fmt.Errorf("%w: %w", pvz_errors.ErrRefreshRollupsFailed, err)
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+)
+
+func TestRollupRepository(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewRollupRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	now := day.AddDate(0, 0, 2)
+
+	t.Run("stale days", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectStaleRollupDays).
+			WithArgs(day.AddDate(0, 0, -7), day, 31).
+			WillReturnRows(pgxmock.NewRows([]string{"day"}).AddRow(day).AddRow(day.AddDate(0, 0, -1)))
+
+		days, err := repo.SelectStaleRollupDays(ctx, day.AddDate(0, 0, -7), day, 31)
+		require.NoError(t, err)
+		require.Equal(t, []time.Time{day, day.AddDate(0, 0, -1)}, days)
+	})
+
+	t.Run("refresh day", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.ExpectExec(QueryLockRollupDay).WithArgs(day, now).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.ExpectExec(QueryDeleteProductRollups).WithArgs(day).
+			WillReturnResult(pgxmock.NewResult("DELETE", 4))
+		mockPool.ExpectExec(QueryDeletePVZRollups).WithArgs(day).
+			WillReturnResult(pgxmock.NewResult("DELETE", 2))
+		mockPool.ExpectExec(QueryInsertProductRollups).WithArgs(day).
+			WillReturnResult(pgxmock.NewResult("INSERT", 5))
+		mockPool.ExpectExec(QueryInsertPVZRollups).WithArgs(day, now).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+		mockPool.ExpectCommit()
+
+		require.NoError(t, repo.RefreshRollupDay(ctx, day, now))
+	})
+
+	t.Run("refresh failure rolls back", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.ExpectExec(QueryLockRollupDay).WithArgs(day, now).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.ExpectExec(QueryDeleteProductRollups).WithArgs(day).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mockPool.ExpectExec(QueryDeletePVZRollups).WithArgs(day).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mockPool.ExpectExec(QueryInsertProductRollups).WithArgs(day).
+			WillReturnError(errors.New("timeout"))
+		mockPool.ExpectRollback()
+
+		err := repo.RefreshRollupDay(ctx, day, now)
+		require.ErrorIs(t, err, pvz_errors.ErrRefreshRollupsFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type storageCellRepository struct {
+	db database.PgxIface
+}
+
+func NewStorageCellRepository(dbConn database.PgxIface) *storageCellRepository {
+	return &storageCellRepository{db: dbConn}
+}
+
+func (r *storageCellRepository) UpsertStorageCells(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	cells []oapi.StorageCell,
+	createdAt time.Time,
+) ([]oapi.StorageCell, error) {
+	ids := make([]uuid.UUID, 0, len(cells))
+	zones := make([]string, 0, len(cells))
+	racks := make([]string, 0, len(cells))
+	shelves := make([]string, 0, len(cells))
+	capacities := make([]int, 0, len(cells))
+	actives := make([]bool, 0, len(cells))
+	for _, cell := range cells {
+		ids = append(ids, cell.Id)
+		zones = append(zones, cell.Zone)
+		racks = append(racks, cell.Rack)
+		shelves = append(shelves, cell.Shelf)
+		capacities = append(capacities, cell.Capacity)
+		actives = append(actives, cell.Active)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, QueryUpsertStorageCells,
+		pvzID, ids, zones, racks, shelves, capacities, actives, createdAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_storage_cells_pvz" {
+			err = pvz_errors.ErrPVZNotFound
+		}
+		return nil, err
+	}
+
+	layout, err := selectStorageCells(ctx, tx, pvzID)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+func (r *storageCellRepository) SelectStorageCells(ctx context.Context, pvzID uuid.UUID) ([]oapi.StorageCell, error) {
+	return selectStorageCells(ctx, r.db, pvzID)
+}
+
+func (r *storageCellRepository) MoveProductToCell(
+	ctx context.Context,
+	productID, cellID uuid.UUID,
+) (oapi.Product, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Product{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var pvzID uuid.UUID
+	var status string
+	var currentCell *uuid.UUID
+	err = tx.QueryRow(ctx, QueryLockProductLocation, productID).Scan(&pvzID, &status, &currentCell)
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrProductNotFound
+		}
+		return oapi.Product{}, err
+	}
+	switch oapi.ProductStatus(status) {
+	case oapi.Issued, oapi.InTransit, oapi.Lost:
+		err = pvz_errors.ErrProductNotStorable
+		return oapi.Product{}, err
+	}
+	if currentCell == nil || *currentCell != cellID {
+		if err = ensureCellAvailable(ctx, tx, pvzID, cellID); err != nil {
+			return oapi.Product{}, err
+		}
+	}
+
+	product, err := scanProduct(tx.QueryRow(ctx, QueryMoveProductToCell, productID, cellID))
+	if err != nil {
+		return oapi.Product{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Product{}, err
+	}
+	return product, nil
+}
+
+func (r *storageCellRepository) SelectProductLocations(
+	ctx context.Context,
+	barcode *string,
+	orderID, pvzID *uuid.UUID,
+) ([]oapi.ProductLocation, error) {
+	rows, err := r.db.Query(ctx, QuerySelectProductLocations, barcode, orderID, pvzID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectCellsFailed, err)
+	}
+	defer rows.Close()
+
+	locations := []oapi.ProductLocation{}
+	for rows.Next() {
+		var location oapi.ProductLocation
+		var status string
+		err = rows.Scan(
+			&location.ProductId,
+			&location.Barcode,
+			&status,
+			&location.CellId,
+			&location.Zone,
+			&location.Rack,
+			&location.Shelf,
+		)
+		if err != nil {
+			return nil, err
+		}
+		location.Status = oapi.ProductStatus(status)
+		locations = append(locations, location)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+func selectStorageCells(ctx context.Context, q querier, pvzID uuid.UUID) ([]oapi.StorageCell, error) {
+	rows, err := q.Query(ctx, QuerySelectStorageCells, pvzID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectCellsFailed, err)
+	}
+	defer rows.Close()
+
+	cells := []oapi.StorageCell{}
+	for rows.Next() {
+		var cell oapi.StorageCell
+		err = rows.Scan(
+			&cell.Id,
+			&cell.PvzId,
+			&cell.Zone,
+			&cell.Rack,
+			&cell.Shelf,
+			&cell.Capacity,
+			&cell.Active,
+			&cell.Occupied,
+		)
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells, cell)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return cells, nil
+}
+
+func ensureCellAvailable(ctx context.Context, tx pgx.Tx, pvzID, cellID uuid.UUID) error {
+	var capacity, occupied int
+	if err := tx.QueryRow(ctx, QueryLockStorageCell, cellID, pvzID).Scan(&capacity, &occupied); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pvz_errors.ErrCellNotFound
+		}
+		return err
+	}
+	if occupied >= capacity {
+		return pvz_errors.ErrCellFull
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var storageCellColumns = []string{"id", "pvz_id", "zone", "rack", "shelf", "capacity", "active", "occupied"}
+
+func TestUpsertStorageCells(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewStorageCellRepository(db)
+	ctx := context.Background()
+	pvzID := uuid.New()
+	now := time.Now()
+	cell := oapi.StorageCell{Id: uuid.New(), PvzId: pvzID, Zone: "A", Rack: "1", Shelf: "2", Capacity: 10, Active: true}
+
+	expectUpsert := func() *pgxmock.ExpectedExec {
+		mockPool.ExpectBegin()
+		return mockPool.
+			ExpectExec(QueryUpsertStorageCells).
+			WithArgs(pvzID, []uuid.UUID{cell.Id}, []string{"A"}, []string{"1"}, []string{"2"},
+				[]int{10}, []bool{true}, now)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectUpsert().WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.
+			ExpectQuery(QuerySelectStorageCells).
+			WithArgs(pvzID).
+			WillReturnRows(pgxmock.NewRows(storageCellColumns).
+				AddRow(cell.Id, pvzID, "A", "1", "2", 10, true, 3))
+		mockPool.ExpectCommit()
+
+		got, err := repo.UpsertStorageCells(ctx, pvzID, []oapi.StorageCell{cell}, now)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, 3, got[0].Occupied)
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		expectUpsert().WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_storage_cells_pvz"})
+		mockPool.ExpectRollback()
+
+		_, err := repo.UpsertStorageCells(ctx, pvzID, []oapi.StorageCell{cell}, now)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestMoveProductToCell(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewStorageCellRepository(db)
+	ctx := context.Background()
+	productID, cellID, pvzID := uuid.New(), uuid.New(), uuid.New()
+
+	expectLock := func(status string, currentCell *uuid.UUID) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockProductLocation).
+			WithArgs(productID).
+			WillReturnRows(pgxmock.NewRows([]string{"pvz_id", "status", "cell_id"}).
+				AddRow(pvzID, status, currentCell))
+	}
+	expectCell := func(capacity, occupied int) {
+		mockPool.
+			ExpectQuery(QueryLockStorageCell).
+			WithArgs(cellID, pvzID).
+			WillReturnRows(pgxmock.NewRows([]string{"capacity", "occupied"}).AddRow(capacity, occupied))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectLock("received", nil)
+		expectCell(5, 4)
+		mockPool.
+			ExpectQuery(QueryMoveProductToCell).
+			WithArgs(productID, cellID).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(productID, uuid.New(), time.Now(), "обувь", nil, nil, nil, "received", &cellID))
+		mockPool.ExpectCommit()
+
+		got, err := repo.MoveProductToCell(ctx, productID, cellID)
+		require.NoError(t, err)
+		require.Equal(t, cellID, *got.CellId)
+	})
+
+	t.Run("same cell skips capacity check", func(t *testing.T) {
+		expectLock("ready", &cellID)
+		mockPool.
+			ExpectQuery(QueryMoveProductToCell).
+			WithArgs(productID, cellID).
+			WillReturnRows(pgxmock.NewRows(productColumns).
+				AddRow(productID, uuid.New(), time.Now(), "обувь", nil, nil, nil, "ready", &cellID))
+		mockPool.ExpectCommit()
+
+		_, err := repo.MoveProductToCell(ctx, productID, cellID)
+		require.NoError(t, err)
+	})
+
+	t.Run("cell full", func(t *testing.T) {
+		expectLock("received", nil)
+		expectCell(5, 5)
+		mockPool.ExpectRollback()
+
+		_, err := repo.MoveProductToCell(ctx, productID, cellID)
+		require.ErrorIs(t, err, pvz_errors.ErrCellFull)
+	})
+
+	t.Run("cell of another pvz", func(t *testing.T) {
+		expectLock("received", nil)
+		mockPool.
+			ExpectQuery(QueryLockStorageCell).
+			WithArgs(cellID, pvzID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.MoveProductToCell(ctx, productID, cellID)
+		require.ErrorIs(t, err, pvz_errors.ErrCellNotFound)
+	})
+
+	t.Run("issued product", func(t *testing.T) {
+		expectLock("issued", nil)
+		mockPool.ExpectRollback()
+
+		_, err := repo.MoveProductToCell(ctx, productID, cellID)
+		require.ErrorIs(t, err, pvz_errors.ErrProductNotStorable)
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockProductLocation).
+			WithArgs(productID).
+			WillReturnError(db.ErrNoRows())
+		mockPool.ExpectRollback()
+
+		_, err := repo.MoveProductToCell(ctx, productID, cellID)
+		require.ErrorIs(t, err, pvz_errors.ErrProductNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectProductLocations(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewStorageCellRepository(db)
+	ctx := context.Background()
+	orderID := uuid.New()
+
+	t.Run("by order", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectProductLocations).
+			WithArgs((*string)(nil), &orderID, (*uuid.UUID)(nil)).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "barcode", "status", "cell_id", "zone", "rack", "shelf",
+			}).
+				AddRow(uuid.New(), strPtr("4601234567890"), "ready", uuidPtr(uuid.New()),
+					strPtr("A"), strPtr("1"), strPtr("2")).
+				AddRow(uuid.New(), nil, "ready", nil, nil, nil, nil))
+
+		got, err := repo.SelectProductLocations(ctx, nil, &orderID, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, "A", *got[0].Zone)
+		require.Nil(t, got[1].CellId)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockPool.
+			ExpectQuery(QuerySelectProductLocations).
+			WithArgs((*string)(nil), &orderID, (*uuid.UUID)(nil)).
+			WillReturnError(errors.New("db down"))
+
+		_, err := repo.SelectProductLocations(ctx, nil, &orderID, nil)
+		require.ErrorIs(t, err, pvz_errors.ErrSelectCellsFailed)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
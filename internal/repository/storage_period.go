@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type storagePeriodRepository struct {
+	db database.PgxIface
+}
+
+func NewStoragePeriodRepository(dbConn database.PgxIface) *storagePeriodRepository {
+	return &storagePeriodRepository{db: dbConn}
+}
+
+func (r *storagePeriodRepository) SelectStoragePeriods(ctx context.Context) ([]oapi.StoragePeriod, error) {
+	rows, err := r.db.Query(ctx, QuerySelectStoragePeriods)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []oapi.StoragePeriod{}
+	for rows.Next() {
+		var period oapi.StoragePeriod
+		var updatedAt time.Time
+		if err = rows.Scan(&period.PvzId, &period.ProductType, &period.Days, &period.UpdatedBy, &updatedAt); err != nil {
+			return nil, err
+		}
+		period.UpdatedAt = &updatedAt
+		periods = append(periods, period)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (r *storagePeriodRepository) UpsertStoragePeriod(
+	ctx context.Context,
+	period oapi.StoragePeriod,
+) (oapi.StoragePeriod, error) {
+	_, err := r.db.Exec(ctx, QueryUpsertStoragePeriod,
+		period.PvzId, period.ProductType, period.Days, period.UpdatedBy, period.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_storage_periods_pvz" {
+			return oapi.StoragePeriod{}, pvz_errors.ErrPVZNotFound
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "fk_storage_periods_type" {
+			return oapi.StoragePeriod{}, pvz_errors.ErrProductTypeNotFound
+		}
+		return oapi.StoragePeriod{}, err
+	}
+	return period, nil
+}
+
+func (r *storagePeriodRepository) FlagOverdueProducts(
+	ctx context.Context,
+	now time.Time,
+	defaultDays, limit int,
+) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, QuerySelectOverdueProducts, now, defaultDays, limit)
+	if err != nil {
+		return 0, err
+	}
+	var returnIDs, pvzIDs, productIDs []uuid.UUID
+	var orderIDs []*uuid.UUID
+	var barcodes []*string
+	for rows.Next() {
+		var productID, pvzID uuid.UUID
+		var orderID *uuid.UUID
+		var barcode *string
+		if err = rows.Scan(&productID, &pvzID, &orderID, &barcode); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		returnIDs = append(returnIDs, uuid.New())
+		pvzIDs = append(pvzIDs, pvzID)
+		productIDs = append(productIDs, productID)
+		orderIDs = append(orderIDs, orderID)
+		barcodes = append(barcodes, barcode)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(productIDs) == 0 {
+		err = tx.Commit(ctx)
+		return 0, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryMarkProductsUnclaimed, productIDs); err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec(ctx, QueryInsertUnclaimedReturns, returnIDs, pvzIDs, productIDs, orderIDs, barcodes, now)
+	if err != nil {
+		return 0, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(productIDs), nil
+}
+
+func (r *storagePeriodRepository) SelectExpiringProducts(
+	ctx context.Context,
+	now time.Time,
+	defaultDays int,
+	remindBefore time.Time,
+	limit int,
+) ([]dto.StorageReminder, error) {
+	rows, err := r.db.Query(ctx, QuerySelectExpiringProducts, now, defaultDays, remindBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []dto.StorageReminder{}
+	for rows.Next() {
+		var reminder dto.StorageReminder
+		err = rows.Scan(
+			&reminder.ProductID,
+			&reminder.OrderID,
+			&reminder.CustomerRef,
+			&reminder.PvzID,
+			&reminder.Barcode,
+			&reminder.Deadline,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *storagePeriodRepository) MarkStorageReminded(
+	ctx context.Context,
+	productIDs []uuid.UUID,
+	remindedAt time.Time,
+) error {
+	_, err := r.db.Exec(ctx, QueryMarkStorageReminded, productIDs, remindedAt)
+	return err
+}
+
+func (r *storagePeriodRepository) SelectUnclaimedItems(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	limit, offset int,
+) ([]oapi.UnclaimedItem, error) {
+	rows, err := r.db.Query(ctx, QuerySelectUnclaimedItems, pvzID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectUnclaimedFailed, err)
+	}
+	defer rows.Close()
+
+	items := []oapi.UnclaimedItem{}
+	for rows.Next() {
+		var item oapi.UnclaimedItem
+		err = rows.Scan(
+			&item.ReturnId,
+			&item.ProductId,
+			&item.PvzId,
+			&item.OrderId,
+			&item.Barcode,
+			&item.CellId,
+			&item.Zone,
+			&item.Rack,
+			&item.Shelf,
+			&item.FlaggedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
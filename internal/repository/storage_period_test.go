@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func TestUpsertStoragePeriod(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewStoragePeriodRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	pvzID := uuid.New()
+	now := time.Now()
+	productType := "электроника"
+	period := oapi.StoragePeriod{PvzId: &pvzID, ProductType: &productType, Days: 7, UpdatedAt: &now}
+
+	t.Run("success", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryUpsertStoragePeriod).
+			WithArgs(&pvzID, &productType, 7, period.UpdatedBy, &now).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		got, err := repo.UpsertStoragePeriod(ctx, period)
+		require.NoError(t, err)
+		require.Equal(t, period, got)
+	})
+
+	t.Run("pvz not found", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryUpsertStoragePeriod).
+			WithArgs(&pvzID, &productType, 7, period.UpdatedBy, &now).
+			WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_storage_periods_pvz"})
+
+		_, err := repo.UpsertStoragePeriod(ctx, period)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	t.Run("type not found", func(t *testing.T) {
+		mockPool.
+			ExpectExec(QueryUpsertStoragePeriod).
+			WithArgs(&pvzID, &productType, 7, period.UpdatedBy, &now).
+			WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_storage_periods_type"})
+
+		_, err := repo.UpsertStoragePeriod(ctx, period)
+		require.ErrorIs(t, err, pvz_errors.ErrProductTypeNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestFlagOverdueProducts(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewStoragePeriodRepository(&database.PgxMockAdapter{Pool: mockPool})
+	ctx := context.Background()
+	now := time.Now()
+	columns := []string{"id", "pvz_id", "order_id", "barcode"}
+
+	t.Run("flags overdue products", func(t *testing.T) {
+		productID, pvzID, orderID := uuid.New(), uuid.New(), uuid.New()
+		barcode := "4600000000017"
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QuerySelectOverdueProducts).
+			WithArgs(now, 14, 500).
+			WillReturnRows(pgxmock.NewRows(columns).AddRow(productID, pvzID, &orderID, &barcode))
+		mockPool.
+			ExpectExec(QueryMarkProductsUnclaimed).
+			WithArgs([]uuid.UUID{productID}).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryInsertUnclaimedReturns).
+			WithArgs(pgxmock.AnyArg(), []uuid.UUID{pvzID}, []uuid.UUID{productID},
+				[]*uuid.UUID{&orderID}, []*string{&barcode}, now).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.ExpectCommit()
+
+		n, err := repo.FlagOverdueProducts(ctx, now, 14, 500)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	})
+
+	t.Run("nothing overdue", func(t *testing.T) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QuerySelectOverdueProducts).
+			WithArgs(now, 14, 500).
+			WillReturnRows(pgxmock.NewRows(columns))
+		mockPool.ExpectCommit()
+
+		n, err := repo.FlagOverdueProducts(ctx, now, 14, 500)
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectUnclaimedItems(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	repo := NewStoragePeriodRepository(&database.PgxMockAdapter{Pool: mockPool})
+	pvzID := uuid.New()
+	now := time.Now()
+	zone, rack, shelf := "A", "1", "2"
+	cellID := uuid.New()
+	mockPool.
+		ExpectQuery(QuerySelectUnclaimedItems).
+		WithArgs(&pvzID, 30, 0).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "product_id", "pvz_id", "order_id", "barcode", "cell_id", "zone", "rack", "shelf", "received_at",
+		}).AddRow(uuid.New(), uuid.New(), pvzID, nil, nil, &cellID, &zone, &rack, &shelf, now))
+
+	items, err := repo.SelectUnclaimedItems(context.Background(), &pvzID, 30, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "A", *items[0].Zone)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type transferRepository struct {
+	db database.PgxIface
+}
+
+func NewTransferRepository(dbConn database.PgxIface) *transferRepository {
+	return &transferRepository{db: dbConn}
+}
+
+func (r *transferRepository) InsertTransfer(
+	ctx context.Context,
+	transfer oapi.Transfer,
+	productIDs []uuid.UUID,
+) (oapi.Transfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	_, err = tx.Exec(ctx, QueryInsertTransfer,
+		transfer.Id, transfer.SourcePvzId, transfer.DestinationPvzId,
+		transfer.CreatedBy, transfer.CreatedAt, transfer.ExpectedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			err = pvz_errors.ErrPVZNotFound
+		}
+		return oapi.Transfer{}, err
+	}
+
+	cmdTag, err := tx.Exec(ctx, QueryDispatchTransferProducts, transfer.Id, productIDs, transfer.SourcePvzId)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	if cmdTag.RowsAffected() != int64(len(productIDs)) {
+		err = pvz_errors.ErrInvalidTransferProducts
+		return oapi.Transfer{}, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryInsertTransferItems, transfer.Id, productIDs); err != nil {
+		return oapi.Transfer{}, err
+	}
+
+	created, err := r.getTransfer(ctx, tx, transfer.Id)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Transfer{}, err
+	}
+	return created, nil
+}
+
+func (r *transferRepository) GetTransfer(ctx context.Context, transferID uuid.UUID) (oapi.Transfer, error) {
+	return r.getTransfer(ctx, r.db, transferID)
+}
+
+func (r *transferRepository) ReceiveTransferItems(
+	ctx context.Context,
+	transferID uuid.UUID,
+	productIDs []uuid.UUID,
+	receivedBy *uuid.UUID,
+	receivedAt time.Time,
+) (oapi.Transfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var destinationPVZ uuid.UUID
+	var status string
+	if err = tx.QueryRow(ctx, QueryLockTransfer, transferID).Scan(&destinationPVZ, &status); err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			err = pvz_errors.ErrTransferNotFound
+		}
+		return oapi.Transfer{}, err
+	}
+	if oapi.TransferStatus(status) == oapi.FullyReceived {
+		err = pvz_errors.ErrTransferAlreadyReceived
+		return oapi.Transfer{}, err
+	}
+
+	if err = lockPVZBarcodes(ctx, tx, destinationPVZ); err != nil {
+		return oapi.Transfer{}, err
+	}
+	var conflict int
+	err = tx.QueryRow(ctx, QueryFindTransferBarcodeConflict, destinationPVZ, productIDs).Scan(&conflict)
+	switch {
+	case err == nil:
+		err = pvz_errors.ErrBarcodeAlreadyAtPVZ
+		return oapi.Transfer{}, err
+	case !errors.Is(err, r.db.ErrNoRows()):
+		return oapi.Transfer{}, err
+	}
+
+	cmdTag, err := tx.Exec(ctx, QueryReceiveTransferItems,
+		transferID, productIDs, receivedAt, receivedBy, destinationPVZ)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	if cmdTag.RowsAffected() != int64(len(productIDs)) {
+		err = pvz_errors.ErrInvalidTransferProducts
+		return oapi.Transfer{}, err
+	}
+
+	if _, err = tx.Exec(ctx, QueryFinishTransferReceipt, transferID, receivedAt); err != nil {
+		return oapi.Transfer{}, err
+	}
+
+	transfer, err := r.getTransfer(ctx, tx, transferID)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return oapi.Transfer{}, err
+	}
+	return transfer, nil
+}
+
+func (r *transferRepository) SelectOverdueTransfers(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	now time.Time,
+	limit, offset int,
+) ([]oapi.Transfer, error) {
+	rows, err := r.db.Query(ctx, QuerySelectOverdueTransfers, pvzID, now, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectTransfersFailed, err)
+	}
+	defer rows.Close()
+
+	transfers := []oapi.Transfer{}
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func (r *transferRepository) getTransfer(ctx context.Context, q querier, transferID uuid.UUID) (oapi.Transfer, error) {
+	transfer, err := scanTransfer(q.QueryRow(ctx, QueryGetTransfer, transferID))
+	if err != nil {
+		if errors.Is(err, r.db.ErrNoRows()) {
+			return oapi.Transfer{}, pvz_errors.ErrTransferNotFound
+		}
+		return oapi.Transfer{}, err
+	}
+
+	rows, err := q.Query(ctx, QueryGetTransferItems, transferID)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	defer rows.Close()
+
+	items := []oapi.TransferItem{}
+	for rows.Next() {
+		var item oapi.TransferItem
+		if err = rows.Scan(&item.ProductId, &item.ReceivedAt, &item.ReceivedBy); err != nil {
+			return oapi.Transfer{}, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return oapi.Transfer{}, err
+	}
+	transfer.Items = &items
+	return transfer, nil
+}
+
+func scanTransfer(row pgx.Row) (oapi.Transfer, error) {
+	var transfer oapi.Transfer
+	var status string
+	err := row.Scan(
+		&transfer.Id,
+		&transfer.SourcePvzId,
+		&transfer.DestinationPvzId,
+		&status,
+		&transfer.CreatedBy,
+		&transfer.CreatedAt,
+		&transfer.ExpectedAt,
+		&transfer.CompletedAt,
+	)
+	if err != nil {
+		return oapi.Transfer{}, err
+	}
+	transfer.Status = oapi.TransferStatus(status)
+	return transfer, nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/database"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+var transferColumns = []string{
+	"id", "source_pvz_id", "destination_pvz_id", "status",
+	"created_by", "created_at", "expected_at", "completed_at",
+}
+
+func expectGetTransfer(mockPool pgxmock.PgxPoolIface, transferID uuid.UUID, status string, items *pgxmock.Rows) {
+	now := time.Now()
+	mockPool.
+		ExpectQuery(QueryGetTransfer).
+		WithArgs(transferID).
+		WillReturnRows(pgxmock.NewRows(transferColumns).
+			AddRow(transferID, uuid.New(), uuid.New(), status, nil, now, now.Add(time.Hour), nil))
+	mockPool.
+		ExpectQuery(QueryGetTransferItems).
+		WithArgs(transferID).
+		WillReturnRows(items)
+}
+
+func TestInsertTransfer(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewTransferRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+	transfer := oapi.Transfer{
+		Id:               uuid.New(),
+		SourcePvzId:      uuid.New(),
+		DestinationPvzId: uuid.New(),
+		Status:           oapi.AwaitingReceipt,
+		CreatedAt:        now,
+		ExpectedAt:       now.Add(time.Hour),
+	}
+	productIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	expectInsert := func() *pgxmock.ExpectedExec {
+		mockPool.ExpectBegin()
+		return mockPool.
+			ExpectExec(QueryInsertTransfer).
+			WithArgs(transfer.Id, transfer.SourcePvzId, transfer.DestinationPvzId,
+				transfer.CreatedBy, transfer.CreatedAt, transfer.ExpectedAt)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		expectInsert().WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.
+			ExpectExec(QueryDispatchTransferProducts).
+			WithArgs(transfer.Id, productIDs, transfer.SourcePvzId).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		mockPool.
+			ExpectExec(QueryInsertTransferItems).
+			WithArgs(transfer.Id, productIDs).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+		expectGetTransfer(mockPool, transfer.Id, "awaiting_receipt", pgxmock.NewRows([]string{
+			"product_id", "received_at", "received_by",
+		}).AddRow(productIDs[0], nil, nil).AddRow(productIDs[1], nil, nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.InsertTransfer(ctx, transfer, productIDs)
+		require.NoError(t, err)
+		require.Equal(t, oapi.AwaitingReceipt, got.Status)
+		require.Len(t, *got.Items, 2)
+	})
+
+	t.Run("product not at source pvz", func(t *testing.T) {
+		expectInsert().WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mockPool.
+			ExpectExec(QueryDispatchTransferProducts).
+			WithArgs(transfer.Id, productIDs, transfer.SourcePvzId).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertTransfer(ctx, transfer, productIDs)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidTransferProducts)
+	})
+
+	t.Run("unknown pvz", func(t *testing.T) {
+		expectInsert().WillReturnError(&pgconn.PgError{Code: "23503", ConstraintName: "fk_transfers_destination_pvz"})
+		mockPool.ExpectRollback()
+
+		_, err := repo.InsertTransfer(ctx, transfer, productIDs)
+		require.ErrorIs(t, err, pvz_errors.ErrPVZNotFound)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestReceiveTransferItems(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewTransferRepository(db)
+	ctx := context.Background()
+	transferID, destinationID, userID := uuid.New(), uuid.New(), uuid.New()
+	productIDs := []uuid.UUID{uuid.New()}
+	now := time.Now()
+
+	expectLock := func(status string) {
+		mockPool.ExpectBegin()
+		mockPool.
+			ExpectQuery(QueryLockTransfer).
+			WithArgs(transferID).
+			WillReturnRows(pgxmock.NewRows([]string{"destination_pvz_id", "status"}).AddRow(destinationID, status))
+	}
+
+	expectBarcodeCheck := func(conflict *pgxmock.Rows) {
+		expectPVZBarcodeLock(mockPool, destinationID)
+		query := mockPool.
+			ExpectQuery(QueryFindTransferBarcodeConflict).
+			WithArgs(destinationID, productIDs)
+		if conflict != nil {
+			query.WillReturnRows(conflict)
+			return
+		}
+		query.WillReturnError(db.ErrNoRows())
+	}
+
+	t.Run("partial receipt", func(t *testing.T) {
+		expectLock("awaiting_receipt")
+		expectBarcodeCheck(nil)
+		mockPool.
+			ExpectExec(QueryReceiveTransferItems).
+			WithArgs(transferID, productIDs, now, &userID, destinationID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockPool.
+			ExpectExec(QueryFinishTransferReceipt).
+			WithArgs(transferID, now).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		expectGetTransfer(mockPool, transferID, "partially_received", pgxmock.NewRows([]string{
+			"product_id", "received_at", "received_by",
+		}).AddRow(productIDs[0], &now, uuidPtr(userID)).AddRow(uuid.New(), nil, nil))
+		mockPool.ExpectCommit()
+
+		got, err := repo.ReceiveTransferItems(ctx, transferID, productIDs, &userID, now)
+		require.NoError(t, err)
+		require.Equal(t, oapi.PartiallyReceived, got.Status)
+		require.Equal(t, &userID, (*got.Items)[0].ReceivedBy)
+	})
+
+	t.Run("barcode already at destination", func(t *testing.T) {
+		expectLock("awaiting_receipt")
+		expectBarcodeCheck(pgxmock.NewRows([]string{"?column?"}).AddRow(1))
+		mockPool.ExpectRollback()
+
+		_, err := repo.ReceiveTransferItems(ctx, transferID, productIDs, &userID, now)
+		require.ErrorIs(t, err, pvz_errors.ErrBarcodeAlreadyAtPVZ)
+	})
+
+	t.Run("product not expected", func(t *testing.T) {
+		expectLock("partially_received")
+		expectBarcodeCheck(nil)
+		mockPool.
+			ExpectExec(QueryReceiveTransferItems).
+			WithArgs(transferID, productIDs, now, &userID, destinationID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mockPool.ExpectRollback()
+
+		_, err := repo.ReceiveTransferItems(ctx, transferID, productIDs, &userID, now)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidTransferProducts)
+	})
+
+	t.Run("already received", func(t *testing.T) {
+		expectLock("fully_received")
+		mockPool.ExpectRollback()
+
+		_, err := repo.ReceiveTransferItems(ctx, transferID, productIDs, &userID, now)
+		require.ErrorIs(t, err, pvz_errors.ErrTransferAlreadyReceived)
+	})
+
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSelectOverdueTransfers(t *testing.T) {
+	mockPool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer mockPool.Close()
+
+	db := &database.PgxMockAdapter{Pool: mockPool}
+	repo := NewTransferRepository(db)
+	now := time.Now()
+
+	mockPool.
+		ExpectQuery(QuerySelectOverdueTransfers).
+		WithArgs((*uuid.UUID)(nil), now, 30, 0).
+		WillReturnRows(pgxmock.NewRows(transferColumns).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), "awaiting_receipt", nil,
+				now.Add(-96*time.Hour), now.Add(-24*time.Hour), nil))
+
+	got, err := repo.SelectOverdueTransfers(context.Background(), nil, now, 30, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Nil(t, got[0].Items)
+	require.NoError(t, mockPool.ExpectationsWereMet())
+}
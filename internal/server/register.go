@@ -25,6 +25,22 @@ func (srv *Server) RegisterHttpHandlers(app *fiber.App) {
 	srv.registerReceptionsHandlers(app, wrapper)
 	srv.registerProductsHandlers(app, wrapper)
 	srv.registerPvzHandlers(app, wrapper)
+	srv.registerDamageHandlers(app, wrapper)
+	srv.registerContainerHandlers(app, wrapper)
+	srv.registerApprovalHandlers(app, wrapper)
+	srv.registerOrderHandlers(app, wrapper)
+	srv.registerReturnHandlers(app, wrapper)
+	srv.registerTransferHandlers(app, wrapper)
+	srv.registerStorageCellHandlers(app, wrapper)
+	srv.registerInventoryHandlers(app, wrapper)
+	srv.registerStoragePeriodHandlers(app, wrapper)
+	srv.registerLabelHandlers(app, wrapper)
+	srv.registerReportHandlers(app, wrapper)
+	srv.registerExportHandlers(app, wrapper)
+	srv.registerJobHandlers(app, wrapper)
+	srv.registerReportSubscriptionHandlers(app, wrapper)
+	srv.registerForecastHandlers(app, wrapper)
+	srv.registerRegionHandlers(app, wrapper)
 }
 
 func (srv *Server) registerAuthHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
@@ -63,6 +79,22 @@ func (srv *Server) registerPvzHandlers(app *fiber.App, wrapper oapi.ServerInterf
 		middleware.MetricsMiddleware("GetPvz", srv.Metrics),
 		wrapper.GetPvz,
 	)
+
+	app.Put(
+		"/pvz/:pvzId/location",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PutPvzPvzIdLocation", srv.Metrics),
+		wrapper.PutPvzPvzIdLocation,
+	)
+
+	app.Put(
+		"/pvz/:pvzId/intake-capacity",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PutPvzPvzIdIntakeCapacity", srv.Metrics),
+		wrapper.PutPvzPvzIdIntakeCapacity,
+	)
 }
 
 func (srv *Server) registerProductsHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
@@ -74,6 +106,78 @@ func (srv *Server) registerProductsHandlers(app *fiber.App, wrapper oapi.ServerI
 		wrapper.PostProducts,
 	)
 
+	app.Post(
+		"/products/batch",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostProductsBatch", srv.Metrics),
+		wrapper.PostProductsBatch,
+	)
+
+	app.Patch(
+		"/products/:productId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PatchProductsProductId", srv.Metrics),
+		wrapper.PatchProductsProductId,
+	)
+
+	app.Delete(
+		"/products/:productId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("DeleteProductsProductId", srv.Metrics),
+		wrapper.DeleteProductsProductId,
+	)
+
+	app.Post(
+		"/products/:productId/restore",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostProductsProductIdRestore", srv.Metrics),
+		wrapper.PostProductsProductIdRestore,
+	)
+
+	app.Get(
+		"/receptions/:receptionId/deleted-products",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReceptionsReceptionIdDeletedProducts", srv.Metrics),
+		wrapper.GetReceptionsReceptionIdDeletedProducts,
+	)
+
+	app.Get(
+		"/products/by-barcode/:code",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetProductsByBarcodeCode", srv.Metrics),
+		wrapper.GetProductsByBarcodeCode,
+	)
+
+	app.Get(
+		"/product-types",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetProductTypes", srv.Metrics),
+		wrapper.GetProductTypes,
+	)
+
+	app.Post(
+		"/product-types",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostProductTypes", srv.Metrics),
+		wrapper.PostProductTypes,
+	)
+
+	app.Put(
+		"/product-types/:code",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PutProductTypesCode", srv.Metrics),
+		wrapper.PutProductTypesCode,
+	)
+
 	app.Post(
 		"/pvz/:pvzId/delete_last_product",
 		middleware.AuthMiddleware,
@@ -99,4 +203,580 @@ func (srv *Server) registerReceptionsHandlers(app *fiber.App, wrapper oapi.Serve
 		middleware.MetricsMiddleware("PostPvzPvzIdCloseLastReception", srv.Metrics),
 		wrapper.PostPvzPvzIdCloseLastReception,
 	)
+
+	app.Get(
+		"/receptions/search",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReceptionsSearch", srv.Metrics),
+		wrapper.GetReceptionsSearch,
+	)
+
+	app.Get(
+		"/receptions/:receptionId/summary",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReceptionsReceptionIdSummary", srv.Metrics),
+		wrapper.GetReceptionsReceptionIdSummary,
+	)
+}
+
+func (srv *Server) registerDamageHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Post(
+		"/products/:productId/damage",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostProductsProductIdDamage", srv.Metrics),
+		wrapper.PostProductsProductIdDamage,
+	)
+
+	app.Get(
+		"/receptions/:receptionId/damage",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReceptionsReceptionIdDamage", srv.Metrics),
+		wrapper.GetReceptionsReceptionIdDamage,
+	)
+
+	app.Get(
+		"/damage/incidents",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetDamageIncidents", srv.Metrics),
+		wrapper.GetDamageIncidents,
+	)
+}
+
+func (srv *Server) registerContainerHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Post(
+		"/containers",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostContainers", srv.Metrics),
+		wrapper.PostContainers,
+	)
+
+	app.Post(
+		"/containers/:containerId/open",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostContainersContainerIdOpen", srv.Metrics),
+		wrapper.PostContainersContainerIdOpen,
+	)
+
+	app.Post(
+		"/containers/:containerId/verify",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostContainersContainerIdVerify", srv.Metrics),
+		wrapper.PostContainersContainerIdVerify,
+	)
+
+	app.Get(
+		"/receptions/:receptionId/containers",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReceptionsReceptionIdContainers", srv.Metrics),
+		wrapper.GetReceptionsReceptionIdContainers,
+	)
+}
+
+func (srv *Server) registerApprovalHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/approvals",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetApprovals", srv.Metrics),
+		wrapper.GetApprovals,
+	)
+
+	app.Post(
+		"/approvals/:approvalId/approve",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostApprovalsApprovalIdApprove", srv.Metrics),
+		wrapper.PostApprovalsApprovalIdApprove,
+	)
+
+	app.Post(
+		"/approvals/:approvalId/reject",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostApprovalsApprovalIdReject", srv.Metrics),
+		wrapper.PostApprovalsApprovalIdReject,
+	)
+
+	app.Get(
+		"/approvals/rules",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetApprovalsRules", srv.Metrics),
+		wrapper.GetApprovalsRules,
+	)
+
+	app.Put(
+		"/approvals/rules/:operation",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PutApprovalsRulesOperation", srv.Metrics),
+		wrapper.PutApprovalsRulesOperation,
+	)
+}
+
+func (srv *Server) registerOrderHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Post(
+		"/orders",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostOrders", srv.Metrics),
+		wrapper.PostOrders,
+	)
+
+	app.Get(
+		"/orders/:orderId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetOrdersOrderId", srv.Metrics),
+		wrapper.GetOrdersOrderId,
+	)
+
+	app.Post(
+		"/orders/:orderId/issue",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostOrdersOrderIdIssue", srv.Metrics),
+		wrapper.PostOrdersOrderIdIssue,
+	)
+
+	app.Post(
+		"/orders/:orderId/pickup-code",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("PostOrdersOrderIdPickupCode", srv.Metrics),
+		wrapper.PostOrdersOrderIdPickupCode,
+	)
+}
+
+func (srv *Server) registerReturnHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/return-reasons",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReturnReasons", srv.Metrics),
+		wrapper.GetReturnReasons,
+	)
+
+	app.Post(
+		"/return-reasons",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostReturnReasons", srv.Metrics),
+		wrapper.PostReturnReasons,
+	)
+
+	app.Post(
+		"/returns",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostReturns", srv.Metrics),
+		wrapper.PostReturns,
+	)
+
+	app.Get(
+		"/returns",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReturns", srv.Metrics),
+		wrapper.GetReturns,
+	)
+
+	app.Post(
+		"/return-shipments",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostReturnShipments", srv.Metrics),
+		wrapper.PostReturnShipments,
+	)
+
+	app.Get(
+		"/return-shipments",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReturnShipments", srv.Metrics),
+		wrapper.GetReturnShipments,
+	)
+
+	app.Get(
+		"/return-shipments/:shipmentId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetReturnShipmentsShipmentId", srv.Metrics),
+		wrapper.GetReturnShipmentsShipmentId,
+	)
+
+	app.Post(
+		"/return-shipments/:shipmentId/returns",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostReturnShipmentsShipmentIdReturns", srv.Metrics),
+		wrapper.PostReturnShipmentsShipmentIdReturns,
+	)
+
+	app.Post(
+		"/return-shipments/:shipmentId/status",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostReturnShipmentsShipmentIdStatus", srv.Metrics),
+		wrapper.PostReturnShipmentsShipmentIdStatus,
+	)
+}
+
+func (srv *Server) registerTransferHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Post(
+		"/transfers",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostTransfers", srv.Metrics),
+		wrapper.PostTransfers,
+	)
+
+	app.Get(
+		"/transfers/overdue",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetTransfersOverdue", srv.Metrics),
+		wrapper.GetTransfersOverdue,
+	)
+
+	app.Get(
+		"/transfers/:transferId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetTransfersTransferId", srv.Metrics),
+		wrapper.GetTransfersTransferId,
+	)
+
+	app.Post(
+		"/transfers/:transferId/receive",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostTransfersTransferIdReceive", srv.Metrics),
+		wrapper.PostTransfersTransferIdReceive,
+	)
+}
+
+func (srv *Server) registerStorageCellHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/pvz/:pvzId/cells",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetPvzPvzIdCells", srv.Metrics),
+		wrapper.GetPvzPvzIdCells,
+	)
+
+	app.Post(
+		"/pvz/:pvzId/cells",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostPvzPvzIdCells", srv.Metrics),
+		wrapper.PostPvzPvzIdCells,
+	)
+
+	app.Put(
+		"/products/:productId/cell",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PutProductsProductIdCell", srv.Metrics),
+		wrapper.PutProductsProductIdCell,
+	)
+
+	app.Get(
+		"/products/locations",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetProductsLocations", srv.Metrics),
+		wrapper.GetProductsLocations,
+	)
+}
+
+func (srv *Server) registerInventoryHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Post(
+		"/inventory-sessions",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostInventorySessions", srv.Metrics),
+		wrapper.PostInventorySessions,
+	)
+
+	app.Get(
+		"/inventory-sessions/:sessionId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetInventorySessionsSessionId", srv.Metrics),
+		wrapper.GetInventorySessionsSessionId,
+	)
+
+	app.Post(
+		"/inventory-sessions/:sessionId/scans",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostInventorySessionsSessionIdScans", srv.Metrics),
+		wrapper.PostInventorySessionsSessionIdScans,
+	)
+
+	app.Post(
+		"/inventory-sessions/:sessionId/close",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee"),
+		middleware.MetricsMiddleware("PostInventorySessionsSessionIdClose", srv.Metrics),
+		wrapper.PostInventorySessionsSessionIdClose,
+	)
+
+	app.Post(
+		"/inventory-sessions/:sessionId/adjustments",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostInventorySessionsSessionIdAdjustments", srv.Metrics),
+		wrapper.PostInventorySessionsSessionIdAdjustments,
+	)
+}
+
+func (srv *Server) registerStoragePeriodHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/storage-periods",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetStoragePeriods", srv.Metrics),
+		wrapper.GetStoragePeriods,
+	)
+
+	app.Put(
+		"/storage-periods",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PutStoragePeriods", srv.Metrics),
+		wrapper.PutStoragePeriods,
+	)
+
+	app.Get(
+		"/unclaimed",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetUnclaimed", srv.Metrics),
+		wrapper.GetUnclaimed,
+	)
+}
+
+func (srv *Server) registerLabelHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/products/:productId/label",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetProductsProductIdLabel", srv.Metrics),
+		wrapper.GetProductsProductIdLabel,
+	)
+
+	app.Post(
+		"/labels",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("PostLabels", srv.Metrics),
+		wrapper.PostLabels,
+	)
+
+	app.Get(
+		"/pvz/:pvzId/label-template",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetPvzPvzIdLabelTemplate", srv.Metrics),
+		wrapper.GetPvzPvzIdLabelTemplate,
+	)
+
+	app.Put(
+		"/pvz/:pvzId/label-template",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PutPvzPvzIdLabelTemplate", srv.Metrics),
+		wrapper.PutPvzPvzIdLabelTemplate,
+	)
+}
+
+func (srv *Server) registerReportHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/reports/intake",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetReportsIntake", srv.Metrics),
+		wrapper.GetReportsIntake,
+	)
+
+	app.Get(
+		"/reports/employees",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetReportsEmployees", srv.Metrics),
+		wrapper.GetReportsEmployees,
+	)
+
+	app.Get(
+		"/reports/reception-sla",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetReportsReceptionSla", srv.Metrics),
+		wrapper.GetReportsReceptionSla,
+	)
+}
+
+func (srv *Server) registerExportHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/export/pvz",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetExportPvz", srv.Metrics),
+		wrapper.GetExportPvz,
+	)
+
+	app.Get(
+		"/export/receptions",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetExportReceptions", srv.Metrics),
+		wrapper.GetExportReceptions,
+	)
+
+	app.Get(
+		"/export/products",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetExportProducts", srv.Metrics),
+		wrapper.GetExportProducts,
+	)
+}
+
+func (srv *Server) registerJobHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Post(
+		"/jobs",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("PostJobs", srv.Metrics),
+		wrapper.PostJobs,
+	)
+
+	app.Get(
+		"/jobs/:jobId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetJobsJobId", srv.Metrics),
+		wrapper.GetJobsJobId,
+	)
+
+	app.Get(
+		"/jobs/:jobId/file",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetJobsJobIdFile", srv.Metrics),
+		wrapper.GetJobsJobIdFile,
+	)
+
+	app.Post(
+		"/jobs/:jobId/cancel",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("PostJobsJobIdCancel", srv.Metrics),
+		wrapper.PostJobsJobIdCancel,
+	)
+}
+
+func (srv *Server) registerReportSubscriptionHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Post(
+		"/report-subscriptions",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostReportSubscriptions", srv.Metrics),
+		wrapper.PostReportSubscriptions,
+	)
+
+	app.Get(
+		"/report-subscriptions",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetReportSubscriptions", srv.Metrics),
+		wrapper.GetReportSubscriptions,
+	)
+
+	app.Get(
+		"/report-subscriptions/:subscriptionId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetReportSubscriptionsSubscriptionId", srv.Metrics),
+		wrapper.GetReportSubscriptionsSubscriptionId,
+	)
+
+	app.Put(
+		"/report-subscriptions/:subscriptionId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PutReportSubscriptionsSubscriptionId", srv.Metrics),
+		wrapper.PutReportSubscriptionsSubscriptionId,
+	)
+
+	app.Delete(
+		"/report-subscriptions/:subscriptionId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("DeleteReportSubscriptionsSubscriptionId", srv.Metrics),
+		wrapper.DeleteReportSubscriptionsSubscriptionId,
+	)
+
+	app.Get(
+		"/report-subscriptions/:subscriptionId/deliveries",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetReportSubscriptionsSubscriptionIdDeliveries", srv.Metrics),
+		wrapper.GetReportSubscriptionsSubscriptionIdDeliveries,
+	)
+}
+
+func (srv *Server) registerForecastHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/pvz/:pvzId/forecast",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("GetPvzPvzIdForecast", srv.Metrics),
+		wrapper.GetPvzPvzIdForecast,
+	)
+}
+
+func (srv *Server) registerRegionHandlers(app *fiber.App, wrapper oapi.ServerInterfaceWrapper) {
+	app.Get(
+		"/pvz.geojson",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetPvzGeojson", srv.Metrics),
+		wrapper.GetPvzGeojson,
+	)
+
+	app.Post(
+		"/regions",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("PostRegions", srv.Metrics),
+		wrapper.PostRegions,
+	)
+
+	app.Get(
+		"/regions",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("employee", "moderator"),
+		middleware.MetricsMiddleware("GetRegions", srv.Metrics),
+		wrapper.GetRegions,
+	)
+
+	app.Delete(
+		"/regions/:regionId",
+		middleware.AuthMiddleware,
+		middleware.RoleMiddleware("moderator"),
+		middleware.MetricsMiddleware("DeleteRegionsRegionId", srv.Metrics),
+		wrapper.DeleteRegionsRegionId,
+	)
 }
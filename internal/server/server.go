@@ -3,22 +3,42 @@ package server
 import (
 	"github.com/gofiber/fiber/v2"
 	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/whaleship/pvz/internal/config"
 	"github.com/whaleship/pvz/internal/database"
 	"github.com/whaleship/pvz/internal/gen/oapi"
 	grpc_handlers "github.com/whaleship/pvz/internal/handlers/grpc"
 	http_handlers "github.com/whaleship/pvz/internal/handlers/http"
+	"github.com/whaleship/pvz/internal/infrastructure"
 	"github.com/whaleship/pvz/internal/metrics"
 	"github.com/whaleship/pvz/internal/repository"
 	"github.com/whaleship/pvz/internal/service"
 )
 
 type Server struct {
-	AuthHandler      *http_handlers.AuthHandler
-	PVZHandler       *http_handlers.PVZHandler
-	ProductHandler   *http_handlers.ProductHandler
-	ReceptionHandler *http_handlers.ReceptionHandler
-	Metrics          metrics.MetricsSender
-	pvzService       grpc_handlers.PVZService
+	AuthHandler               *http_handlers.AuthHandler
+	PVZHandler                *http_handlers.PVZHandler
+	ProductHandler            *http_handlers.ProductHandler
+	ReceptionHandler          *http_handlers.ReceptionHandler
+	DamageHandler             *http_handlers.DamageHandler
+	ContainerHandler          *http_handlers.ContainerHandler
+	ApprovalHandler           *http_handlers.ApprovalHandler
+	ProductTypeHandler        *http_handlers.ProductTypeHandler
+	OrderHandler              *http_handlers.OrderHandler
+	ReturnHandler             *http_handlers.ReturnHandler
+	ReturnShipmentHandler     *http_handlers.ReturnShipmentHandler
+	TransferHandler           *http_handlers.TransferHandler
+	StorageCellHandler        *http_handlers.StorageCellHandler
+	InventoryHandler          *http_handlers.InventoryHandler
+	StoragePeriodHandler      *http_handlers.StoragePeriodHandler
+	ReportHandler             *http_handlers.ReportHandler
+	LabelHandler              *http_handlers.LabelHandler
+	ExportHandler             *http_handlers.ExportHandler
+	JobHandler                *http_handlers.JobHandler
+	ReportSubscriptionHandler *http_handlers.ReportSubscriptionHandler
+	ForecastHandler           *http_handlers.ForecastHandler
+	RegionHandler             *http_handlers.RegionHandler
+	Metrics                   metrics.MetricsSender
+	pvzService                grpc_handlers.PVZService
 }
 
 func (srv *Server) PostDummyLogin(c *fiber.Ctx) error {
@@ -41,10 +61,46 @@ func (srv *Server) GetPvz(c *fiber.Ctx, params oapi.GetPvzParams) error {
 	return srv.PVZHandler.GetPvz(c)
 }
 
+func (srv *Server) PutPvzPvzIdLocation(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	return srv.PVZHandler.PutPvzLocation(c, pvzId)
+}
+
+func (srv *Server) PutPvzPvzIdIntakeCapacity(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	return srv.PVZHandler.PutPvzIntakeCapacity(c, pvzId)
+}
+
 func (srv *Server) PostProducts(c *fiber.Ctx) error {
 	return srv.ProductHandler.PostProducts(c)
 }
 
+func (srv *Server) PostProductsBatch(c *fiber.Ctx) error {
+	return srv.ProductHandler.PostProductsBatch(c)
+}
+
+func (srv *Server) DeleteProductsProductId(c *fiber.Ctx, productId openapi_types.UUID) error {
+	return srv.ProductHandler.DeleteProduct(c, productId)
+}
+
+func (srv *Server) PatchProductsProductId(c *fiber.Ctx, productId openapi_types.UUID) error {
+	return srv.ProductHandler.PatchProduct(c, productId)
+}
+
+func (srv *Server) PostProductsProductIdRestore(c *fiber.Ctx, productId openapi_types.UUID) error {
+	return srv.ProductHandler.RestoreProduct(c, productId)
+}
+
+func (srv *Server) GetReceptionsReceptionIdDeletedProducts(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	return srv.ProductHandler.GetDeletedProducts(c, receptionId)
+}
+
+func (srv *Server) GetProductsByBarcodeCode(
+	c *fiber.Ctx,
+	code string,
+	params oapi.GetProductsByBarcodeCodeParams,
+) error {
+	return srv.ProductHandler.GetProductByBarcode(c, code, params)
+}
+
 func (srv *Server) PostPvzPvzIdDeleteLastProduct(c *fiber.Ctx, pvzId openapi_types.UUID) error {
 	return srv.ProductHandler.PostPvzPvzIdDeleteLastProduct(c, pvzId)
 }
@@ -57,28 +113,406 @@ func (srv *Server) PostReceptions(c *fiber.Ctx) error {
 	return srv.ReceptionHandler.PostReception(c)
 }
 
+func (srv *Server) GetReceptionsSearch(c *fiber.Ctx, params oapi.GetReceptionsSearchParams) error {
+	return srv.ReceptionHandler.SearchReceptions(c, params)
+}
+
+func (srv *Server) GetReceptionsReceptionIdSummary(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	return srv.ReceptionHandler.GetReceptionSummary(c, receptionId)
+}
+
+func (srv *Server) PostProductsProductIdDamage(c *fiber.Ctx, productId openapi_types.UUID) error {
+	return srv.DamageHandler.PostProductDamage(c, productId)
+}
+
+func (srv *Server) GetReceptionsReceptionIdDamage(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	return srv.DamageHandler.GetReceptionDamage(c, receptionId)
+}
+
+func (srv *Server) GetDamageIncidents(c *fiber.Ctx, params oapi.GetDamageIncidentsParams) error {
+	return srv.DamageHandler.GetDamageIncidents(c, params)
+}
+
+func (srv *Server) PostContainers(c *fiber.Ctx) error {
+	return srv.ContainerHandler.PostContainer(c)
+}
+
+func (srv *Server) PostContainersContainerIdOpen(c *fiber.Ctx, containerId openapi_types.UUID) error {
+	return srv.ContainerHandler.OpenContainer(c, containerId)
+}
+
+func (srv *Server) PostContainersContainerIdVerify(c *fiber.Ctx, containerId openapi_types.UUID) error {
+	return srv.ContainerHandler.VerifyContainer(c, containerId)
+}
+
+func (srv *Server) GetReceptionsReceptionIdContainers(c *fiber.Ctx, receptionId openapi_types.UUID) error {
+	return srv.ContainerHandler.GetReceptionContainers(c, receptionId)
+}
+
+func (srv *Server) GetApprovals(c *fiber.Ctx, params oapi.GetApprovalsParams) error {
+	return srv.ApprovalHandler.GetApprovals(c, params)
+}
+
+func (srv *Server) PostApprovalsApprovalIdApprove(c *fiber.Ctx, approvalId openapi_types.UUID) error {
+	return srv.ApprovalHandler.Approve(c, approvalId)
+}
+
+func (srv *Server) PostApprovalsApprovalIdReject(c *fiber.Ctx, approvalId openapi_types.UUID) error {
+	return srv.ApprovalHandler.Reject(c, approvalId)
+}
+
+func (srv *Server) GetApprovalsRules(c *fiber.Ctx) error {
+	return srv.ApprovalHandler.GetApprovalRules(c)
+}
+
+func (srv *Server) PutApprovalsRulesOperation(c *fiber.Ctx, operation oapi.ApprovalOperation) error {
+	return srv.ApprovalHandler.PutApprovalRule(c, operation)
+}
+
+func (srv *Server) GetProductTypes(c *fiber.Ctx, params oapi.GetProductTypesParams) error {
+	return srv.ProductTypeHandler.GetProductTypes(c, params)
+}
+
+func (srv *Server) PostProductTypes(c *fiber.Ctx) error {
+	return srv.ProductTypeHandler.PostProductType(c)
+}
+
+func (srv *Server) PutProductTypesCode(c *fiber.Ctx, code string) error {
+	return srv.ProductTypeHandler.PutProductType(c, code)
+}
+
+func (srv *Server) PostOrders(c *fiber.Ctx) error {
+	return srv.OrderHandler.PostOrder(c)
+}
+
+func (srv *Server) GetOrdersOrderId(c *fiber.Ctx, orderId openapi_types.UUID) error {
+	return srv.OrderHandler.GetOrder(c, orderId)
+}
+
+func (srv *Server) PostOrdersOrderIdIssue(c *fiber.Ctx, orderId openapi_types.UUID) error {
+	return srv.OrderHandler.IssueOrder(c, orderId)
+}
+
+func (srv *Server) PostOrdersOrderIdPickupCode(c *fiber.Ctx, orderId openapi_types.UUID) error {
+	return srv.OrderHandler.ResetPickupCode(c, orderId)
+}
+
+func (srv *Server) GetReturnReasons(c *fiber.Ctx, params oapi.GetReturnReasonsParams) error {
+	return srv.ReturnHandler.GetReturnReasons(c, params)
+}
+
+func (srv *Server) PostReturnReasons(c *fiber.Ctx) error {
+	return srv.ReturnHandler.PostReturnReason(c)
+}
+
+func (srv *Server) PostReturns(c *fiber.Ctx) error {
+	return srv.ReturnHandler.PostReturn(c)
+}
+
+func (srv *Server) GetReturns(c *fiber.Ctx, params oapi.GetReturnsParams) error {
+	return srv.ReturnHandler.GetReturns(c, params)
+}
+
+func (srv *Server) PostReturnShipments(c *fiber.Ctx) error {
+	return srv.ReturnShipmentHandler.PostReturnShipment(c)
+}
+
+func (srv *Server) GetReturnShipments(c *fiber.Ctx, params oapi.GetReturnShipmentsParams) error {
+	return srv.ReturnShipmentHandler.GetReturnShipments(c, params)
+}
+
+func (srv *Server) GetReturnShipmentsShipmentId(c *fiber.Ctx, shipmentId openapi_types.UUID) error {
+	return srv.ReturnShipmentHandler.GetReturnShipment(c, shipmentId)
+}
+
+func (srv *Server) PostReturnShipmentsShipmentIdReturns(c *fiber.Ctx, shipmentId openapi_types.UUID) error {
+	return srv.ReturnShipmentHandler.AddReturns(c, shipmentId)
+}
+
+func (srv *Server) PostReturnShipmentsShipmentIdStatus(c *fiber.Ctx, shipmentId openapi_types.UUID) error {
+	return srv.ReturnShipmentHandler.UpdateStatus(c, shipmentId)
+}
+
+func (srv *Server) PostTransfers(c *fiber.Ctx) error {
+	return srv.TransferHandler.PostTransfer(c)
+}
+
+func (srv *Server) GetTransfersOverdue(c *fiber.Ctx, params oapi.GetTransfersOverdueParams) error {
+	return srv.TransferHandler.GetOverdueTransfers(c, params)
+}
+
+func (srv *Server) GetTransfersTransferId(c *fiber.Ctx, transferId openapi_types.UUID) error {
+	return srv.TransferHandler.GetTransfer(c, transferId)
+}
+
+func (srv *Server) PostTransfersTransferIdReceive(c *fiber.Ctx, transferId openapi_types.UUID) error {
+	return srv.TransferHandler.ReceiveTransfer(c, transferId)
+}
+
+func (srv *Server) GetPvzPvzIdCells(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	return srv.StorageCellHandler.GetCells(c, pvzId)
+}
+
+func (srv *Server) PostPvzPvzIdCells(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	return srv.StorageCellHandler.PostCells(c, pvzId)
+}
+
+func (srv *Server) PutProductsProductIdCell(c *fiber.Ctx, productId openapi_types.UUID) error {
+	return srv.StorageCellHandler.PutProductCell(c, productId)
+}
+
+func (srv *Server) GetProductsLocations(c *fiber.Ctx, params oapi.GetProductsLocationsParams) error {
+	return srv.StorageCellHandler.GetProductLocations(c, params)
+}
+
+func (srv *Server) PostInventorySessions(c *fiber.Ctx) error {
+	return srv.InventoryHandler.StartSession(c)
+}
+
+func (srv *Server) GetInventorySessionsSessionId(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	return srv.InventoryHandler.GetSession(c, sessionId)
+}
+
+func (srv *Server) PostInventorySessionsSessionIdScans(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	return srv.InventoryHandler.Scan(c, sessionId)
+}
+
+func (srv *Server) PostInventorySessionsSessionIdClose(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	return srv.InventoryHandler.CloseSession(c, sessionId)
+}
+
+func (srv *Server) PostInventorySessionsSessionIdAdjustments(c *fiber.Ctx, sessionId openapi_types.UUID) error {
+	return srv.InventoryHandler.AcceptAdjustments(c, sessionId)
+}
+
+func (srv *Server) GetStoragePeriods(c *fiber.Ctx) error {
+	return srv.StoragePeriodHandler.GetStoragePeriods(c)
+}
+
+func (srv *Server) PutStoragePeriods(c *fiber.Ctx) error {
+	return srv.StoragePeriodHandler.PutStoragePeriods(c)
+}
+
+func (srv *Server) GetUnclaimed(c *fiber.Ctx, params oapi.GetUnclaimedParams) error {
+	return srv.StoragePeriodHandler.GetUnclaimed(c, params)
+}
+
+func (srv *Server) GetProductsProductIdLabel(
+	c *fiber.Ctx,
+	productId openapi_types.UUID,
+	params oapi.GetProductsProductIdLabelParams,
+) error {
+	return srv.LabelHandler.GetProductLabel(c, productId, params)
+}
+
+func (srv *Server) PostLabels(c *fiber.Ctx) error {
+	return srv.LabelHandler.PostLabels(c)
+}
+
+func (srv *Server) GetPvzPvzIdLabelTemplate(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	return srv.LabelHandler.GetTemplate(c, pvzId)
+}
+
+func (srv *Server) PutPvzPvzIdLabelTemplate(c *fiber.Ctx, pvzId openapi_types.UUID) error {
+	return srv.LabelHandler.PutTemplate(c, pvzId)
+}
+
+func (srv *Server) GetReportsIntake(c *fiber.Ctx, params oapi.GetReportsIntakeParams) error {
+	return srv.ReportHandler.GetIntake(c, params)
+}
+
+func (srv *Server) GetReportsEmployees(c *fiber.Ctx, params oapi.GetReportsEmployeesParams) error {
+	return srv.ReportHandler.GetEmployees(c, params)
+}
+
+func (srv *Server) GetReportsReceptionSla(c *fiber.Ctx, params oapi.GetReportsReceptionSlaParams) error {
+	return srv.ReportHandler.GetReceptionSLA(c, params)
+}
+
+func (srv *Server) GetExportPvz(c *fiber.Ctx, params oapi.GetExportPvzParams) error {
+	return srv.ExportHandler.ExportPVZs(c, params)
+}
+
+func (srv *Server) GetExportReceptions(c *fiber.Ctx, params oapi.GetExportReceptionsParams) error {
+	return srv.ExportHandler.ExportReceptions(c, params)
+}
+
+func (srv *Server) GetExportProducts(c *fiber.Ctx, params oapi.GetExportProductsParams) error {
+	return srv.ExportHandler.ExportProducts(c, params)
+}
+
+func (srv *Server) PostJobs(c *fiber.Ctx) error {
+	return srv.JobHandler.PostJob(c)
+}
+
+func (srv *Server) GetJobsJobId(c *fiber.Ctx, jobId openapi_types.UUID) error {
+	return srv.JobHandler.GetJob(c, jobId)
+}
+
+func (srv *Server) GetJobsJobIdFile(c *fiber.Ctx, jobId openapi_types.UUID) error {
+	return srv.JobHandler.GetJobFile(c, jobId)
+}
+
+func (srv *Server) PostJobsJobIdCancel(c *fiber.Ctx, jobId openapi_types.UUID) error {
+	return srv.JobHandler.CancelJob(c, jobId)
+}
+
+func (srv *Server) PostReportSubscriptions(c *fiber.Ctx) error {
+	return srv.ReportSubscriptionHandler.CreateSubscription(c)
+}
+
+func (srv *Server) GetReportSubscriptions(c *fiber.Ctx) error {
+	return srv.ReportSubscriptionHandler.GetSubscriptions(c)
+}
+
+func (srv *Server) GetReportSubscriptionsSubscriptionId(c *fiber.Ctx, subscriptionId openapi_types.UUID) error {
+	return srv.ReportSubscriptionHandler.GetSubscription(c, subscriptionId)
+}
+
+func (srv *Server) PutReportSubscriptionsSubscriptionId(c *fiber.Ctx, subscriptionId openapi_types.UUID) error {
+	return srv.ReportSubscriptionHandler.UpdateSubscription(c, subscriptionId)
+}
+
+func (srv *Server) DeleteReportSubscriptionsSubscriptionId(c *fiber.Ctx, subscriptionId openapi_types.UUID) error {
+	return srv.ReportSubscriptionHandler.DeleteSubscription(c, subscriptionId)
+}
+
+func (srv *Server) GetReportSubscriptionsSubscriptionIdDeliveries(
+	c *fiber.Ctx,
+	subscriptionId openapi_types.UUID,
+	params oapi.GetReportSubscriptionsSubscriptionIdDeliveriesParams,
+) error {
+	return srv.ReportSubscriptionHandler.GetDeliveries(c, subscriptionId, params)
+}
+
+func (srv *Server) GetPvzPvzIdForecast(
+	c *fiber.Ctx,
+	pvzId openapi_types.UUID,
+	params oapi.GetPvzPvzIdForecastParams,
+) error {
+	return srv.ForecastHandler.GetIntakeForecast(c, pvzId, params)
+}
+
+func (srv *Server) GetPvzGeojson(c *fiber.Ctx, params oapi.GetPvzGeojsonParams) error {
+	return srv.RegionHandler.GetPVZGeoJSON(c, params)
+}
+
+func (srv *Server) PostRegions(c *fiber.Ctx) error {
+	return srv.RegionHandler.UploadRegions(c)
+}
+
+func (srv *Server) GetRegions(c *fiber.Ctx) error {
+	return srv.RegionHandler.GetRegions(c)
+}
+
+func (srv *Server) DeleteRegionsRegionId(c *fiber.Ctx, regionId openapi_types.UUID) error {
+	return srv.RegionHandler.DeleteRegion(c, regionId)
+}
+
 func NewServer(conn database.PgxIface, ipcManager metrics.MetricsSender) *Server {
 	userRepo := repository.NewUserRepository(conn)
 	pvzRepo := repository.NewPVZRepository(conn)
 	productRepo := repository.NewProductRepository(conn)
 	receptionRepo := repository.NewReceptionRepository(conn)
+	damageRepo := repository.NewDamageRepository(conn)
+	containerRepo := repository.NewContainerRepository(conn)
+	approvalRepo := repository.NewApprovalRepository(conn)
+	productTypeRepo := repository.NewProductTypeRepository(conn)
+	orderRepo := repository.NewOrderRepository(conn)
+	returnRepo := repository.NewReturnRepository(conn)
+	returnShipmentRepo := repository.NewReturnShipmentRepository(conn)
+	transferRepo := repository.NewTransferRepository(conn)
+	storageCellRepo := repository.NewStorageCellRepository(conn)
+	inventoryRepo := repository.NewInventoryRepository(conn)
+	storagePeriodRepo := repository.NewStoragePeriodRepository(conn)
+	labelRepo := repository.NewLabelRepository(conn)
+	reportRepo := repository.NewReportRepository(conn)
+	exportRepo := repository.NewExportRepository(conn)
+	jobRepo := repository.NewJobRepository(conn)
+	reportSubscriptionRepo := repository.NewReportSubscriptionRepository(conn)
+	forecastRepo := repository.NewForecastRepository(conn)
+	regionRepo := repository.NewRegionRepository(conn)
 
 	authSvc := service.NewAuthService(userRepo)
-	pvzSvc := service.NewPVZService(pvzRepo, receptionRepo, productRepo, ipcManager)
+	regionSvc := service.NewRegionService(regionRepo)
+	pvzSvc := service.NewPVZService(pvzRepo, receptionRepo, productRepo, containerRepo, regionSvc, ipcManager)
 	productSvc := service.NewProductService(productRepo, ipcManager)
 	receptionSvc := service.NewReceptionService(receptionRepo, ipcManager)
+	damageSvc := service.NewDamageService(damageRepo)
+	containerSvc := service.NewContainerService(containerRepo, ipcManager)
+	approvalSvc := service.NewApprovalService(approvalRepo)
+	productTypeSvc := service.NewProductTypeService(productTypeRepo)
+	orderSvc := service.NewOrderService(orderRepo, nil)
+	if url := config.GetPickupCodeWebhookURL(); url != "" {
+		orderSvc = service.NewOrderService(orderRepo, infrastructure.NewWebhookNotifier(url))
+	}
+	returnSvc := service.NewReturnService(returnRepo)
+	returnShipmentSvc := service.NewReturnShipmentService(returnShipmentRepo)
+	transferSvc := service.NewTransferService(transferRepo)
+	storageCellSvc := service.NewStorageCellService(storageCellRepo)
+	inventorySvc := service.NewInventoryService(inventoryRepo)
+	storagePeriodSvc := service.NewStoragePeriodService(storagePeriodRepo, nil)
+	labelSvc := service.NewLabelService(labelRepo)
+	reportSvc := service.NewReportService(reportRepo)
+	exportSvc := service.NewExportService(exportRepo)
+	jobStorage := infrastructure.NewLocalFileStorage(config.GetJobStorageDir())
+	jobSvc := service.NewJobService(jobRepo, exportRepo, reportSvc, jobStorage)
+	forecastSvc := service.NewForecastService(forecastRepo)
+	reportSubscriptionSvc := service.NewReportSubscriptionService(
+		reportSubscriptionRepo,
+		reportSvc,
+		reportRepo,
+		damageRepo,
+		nil,
+	)
 
 	authHandler := http_handlers.NewAuthHandler(authSvc)
 	pvzHandler := http_handlers.NewPVZHandler(pvzSvc)
-	productHandler := http_handlers.NewProductHandler(productSvc)
-	receptionHandler := http_handlers.NewReceptionHandler(receptionSvc)
+	productHandler := http_handlers.NewProductHandler(productSvc, approvalSvc)
+	receptionHandler := http_handlers.NewReceptionHandler(receptionSvc, approvalSvc)
+	damageHandler := http_handlers.NewDamageHandler(damageSvc)
+	containerHandler := http_handlers.NewContainerHandler(containerSvc)
+	approvalHandler := http_handlers.NewApprovalHandler(approvalSvc)
+	productTypeHandler := http_handlers.NewProductTypeHandler(productTypeSvc)
+	orderHandler := http_handlers.NewOrderHandler(orderSvc)
+	returnHandler := http_handlers.NewReturnHandler(returnSvc)
+	returnShipmentHandler := http_handlers.NewReturnShipmentHandler(returnShipmentSvc)
+	transferHandler := http_handlers.NewTransferHandler(transferSvc)
+	storageCellHandler := http_handlers.NewStorageCellHandler(storageCellSvc)
+	inventoryHandler := http_handlers.NewInventoryHandler(inventorySvc)
+	storagePeriodHandler := http_handlers.NewStoragePeriodHandler(storagePeriodSvc)
+	labelHandler := http_handlers.NewLabelHandler(labelSvc)
+	reportHandler := http_handlers.NewReportHandler(reportSvc)
+	exportHandler := http_handlers.NewExportHandler(exportSvc)
+	jobHandler := http_handlers.NewJobHandler(jobSvc)
+	reportSubscriptionHandler := http_handlers.NewReportSubscriptionHandler(reportSubscriptionSvc)
+	forecastHandler := http_handlers.NewForecastHandler(forecastSvc)
+	regionHandler := http_handlers.NewRegionHandler(regionSvc)
 
 	return &Server{
-		AuthHandler:      authHandler,
-		PVZHandler:       pvzHandler,
-		ProductHandler:   productHandler,
-		ReceptionHandler: receptionHandler,
-		Metrics:          ipcManager,
-		pvzService:       pvzSvc,
+		AuthHandler:               authHandler,
+		PVZHandler:                pvzHandler,
+		ProductHandler:            productHandler,
+		ReceptionHandler:          receptionHandler,
+		DamageHandler:             damageHandler,
+		ContainerHandler:          containerHandler,
+		ApprovalHandler:           approvalHandler,
+		ProductTypeHandler:        productTypeHandler,
+		OrderHandler:              orderHandler,
+		ReturnHandler:             returnHandler,
+		ReturnShipmentHandler:     returnShipmentHandler,
+		TransferHandler:           transferHandler,
+		StorageCellHandler:        storageCellHandler,
+		InventoryHandler:          inventoryHandler,
+		StoragePeriodHandler:      storagePeriodHandler,
+		LabelHandler:              labelHandler,
+		ReportHandler:             reportHandler,
+		ExportHandler:             exportHandler,
+		JobHandler:                jobHandler,
+		ReportSubscriptionHandler: reportSubscriptionHandler,
+		ForecastHandler:           forecastHandler,
+		RegionHandler:             regionHandler,
+		Metrics:                   ipcManager,
+		pvzService:                pvzSvc,
 	}
 }
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type approvalRepository interface {
+	GetApprovalRule(ctx context.Context, operation oapi.ApprovalOperation) (oapi.ApprovalRule, error)
+	SelectApprovalRules(ctx context.Context) ([]oapi.ApprovalRule, error)
+	UpdateApprovalRule(ctx context.Context, rule oapi.ApprovalRule) (oapi.ApprovalRule, error)
+	HasReceptionDiscrepancies(ctx context.Context, pvzID uuid.UUID) (bool, error)
+	InsertApproval(ctx context.Context, approval oapi.Approval) (oapi.Approval, error)
+	SelectApprovals(ctx context.Context, status oapi.ApprovalStatus, limit, offset int) ([]oapi.Approval, error)
+	ApproveRequest(
+		ctx context.Context,
+		approvalID, decidedBy uuid.UUID,
+		decidedAt time.Time,
+		comment *string,
+	) (oapi.Approval, error)
+	RejectRequest(
+		ctx context.Context,
+		approvalID, decidedBy uuid.UUID,
+		decidedAt time.Time,
+		comment *string,
+	) (oapi.Approval, error)
+}
+
+type approvalService struct {
+	approvalRepo approvalRepository
+}
+
+func NewApprovalService(repo approvalRepository) *approvalService {
+	return &approvalService{approvalRepo: repo}
+}
+
+func (s *approvalService) RequestApproval(
+	ctx context.Context,
+	operation oapi.ApprovalOperation,
+	subjectID, requestedBy uuid.UUID,
+) (oapi.Approval, bool, error) {
+	rule, err := s.approvalRepo.GetApprovalRule(ctx, operation)
+	if err != nil {
+		return oapi.Approval{}, false, err
+	}
+	if !rule.Enabled {
+		return oapi.Approval{}, false, nil
+	}
+
+	if operation == oapi.CloseReception {
+		hasDiscrepancies, err := s.approvalRepo.HasReceptionDiscrepancies(ctx, subjectID)
+		if err != nil {
+			return oapi.Approval{}, false, err
+		}
+		if !hasDiscrepancies {
+			return oapi.Approval{}, false, nil
+		}
+	}
+
+	id := uuid.New()
+	now := time.Now()
+	approval := oapi.Approval{
+		Id:          &id,
+		Operation:   operation,
+		RequestedAt: &now,
+	}
+	if operation == oapi.DeleteProduct {
+		approval.TargetId = subjectID
+	} else {
+		approval.PvzId = subjectID
+	}
+	if requestedBy != uuid.Nil {
+		approval.RequestedBy = &requestedBy
+	}
+
+	approval, err = s.approvalRepo.InsertApproval(ctx, approval)
+	if err != nil {
+		return oapi.Approval{}, false, err
+	}
+	return approval, true, nil
+}
+
+func (s *approvalService) GetApprovals(ctx context.Context, params oapi.GetApprovalsParams) ([]oapi.Approval, error) {
+	page, limit := 1, 30
+	if params.Page != nil && *params.Page > 0 {
+		page = *params.Page
+	}
+	if params.Limit != nil && *params.Limit > 0 && *params.Limit <= 100 {
+		limit = *params.Limit
+	}
+	offset := (page - 1) * limit
+
+	status := oapi.ApprovalStatusPending
+	if params.Status != nil {
+		status = oapi.ApprovalStatus(*params.Status)
+	}
+	switch status {
+	case oapi.ApprovalStatusPending, oapi.ApprovalStatusApproved, oapi.ApprovalStatusRejected:
+	default:
+		return nil, pvz_errors.ErrInvalidApproval
+	}
+
+	return s.approvalRepo.SelectApprovals(ctx, status, limit, offset)
+}
+
+func (s *approvalService) Approve(
+	ctx context.Context,
+	userID, approvalID uuid.UUID,
+	req oapi.ApprovalDecision,
+) (oapi.Approval, error) {
+	if err := normalizeDecision(&req); err != nil {
+		return oapi.Approval{}, err
+	}
+	return s.approvalRepo.ApproveRequest(ctx, approvalID, userID, time.Now(), req.Comment)
+}
+
+func (s *approvalService) Reject(
+	ctx context.Context,
+	userID, approvalID uuid.UUID,
+	req oapi.ApprovalDecision,
+) (oapi.Approval, error) {
+	if err := normalizeDecision(&req); err != nil {
+		return oapi.Approval{}, err
+	}
+	return s.approvalRepo.RejectRequest(ctx, approvalID, userID, time.Now(), req.Comment)
+}
+
+func (s *approvalService) GetApprovalRules(ctx context.Context) ([]oapi.ApprovalRule, error) {
+	return s.approvalRepo.SelectApprovalRules(ctx)
+}
+
+func (s *approvalService) UpdateApprovalRule(
+	ctx context.Context,
+	userID uuid.UUID,
+	operation oapi.ApprovalOperation,
+	req oapi.PutApprovalsRulesOperationJSONRequestBody,
+) (oapi.ApprovalRule, error) {
+	switch operation {
+	case oapi.DeleteLastProduct, oapi.DeleteProduct, oapi.CloseReception:
+	default:
+		return oapi.ApprovalRule{}, pvz_errors.ErrInvalidApprovalOperation
+	}
+
+	now := time.Now()
+	rule := oapi.ApprovalRule{
+		Operation: operation,
+		Enabled:   req.Enabled,
+		UpdatedAt: &now,
+	}
+	if userID != uuid.Nil {
+		rule.UpdatedBy = &userID
+	}
+	return s.approvalRepo.UpdateApprovalRule(ctx, rule)
+}
+
+func normalizeDecision(req *oapi.ApprovalDecision) error {
+	if req.Comment == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*req.Comment)
+	if utf8.RuneCountInString(trimmed) > maxCommentLen {
+		return pvz_errors.ErrInvalidApproval
+	}
+	if trimmed == "" {
+		req.Comment = nil
+		return nil
+	}
+	req.Comment = &trimmed
+	return nil
+}
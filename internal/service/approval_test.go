@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockApprovalRepo struct{ mock.Mock }
+
+func (m *mockApprovalRepo) GetApprovalRule(
+	ctx context.Context,
+	operation oapi.ApprovalOperation) (oapi.ApprovalRule, error) {
+	args := m.Called(ctx, operation)
+	return args.Get(0).(oapi.ApprovalRule), args.Error(1)
+}
+
+func (m *mockApprovalRepo) SelectApprovalRules(ctx context.Context) ([]oapi.ApprovalRule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]oapi.ApprovalRule), args.Error(1)
+}
+
+func (m *mockApprovalRepo) UpdateApprovalRule(
+	ctx context.Context,
+	rule oapi.ApprovalRule) (oapi.ApprovalRule, error) {
+	args := m.Called(ctx, rule)
+	return args.Get(0).(oapi.ApprovalRule), args.Error(1)
+}
+
+func (m *mockApprovalRepo) HasReceptionDiscrepancies(ctx context.Context, pvzID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, pvzID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockApprovalRepo) InsertApproval(ctx context.Context, approval oapi.Approval) (oapi.Approval, error) {
+	args := m.Called(ctx, approval)
+	return args.Get(0).(oapi.Approval), args.Error(1)
+}
+
+func (m *mockApprovalRepo) SelectApprovals(
+	ctx context.Context,
+	status oapi.ApprovalStatus,
+	limit, offset int) ([]oapi.Approval, error) {
+	args := m.Called(ctx, status, limit, offset)
+	return args.Get(0).([]oapi.Approval), args.Error(1)
+}
+
+func (m *mockApprovalRepo) ApproveRequest(
+	ctx context.Context,
+	approvalID, decidedBy uuid.UUID,
+	decidedAt time.Time,
+	comment *string) (oapi.Approval, error) {
+	args := m.Called(ctx, approvalID, decidedBy, decidedAt, comment)
+	return args.Get(0).(oapi.Approval), args.Error(1)
+}
+
+func (m *mockApprovalRepo) RejectRequest(
+	ctx context.Context,
+	approvalID, decidedBy uuid.UUID,
+	decidedAt time.Time,
+	comment *string) (oapi.Approval, error) {
+	args := m.Called(ctx, approvalID, decidedBy, decidedAt, comment)
+	return args.Get(0).(oapi.Approval), args.Error(1)
+}
+
+func TestRequestApproval(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("rule disabled", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		repo.
+			On("GetApprovalRule", mock.Anything, oapi.DeleteLastProduct).
+			Return(oapi.ApprovalRule{Operation: oapi.DeleteLastProduct}, nil)
+
+		_, pending, err := svc.RequestApproval(ctx, oapi.DeleteLastProduct, pvzID, userID)
+		require.NoError(t, err)
+		require.False(t, pending)
+		repo.AssertNotCalled(t, "InsertApproval", mock.Anything, mock.Anything)
+	})
+
+	t.Run("close without discrepancies", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		repo.
+			On("GetApprovalRule", mock.Anything, oapi.CloseReception).
+			Return(oapi.ApprovalRule{Operation: oapi.CloseReception, Enabled: true}, nil)
+		repo.On("HasReceptionDiscrepancies", mock.Anything, pvzID).Return(false, nil)
+
+		_, pending, err := svc.RequestApproval(ctx, oapi.CloseReception, pvzID, userID)
+		require.NoError(t, err)
+		require.False(t, pending)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("close with discrepancies", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		targetID := uuid.New()
+		repo.
+			On("GetApprovalRule", mock.Anything, oapi.CloseReception).
+			Return(oapi.ApprovalRule{Operation: oapi.CloseReception, Enabled: true}, nil)
+		repo.On("HasReceptionDiscrepancies", mock.Anything, pvzID).Return(true, nil)
+		repo.
+			On("InsertApproval", mock.Anything, mock.MatchedBy(func(a oapi.Approval) bool {
+				return a.Operation == oapi.CloseReception && a.PvzId == pvzID && *a.RequestedBy == userID
+			})).
+			Return(oapi.Approval{Operation: oapi.CloseReception, TargetId: targetID, Status: "pending"}, nil)
+
+		approval, pending, err := svc.RequestApproval(ctx, oapi.CloseReception, pvzID, userID)
+		require.NoError(t, err)
+		require.True(t, pending)
+		require.Equal(t, targetID, approval.TargetId)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("delete product targets the product", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		productID := uuid.New()
+		repo.
+			On("GetApprovalRule", mock.Anything, oapi.DeleteProduct).
+			Return(oapi.ApprovalRule{Operation: oapi.DeleteProduct, Enabled: true}, nil)
+		repo.
+			On("InsertApproval", mock.Anything, mock.MatchedBy(func(a oapi.Approval) bool {
+				return a.Operation == oapi.DeleteProduct && a.TargetId == productID && a.PvzId == uuid.Nil
+			})).
+			Return(oapi.Approval{Operation: oapi.DeleteProduct, PvzId: pvzID, TargetId: productID}, nil)
+
+		approval, pending, err := svc.RequestApproval(ctx, oapi.DeleteProduct, productID, userID)
+		require.NoError(t, err)
+		require.True(t, pending)
+		require.Equal(t, pvzID, approval.PvzId)
+		repo.AssertNotCalled(t, "HasReceptionDiscrepancies", mock.Anything, mock.Anything)
+	})
+
+	t.Run("already pending", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		repo.
+			On("GetApprovalRule", mock.Anything, oapi.DeleteLastProduct).
+			Return(oapi.ApprovalRule{Operation: oapi.DeleteLastProduct, Enabled: true}, nil)
+		repo.
+			On("InsertApproval", mock.Anything, mock.Anything).
+			Return(oapi.Approval{}, pvz_errors.ErrApprovalAlreadyPending)
+
+		_, pending, err := svc.RequestApproval(ctx, oapi.DeleteLastProduct, pvzID, userID)
+		require.ErrorIs(t, err, pvz_errors.ErrApprovalAlreadyPending)
+		require.False(t, pending)
+	})
+}
+
+func TestApprovalDecisions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	approvalID := uuid.New()
+
+	t.Run("comment too long", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		long := strings.Repeat("x", maxCommentLen+1)
+		_, err := svc.Approve(ctx, userID, approvalID, oapi.ApprovalDecision{Comment: &long})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidApproval)
+	})
+
+	t.Run("approve trims comment", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		repo.
+			On("ApproveRequest", mock.Anything, approvalID, userID, mock.Anything, strPtr("ок")).
+			Return(oapi.Approval{Status: oapi.ApprovalStatusApproved}, nil)
+
+		got, err := svc.Approve(ctx, userID, approvalID, oapi.ApprovalDecision{Comment: strPtr("  ок ")})
+		require.NoError(t, err)
+		require.Equal(t, oapi.ApprovalStatusApproved, got.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reject drops blank comment", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		repo.
+			On("RejectRequest", mock.Anything, approvalID, userID, mock.Anything, (*string)(nil)).
+			Return(oapi.Approval{Status: oapi.ApprovalStatusRejected}, nil)
+
+		_, err := svc.Reject(ctx, userID, approvalID, oapi.ApprovalDecision{Comment: strPtr("  ")})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestGetApprovals(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to pending", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		repo.
+			On("SelectApprovals", mock.Anything, oapi.ApprovalStatusPending, 30, 0).
+			Return([]oapi.Approval{}, nil)
+
+		_, err := svc.GetApprovals(ctx, oapi.GetApprovalsParams{})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("status and paging", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		status := oapi.GetApprovalsParamsStatusRejected
+		repo.
+			On("SelectApprovals", mock.Anything, oapi.ApprovalStatusRejected, 10, 20).
+			Return([]oapi.Approval{}, nil)
+
+		_, err := svc.GetApprovals(ctx, oapi.GetApprovalsParams{Status: &status, Page: intPtr(3), Limit: intPtr(10)})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateApprovalRule(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("unknown operation", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		_, err := svc.UpdateApprovalRule(ctx, userID, "drop_pvz", oapi.PutApprovalsRulesOperationJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidApprovalOperation)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mockApprovalRepo)
+		svc := NewApprovalService(repo)
+		repo.
+			On("UpdateApprovalRule", mock.Anything, mock.MatchedBy(func(r oapi.ApprovalRule) bool {
+				return r.Operation == oapi.DeleteLastProduct && r.Enabled && *r.UpdatedBy == userID
+			})).
+			Return(oapi.ApprovalRule{Operation: oapi.DeleteLastProduct, Enabled: true}, nil)
+
+		got, err := svc.UpdateApprovalRule(ctx, userID, oapi.DeleteLastProduct,
+			oapi.PutApprovalsRulesOperationJSONRequestBody{Enabled: true})
+		require.NoError(t, err)
+		require.True(t, got.Enabled)
+		repo.AssertExpectations(t)
+	})
+}
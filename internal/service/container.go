@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/metrics"
+)
+
+const (
+	maxBarcodeLen     = 128
+	maxContainerItems = 10000
+)
+
+type containerRepository interface {
+	InsertContainer(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		container oapi.Container,
+		products []oapi.Product,
+	) (oapi.Container, []oapi.Product, error)
+	OpenContainer(ctx context.Context, containerID uuid.UUID, openedAt time.Time) (oapi.Container, error)
+	VerifyContainerProducts(
+		ctx context.Context,
+		containerID uuid.UUID,
+		productIDs []uuid.UUID,
+		verifiedAt time.Time,
+	) (oapi.Container, error)
+	GetContainersByReceptionIDs(ctx context.Context, receptionIDs []*uuid.UUID) ([]oapi.Container, error)
+}
+
+type containerService struct {
+	containerRepo containerRepository
+	metrics       metrics.MetricsSender
+}
+
+func NewContainerService(repo containerRepository, aggregator metrics.MetricsSender) *containerService {
+	return &containerService{
+		containerRepo: repo,
+		metrics:       aggregator,
+	}
+}
+
+func (s *containerService) ScanContainer(
+	ctx context.Context,
+	req oapi.PostContainersJSONRequestBody,
+) (dto.ContainerWithProducts, error) {
+	barcode := strings.TrimSpace(req.Barcode)
+	if barcode == "" || utf8.RuneCountInString(barcode) > maxBarcodeLen {
+		return dto.ContainerWithProducts{}, pvz_errors.ErrInvalidContainer
+	}
+	kind := oapi.ContainerKind(req.Kind)
+	switch kind {
+	case oapi.ContainerKindContainer, oapi.ContainerKindPallet:
+	default:
+		return dto.ContainerWithProducts{}, pvz_errors.ErrInvalidContainer
+	}
+
+	total := 0
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return dto.ContainerWithProducts{}, pvz_errors.ErrInvalidContainer
+		}
+		total += item.Quantity
+		if total > maxContainerItems {
+			return dto.ContainerWithProducts{}, pvz_errors.ErrInvalidContainer
+		}
+	}
+	if total == 0 {
+		return dto.ContainerWithProducts{}, pvz_errors.ErrInvalidContainer
+	}
+
+	id := uuid.New()
+	now := time.Now()
+	products := make([]oapi.Product, 0, total)
+	for _, item := range req.Items {
+		for range item.Quantity {
+			productID := uuid.New()
+			products = append(products, oapi.Product{
+				Id:          &productID,
+				DateTime:    &now,
+				Type:        item.Type,
+				ContainerId: &id,
+			})
+		}
+	}
+
+	container, products, err := s.containerRepo.InsertContainer(ctx, req.PvzId, oapi.Container{
+		Id:            &id,
+		Barcode:       barcode,
+		Kind:          kind,
+		DeclaredItems: total,
+		CreatedAt:     &now,
+	}, products)
+	if err != nil {
+		return dto.ContainerWithProducts{}, err
+	}
+
+	if s.metrics != nil {
+		s.metrics.SendBusinessMetricsUpdate(metrics.MetricsUpdate{
+			ProductsAddedDelta: int64(total),
+		})
+	}
+
+	return dto.ContainerWithProducts{Container: container, Products: products}, nil
+}
+
+func (s *containerService) OpenContainer(ctx context.Context, containerID uuid.UUID) (oapi.Container, error) {
+	return s.containerRepo.OpenContainer(ctx, containerID, time.Now())
+}
+
+func (s *containerService) VerifyContainer(
+	ctx context.Context,
+	containerID uuid.UUID,
+	req oapi.PostContainersContainerIdVerifyJSONRequestBody,
+) (oapi.Container, error) {
+	productIDs := uniqueIDs(req.ProductIds)
+	if len(productIDs) == 0 {
+		return oapi.Container{}, pvz_errors.ErrInvalidContainerProducts
+	}
+
+	return s.containerRepo.VerifyContainerProducts(ctx, containerID, productIDs, time.Now())
+}
+
+func (s *containerService) GetReceptionContainers(
+	ctx context.Context,
+	receptionID uuid.UUID,
+) ([]oapi.Container, error) {
+	return s.containerRepo.GetContainersByReceptionIDs(ctx, []*uuid.UUID{&receptionID})
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	return unique
+}
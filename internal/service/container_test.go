@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/metrics"
+)
+
+type mockContainerRepo struct{ mock.Mock }
+
+func (m *mockContainerRepo) InsertContainer(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	container oapi.Container,
+	products []oapi.Product) (oapi.Container, []oapi.Product, error) {
+	args := m.Called(ctx, pvzID, container, products)
+	return args.Get(0).(oapi.Container), args.Get(1).([]oapi.Product), args.Error(2)
+}
+
+func (m *mockContainerRepo) OpenContainer(
+	ctx context.Context,
+	containerID uuid.UUID,
+	openedAt time.Time) (oapi.Container, error) {
+	args := m.Called(ctx, containerID, openedAt)
+	return args.Get(0).(oapi.Container), args.Error(1)
+}
+
+func (m *mockContainerRepo) VerifyContainerProducts(
+	ctx context.Context,
+	containerID uuid.UUID,
+	productIDs []uuid.UUID,
+	verifiedAt time.Time) (oapi.Container, error) {
+	args := m.Called(ctx, containerID, productIDs, verifiedAt)
+	return args.Get(0).(oapi.Container), args.Error(1)
+}
+
+func (m *mockContainerRepo) GetContainersByReceptionIDs(
+	ctx context.Context,
+	receptionIDs []*uuid.UUID) ([]oapi.Container, error) {
+	args := m.Called(ctx, receptionIDs)
+	return args.Get(0).([]oapi.Container), args.Error(1)
+}
+
+type containerItem = struct {
+	Quantity int              `json:"quantity"`
+	Type     oapi.ProductType `json:"type"`
+}
+
+func TestScanContainer(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+
+	invalid := map[string]oapi.PostContainersJSONRequestBody{
+		"empty barcode": {PvzId: pvzID, Barcode: "  ", Kind: "pallet",
+			Items: []containerItem{{Quantity: 1, Type: "обувь"}}},
+		"long barcode": {PvzId: pvzID, Barcode: strings.Repeat("x", maxBarcodeLen+1), Kind: "pallet",
+			Items: []containerItem{{Quantity: 1, Type: "обувь"}}},
+		"unknown kind": {PvzId: pvzID, Barcode: "B-1", Kind: "box",
+			Items: []containerItem{{Quantity: 1, Type: "обувь"}}},
+		"no items": {PvzId: pvzID, Barcode: "B-1", Kind: "pallet"},
+		"zero quantity": {PvzId: pvzID, Barcode: "B-1", Kind: "pallet",
+			Items: []containerItem{{Quantity: 0, Type: "обувь"}}},
+		"too many items": {PvzId: pvzID, Barcode: "B-1", Kind: "pallet",
+			Items: []containerItem{{Quantity: maxContainerItems + 1, Type: "обувь"}}},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			repo := new(mockContainerRepo)
+			svc := NewContainerService(repo, nil)
+			_, err := svc.ScanContainer(ctx, req)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidContainer)
+			repo.AssertNotCalled(t, "InsertContainer")
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mockContainerRepo)
+		mockMetrics := new(mockMetrics)
+		svc := NewContainerService(repo, mockMetrics)
+		req := oapi.PostContainersJSONRequestBody{
+			PvzId:   pvzID,
+			Barcode: " PAL-7 ",
+			Kind:    "pallet",
+			Items: []containerItem{
+				{Quantity: 2, Type: "обувь"},
+				{Quantity: 1, Type: "электроника"},
+			},
+		}
+
+		var sent []oapi.Product
+		repo.
+			On("InsertContainer", mock.Anything, pvzID, mock.MatchedBy(func(c oapi.Container) bool {
+				return c.Barcode == "PAL-7" && c.Kind == oapi.ContainerKindPallet && c.DeclaredItems == 3
+			}), mock.Anything).
+			Run(func(args mock.Arguments) { sent = args.Get(3).([]oapi.Product) }).
+			Return(oapi.Container{Barcode: "PAL-7", DeclaredItems: 3}, []oapi.Product{{}, {}, {}}, nil)
+		mockMetrics.On("SendBusinessMetricsUpdate", metrics.MetricsUpdate{ProductsAddedDelta: 3}).Once()
+
+		got, err := svc.ScanContainer(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "PAL-7", got.Container.Barcode)
+		require.Len(t, got.Products, 3)
+		require.Len(t, sent, 3)
+		require.Equal(t, oapi.ProductType("электроника"), sent[2].Type)
+		require.NotNil(t, sent[0].ContainerId)
+		repo.AssertExpectations(t)
+		mockMetrics.AssertExpectations(t)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo := new(mockContainerRepo)
+		svc := NewContainerService(repo, nil)
+		req := oapi.PostContainersJSONRequestBody{PvzId: pvzID, Barcode: "B-1", Kind: "container",
+			Items: []containerItem{{Quantity: 1, Type: "одежда"}}}
+		repo.
+			On("InsertContainer", mock.Anything, pvzID, mock.Anything, mock.Anything).
+			Return(oapi.Container{}, []oapi.Product(nil), pvz_errors.ErrContainerAlreadyExists)
+
+		_, err := svc.ScanContainer(ctx, req)
+		require.ErrorIs(t, err, pvz_errors.ErrContainerAlreadyExists)
+	})
+}
+
+func TestVerifyContainer(t *testing.T) {
+	ctx := context.Background()
+	containerID := uuid.New()
+
+	t.Run("empty product list", func(t *testing.T) {
+		repo := new(mockContainerRepo)
+		svc := NewContainerService(repo, nil)
+		_, err := svc.VerifyContainer(ctx, containerID, oapi.PostContainersContainerIdVerifyJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidContainerProducts)
+		repo.AssertNotCalled(t, "VerifyContainerProducts")
+	})
+
+	t.Run("deduplicates ids", func(t *testing.T) {
+		repo := new(mockContainerRepo)
+		svc := NewContainerService(repo, nil)
+		id := uuid.New()
+		req := oapi.PostContainersContainerIdVerifyJSONRequestBody{ProductIds: []uuid.UUID{id, id}}
+		repo.
+			On("VerifyContainerProducts", mock.Anything, containerID, []uuid.UUID{id}, mock.Anything).
+			Return(oapi.Container{State: oapi.ContainerStateVerified}, nil)
+
+		got, err := svc.VerifyContainer(ctx, containerID, req)
+		require.NoError(t, err)
+		require.Equal(t, oapi.ContainerStateVerified, got.State)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestGetReceptionContainers(t *testing.T) {
+	repo := new(mockContainerRepo)
+	svc := NewContainerService(repo, nil)
+	receptionID := uuid.New()
+	repo.
+		On("GetContainersByReceptionIDs", mock.Anything, []*uuid.UUID{&receptionID}).
+		Return([]oapi.Container(nil), errors.New("fail"))
+
+	_, err := svc.GetReceptionContainers(context.Background(), receptionID)
+	require.Error(t, err)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type damageRepository interface {
+	UpsertProductDamage(ctx context.Context, damage oapi.ProductDamage) (oapi.ProductDamage, error)
+	GetReceptionDamage(ctx context.Context, receptionID uuid.UUID) ([]oapi.ProductDamage, error)
+	SelectDamageIncidents(
+		ctx context.Context,
+		startDate, endDate time.Time,
+		pvzID *uuid.UUID,
+		carrier *string,
+		limit, offset int,
+	) ([]oapi.ProductDamage, error)
+}
+
+type damageService struct {
+	damageRepo damageRepository
+}
+
+func NewDamageService(repo damageRepository) *damageService {
+	return &damageService{damageRepo: repo}
+}
+
+func (s *damageService) MarkProductDamage(
+	ctx context.Context,
+	userID, productID uuid.UUID,
+	req oapi.PostProductsProductIdDamageJSONRequestBody,
+) (oapi.ProductDamage, error) {
+	kind := oapi.ProductDamageKind(req.Kind)
+	switch kind {
+	case oapi.ProductDamageKindDamaged, oapi.ProductDamageKindWet, oapi.ProductDamageKindOpened:
+	default:
+		return oapi.ProductDamage{}, pvz_errors.ErrInvalidDamage
+	}
+	severity := oapi.ProductDamageSeverity(req.Severity)
+	switch severity {
+	case oapi.ProductDamageSeverityLow, oapi.ProductDamageSeverityMedium, oapi.ProductDamageSeverityHigh:
+	default:
+		return oapi.ProductDamage{}, pvz_errors.ErrInvalidDamage
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		trimmed := strings.TrimSpace(*req.Comment)
+		if utf8.RuneCountInString(trimmed) > maxCommentLen {
+			return oapi.ProductDamage{}, pvz_errors.ErrInvalidDamage
+		}
+		if trimmed != "" {
+			comment = &trimmed
+		}
+	}
+
+	id := uuid.New()
+	now := time.Now()
+	damage := oapi.ProductDamage{
+		Id:        &id,
+		ProductId: productID,
+		Kind:      kind,
+		Severity:  severity,
+		Comment:   comment,
+		CreatedAt: &now,
+	}
+	if userID != uuid.Nil {
+		damage.ReportedBy = &userID
+	}
+	return s.damageRepo.UpsertProductDamage(ctx, damage)
+}
+
+func (s *damageService) GetReceptionDamage(
+	ctx context.Context,
+	receptionID uuid.UUID,
+) ([]oapi.ProductDamage, error) {
+	return s.damageRepo.GetReceptionDamage(ctx, receptionID)
+}
+
+func (s *damageService) GetDamageIncidents(
+	ctx context.Context,
+	params oapi.GetDamageIncidentsParams,
+) ([]oapi.ProductDamage, error) {
+	page, limit := 1, 30
+	if params.Page != nil && *params.Page > 0 {
+		page = *params.Page
+	}
+	if params.Limit != nil && *params.Limit > 0 && *params.Limit <= 100 {
+		limit = *params.Limit
+	}
+	offset := (page - 1) * limit
+
+	startDate := time.Time{}
+	if params.StartDate != nil {
+		startDate = *params.StartDate
+	}
+	endDate := time.Now()
+	if params.EndDate != nil {
+		endDate = *params.EndDate
+	}
+
+	var carrier *string
+	if params.Carrier != nil && strings.TrimSpace(*params.Carrier) != "" {
+		trimmed := strings.TrimSpace(*params.Carrier)
+		carrier = &trimmed
+	}
+
+	incidents, err := s.damageRepo.SelectDamageIncidents(
+		ctx,
+		startDate, endDate,
+		params.PvzId, carrier,
+		limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
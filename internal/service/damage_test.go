@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockDamageRepo struct{ mock.Mock }
+
+func (m *mockDamageRepo) UpsertProductDamage(
+	ctx context.Context,
+	damage oapi.ProductDamage) (oapi.ProductDamage, error) {
+	args := m.Called(ctx, damage)
+	return args.Get(0).(oapi.ProductDamage), args.Error(1)
+}
+
+func (m *mockDamageRepo) GetReceptionDamage(
+	ctx context.Context,
+	receptionID uuid.UUID) ([]oapi.ProductDamage, error) {
+	args := m.Called(ctx, receptionID)
+	return args.Get(0).([]oapi.ProductDamage), args.Error(1)
+}
+
+func (m *mockDamageRepo) SelectDamageIncidents(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	pvzID *uuid.UUID,
+	carrier *string,
+	limit, offset int) ([]oapi.ProductDamage, error) {
+	args := m.Called(ctx, startDate, endDate, pvzID, carrier, limit, offset)
+	return args.Get(0).([]oapi.ProductDamage), args.Error(1)
+}
+
+func TestMarkProductDamage(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+
+	t.Run("invalid kind", func(t *testing.T) {
+		repo := new(mockDamageRepo)
+		svc := NewDamageService(repo)
+		req := oapi.PostProductsProductIdDamageJSONRequestBody{Kind: "burnt", Severity: "low"}
+		_, err := svc.MarkProductDamage(context.Background(), userID, productID, req)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidDamage)
+		repo.AssertNotCalled(t, "UpsertProductDamage")
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		repo := new(mockDamageRepo)
+		svc := NewDamageService(repo)
+		req := oapi.PostProductsProductIdDamageJSONRequestBody{Kind: "wet", Severity: "fatal"}
+		_, err := svc.MarkProductDamage(context.Background(), userID, productID, req)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidDamage)
+	})
+
+	t.Run("comment too long", func(t *testing.T) {
+		repo := new(mockDamageRepo)
+		svc := NewDamageService(repo)
+		comment := strings.Repeat("x", maxCommentLen+1)
+		req := oapi.PostProductsProductIdDamageJSONRequestBody{Kind: "wet", Severity: "low", Comment: &comment}
+		_, err := svc.MarkProductDamage(context.Background(), userID, productID, req)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidDamage)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mockDamageRepo)
+		svc := NewDamageService(repo)
+		req := oapi.PostProductsProductIdDamageJSONRequestBody{
+			Kind:     "damaged",
+			Severity: "high",
+			Comment:  strPtr(" разбит экран "),
+		}
+		repo.
+			On("UpsertProductDamage", mock.Anything, mock.MatchedBy(func(d oapi.ProductDamage) bool {
+				return d.ProductId == productID &&
+					d.Kind == oapi.ProductDamageKindDamaged &&
+					d.Severity == oapi.ProductDamageSeverityHigh &&
+					*d.Comment == "разбит экран" &&
+					*d.ReportedBy == userID
+			})).
+			Return(oapi.ProductDamage{ProductId: productID}, nil)
+
+		out, err := svc.MarkProductDamage(context.Background(), userID, productID, req)
+		require.NoError(t, err)
+		require.Equal(t, productID, out.ProductId)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestGetDamageIncidents(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		repo := new(mockDamageRepo)
+		svc := NewDamageService(repo)
+		repo.
+			On("SelectDamageIncidents", mock.Anything, time.Time{}, mock.Anything,
+				(*uuid.UUID)(nil), (*string)(nil), 30, 0).
+			Return([]oapi.ProductDamage{}, nil)
+
+		out, err := svc.GetDamageIncidents(context.Background(), oapi.GetDamageIncidentsParams{})
+		require.NoError(t, err)
+		require.Empty(t, out)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("filters and paging", func(t *testing.T) {
+		repo := new(mockDamageRepo)
+		svc := NewDamageService(repo)
+		pvzID := uuid.New()
+		start := time.Now().Add(-24 * time.Hour)
+		end := time.Now()
+		params := oapi.GetDamageIncidentsParams{
+			StartDate: &start,
+			EndDate:   &end,
+			PvzId:     &pvzID,
+			Carrier:   strPtr(" СДЭК "),
+			Page:      intPtr(3),
+			Limit:     intPtr(10),
+		}
+		repo.
+			On("SelectDamageIncidents", mock.Anything, start, end, &pvzID, strPtr("СДЭК"), 10, 20).
+			Return([]oapi.ProductDamage{{ProductId: uuid.New()}}, nil)
+
+		out, err := svc.GetDamageIncidents(context.Background(), params)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo := new(mockDamageRepo)
+		svc := NewDamageService(repo)
+		repo.
+			On("SelectDamageIncidents", mock.Anything, mock.Anything, mock.Anything,
+				mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]oapi.ProductDamage(nil), fmt.Errorf("%w: %w", pvz_errors.ErrSelectDamageFailed, errors.New("db")))
+
+		_, err := svc.GetDamageIncidents(context.Background(), oapi.GetDamageIncidentsParams{})
+		require.ErrorIs(t, err, pvz_errors.ErrSelectDamageFailed)
+		require.Equal(t, pvz_errors.ErrSelectDamageFailed.Error()+": db", err.Error())
+	})
+}
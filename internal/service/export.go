@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type exportRepository interface {
+	ExportPVZs(ctx context.Context, filter dto.ExportFilter, w export.Writer) error
+	ExportReceptions(ctx context.Context, filter dto.ExportFilter, w export.Writer) error
+	ExportProducts(ctx context.Context, filter dto.ExportFilter, w export.Writer) error
+}
+
+type exportService struct {
+	exportRepo exportRepository
+}
+
+func NewExportService(repo exportRepository) *exportService {
+	return &exportService{exportRepo: repo}
+}
+
+func (s *exportService) ExportPVZs(
+	ctx context.Context,
+	params oapi.GetExportPvzParams,
+	format oapi.ExportFormat,
+) (func(io.Writer) error, error) {
+	filter, err := exportFilter(params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	filter.RegionID = params.RegionId
+	return exportStream(format, func(w export.Writer) error {
+		return s.exportRepo.ExportPVZs(ctx, filter, w)
+	})
+}
+
+func (s *exportService) ExportReceptions(
+	ctx context.Context,
+	params oapi.GetExportReceptionsParams,
+	format oapi.ExportFormat,
+) (func(io.Writer) error, error) {
+	filter, err := exportFilter(params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	filter.PvzID = params.PvzId
+	return exportStream(format, func(w export.Writer) error {
+		return s.exportRepo.ExportReceptions(ctx, filter, w)
+	})
+}
+
+func (s *exportService) ExportProducts(
+	ctx context.Context,
+	params oapi.GetExportProductsParams,
+	format oapi.ExportFormat,
+) (func(io.Writer) error, error) {
+	filter, err := exportFilter(params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	filter.PvzID = params.PvzId
+	filter.ProductType = trimmedOrNil(params.ProductType)
+	return exportStream(format, func(w export.Writer) error {
+		return s.exportRepo.ExportProducts(ctx, filter, w)
+	})
+}
+
+func exportFilter(startDate, endDate *time.Time) (dto.ExportFilter, error) {
+	filter := dto.ExportFilter{To: time.Now()}
+	if startDate != nil {
+		filter.From = *startDate
+	}
+	if endDate != nil {
+		filter.To = *endDate
+	}
+	if filter.To.Before(filter.From) {
+		return dto.ExportFilter{}, pvz_errors.ErrInvalidExport
+	}
+	return filter, nil
+}
+
+// exportStream validates the format up front so that errors can still be
+// reported with a status code; the returned function runs once the response
+// body is being streamed.
+func exportStream(format oapi.ExportFormat, run func(w export.Writer) error) (func(io.Writer) error, error) {
+	if export.ContentType(export.Format(format)) == "" {
+		return nil, pvz_errors.ErrInvalidExport
+	}
+	return func(out io.Writer) error {
+		w, err := export.NewWriter(export.Format(format), out)
+		if err != nil {
+			return err
+		}
+		if err = run(w); err != nil {
+			return err
+		}
+		return w.Close()
+	}, nil
+}
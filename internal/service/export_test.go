@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockExportRepo struct{ mock.Mock }
+
+func (m *mockExportRepo) ExportPVZs(ctx context.Context, filter dto.ExportFilter, w export.Writer) error {
+	args := m.Called(ctx, filter, w)
+	return args.Error(0)
+}
+
+func (m *mockExportRepo) ExportReceptions(ctx context.Context, filter dto.ExportFilter, w export.Writer) error {
+	args := m.Called(ctx, filter, w)
+	return args.Error(0)
+}
+
+func (m *mockExportRepo) ExportProducts(ctx context.Context, filter dto.ExportFilter, w export.Writer) error {
+	args := m.Called(ctx, filter, w)
+	return args.Error(0)
+}
+
+func TestExportProducts(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+
+	t.Run("streams through writer", func(t *testing.T) {
+		repo := new(mockExportRepo)
+		svc := NewExportService(repo)
+		productType := " обувь "
+		repo.
+			On("ExportProducts", mock.Anything, mock.MatchedBy(func(f dto.ExportFilter) bool {
+				return *f.PvzID == pvzID && *f.ProductType == "обувь" && f.From.IsZero()
+			}), mock.Anything).
+			Run(func(args mock.Arguments) {
+				w := args.Get(2).(export.Writer)
+				_ = w.WriteHeader([]string{"id"})
+				_ = w.WriteRow([]any{"p1"})
+			}).
+			Return(nil)
+
+		stream, err := svc.ExportProducts(ctx, oapi.GetExportProductsParams{
+			PvzId: &pvzID, ProductType: &productType,
+		}, oapi.Ndjson)
+		require.NoError(t, err)
+
+		var out strings.Builder
+		require.NoError(t, stream(&out))
+		require.Equal(t, "{\"id\":\"p1\"}\n", out.String())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		svc := NewExportService(new(mockExportRepo))
+		start, end := time.Now(), time.Now().Add(-time.Hour)
+		_, err := svc.ExportProducts(ctx, oapi.GetExportProductsParams{StartDate: &start, EndDate: &end}, oapi.Csv)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidExport)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		svc := NewExportService(new(mockExportRepo))
+		_, err := svc.ExportPVZs(ctx, oapi.GetExportPvzParams{}, oapi.ExportFormat("pdf"))
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidExport)
+	})
+}
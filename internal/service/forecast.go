@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	defaultForecastDays    = 14
+	maxForecastDays        = 60
+	defaultForecastHistory = 84
+	minForecastHistory     = 14
+	maxForecastHistory     = 365
+	forecastConfidence     = 0.95
+	// two-sided normal quantile for forecastConfidence
+	forecastZ = 1.96
+)
+
+type forecastRepository interface {
+	GetForecastPVZ(ctx context.Context, pvzID uuid.UUID) (dto.ForecastPVZ, error)
+	SelectHourlyIntake(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		from, to time.Time,
+		timezone string,
+	) ([]dto.HourlyIntake, error)
+}
+
+type forecastService struct {
+	forecastRepo forecastRepository
+}
+
+func NewForecastService(repo forecastRepository) *forecastService {
+	return &forecastService{forecastRepo: repo}
+}
+
+func (s *forecastService) GetIntakeForecast(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	params oapi.GetPvzPvzIdForecastParams,
+) (oapi.IntakeForecast, error) {
+	days, historyDays := defaultForecastDays, defaultForecastHistory
+	if params.Days != nil {
+		days = *params.Days
+	}
+	if params.HistoryDays != nil {
+		historyDays = *params.HistoryDays
+	}
+	if days < 1 || days > maxForecastDays || historyDays < minForecastHistory || historyDays > maxForecastHistory {
+		return oapi.IntakeForecast{}, pvz_errors.ErrInvalidForecast
+	}
+	timezone := "UTC"
+	if tz := trimmedOrNil(params.Timezone); tz != nil {
+		timezone = *tz
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return oapi.IntakeForecast{}, pvz_errors.ErrInvalidForecast
+	}
+
+	pvz, err := s.forecastRepo.GetForecastPVZ(ctx, pvzID)
+	if err != nil {
+		return oapi.IntakeForecast{}, err
+	}
+	today := localMidnight(time.Now().In(loc))
+	historyFrom := today.AddDate(0, 0, -historyDays)
+	// the registration day is partial, so history starts on the day after
+	if registered := pvz.RegisteredAt.In(loc); !registered.Before(historyFrom) {
+		historyFrom = localMidnight(registered).AddDate(0, 0, 1)
+	}
+	var history []time.Time
+	for day := historyFrom; day.Before(today); day = day.AddDate(0, 0, 1) {
+		history = append(history, day)
+	}
+	if len(history) < minForecastHistory {
+		return oapi.IntakeForecast{}, pvz_errors.ErrForecastHistoryTooShort
+	}
+
+	intake, err := s.forecastRepo.SelectHourlyIntake(ctx, pvzID, historyFrom.UTC(), today.UTC(), timezone)
+	if err != nil {
+		return oapi.IntakeForecast{}, err
+	}
+	index := make(map[string]int, len(history))
+	for i, day := range history {
+		index[day.Format(time.DateOnly)] = i
+	}
+	volumes := make([]float64, len(history))
+	var profile hourProfile
+	for _, row := range intake {
+		i, ok := index[row.Day.Format(time.DateOnly)]
+		if !ok || row.Hour < 0 || row.Hour > 23 {
+			continue
+		}
+		volumes[i] += float64(row.Products)
+		profile.add(history[i], row.Hour, float64(row.Products))
+	}
+
+	model := fitIntakeModel(history, volumes)
+	forecast := oapi.IntakeForecast{
+		PvzId:          pvzID,
+		Timezone:       timezone,
+		HistoryFrom:    historyFrom,
+		HistoryTo:      today,
+		Confidence:     forecastConfidence,
+		TrendPerDay:    float32(math.Round(model.slope*100) / 100),
+		WeekdayFactors: make([]float32, 0, 7),
+		HolidayFactor:  float32(math.Round(model.holiday*1000) / 1000),
+		Days:           make([]oapi.ForecastDay, 0, days),
+		Warnings:       []string{},
+	}
+	for _, factor := range model.weekday {
+		forecast.WeekdayFactors = append(forecast.WeekdayFactors, float32(math.Round(factor*1000)/1000))
+	}
+	forecast.Capacity = pvz.IntakeCapacity
+
+	for h := 1; h <= days; h++ {
+		date := today.AddDate(0, 0, h)
+		point, half := model.predict(len(history)+h, date)
+		day := oapi.ForecastDay{
+			Date:    date,
+			Weekday: isoWeekday(date) + 1,
+			Holiday: isPublicHoliday(date),
+			Volume:  roundVolume(point),
+			Lower:   roundVolume(math.Max(0, point-half)),
+			Upper:   roundVolume(point + half),
+			Hours:   make([]oapi.ForecastHour, 0, 24),
+		}
+		for hour, share := range profile.shares(date) {
+			day.Hours = append(day.Hours, oapi.ForecastHour{
+				Hour:   hour,
+				Volume: roundVolume(point * share),
+				Lower:  roundVolume(math.Max(0, point-half) * share),
+				Upper:  roundVolume((point + half) * share),
+			})
+		}
+		if forecast.Capacity != nil {
+			capacity := float64(*forecast.Capacity)
+			day.OverCapacity = point > capacity
+			day.CapacityRisk = point+half > capacity
+			switch {
+			case day.OverCapacity:
+				forecast.Warnings = append(forecast.Warnings, fmt.Sprintf(
+					"%s: ожидается %.0f товаров при дневной пропускной способности ПВЗ %d",
+					date.Format(time.DateOnly), point, *forecast.Capacity))
+			case day.CapacityRisk:
+				forecast.Warnings = append(forecast.Warnings, fmt.Sprintf(
+					"%s: верхняя граница прогноза %.0f товаров выше дневной пропускной способности ПВЗ %d",
+					date.Format(time.DateOnly), point+half, *forecast.Capacity))
+			}
+		}
+		forecast.Days = append(forecast.Days, day)
+	}
+	return forecast, nil
+}
+
+// intakeModel is a multiplicative decomposition of daily intake: a linear
+// trend fitted to the volumes divided by their weekday or holiday factor.
+type intakeModel struct {
+	level, slope float64
+	weekday      [7]float64
+	holiday      float64
+	// sigma, points, meanT and sxx describe the trend regression and give
+	// the width of the prediction interval
+	sigma      float64
+	points     int
+	meanT, sxx float64
+}
+
+func fitIntakeModel(days []time.Time, volumes []float64) intakeModel {
+	m := intakeModel{weekday: [7]float64{1, 1, 1, 1, 1, 1, 1}, holiday: 1}
+
+	var sums, counts [7]float64
+	var total, regular float64
+	for i, day := range days {
+		if isPublicHoliday(day) {
+			continue
+		}
+		w := isoWeekday(day)
+		sums[w] += volumes[i]
+		counts[w]++
+		total += volumes[i]
+		regular++
+	}
+	if total == 0 {
+		return m
+	}
+	mean := total / regular
+	for w := range m.weekday {
+		if counts[w] > 0 {
+			m.weekday[w] = sums[w] / counts[w] / mean
+		}
+	}
+
+	var ts, zs []float64
+	for i, day := range days {
+		if factor := m.weekday[isoWeekday(day)]; !isPublicHoliday(day) && factor > 0 {
+			ts = append(ts, float64(i))
+			zs = append(zs, volumes[i]/factor)
+		}
+	}
+	m.points = len(ts)
+	var meanZ float64
+	for i := range ts {
+		m.meanT += ts[i]
+		meanZ += zs[i]
+	}
+	m.meanT /= float64(m.points)
+	meanZ /= float64(m.points)
+	var sxy float64
+	for i := range ts {
+		m.sxx += (ts[i] - m.meanT) * (ts[i] - m.meanT)
+		sxy += (ts[i] - m.meanT) * (zs[i] - meanZ)
+	}
+	if m.sxx > 0 {
+		m.slope = sxy / m.sxx
+	}
+	m.level = meanZ - m.slope*m.meanT
+	if m.points > 2 {
+		var sse float64
+		for i := range ts {
+			residual := zs[i] - (m.level + m.slope*ts[i])
+			sse += residual * residual
+		}
+		m.sigma = math.Sqrt(sse / float64(m.points-2))
+	}
+
+	// without holidays in the history a holiday is taken to be as quiet as
+	// the quietest weekday
+	var holidayVolume, holidayExpected float64
+	for i, day := range days {
+		if isPublicHoliday(day) {
+			holidayVolume += volumes[i]
+			holidayExpected += math.Max(0, m.level+m.slope*float64(i))
+		}
+	}
+	if holidayExpected > 0 {
+		m.holiday = holidayVolume / holidayExpected
+	} else {
+		m.holiday = m.weekday[0]
+		for _, factor := range m.weekday[1:] {
+			m.holiday = math.Min(m.holiday, factor)
+		}
+	}
+	return m
+}
+
+// predict returns the expected volume of day, the t-th day since the start
+// of the history, and the half-width of its prediction interval.
+func (m intakeModel) predict(t int, day time.Time) (float64, float64) {
+	factor := m.weekday[isoWeekday(day)]
+	if isPublicHoliday(day) {
+		factor = m.holiday
+	}
+	point := math.Max(0, m.level+m.slope*float64(t)) * factor
+	if m.points == 0 {
+		return point, 0
+	}
+	spread := 1 + 1/float64(m.points)
+	if m.sxx > 0 {
+		spread += (float64(t) - m.meanT) * (float64(t) - m.meanT) / m.sxx
+	}
+	return point, forecastZ * m.sigma * math.Sqrt(spread) * factor
+}
+
+// hourProfile accumulates intake by hour, per weekday for regular days and
+// overall for holidays and weekdays without intake.
+type hourProfile struct {
+	weekday [7][24]float64
+	overall [24]float64
+}
+
+func (p *hourProfile) add(day time.Time, hour int, products float64) {
+	if !isPublicHoliday(day) {
+		p.weekday[isoWeekday(day)][hour] += products
+	}
+	p.overall[hour] += products
+}
+
+func (p *hourProfile) shares(day time.Time) [24]float64 {
+	if !isPublicHoliday(day) {
+		if shares, ok := hourShares(p.weekday[isoWeekday(day)]); ok {
+			return shares
+		}
+	}
+	if shares, ok := hourShares(p.overall); ok {
+		return shares
+	}
+	var uniform [24]float64
+	for h := range uniform {
+		uniform[h] = 1.0 / 24
+	}
+	return uniform
+}
+
+func hourShares(hours [24]float64) ([24]float64, bool) {
+	var total float64
+	for _, v := range hours {
+		total += v
+	}
+	if total == 0 {
+		return hours, false
+	}
+	for h := range hours {
+		hours[h] /= total
+	}
+	return hours, true
+}
+
+// isPublicHoliday reports the non-working public holidays of the Russian
+// Federation. Days off moved by government decree change every year and are
+// not taken into account.
+func isPublicHoliday(day time.Time) bool {
+	switch day.Month() {
+	case time.January:
+		return day.Day() <= 8
+	case time.February:
+		return day.Day() == 23
+	case time.March:
+		return day.Day() == 8
+	case time.May:
+		return day.Day() == 1 || day.Day() == 9
+	case time.June:
+		return day.Day() == 12
+	case time.November:
+		return day.Day() == 4
+	default:
+		return false
+	}
+}
+
+// isoWeekday numbers the days of the week from Monday, starting at zero.
+func isoWeekday(day time.Time) int {
+	return (int(day.Weekday()) + 6) % 7
+}
+
+func localMidnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func roundVolume(v float64) float32 {
+	return float32(math.Round(v*10) / 10)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockForecastRepo struct{ mock.Mock }
+
+func (m *mockForecastRepo) GetForecastPVZ(ctx context.Context, pvzID uuid.UUID) (dto.ForecastPVZ, error) {
+	args := m.Called(ctx, pvzID)
+	return args.Get(0).(dto.ForecastPVZ), args.Error(1)
+}
+
+func (m *mockForecastRepo) SelectHourlyIntake(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	from, to time.Time,
+	timezone string,
+) ([]dto.HourlyIntake, error) {
+	args := m.Called(ctx, pvzID, from, to, timezone)
+	return args.Get(0).([]dto.HourlyIntake), args.Error(1)
+}
+
+func TestFitIntakeModel(t *testing.T) {
+	// Monday to Sunday
+	weekly := []float64{120, 100, 100, 100, 110, 90, 50}
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("weekday and holiday effects", func(t *testing.T) {
+		var days []time.Time
+		var volumes []float64
+		for i := range 56 {
+			day := monday.AddDate(0, 0, i)
+			volume := weekly[i%7]
+			if isPublicHoliday(day) {
+				volume = 30
+			}
+			days, volumes = append(days, day), append(volumes, volume)
+		}
+
+		model := fitIntakeModel(days, volumes)
+		require.InDelta(t, 0, model.slope, 1e-9)
+		require.InDelta(t, 0, model.sigma, 1e-9)
+		require.InDelta(t, 120.0/50, model.weekday[0]/model.weekday[6], 1e-9)
+
+		nextMonday := monday.AddDate(0, 0, 56)
+		point, half := model.predict(56, nextMonday)
+		require.InDelta(t, 120, point, 1e-9)
+		require.InDelta(t, 0, half, 1e-9)
+		point, _ = model.predict(59, time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+		require.InDelta(t, 30, point, 1e-9)
+	})
+
+	t.Run("holiday defaults to the quietest weekday", func(t *testing.T) {
+		start := time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC)
+		var days []time.Time
+		var volumes []float64
+		for i := range 21 {
+			days, volumes = append(days, start.AddDate(0, 0, i)), append(volumes, weekly[i%7]+float64(i))
+		}
+
+		model := fitIntakeModel(days, volumes)
+		require.Greater(t, model.slope, 0.5)
+		require.Equal(t, model.weekday[6], model.holiday)
+
+		sunday, sigma := time.Date(2025, 5, 11, 0, 0, 0, 0, time.UTC), model.sigma
+		victoryDay := time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC)
+		holidayPoint, _ := model.predict(32, victoryDay)
+		fridayPoint, _ := model.predict(32, victoryDay.AddDate(0, 0, 7))
+		require.Less(t, holidayPoint, fridayPoint)
+
+		_, nearHalf := model.predict(21, sunday.AddDate(0, 0, -14))
+		_, farHalf := model.predict(34, sunday)
+		require.Greater(t, sigma, 0.0)
+		require.Less(t, nearHalf, farHalf)
+	})
+
+	t.Run("no intake", func(t *testing.T) {
+		days := []time.Time{monday, monday.AddDate(0, 0, 1)}
+		point, half := fitIntakeModel(days, []float64{0, 0}).predict(5, monday.AddDate(0, 0, 5))
+		require.Zero(t, point)
+		require.Zero(t, half)
+	})
+}
+
+func TestGetIntakeForecast(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	today := localMidnight(time.Now().UTC())
+
+	t.Run("capacity warnings", func(t *testing.T) {
+		repo := new(mockForecastRepo)
+		svc := NewForecastService(repo)
+		capacity := 100
+		repo.On("GetForecastPVZ", ctx, pvzID).
+			Return(dto.ForecastPVZ{RegisteredAt: today.AddDate(-1, 0, 0), IntakeCapacity: &capacity}, nil)
+		var intake []dto.HourlyIntake
+		for i := 1; i <= 28; i++ {
+			day := today.AddDate(0, 0, -i)
+			intake = append(intake,
+				dto.HourlyIntake{Day: day, Hour: 10, Products: 90},
+				dto.HourlyIntake{Day: day, Hour: 15, Products: 30})
+		}
+		repo.On("SelectHourlyIntake", ctx, pvzID, today.AddDate(0, 0, -28), today, "UTC").Return(intake, nil)
+
+		days, history := 3, 28
+		forecast, err := svc.GetIntakeForecast(ctx, pvzID, oapi.GetPvzPvzIdForecastParams{
+			Days: &days, HistoryDays: &history,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 100, *forecast.Capacity)
+		require.Len(t, forecast.Days, 3)
+		require.Len(t, forecast.Warnings, 3)
+		require.Contains(t, forecast.Warnings[0], "дневной пропускной способности ПВЗ 100")
+		first := forecast.Days[0]
+		require.Equal(t, today.AddDate(0, 0, 1), first.Date)
+		require.Equal(t, float32(120), first.Volume)
+		require.Equal(t, float32(120), first.Upper)
+		require.True(t, first.OverCapacity)
+		require.True(t, first.CapacityRisk)
+		require.Len(t, first.Hours, 24)
+		require.Equal(t, float32(90), first.Hours[10].Volume)
+		require.Zero(t, first.Hours[0].Volume)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("new pvz", func(t *testing.T) {
+		repo := new(mockForecastRepo)
+		svc := NewForecastService(repo)
+		repo.On("GetForecastPVZ", ctx, pvzID).
+			Return(dto.ForecastPVZ{RegisteredAt: today.AddDate(0, 0, -10)}, nil)
+
+		_, err := svc.GetIntakeForecast(ctx, pvzID, oapi.GetPvzPvzIdForecastParams{})
+		require.ErrorIs(t, err, pvz_errors.ErrForecastHistoryTooShort)
+	})
+
+	zero, short, local := 0, 7, "Local"
+	for name, params := range map[string]oapi.GetPvzPvzIdForecastParams{
+		"no days":          {Days: &zero},
+		"short history":    {HistoryDays: &short},
+		"server time zone": {Timezone: &local},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := NewForecastService(new(mockForecastRepo))
+			_, err := svc.GetIntakeForecast(ctx, pvzID, params)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidForecast)
+		})
+	}
+}
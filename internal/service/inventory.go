@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const maxInventoryAdjustments = 500
+
+type inventoryRepository interface {
+	InsertInventorySession(ctx context.Context, session oapi.InventorySession) (oapi.InventorySession, error)
+	GetInventorySession(ctx context.Context, sessionID uuid.UUID) (oapi.InventorySession, error)
+	UpsertInventoryScan(ctx context.Context, sessionID uuid.UUID, scan oapi.InventoryScan) (oapi.InventoryScan, error)
+	CloseInventorySession(
+		ctx context.Context,
+		sessionID uuid.UUID,
+		closedBy *uuid.UUID,
+		closedAt time.Time,
+	) (oapi.InventorySession, error)
+	AcceptInventoryAdjustments(
+		ctx context.Context,
+		sessionID uuid.UUID,
+		barcodes []string,
+		acceptedBy *uuid.UUID,
+		acceptedAt time.Time,
+	) (oapi.InventorySession, error)
+}
+
+type inventoryService struct {
+	inventoryRepo inventoryRepository
+}
+
+func NewInventoryService(repo inventoryRepository) *inventoryService {
+	return &inventoryService{inventoryRepo: repo}
+}
+
+func (s *inventoryService) StartSession(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostInventorySessionsJSONRequestBody,
+) (oapi.InventorySession, error) {
+	if req.PvzId == uuid.Nil {
+		return oapi.InventorySession{}, pvz_errors.ErrInvalidInventory
+	}
+
+	session := oapi.InventorySession{
+		Id:            uuid.New(),
+		PvzId:         req.PvzId,
+		Status:        oapi.Counting,
+		StartedAt:     time.Now(),
+		Discrepancies: &[]oapi.InventoryDiscrepancy{},
+	}
+	if userID != uuid.Nil {
+		session.StartedBy = &userID
+	}
+	return s.inventoryRepo.InsertInventorySession(ctx, session)
+}
+
+func (s *inventoryService) GetSession(ctx context.Context, sessionID uuid.UUID) (oapi.InventorySession, error) {
+	return s.inventoryRepo.GetInventorySession(ctx, sessionID)
+}
+
+func (s *inventoryService) Scan(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+	req oapi.PostInventorySessionsSessionIdScansJSONRequestBody,
+) (oapi.InventoryScan, error) {
+	barcode := strings.TrimSpace(req.Barcode)
+	if barcode == "" || len(barcode) > maxBarcodeLen {
+		return oapi.InventoryScan{}, pvz_errors.ErrInvalidInventory
+	}
+
+	scan := oapi.InventoryScan{
+		Barcode:   barcode,
+		CellId:    req.CellId,
+		ScannedAt: time.Now(),
+	}
+	if userID != uuid.Nil {
+		scan.ScannedBy = &userID
+	}
+	return s.inventoryRepo.UpsertInventoryScan(ctx, sessionID, scan)
+}
+
+func (s *inventoryService) CloseSession(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+) (oapi.InventorySession, error) {
+	var closedBy *uuid.UUID
+	if userID != uuid.Nil {
+		closedBy = &userID
+	}
+	return s.inventoryRepo.CloseInventorySession(ctx, sessionID, closedBy, time.Now())
+}
+
+func (s *inventoryService) AcceptAdjustments(
+	ctx context.Context,
+	userID, sessionID uuid.UUID,
+	req oapi.PostInventorySessionsSessionIdAdjustmentsJSONRequestBody,
+) (oapi.InventorySession, error) {
+	seen := make(map[string]struct{}, len(req.Barcodes))
+	barcodes := make([]string, 0, len(req.Barcodes))
+	for _, raw := range req.Barcodes {
+		barcode := strings.TrimSpace(raw)
+		if barcode == "" {
+			return oapi.InventorySession{}, pvz_errors.ErrInvalidInventory
+		}
+		if _, ok := seen[barcode]; ok {
+			continue
+		}
+		seen[barcode] = struct{}{}
+		barcodes = append(barcodes, barcode)
+	}
+	if len(barcodes) == 0 || len(barcodes) > maxInventoryAdjustments {
+		return oapi.InventorySession{}, pvz_errors.ErrInvalidInventory
+	}
+
+	var acceptedBy *uuid.UUID
+	if userID != uuid.Nil {
+		acceptedBy = &userID
+	}
+	return s.inventoryRepo.AcceptInventoryAdjustments(ctx, sessionID, barcodes, acceptedBy, time.Now())
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockInventoryRepo struct{ mock.Mock }
+
+func (m *mockInventoryRepo) InsertInventorySession(
+	ctx context.Context,
+	session oapi.InventorySession,
+) (oapi.InventorySession, error) {
+	args := m.Called(ctx, session)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func (m *mockInventoryRepo) GetInventorySession(
+	ctx context.Context,
+	sessionID uuid.UUID,
+) (oapi.InventorySession, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func (m *mockInventoryRepo) UpsertInventoryScan(
+	ctx context.Context,
+	sessionID uuid.UUID,
+	scan oapi.InventoryScan,
+) (oapi.InventoryScan, error) {
+	args := m.Called(ctx, sessionID, scan)
+	return args.Get(0).(oapi.InventoryScan), args.Error(1)
+}
+
+func (m *mockInventoryRepo) CloseInventorySession(
+	ctx context.Context,
+	sessionID uuid.UUID,
+	closedBy *uuid.UUID,
+	closedAt time.Time,
+) (oapi.InventorySession, error) {
+	args := m.Called(ctx, sessionID, closedBy, closedAt)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func (m *mockInventoryRepo) AcceptInventoryAdjustments(
+	ctx context.Context,
+	sessionID uuid.UUID,
+	barcodes []string,
+	acceptedBy *uuid.UUID,
+	acceptedAt time.Time,
+) (oapi.InventorySession, error) {
+	args := m.Called(ctx, sessionID, barcodes, acceptedBy, acceptedAt)
+	return args.Get(0).(oapi.InventorySession), args.Error(1)
+}
+
+func TestStartInventorySession(t *testing.T) {
+	ctx := context.Background()
+	userID, pvzID := uuid.New(), uuid.New()
+
+	repo := new(mockInventoryRepo)
+	svc := NewInventoryService(repo)
+	repo.
+		On("InsertInventorySession", mock.Anything, mock.MatchedBy(func(s oapi.InventorySession) bool {
+			return s.PvzId == pvzID && s.Status == oapi.Counting && *s.StartedBy == userID
+		})).
+		Return(oapi.InventorySession{Status: oapi.Counting}, nil)
+
+	got, err := svc.StartSession(ctx, userID, oapi.PostInventorySessionsJSONRequestBody{PvzId: pvzID})
+	require.NoError(t, err)
+	require.Equal(t, oapi.Counting, got.Status)
+	repo.AssertExpectations(t)
+}
+
+func TestInventoryScan(t *testing.T) {
+	ctx := context.Background()
+	userID, sessionID := uuid.New(), uuid.New()
+
+	t.Run("empty barcode", func(t *testing.T) {
+		svc := NewInventoryService(new(mockInventoryRepo))
+		_, err := svc.Scan(ctx, userID, sessionID, oapi.PostInventorySessionsSessionIdScansJSONRequestBody{
+			Barcode: "  ",
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidInventory)
+	})
+
+	t.Run("trimmed barcode", func(t *testing.T) {
+		repo := new(mockInventoryRepo)
+		svc := NewInventoryService(repo)
+		repo.
+			On("UpsertInventoryScan", mock.Anything, sessionID, mock.MatchedBy(func(s oapi.InventoryScan) bool {
+				return s.Barcode == "4601234567890" && *s.ScannedBy == userID
+			})).
+			Return(oapi.InventoryScan{Barcode: "4601234567890"}, nil)
+
+		_, err := svc.Scan(ctx, userID, sessionID, oapi.PostInventorySessionsSessionIdScansJSONRequestBody{
+			Barcode: " 4601234567890 ",
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAcceptInventoryAdjustments(t *testing.T) {
+	ctx := context.Background()
+	userID, sessionID := uuid.New(), uuid.New()
+
+	t.Run("empty", func(t *testing.T) {
+		svc := NewInventoryService(new(mockInventoryRepo))
+		_, err := svc.AcceptAdjustments(ctx, userID, sessionID,
+			oapi.PostInventorySessionsSessionIdAdjustmentsJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidInventory)
+	})
+
+	t.Run("deduplicated", func(t *testing.T) {
+		repo := new(mockInventoryRepo)
+		svc := NewInventoryService(repo)
+		repo.
+			On("AcceptInventoryAdjustments", mock.Anything, sessionID, []string{"A-1", "A-2"}, &userID, mock.Anything).
+			Return(oapi.InventorySession{Status: oapi.Counted}, nil)
+
+		_, err := svc.AcceptAdjustments(ctx, userID, sessionID,
+			oapi.PostInventorySessionsSessionIdAdjustmentsJSONRequestBody{Barcodes: []string{"A-1", " A-1", "A-2"}})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
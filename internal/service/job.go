@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	jobRetention    = 7 * 24 * time.Hour
+	jobStaleAfter   = time.Hour
+	jobProgressStep = 1000
+)
+
+type jobRepository interface {
+	InsertJob(ctx context.Context, job oapi.Job) (oapi.Job, error)
+	GetJob(ctx context.Context, jobID uuid.UUID) (oapi.Job, error)
+	ClaimJob(ctx context.Context, startedAt time.Time) (oapi.Job, bool, error)
+	UpdateJobProgress(ctx context.Context, jobID uuid.UUID, progress int) error
+	FinishJob(
+		ctx context.Context,
+		jobID uuid.UUID,
+		status oapi.JobStatus,
+		progress int,
+		reason *string,
+		finishedAt, expiresAt time.Time,
+	) error
+	CancelJob(ctx context.Context, jobID uuid.UUID, cancelledAt, expiresAt time.Time) (oapi.Job, error)
+	FailStaleJobs(
+		ctx context.Context,
+		startedBefore, finishedAt, expiresAt time.Time,
+		reason string,
+	) ([]dto.JobFile, error)
+	ExpireJobs(ctx context.Context, now time.Time) ([]dto.JobFile, error)
+}
+
+type jobStorage interface {
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	Remove(name string) error
+}
+
+type intakeReporter interface {
+	GetIntakeReport(ctx context.Context, params oapi.GetReportsIntakeParams) (oapi.IntakeReport, error)
+}
+
+type jobService struct {
+	jobRepo    jobRepository
+	exportRepo exportRepository
+	reports    intakeReporter
+	storage    jobStorage
+}
+
+func NewJobService(
+	jobRepo jobRepository,
+	exportRepo exportRepository,
+	reports intakeReporter,
+	storage jobStorage,
+) *jobService {
+	return &jobService{jobRepo: jobRepo, exportRepo: exportRepo, reports: reports, storage: storage}
+}
+
+func (s *jobService) CreateJob(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	req oapi.PostJobsJSONRequestBody,
+) (oapi.Job, error) {
+	job := oapi.Job{
+		Id:        uuid.New(),
+		Kind:      req.Kind,
+		Format:    oapi.Csv,
+		Status:    oapi.Queued,
+		CreatedAt: time.Now(),
+	}
+	if req.Format != nil {
+		job.Format = *req.Format
+	}
+	if req.Params != nil {
+		job.Params = *req.Params
+	}
+	if userID != uuid.Nil {
+		job.CreatedBy = &userID
+	}
+	if export.ContentType(export.Format(job.Format)) == "" {
+		return oapi.Job{}, pvz_errors.ErrInvalidJob
+	}
+
+	switch job.Kind {
+	case oapi.ExportPvz, oapi.ExportReceptions, oapi.ExportProducts:
+		if _, err := exportFilter(job.Params.StartDate, job.Params.EndDate); err != nil {
+			return oapi.Job{}, pvz_errors.ErrInvalidJob
+		}
+	case oapi.ReportIntake:
+		if role != oapi.UserRoleModerator {
+			return oapi.Job{}, pvz_errors.ErrJobForbidden
+		}
+		params, ok := intakeParams(job.Params)
+		if !ok {
+			return oapi.Job{}, pvz_errors.ErrInvalidJob
+		}
+		if _, _, err := reportRange(params.From, params.To, *params.Granularity); err != nil {
+			return oapi.Job{}, pvz_errors.ErrInvalidJob
+		}
+	default:
+		return oapi.Job{}, pvz_errors.ErrInvalidJob
+	}
+	return s.jobRepo.InsertJob(ctx, job)
+}
+
+func (s *jobService) GetJob(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	jobID uuid.UUID,
+) (oapi.Job, error) {
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil {
+		return oapi.Job{}, err
+	}
+	if role != oapi.UserRoleModerator && (job.CreatedBy == nil || *job.CreatedBy != userID) {
+		return oapi.Job{}, pvz_errors.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (s *jobService) OpenJobFile(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	jobID uuid.UUID,
+) (io.ReadCloser, oapi.Job, error) {
+	job, err := s.GetJob(ctx, userID, role, jobID)
+	if err != nil {
+		return nil, oapi.Job{}, err
+	}
+	switch job.Status {
+	case oapi.Succeeded:
+	case oapi.Expired:
+		return nil, oapi.Job{}, pvz_errors.ErrJobExpired
+	default:
+		return nil, oapi.Job{}, pvz_errors.ErrJobNotReady
+	}
+
+	file, err := s.storage.Open(jobFileName(job.Id, string(job.Format)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, oapi.Job{}, pvz_errors.ErrJobExpired
+		}
+		return nil, oapi.Job{}, err
+	}
+	return file, job, nil
+}
+
+func (s *jobService) CancelJob(
+	ctx context.Context,
+	userID uuid.UUID,
+	role oapi.UserRole,
+	jobID uuid.UUID,
+) (oapi.Job, error) {
+	if _, err := s.GetJob(ctx, userID, role, jobID); err != nil {
+		return oapi.Job{}, err
+	}
+	now := time.Now()
+	return s.jobRepo.CancelJob(ctx, jobID, now, now.Add(jobRetention))
+}
+
+// ProcessNextJob claims the oldest queued job and runs it to completion. It
+// reports false when the queue is empty.
+func (s *jobService) ProcessNextJob(ctx context.Context) (bool, error) {
+	job, ok, err := s.jobRepo.ClaimJob(ctx, time.Now())
+	if err != nil || !ok {
+		return false, err
+	}
+
+	name := jobFileName(job.Id, string(job.Format))
+	progress, runErr := s.runJob(ctx, job, name)
+	if runErr != nil {
+		_ = s.storage.Remove(name)
+		if errors.Is(runErr, pvz_errors.ErrJobCancelled) {
+			return true, nil
+		}
+	}
+
+	// the job context may already be done, but the outcome still has to be recorded
+	ctx = context.WithoutCancel(ctx)
+	finishedAt := time.Now()
+	status, reason := oapi.Succeeded, (*string)(nil)
+	if runErr != nil {
+		message := runErr.Error()
+		status, reason = oapi.Errored, &message
+	}
+	err = s.jobRepo.FinishJob(ctx, job.Id, status, progress, reason, finishedAt, finishedAt.Add(jobRetention))
+	if errors.Is(err, pvz_errors.ErrJobCancelled) {
+		return true, s.storage.Remove(name)
+	}
+	if err != nil {
+		return true, err
+	}
+	if runErr != nil {
+		return true, fmt.Errorf("job %s: %w", job.Id, runErr)
+	}
+	return true, nil
+}
+
+// ExpireJobs fails jobs abandoned by a crashed worker and removes results
+// that are past their retention period.
+func (s *jobService) ExpireJobs(ctx context.Context) (int, error) {
+	now := time.Now()
+	stale, err := s.jobRepo.FailStaleJobs(ctx, now.Add(-jobStaleAfter), now, now.Add(jobRetention),
+		"обработчик задачи не ответил вовремя")
+	if err != nil {
+		return 0, err
+	}
+	expired, err := s.jobRepo.ExpireJobs(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, file := range append(stale, expired...) {
+		if err = s.storage.Remove(jobFileName(file.ID, file.Format)); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+func (s *jobService) runJob(ctx context.Context, job oapi.Job, name string) (int, error) {
+	file, err := s.storage.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	w, err := export.NewWriter(export.Format(job.Format), file)
+	if err != nil {
+		return 0, err
+	}
+	tracked := &progressWriter{Writer: w, ctx: ctx, jobID: job.Id, jobRepo: s.jobRepo}
+
+	filter, err := exportFilter(job.Params.StartDate, job.Params.EndDate)
+	if err != nil {
+		return 0, err
+	}
+	filter.PvzID = job.Params.PvzId
+	filter.RegionID = job.Params.RegionId
+	filter.ProductType = trimmedOrNil(job.Params.ProductType)
+
+	switch job.Kind {
+	case oapi.ExportPvz:
+		err = s.exportRepo.ExportPVZs(ctx, filter, tracked)
+	case oapi.ExportReceptions:
+		err = s.exportRepo.ExportReceptions(ctx, filter, tracked)
+	case oapi.ExportProducts:
+		err = s.exportRepo.ExportProducts(ctx, filter, tracked)
+	case oapi.ReportIntake:
+		err = s.writeIntakeReport(ctx, job.Params, tracked)
+	default:
+		err = pvz_errors.ErrInvalidJob
+	}
+	if err != nil {
+		return tracked.rows, err
+	}
+	if err = w.Close(); err != nil {
+		return tracked.rows, err
+	}
+	return tracked.rows, file.Close()
+}
+
+func (s *jobService) writeIntakeReport(ctx context.Context, jobParams oapi.JobParams, w export.Writer) error {
+	params, ok := intakeParams(jobParams)
+	if !ok {
+		return pvz_errors.ErrInvalidJob
+	}
+	report, err := s.reports.GetIntakeReport(ctx, params)
+	if err != nil {
+		return err
+	}
+	return writeIntakeRows(w, report)
+}
+
+func writeIntakeRows(w export.Writer, report oapi.IntakeReport) error {
+	header := []string{"periodStart", "key", "receptions", "products", "previousProducts", "productsChangePct"}
+	if err := w.WriteHeader(header); err != nil {
+		return err
+	}
+	for _, row := range report.Rows {
+		err := w.WriteRow([]any{
+			row.PeriodStart, row.Key, row.Receptions, row.Products, row.PreviousProducts, row.ProductsChangePct,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func intakeParams(params oapi.JobParams) (oapi.GetReportsIntakeParams, bool) {
+	if params.StartDate == nil || params.EndDate == nil {
+		return oapi.GetReportsIntakeParams{}, false
+	}
+	granularity := oapi.Day
+	if params.Granularity != nil {
+		granularity = *params.Granularity
+	}
+	return oapi.GetReportsIntakeParams{
+		From:        *params.StartDate,
+		To:          *params.EndDate,
+		Granularity: &granularity,
+		GroupBy:     params.GroupBy,
+		City:        params.City,
+		PvzId:       params.PvzId,
+		ProductType: params.ProductType,
+	}, true
+}
+
+func jobFileName(jobID uuid.UUID, format string) string {
+	return jobID.String() + "." + format
+}
+
+// progressWriter records the number of written rows every jobProgressStep
+// rows; the update fails once the job has been cancelled, which stops the
+// export early.
+type progressWriter struct {
+	export.Writer
+	ctx     context.Context
+	jobID   uuid.UUID
+	jobRepo jobRepository
+	rows    int
+}
+
+func (w *progressWriter) WriteRow(values []any) error {
+	if err := w.Writer.WriteRow(values); err != nil {
+		return err
+	}
+	w.rows++
+	if w.rows%jobProgressStep == 0 {
+		return w.jobRepo.UpdateJobProgress(w.ctx, w.jobID, w.rows)
+	}
+	return nil
+}
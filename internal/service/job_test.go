@@ -0,0 +1,255 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockJobRepo struct{ mock.Mock }
+
+func (m *mockJobRepo) InsertJob(ctx context.Context, job oapi.Job) (oapi.Job, error) {
+	args := m.Called(ctx, job)
+	return args.Get(0).(oapi.Job), args.Error(1)
+}
+
+func (m *mockJobRepo) GetJob(ctx context.Context, jobID uuid.UUID) (oapi.Job, error) {
+	args := m.Called(ctx, jobID)
+	return args.Get(0).(oapi.Job), args.Error(1)
+}
+
+func (m *mockJobRepo) ClaimJob(ctx context.Context, startedAt time.Time) (oapi.Job, bool, error) {
+	args := m.Called(ctx, startedAt)
+	return args.Get(0).(oapi.Job), args.Bool(1), args.Error(2)
+}
+
+func (m *mockJobRepo) UpdateJobProgress(ctx context.Context, jobID uuid.UUID, progress int) error {
+	args := m.Called(ctx, jobID, progress)
+	return args.Error(0)
+}
+
+func (m *mockJobRepo) FinishJob(
+	ctx context.Context,
+	jobID uuid.UUID,
+	status oapi.JobStatus,
+	progress int,
+	reason *string,
+	finishedAt, expiresAt time.Time,
+) error {
+	args := m.Called(ctx, jobID, status, progress, reason, finishedAt, expiresAt)
+	return args.Error(0)
+}
+
+func (m *mockJobRepo) CancelJob(
+	ctx context.Context,
+	jobID uuid.UUID,
+	cancelledAt, expiresAt time.Time,
+) (oapi.Job, error) {
+	args := m.Called(ctx, jobID, cancelledAt, expiresAt)
+	return args.Get(0).(oapi.Job), args.Error(1)
+}
+
+func (m *mockJobRepo) FailStaleJobs(
+	ctx context.Context,
+	startedBefore, finishedAt, expiresAt time.Time,
+	reason string,
+) ([]dto.JobFile, error) {
+	args := m.Called(ctx, startedBefore, finishedAt, expiresAt, reason)
+	return args.Get(0).([]dto.JobFile), args.Error(1)
+}
+
+func (m *mockJobRepo) ExpireJobs(ctx context.Context, now time.Time) ([]dto.JobFile, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]dto.JobFile), args.Error(1)
+}
+
+type memoryFile struct{ bytes.Buffer }
+
+func (f *memoryFile) Close() error { return nil }
+
+type memoryStorage struct {
+	files   map[string]*memoryFile
+	removed []string
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: map[string]*memoryFile{}}
+}
+
+func (s *memoryStorage) Create(name string) (io.WriteCloser, error) {
+	s.files[name] = &memoryFile{}
+	return s.files[name], nil
+}
+
+func (s *memoryStorage) Open(name string) (io.ReadCloser, error) {
+	file, ok := s.files[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(file.Bytes())), nil
+}
+
+func (s *memoryStorage) Remove(name string) error {
+	delete(s.files, name)
+	s.removed = append(s.removed, name)
+	return nil
+}
+
+func TestCreateJob(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	start, end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("export defaults to csv", func(t *testing.T) {
+		repo := new(mockJobRepo)
+		svc := NewJobService(repo, new(mockExportRepo), nil, newMemoryStorage())
+		repo.On("InsertJob", ctx, mock.MatchedBy(func(job oapi.Job) bool {
+			return job.Kind == oapi.ExportProducts && job.Format == oapi.Csv &&
+				job.Status == oapi.Queued && *job.CreatedBy == userID
+		})).Return(oapi.Job{Kind: oapi.ExportProducts}, nil)
+
+		_, err := svc.CreateJob(ctx, userID, oapi.UserRoleEmployee, oapi.PostJobsJSONRequestBody{
+			Kind:   oapi.ExportProducts,
+			Params: &oapi.JobParams{StartDate: &start, EndDate: &end},
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name string
+		role oapi.UserRole
+		req  oapi.PostJobsJSONRequestBody
+		err  error
+	}{
+		{
+			name: "report requires moderator",
+			role: oapi.UserRoleEmployee,
+			req: oapi.PostJobsJSONRequestBody{
+				Kind: oapi.ReportIntake, Params: &oapi.JobParams{StartDate: &start, EndDate: &end},
+			},
+			err: pvz_errors.ErrJobForbidden,
+		},
+		{
+			name: "report requires period",
+			role: oapi.UserRoleModerator,
+			req:  oapi.PostJobsJSONRequestBody{Kind: oapi.ReportIntake},
+			err:  pvz_errors.ErrInvalidJob,
+		},
+		{
+			name: "reversed range",
+			role: oapi.UserRoleEmployee,
+			req: oapi.PostJobsJSONRequestBody{
+				Kind: oapi.ExportPvz, Params: &oapi.JobParams{StartDate: &end, EndDate: &start},
+			},
+			err: pvz_errors.ErrInvalidJob,
+		},
+		{
+			name: "unknown kind",
+			role: oapi.UserRoleModerator,
+			req:  oapi.PostJobsJSONRequestBody{Kind: oapi.JobKind("export_orders")},
+			err:  pvz_errors.ErrInvalidJob,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewJobService(new(mockJobRepo), new(mockExportRepo), nil, newMemoryStorage())
+			_, err := svc.CreateJob(ctx, userID, tc.role, tc.req)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestGetJobHidesForeignJobs(t *testing.T) {
+	ctx := context.Background()
+	owner, other := uuid.New(), uuid.New()
+	jobID := uuid.New()
+	repo := new(mockJobRepo)
+	svc := NewJobService(repo, new(mockExportRepo), nil, newMemoryStorage())
+	repo.On("GetJob", ctx, jobID).Return(oapi.Job{Id: jobID, CreatedBy: &owner, Status: oapi.Expired}, nil)
+
+	_, err := svc.GetJob(ctx, other, oapi.UserRoleEmployee, jobID)
+	require.ErrorIs(t, err, pvz_errors.ErrJobNotFound)
+
+	_, _, err = svc.OpenJobFile(ctx, other, oapi.UserRoleModerator, jobID)
+	require.ErrorIs(t, err, pvz_errors.ErrJobExpired)
+}
+
+func TestProcessNextJob(t *testing.T) {
+	ctx := context.Background()
+	jobID := uuid.New()
+	job := oapi.Job{Id: jobID, Kind: oapi.ExportPvz, Format: oapi.Ndjson, Status: oapi.Running}
+	name := jobID.String() + ".ndjson"
+
+	t.Run("empty queue", func(t *testing.T) {
+		repo := new(mockJobRepo)
+		svc := NewJobService(repo, new(mockExportRepo), nil, newMemoryStorage())
+		repo.On("ClaimJob", ctx, mock.Anything).Return(oapi.Job{}, false, nil)
+
+		processed, err := svc.ProcessNextJob(ctx)
+		require.NoError(t, err)
+		require.False(t, processed)
+	})
+
+	t.Run("writes result and finishes", func(t *testing.T) {
+		repo, exportRepo, storage := new(mockJobRepo), new(mockExportRepo), newMemoryStorage()
+		svc := NewJobService(repo, exportRepo, nil, storage)
+		repo.On("ClaimJob", ctx, mock.Anything).Return(job, true, nil)
+		exportRepo.On("ExportPVZs", ctx, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				w := args.Get(2).(export.Writer)
+				_ = w.WriteHeader([]string{"city"})
+				_ = w.WriteRow([]any{"Москва"})
+				_ = w.WriteRow([]any{"Казань"})
+			}).
+			Return(nil)
+		repo.On("FinishJob", mock.Anything, jobID, oapi.Succeeded, 2, (*string)(nil), mock.Anything, mock.Anything).
+			Return(nil)
+
+		processed, err := svc.ProcessNextJob(ctx)
+		require.NoError(t, err)
+		require.True(t, processed)
+		require.Equal(t, "{\"city\":\"Москва\"}\n{\"city\":\"Казань\"}\n", storage.files[name].String())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("cancelled while running", func(t *testing.T) {
+		repo, exportRepo, storage := new(mockJobRepo), new(mockExportRepo), newMemoryStorage()
+		svc := NewJobService(repo, exportRepo, nil, storage)
+		repo.On("ClaimJob", ctx, mock.Anything).Return(job, true, nil)
+		exportRepo.On("ExportPVZs", ctx, mock.Anything, mock.Anything).Return(pvz_errors.ErrJobCancelled)
+
+		processed, err := svc.ProcessNextJob(ctx)
+		require.NoError(t, err)
+		require.True(t, processed)
+		require.Equal(t, []string{name}, storage.removed)
+		repo.AssertNotCalled(t, "FinishJob")
+	})
+}
+
+func TestExpireJobsRemovesFiles(t *testing.T) {
+	ctx := context.Background()
+	stale, expired := uuid.New(), uuid.New()
+	repo, storage := new(mockJobRepo), newMemoryStorage()
+	svc := NewJobService(repo, new(mockExportRepo), nil, storage)
+	repo.On("FailStaleJobs", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]dto.JobFile{{ID: stale, Format: "csv"}}, nil)
+	repo.On("ExpireJobs", ctx, mock.Anything).Return([]dto.JobFile{{ID: expired, Format: "xlsx"}}, nil)
+
+	count, err := svc.ExpireJobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Equal(t, []string{stale.String() + ".csv", expired.String() + ".xlsx"}, storage.removed)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/label"
+)
+
+const (
+	maxLabelBatch     = 200
+	maxLabelHeaderLen = 64
+	minLabelWidthMm   = 30
+	minLabelHeightMm  = 30
+	maxLabelSideMm    = 150
+)
+
+type labelRepository interface {
+	SelectLabelData(ctx context.Context, productIDs []uuid.UUID) ([]dto.LabelData, error)
+	GetLabelTemplate(ctx context.Context, pvzID uuid.UUID) (*oapi.LabelTemplate, error)
+	UpsertLabelTemplate(ctx context.Context, tmpl oapi.LabelTemplate) (oapi.LabelTemplate, error)
+}
+
+type labelService struct {
+	labelRepo labelRepository
+}
+
+func NewLabelService(repo labelRepository) *labelService {
+	return &labelService{labelRepo: repo}
+}
+
+func defaultLabelTemplate(pvzID uuid.UUID) oapi.LabelTemplate {
+	return oapi.LabelTemplate{PvzId: pvzID, Symbology: oapi.Code128, WidthMm: 58, HeightMm: 40, Dpi: 203}
+}
+
+func (s *labelService) RenderProductLabel(
+	ctx context.Context,
+	productID uuid.UUID,
+	params oapi.GetProductsProductIdLabelParams,
+) ([]byte, oapi.LabelFormat, error) {
+	return s.render(ctx, []uuid.UUID{productID}, params.Format)
+}
+
+func (s *labelService) RenderLabels(
+	ctx context.Context,
+	req oapi.PostLabelsJSONRequestBody,
+) ([]byte, oapi.LabelFormat, error) {
+	if len(req.ProductIds) == 0 || len(req.ProductIds) > maxLabelBatch {
+		return nil, "", pvz_errors.ErrInvalidLabel
+	}
+	return s.render(ctx, req.ProductIds, req.Format)
+}
+
+func (s *labelService) render(
+	ctx context.Context,
+	productIDs []uuid.UUID,
+	format *oapi.LabelFormat,
+) ([]byte, oapi.LabelFormat, error) {
+	labelFormat := oapi.Pdf
+	if format != nil {
+		labelFormat = *format
+	}
+	if labelFormat != oapi.Pdf && labelFormat != oapi.Zpl {
+		return nil, "", pvz_errors.ErrInvalidLabel
+	}
+
+	rows, err := s.labelRepo.SelectLabelData(ctx, uniqueIDs(productIDs))
+	if err != nil {
+		return nil, "", err
+	}
+	byID := make(map[uuid.UUID]dto.LabelData, len(rows))
+	for _, row := range rows {
+		byID[row.ProductID] = row
+	}
+
+	labels := make([]label.Label, 0, len(productIDs))
+	for _, id := range productIDs {
+		data, ok := byID[id]
+		if !ok {
+			return nil, "", pvz_errors.ErrProductNotFound
+		}
+		labels = append(labels, buildLabel(data))
+	}
+
+	var body []byte
+	if labelFormat == oapi.Zpl {
+		body, err = label.ZPL(labels)
+	} else {
+		body, err = label.PDF(labels)
+	}
+	if errors.Is(err, label.ErrUnsupportedBarcode) {
+		return nil, "", pvz_errors.ErrLabelNotPrintable
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return body, labelFormat, nil
+}
+
+func buildLabel(data dto.LabelData) label.Label {
+	tmpl := defaultLabelTemplate(data.PvzID)
+	if data.Template != nil {
+		tmpl = *data.Template
+	}
+
+	var lines []string
+	if tmpl.Header != nil {
+		lines = append(lines, *tmpl.Header)
+	}
+	lines = append(lines, "ПВЗ "+data.City, data.Type)
+	if data.Zone != nil && data.Rack != nil && data.Shelf != nil {
+		lines = append(lines, "Ячейка "+*data.Zone+"-"+*data.Rack+"-"+*data.Shelf)
+	}
+	lines = append(lines, "Принят "+data.ReceivedAt.Format("02.01.2006"))
+
+	barcode := data.ProductID.String()
+	if data.Barcode != nil {
+		barcode = *data.Barcode
+	}
+	return label.Label{
+		WidthMm:   float64(tmpl.WidthMm),
+		HeightMm:  float64(tmpl.HeightMm),
+		Dpi:       tmpl.Dpi,
+		Symbology: label.Symbology(tmpl.Symbology),
+		Barcode:   barcode,
+		Lines:     lines,
+	}
+}
+
+func (s *labelService) GetTemplate(ctx context.Context, pvzID uuid.UUID) (oapi.LabelTemplate, error) {
+	tmpl, err := s.labelRepo.GetLabelTemplate(ctx, pvzID)
+	if err != nil {
+		return oapi.LabelTemplate{}, err
+	}
+	if tmpl == nil {
+		return defaultLabelTemplate(pvzID), nil
+	}
+	return *tmpl, nil
+}
+
+func (s *labelService) SetTemplate(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PutPvzPvzIdLabelTemplateJSONRequestBody,
+) (oapi.LabelTemplate, error) {
+	if req.Symbology != oapi.Code128 && req.Symbology != oapi.Qr {
+		return oapi.LabelTemplate{}, pvz_errors.ErrInvalidLabel
+	}
+	if req.WidthMm < minLabelWidthMm || req.WidthMm > maxLabelSideMm ||
+		req.HeightMm < minLabelHeightMm || req.HeightMm > maxLabelSideMm {
+		return oapi.LabelTemplate{}, pvz_errors.ErrInvalidLabel
+	}
+	if req.Dpi != 203 && req.Dpi != 300 && req.Dpi != 600 {
+		return oapi.LabelTemplate{}, pvz_errors.ErrInvalidLabel
+	}
+
+	now := time.Now()
+	tmpl := oapi.LabelTemplate{
+		PvzId:     pvzID,
+		Symbology: req.Symbology,
+		WidthMm:   req.WidthMm,
+		HeightMm:  req.HeightMm,
+		Dpi:       req.Dpi,
+		UpdatedAt: &now,
+	}
+	if req.Header != nil {
+		header := strings.TrimSpace(*req.Header)
+		if utf8.RuneCountInString(header) > maxLabelHeaderLen {
+			return oapi.LabelTemplate{}, pvz_errors.ErrInvalidLabel
+		}
+		if header != "" {
+			tmpl.Header = &header
+		}
+	}
+	return s.labelRepo.UpsertLabelTemplate(ctx, tmpl)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockLabelRepo struct{ mock.Mock }
+
+func (m *mockLabelRepo) SelectLabelData(ctx context.Context, productIDs []uuid.UUID) ([]dto.LabelData, error) {
+	args := m.Called(ctx, productIDs)
+	return args.Get(0).([]dto.LabelData), args.Error(1)
+}
+
+func (m *mockLabelRepo) GetLabelTemplate(ctx context.Context, pvzID uuid.UUID) (*oapi.LabelTemplate, error) {
+	args := m.Called(ctx, pvzID)
+	tmpl, _ := args.Get(0).(*oapi.LabelTemplate)
+	return tmpl, args.Error(1)
+}
+
+func (m *mockLabelRepo) UpsertLabelTemplate(ctx context.Context, tmpl oapi.LabelTemplate) (oapi.LabelTemplate, error) {
+	args := m.Called(ctx, tmpl)
+	return args.Get(0).(oapi.LabelTemplate), args.Error(1)
+}
+
+func TestRenderLabels(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	barcode := "4600000000017"
+	zone, rack, shelf := "A", "1", "2"
+	rows := []dto.LabelData{
+		{ProductID: second, PvzID: pvzID, City: "Казань", Type: "обувь", ReceivedAt: time.Now()},
+		{
+			ProductID: first, PvzID: pvzID, City: "Казань", Type: "обувь", Barcode: &barcode,
+			ReceivedAt: time.Now(), Zone: &zone, Rack: &rack, Shelf: &shelf,
+			Template: &oapi.LabelTemplate{PvzId: pvzID, Symbology: oapi.Qr, WidthMm: 50, HeightMm: 50, Dpi: 300},
+		},
+	}
+
+	t.Run("zpl in request order", func(t *testing.T) {
+		repo := new(mockLabelRepo)
+		svc := NewLabelService(repo)
+		repo.On("SelectLabelData", mock.Anything, []uuid.UUID{first, second}).Return(rows, nil)
+		format := oapi.Zpl
+
+		body, got, err := svc.RenderLabels(ctx, oapi.PostLabelsJSONRequestBody{
+			ProductIds: []uuid.UUID{first, second, first},
+			Format:     &format,
+		})
+		require.NoError(t, err)
+		require.Equal(t, oapi.Zpl, got)
+		labels := strings.Split(strings.TrimSpace(string(body)), "^XZ")
+		require.Len(t, labels, 4)
+		require.Contains(t, labels[0], "^BQN")
+		require.Contains(t, labels[0], "Ячейка A-1-2")
+		require.Contains(t, labels[1], "^BCN")
+		require.Contains(t, labels[1], second.String())
+	})
+
+	t.Run("pdf by default", func(t *testing.T) {
+		repo := new(mockLabelRepo)
+		svc := NewLabelService(repo)
+		repo.On("SelectLabelData", mock.Anything, []uuid.UUID{second}).Return(rows[:1], nil)
+
+		body, got, err := svc.RenderProductLabel(ctx, second, oapi.GetProductsProductIdLabelParams{})
+		require.NoError(t, err)
+		require.Equal(t, oapi.Pdf, got)
+		require.True(t, bytes.HasPrefix(body, []byte("%PDF")))
+	})
+
+	t.Run("product not found", func(t *testing.T) {
+		repo := new(mockLabelRepo)
+		svc := NewLabelService(repo)
+		missing := uuid.New()
+		repo.On("SelectLabelData", mock.Anything, []uuid.UUID{missing}).Return([]dto.LabelData{}, nil)
+
+		_, _, err := svc.RenderProductLabel(ctx, missing, oapi.GetProductsProductIdLabelParams{})
+		require.ErrorIs(t, err, pvz_errors.ErrProductNotFound)
+	})
+
+	t.Run("barcode not printable", func(t *testing.T) {
+		repo := new(mockLabelRepo)
+		svc := NewLabelService(repo)
+		cyrillic := "штрихкод"
+		repo.
+			On("SelectLabelData", mock.Anything, []uuid.UUID{first}).
+			Return([]dto.LabelData{{ProductID: first, PvzID: pvzID, Barcode: &cyrillic}}, nil)
+
+		_, _, err := svc.RenderProductLabel(ctx, first, oapi.GetProductsProductIdLabelParams{})
+		require.ErrorIs(t, err, pvz_errors.ErrLabelNotPrintable)
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		svc := NewLabelService(new(mockLabelRepo))
+		_, _, err := svc.RenderLabels(ctx, oapi.PostLabelsJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidLabel)
+
+		format := oapi.LabelFormat("png")
+		_, _, err = svc.RenderLabels(ctx, oapi.PostLabelsJSONRequestBody{ProductIds: []uuid.UUID{first}, Format: &format})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidLabel)
+	})
+}
+
+func TestLabelTemplate(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+
+	t.Run("default template", func(t *testing.T) {
+		repo := new(mockLabelRepo)
+		svc := NewLabelService(repo)
+		repo.On("GetLabelTemplate", mock.Anything, pvzID).Return(nil, nil)
+
+		tmpl, err := svc.GetTemplate(ctx, pvzID)
+		require.NoError(t, err)
+		require.Equal(t, defaultLabelTemplate(pvzID), tmpl)
+	})
+
+	invalid := map[string]oapi.PutPvzPvzIdLabelTemplateJSONRequestBody{
+		"symbology":   {Symbology: "ean13", WidthMm: 58, HeightMm: 40, Dpi: 203},
+		"too narrow":  {Symbology: oapi.Code128, WidthMm: 20, HeightMm: 40, Dpi: 203},
+		"too tall":    {Symbology: oapi.Code128, WidthMm: 58, HeightMm: 200, Dpi: 203},
+		"dpi":         {Symbology: oapi.Qr, WidthMm: 58, HeightMm: 40, Dpi: 150},
+		"long header": {Symbology: oapi.Qr, WidthMm: 58, HeightMm: 40, Dpi: 203, Header: strPtr(strings.Repeat("я", 65))},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			svc := NewLabelService(new(mockLabelRepo))
+			_, err := svc.SetTemplate(ctx, pvzID, req)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidLabel)
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mockLabelRepo)
+		svc := NewLabelService(repo)
+		repo.
+			On("UpsertLabelTemplate", mock.Anything, mock.MatchedBy(func(tmpl oapi.LabelTemplate) bool {
+				return tmpl.PvzId == pvzID && tmpl.Header == nil && tmpl.UpdatedAt != nil
+			})).
+			Return(oapi.LabelTemplate{PvzId: pvzID}, nil)
+
+		_, err := svc.SetTemplate(ctx, pvzID, oapi.PutPvzPvzIdLabelTemplateJSONRequestBody{
+			Symbology: oapi.Qr, WidthMm: 58, HeightMm: 40, Dpi: 300, Header: strPtr("  "),
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/utils"
+)
+
+const (
+	maxCustomerRefLen = 128
+	maxOrderItems     = 100
+)
+
+type orderRepository interface {
+	InsertOrder(ctx context.Context, order oapi.Order, productIDs []uuid.UUID, codeHash string) (oapi.Order, error)
+	GetOrder(ctx context.Context, orderID uuid.UUID) (oapi.Order, error)
+	IssueOrder(
+		ctx context.Context,
+		orderID uuid.UUID,
+		codeHash string,
+		productIDs []uuid.UUID,
+		issuedBy uuid.UUID,
+		issuedAt time.Time,
+		nextCodeHash string,
+		beforeCommit func(oapi.Order) error,
+	) (oapi.Order, error)
+	ResetPickupCode(
+		ctx context.Context,
+		orderID uuid.UUID,
+		codeHash string,
+		unlock bool,
+		beforeCommit func(oapi.Order) error,
+	) (oapi.Order, error)
+}
+
+type pickupCodeNotifier interface {
+	NotifyPickupCode(ctx context.Context, notice dto.PickupCodeNotice) error
+}
+
+type orderService struct {
+	orderRepo orderRepository
+	notifier  pickupCodeNotifier
+}
+
+func NewOrderService(repo orderRepository, notifier pickupCodeNotifier) *orderService {
+	return &orderService{orderRepo: repo, notifier: notifier}
+}
+
+func (s *orderService) CreateOrder(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostOrdersJSONRequestBody,
+) (oapi.OrderWithPickupCode, error) {
+	customerRef := strings.TrimSpace(req.CustomerRef)
+	productIDs := uniqueIDs(req.ProductIds)
+	if customerRef == "" || utf8.RuneCountInString(customerRef) > maxCustomerRefLen ||
+		len(productIDs) == 0 || len(productIDs) > maxOrderItems {
+		return oapi.OrderWithPickupCode{}, pvz_errors.ErrInvalidOrder
+	}
+
+	code, err := utils.GeneratePickupCode()
+	if err != nil {
+		return oapi.OrderWithPickupCode{}, err
+	}
+
+	order := oapi.Order{
+		Id:          uuid.New(),
+		PvzId:       req.PvzId,
+		CustomerRef: customerRef,
+		Status:      oapi.AwaitingPickup,
+		CreatedBy:   &userID,
+		CreatedAt:   time.Now(),
+	}
+	created, err := s.orderRepo.InsertOrder(ctx, order, productIDs, utils.HashPickupCode(code))
+	if err != nil {
+		return oapi.OrderWithPickupCode{}, err
+	}
+	return oapi.OrderWithPickupCode{Order: created, PickupCode: &code}, nil
+}
+
+func (s *orderService) GetOrder(ctx context.Context, orderID uuid.UUID) (oapi.Order, error) {
+	return s.orderRepo.GetOrder(ctx, orderID)
+}
+
+// IssueOrder replaces the used pickup code. After a partial issue the new
+// code for the remaining items goes to the customer, never to the employee,
+// and the issue is rolled back if it cannot be sent.
+func (s *orderService) IssueOrder(
+	ctx context.Context,
+	userID, orderID uuid.UUID,
+	req oapi.PostOrdersOrderIdIssueJSONRequestBody,
+) (oapi.Order, error) {
+	pickupCode := strings.TrimSpace(req.PickupCode)
+	if pickupCode == "" {
+		return oapi.Order{}, pvz_errors.ErrInvalidOrder
+	}
+	productIDs := []uuid.UUID{}
+	if req.ProductIds != nil {
+		productIDs = uniqueIDs(*req.ProductIds)
+	}
+
+	nextCode, err := utils.GeneratePickupCode()
+	if err != nil {
+		return oapi.Order{}, err
+	}
+
+	return s.orderRepo.IssueOrder(ctx, orderID, utils.HashPickupCode(pickupCode),
+		productIDs, userID, time.Now(), utils.HashPickupCode(nextCode),
+		func(order oapi.Order) error {
+			if order.Status != oapi.PartiallyIssued {
+				return nil
+			}
+			if s.notifier == nil {
+				return pvz_errors.ErrPickupCodeNotifierOff
+			}
+			return s.sendPickupCode(ctx, order, nextCode)
+		})
+}
+
+// ResetPickupCode sends a fresh code straight to the customer so that the
+// employee who asked for it never sees it. The new code is only stored once
+// it has been sent. Only a moderator may reset a code locked by failed pickup
+// attempts.
+func (s *orderService) ResetPickupCode(
+	ctx context.Context,
+	role oapi.UserRole,
+	orderID uuid.UUID,
+) (oapi.Order, error) {
+	if s.notifier == nil {
+		return oapi.Order{}, pvz_errors.ErrPickupCodeNotifierOff
+	}
+
+	code, err := utils.GeneratePickupCode()
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	return s.orderRepo.ResetPickupCode(ctx, orderID, utils.HashPickupCode(code), role == oapi.UserRoleModerator,
+		func(order oapi.Order) error {
+			return s.sendPickupCode(ctx, order, code)
+		})
+}
+
+func (s *orderService) sendPickupCode(ctx context.Context, order oapi.Order, code string) error {
+	notice := dto.PickupCodeNotice{
+		OrderID:     order.Id,
+		CustomerRef: order.CustomerRef,
+		PvzID:       order.PvzId,
+		PickupCode:  code,
+	}
+	if err := s.notifier.NotifyPickupCode(ctx, notice); err != nil {
+		return fmt.Errorf("%w: %w", pvz_errors.ErrPickupCodeNotSent, err)
+	}
+	return nil
+}
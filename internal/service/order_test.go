@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/utils"
+)
+
+type mockOrderRepo struct{ mock.Mock }
+
+func (m *mockOrderRepo) InsertOrder(
+	ctx context.Context,
+	order oapi.Order,
+	productIDs []uuid.UUID,
+	codeHash string,
+) (oapi.Order, error) {
+	args := m.Called(ctx, order, productIDs, codeHash)
+	return args.Get(0).(oapi.Order), args.Error(1)
+}
+
+func (m *mockOrderRepo) GetOrder(ctx context.Context, orderID uuid.UUID) (oapi.Order, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).(oapi.Order), args.Error(1)
+}
+
+func (m *mockOrderRepo) IssueOrder(
+	ctx context.Context,
+	orderID uuid.UUID,
+	codeHash string,
+	productIDs []uuid.UUID,
+	issuedBy uuid.UUID,
+	issuedAt time.Time,
+	nextCodeHash string,
+	beforeCommit func(oapi.Order) error,
+) (oapi.Order, error) {
+	args := m.Called(ctx, orderID, codeHash, productIDs, issuedBy, issuedAt, nextCodeHash)
+	order, err := args.Get(0).(oapi.Order), args.Error(1)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if err = beforeCommit(order); err != nil {
+		return oapi.Order{}, err
+	}
+	return order, nil
+}
+
+func (m *mockOrderRepo) ResetPickupCode(
+	ctx context.Context,
+	orderID uuid.UUID,
+	codeHash string,
+	unlock bool,
+	beforeCommit func(oapi.Order) error,
+) (oapi.Order, error) {
+	args := m.Called(ctx, orderID, codeHash, unlock)
+	order, err := args.Get(0).(oapi.Order), args.Error(1)
+	if err != nil {
+		return oapi.Order{}, err
+	}
+	if err = beforeCommit(order); err != nil {
+		return oapi.Order{}, err
+	}
+	return order, nil
+}
+
+type mockPickupCodeNotifier struct{ mock.Mock }
+
+func (m *mockPickupCodeNotifier) NotifyPickupCode(ctx context.Context, notice dto.PickupCodeNotice) error {
+	return m.Called(ctx, notice).Error(0)
+}
+
+func TestCreateOrder(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	productID := uuid.New()
+
+	t.Run("blank customer ref", func(t *testing.T) {
+		svc := NewOrderService(new(mockOrderRepo), nil)
+		_, err := svc.CreateOrder(ctx, userID, oapi.PostOrdersJSONRequestBody{
+			CustomerRef: "  ",
+			PvzId:       uuid.New(),
+			ProductIds:  []uuid.UUID{productID},
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidOrder)
+	})
+
+	t.Run("no products", func(t *testing.T) {
+		svc := NewOrderService(new(mockOrderRepo), nil)
+		_, err := svc.CreateOrder(ctx, userID, oapi.PostOrdersJSONRequestBody{CustomerRef: "ORD-1", PvzId: uuid.New()})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidOrder)
+	})
+
+	t.Run("stores hash of returned code", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		svc := NewOrderService(repo, nil)
+		var storedHash string
+		repo.
+			On("InsertOrder", mock.Anything,
+				mock.MatchedBy(func(o oapi.Order) bool {
+					return o.CustomerRef == "ORD-1" && *o.CreatedBy == userID && o.Status == oapi.AwaitingPickup
+				}),
+				[]uuid.UUID{productID}, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { storedHash = args.String(3) }).
+			Return(oapi.Order{CustomerRef: "ORD-1"}, nil)
+
+		got, err := svc.CreateOrder(ctx, userID, oapi.PostOrdersJSONRequestBody{
+			CustomerRef: " ORD-1 ",
+			PvzId:       uuid.New(),
+			ProductIds:  []uuid.UUID{productID, productID},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, got.PickupCode)
+		require.Len(t, *got.PickupCode, utils.PickupCodeLen)
+		require.Equal(t, utils.HashPickupCode(*got.PickupCode), storedHash)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestIssueOrderService(t *testing.T) {
+	ctx := context.Background()
+	userID, orderID := uuid.New(), uuid.New()
+
+	t.Run("empty code", func(t *testing.T) {
+		svc := NewOrderService(new(mockOrderRepo), nil)
+		_, err := svc.IssueOrder(ctx, userID, orderID, oapi.PostOrdersOrderIdIssueJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidOrder)
+	})
+
+	t.Run("partial issue sends new code to the customer", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		notifier := new(mockPickupCodeNotifier)
+		svc := NewOrderService(repo, notifier)
+		ids := []uuid.UUID{uuid.New()}
+		order := oapi.Order{Id: orderID, CustomerRef: "ORD-1", Status: oapi.PartiallyIssued}
+		var nextHash string
+		repo.
+			On("IssueOrder", mock.Anything, orderID, utils.HashPickupCode("123456"), ids, userID,
+				mock.AnythingOfType("time.Time"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { nextHash = args.String(6) }).
+			Return(order, nil)
+		notifier.
+			On("NotifyPickupCode", mock.Anything, mock.MatchedBy(func(n dto.PickupCodeNotice) bool {
+				return n.OrderID == orderID && utils.HashPickupCode(n.PickupCode) == nextHash
+			})).
+			Return(nil)
+
+		got, err := svc.IssueOrder(ctx, userID, orderID, oapi.PostOrdersOrderIdIssueJSONRequestBody{
+			PickupCode: " 123456 ",
+			ProductIds: &ids,
+		})
+		require.NoError(t, err)
+		require.Equal(t, order, got)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("partial issue without notifier", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		svc := NewOrderService(repo, nil)
+		repo.
+			On("IssueOrder", mock.Anything, orderID, mock.Anything, mock.Anything, userID,
+				mock.Anything, mock.Anything).
+			Return(oapi.Order{Status: oapi.PartiallyIssued}, nil)
+
+		_, err := svc.IssueOrder(ctx, userID, orderID, oapi.PostOrdersOrderIdIssueJSONRequestBody{PickupCode: "123456"})
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeNotifierOff)
+	})
+
+	t.Run("partial issue with unsent code", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		notifier := new(mockPickupCodeNotifier)
+		svc := NewOrderService(repo, notifier)
+		repo.
+			On("IssueOrder", mock.Anything, orderID, mock.Anything, mock.Anything, userID,
+				mock.Anything, mock.Anything).
+			Return(oapi.Order{Status: oapi.PartiallyIssued}, nil)
+		notifier.On("NotifyPickupCode", mock.Anything, mock.Anything).Return(errors.New("timeout"))
+
+		_, err := svc.IssueOrder(ctx, userID, orderID, oapi.PostOrdersOrderIdIssueJSONRequestBody{PickupCode: "123456"})
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeNotSent)
+	})
+
+	t.Run("completed order needs no notifier", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		svc := NewOrderService(repo, nil)
+		repo.
+			On("IssueOrder", mock.Anything, orderID, utils.HashPickupCode("654321"), []uuid.UUID{}, userID,
+				mock.AnythingOfType("time.Time"), mock.AnythingOfType("string")).
+			Return(oapi.Order{Status: oapi.Completed}, nil)
+
+		got, err := svc.IssueOrder(ctx, userID, orderID, oapi.PostOrdersOrderIdIssueJSONRequestBody{PickupCode: "654321"})
+		require.NoError(t, err)
+		require.Equal(t, oapi.Completed, got.Status)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		svc := NewOrderService(repo, nil)
+		repo.
+			On("IssueOrder", mock.Anything, orderID, mock.Anything, mock.Anything, userID,
+				mock.Anything, mock.Anything).
+			Return(oapi.Order{}, pvz_errors.ErrInvalidPickupCode)
+
+		_, err := svc.IssueOrder(ctx, userID, orderID, oapi.PostOrdersOrderIdIssueJSONRequestBody{PickupCode: "000000"})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidPickupCode)
+	})
+}
+
+func TestResetPickupCode(t *testing.T) {
+	ctx := context.Background()
+	orderID := uuid.New()
+	order := oapi.Order{Id: orderID, PvzId: uuid.New(), CustomerRef: "ORD-1", Status: oapi.AwaitingPickup}
+
+	t.Run("no notifier", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		svc := NewOrderService(repo, nil)
+
+		_, err := svc.ResetPickupCode(ctx, oapi.UserRoleEmployee, orderID)
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeNotifierOff)
+		repo.AssertNotCalled(t, "ResetPickupCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("code goes to the customer", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		notifier := new(mockPickupCodeNotifier)
+		svc := NewOrderService(repo, notifier)
+		var storedHash string
+		repo.
+			On("ResetPickupCode", mock.Anything, orderID, mock.AnythingOfType("string"), false).
+			Run(func(args mock.Arguments) { storedHash = args.String(2) }).
+			Return(order, nil)
+		notifier.
+			On("NotifyPickupCode", mock.Anything, mock.MatchedBy(func(n dto.PickupCodeNotice) bool {
+				return n.OrderID == orderID && n.CustomerRef == "ORD-1" && utils.HashPickupCode(n.PickupCode) == storedHash
+			})).
+			Return(nil)
+
+		got, err := svc.ResetPickupCode(ctx, oapi.UserRoleEmployee, orderID)
+		require.NoError(t, err)
+		require.Equal(t, order, got)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("moderator unlocks", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		notifier := new(mockPickupCodeNotifier)
+		svc := NewOrderService(repo, notifier)
+		repo.On("ResetPickupCode", mock.Anything, orderID, mock.Anything, true).Return(order, nil)
+		notifier.On("NotifyPickupCode", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := svc.ResetPickupCode(ctx, oapi.UserRoleModerator, orderID)
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("locked for employee", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		notifier := new(mockPickupCodeNotifier)
+		svc := NewOrderService(repo, notifier)
+		repo.
+			On("ResetPickupCode", mock.Anything, orderID, mock.Anything, false).
+			Return(oapi.Order{}, pvz_errors.ErrPickupCodeLocked)
+
+		_, err := svc.ResetPickupCode(ctx, oapi.UserRoleEmployee, orderID)
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeLocked)
+		notifier.AssertNotCalled(t, "NotifyPickupCode", mock.Anything, mock.Anything)
+	})
+
+	t.Run("delivery failed", func(t *testing.T) {
+		repo := new(mockOrderRepo)
+		notifier := new(mockPickupCodeNotifier)
+		svc := NewOrderService(repo, notifier)
+		repo.On("ResetPickupCode", mock.Anything, orderID, mock.Anything, false).Return(order, nil)
+		notifier.On("NotifyPickupCode", mock.Anything, mock.Anything).Return(errors.New("timeout"))
+
+		_, err := svc.ResetPickupCode(ctx, oapi.UserRoleEmployee, orderID)
+		require.ErrorIs(t, err, pvz_errors.ErrPickupCodeNotSent)
+	})
+}
@@ -2,46 +2,77 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
 	"github.com/whaleship/pvz/internal/metrics"
 )
 
-type productRepoWriter interface {
-	InsertProduct(ctx context.Context,
-		pvzID, productID uuid.UUID,
-		dateTime time.Time,
-		productType string) (uuid.UUID, error)
-	DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error
+const maxSKULen = 128
+
+type productRepository interface {
+	InsertProduct(ctx context.Context, pvzID uuid.UUID, product oapi.Product) (uuid.UUID, error)
+	DeleteLastProduct(ctx context.Context, pvzID, deletedBy uuid.UUID, deletedAt time.Time) error
+	GetProductByBarcode(ctx context.Context, barcode string, pvzID *uuid.UUID) (oapi.Product, error)
+	InsertProductBatch(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		products []oapi.Product,
+		atomic bool,
+	) (uuid.UUID, []error, error)
+	TrashProduct(ctx context.Context, productID, deletedBy uuid.UUID, deletedAt time.Time) (oapi.DeletedProduct, error)
+	UpdateProduct(
+		ctx context.Context,
+		productID uuid.UUID,
+		patch oapi.PatchProductsProductIdJSONRequestBody,
+	) (oapi.Product, error)
+	RestoreProduct(ctx context.Context, productID uuid.UUID) (oapi.Product, error)
+	SelectDeletedProducts(ctx context.Context, receptionID uuid.UUID) ([]oapi.DeletedProduct, error)
 }
 
 type productService struct {
-	productRepo productRepoWriter
+	productRepo productRepository
 	metrics     metrics.MetricsSender
 }
 
-func NewProductService(repo productRepoWriter, aggregator metrics.MetricsSender) *productService {
+func NewProductService(repo productRepository, aggregator metrics.MetricsSender) *productService {
 	return &productService{
 		productRepo: repo,
 		metrics:     aggregator,
 	}
 }
 
-func (s *productService) AddProduct(ctx context.Context, req oapi.PostProductsJSONRequestBody) (oapi.Product, error) {
+func (s *productService) AddProduct(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostProductsJSONRequestBody,
+) (oapi.Product, error) {
+	if err := normalizeProductCodes(&req); err != nil {
+		return oapi.Product{}, err
+	}
+
 	newProductID := uuid.New()
 	now := time.Now()
-	receptionID, err := s.productRepo.InsertProduct(ctx, req.PvzId, newProductID, now, string(req.Type))
+	product := oapi.Product{
+		Id:       &newProductID,
+		DateTime: &now,
+		Type:     req.Type,
+		Barcode:  req.Barcode,
+		Sku:      req.Sku,
+		CellId:   req.CellId,
+	}
+	if userID != uuid.Nil {
+		product.CreatedBy = &userID
+	}
+	receptionID, err := s.productRepo.InsertProduct(ctx, req.PvzId, product)
 	if err != nil {
 		return oapi.Product{}, err
 	}
-	product := oapi.Product{
-		Id:          &newProductID,
-		DateTime:    &now,
-		ReceptionId: receptionID,
-		Type:        oapi.ProductType(req.Type),
-	}
+	product.ReceptionId = receptionID
 
 	if s.metrics != nil {
 		s.metrics.SendBusinessMetricsUpdate(metrics.MetricsUpdate{
@@ -52,6 +83,36 @@ func (s *productService) AddProduct(ctx context.Context, req oapi.PostProductsJS
 	return product, nil
 }
 
-func (s *productService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
-	return s.productRepo.DeleteLastProduct(ctx, pvzID)
+func (s *productService) DeleteLastProduct(ctx context.Context, userID, pvzID uuid.UUID) error {
+	return s.productRepo.DeleteLastProduct(ctx, pvzID, userID, time.Now())
+}
+
+func (s *productService) GetProductByBarcode(
+	ctx context.Context,
+	code string,
+	params oapi.GetProductsByBarcodeCodeParams,
+) (oapi.Product, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || utf8.RuneCountInString(code) > maxBarcodeLen {
+		return oapi.Product{}, pvz_errors.ErrInvalidBarcode
+	}
+	return s.productRepo.GetProductByBarcode(ctx, code, params.PvzId)
+}
+
+func normalizeProductCodes(req *oapi.PostProductsJSONRequestBody) error {
+	if req.Barcode != nil {
+		barcode := strings.TrimSpace(*req.Barcode)
+		if barcode == "" || utf8.RuneCountInString(barcode) > maxBarcodeLen {
+			return pvz_errors.ErrInvalidBarcode
+		}
+		req.Barcode = &barcode
+	}
+	if req.Sku != nil {
+		sku := strings.TrimSpace(*req.Sku)
+		if sku == "" || utf8.RuneCountInString(sku) > maxSKULen {
+			return pvz_errors.ErrInvalidBarcode
+		}
+		req.Sku = &sku
+	}
+	return nil
 }
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/metrics"
+)
+
+const maxBatchItems = 1000
+
+func (s *productService) AddProductBatch(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostProductsBatchJSONRequestBody,
+) (oapi.ProductBatchResult, error) {
+	if len(req.Items) == 0 || len(req.Items) > maxBatchItems {
+		return oapi.ProductBatchResult{}, pvz_errors.ErrInvalidProductBatch
+	}
+	mode := oapi.Atomic
+	if req.Mode != nil {
+		switch *req.Mode {
+		case oapi.Atomic, oapi.Partial:
+			mode = *req.Mode
+		default:
+			return oapi.ProductBatchResult{}, pvz_errors.ErrInvalidProductBatch
+		}
+	}
+	atomic := mode == oapi.Atomic
+
+	now := time.Now()
+	failures := make([]error, len(req.Items))
+	products := make([]oapi.Product, 0, len(req.Items))
+	indexes := make([]int, 0, len(req.Items))
+	for i, item := range req.Items {
+		product, err := newBatchProduct(req.PvzId, item, now)
+		if err != nil {
+			failures[i] = err
+			continue
+		}
+		if userID != uuid.Nil {
+			product.CreatedBy = &userID
+		}
+		products = append(products, product)
+		indexes = append(indexes, i)
+	}
+
+	inserted := make([]*oapi.Product, len(req.Items))
+	if len(products) > 0 && (!atomic || len(products) == len(req.Items)) {
+		receptionID, repoFailures, err := s.productRepo.InsertProductBatch(ctx, req.PvzId, products, atomic)
+		if err != nil {
+			return oapi.ProductBatchResult{}, err
+		}
+		for j, idx := range indexes {
+			if repoFailures[j] != nil {
+				failures[idx] = repoFailures[j]
+				continue
+			}
+			products[j].ReceptionId = receptionID
+			inserted[idx] = &products[j]
+		}
+	}
+
+	result, err := buildBatchResult(mode, failures, inserted)
+	if result.Created > 0 && s.metrics != nil {
+		s.metrics.SendBusinessMetricsUpdate(metrics.MetricsUpdate{
+			ProductsAddedDelta: int64(result.Created),
+		})
+	}
+	return result, err
+}
+
+func newBatchProduct(pvzID uuid.UUID, item oapi.ProductBatchItem, now time.Time) (oapi.Product, error) {
+	req := oapi.PostProductsJSONRequestBody{
+		PvzId:   pvzID,
+		Type:    item.Type,
+		Barcode: item.Barcode,
+		Sku:     item.Sku,
+		CellId:  item.CellId,
+	}
+	if err := normalizeProductCodes(&req); err != nil {
+		return oapi.Product{}, err
+	}
+	id := uuid.New()
+	return oapi.Product{
+		Id:       &id,
+		DateTime: &now,
+		Type:     req.Type,
+		Barcode:  req.Barcode,
+		Sku:      req.Sku,
+		CellId:   req.CellId,
+	}, nil
+}
+
+func buildBatchResult(
+	mode oapi.ProductBatchMode,
+	failures []error,
+	inserted []*oapi.Product,
+) (oapi.ProductBatchResult, error) {
+	result := oapi.ProductBatchResult{
+		Mode:  mode,
+		Items: make([]oapi.ProductBatchItemResult, len(failures)),
+	}
+	var firstErr error
+	for i := range failures {
+		item := oapi.ProductBatchItemResult{Index: i, Status: oapi.Skipped}
+		switch {
+		case failures[i] != nil:
+			msg := failures[i].Error()
+			item.Status = oapi.Failed
+			item.Error = &msg
+			result.Failed++
+			if firstErr == nil {
+				firstErr = failures[i]
+			}
+		case inserted[i] != nil:
+			item.Status = oapi.Created
+			item.Product = inserted[i]
+			result.ReceptionId = &inserted[i].ReceptionId
+			result.Created++
+		}
+		result.Items[i] = item
+	}
+	if result.Created == 0 {
+		return result, firstErr
+	}
+	return result, nil
+}
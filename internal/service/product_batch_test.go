@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/metrics"
+)
+
+func TestAddProductBatch(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+	receptionID := uuid.New()
+	partial := oapi.Partial
+
+	t.Run("invalid request", func(t *testing.T) {
+		svc := NewProductService(new(mockProductRepo), nil)
+		unknown := oapi.ProductBatchMode("best_effort")
+
+		_, err := svc.AddProductBatch(ctx, uuid.Nil, oapi.PostProductsBatchJSONRequestBody{PvzId: pvzID})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidProductBatch)
+
+		_, err = svc.AddProductBatch(ctx, uuid.Nil, oapi.PostProductsBatchJSONRequestBody{
+			PvzId: pvzID,
+			Mode:  &unknown,
+			Items: []oapi.ProductBatchItem{{Type: "обувь"}},
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidProductBatch)
+	})
+
+	t.Run("atomic skips repository on invalid item", func(t *testing.T) {
+		repo := new(mockProductRepo)
+		svc := NewProductService(repo, nil)
+
+		result, err := svc.AddProductBatch(ctx, uuid.Nil, oapi.PostProductsBatchJSONRequestBody{
+			PvzId: pvzID,
+			Items: []oapi.ProductBatchItem{{Type: "обувь"}, {Type: "обувь", Barcode: strPtr(" ")}},
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidBarcode)
+		require.Equal(t, oapi.Atomic, result.Mode)
+		require.Equal(t, 0, result.Created)
+		require.Equal(t, 1, result.Failed)
+		require.Equal(t, oapi.Skipped, result.Items[0].Status)
+		require.Equal(t, oapi.Failed, result.Items[1].Status)
+		repo.AssertNotCalled(t, "InsertProductBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("partial maps repository failures back to items", func(t *testing.T) {
+		repo := new(mockProductRepo)
+		aggregator := new(mockMetrics)
+		svc := NewProductService(repo, aggregator)
+		cellID := uuid.New()
+		repo.
+			On("InsertProductBatch", mock.Anything, pvzID, mock.MatchedBy(func(p []oapi.Product) bool {
+				return len(p) == 2 && p[0].Type == "обувь" && p[1].Type == "одежда" &&
+					p[0].CellId == nil && p[1].CellId != nil && *p[1].CellId == cellID
+			}), false).
+			Return(receptionID, []error{nil, pvz_errors.ErrDuplicateBarcodeScan}, nil)
+		aggregator.
+			On("SendBusinessMetricsUpdate", metrics.MetricsUpdate{ProductsAddedDelta: 1}).
+			Return()
+
+		result, err := svc.AddProductBatch(ctx, uuid.Nil, oapi.PostProductsBatchJSONRequestBody{
+			PvzId: pvzID,
+			Mode:  &partial,
+			Items: []oapi.ProductBatchItem{
+				{Type: "обувь"},
+				{Type: "обувь", Sku: strPtr("")},
+				{Type: "одежда", Barcode: strPtr("A"), CellId: &cellID},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Created)
+		require.Equal(t, 2, result.Failed)
+		require.Equal(t, &receptionID, result.ReceptionId)
+		require.Equal(t, oapi.Created, result.Items[0].Status)
+		require.Equal(t, receptionID, result.Items[0].Product.ReceptionId)
+		require.Equal(t, oapi.Failed, result.Items[1].Status)
+		require.Equal(t, oapi.Failed, result.Items[2].Status)
+		require.Equal(t, pvz_errors.ErrDuplicateBarcodeScan.Error(), *result.Items[2].Error)
+		repo.AssertExpectations(t)
+		aggregator.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(mockProductRepo)
+		svc := NewProductService(repo, nil)
+		repo.
+			On("InsertProductBatch", mock.Anything, pvzID, mock.Anything, true).
+			Return(uuid.Nil, nil, errors.New("db down"))
+
+		_, err := svc.AddProductBatch(ctx, uuid.Nil, oapi.PostProductsBatchJSONRequestBody{
+			PvzId: pvzID,
+			Items: []oapi.ProductBatchItem{{Type: "обувь"}},
+		})
+		require.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
 	"github.com/whaleship/pvz/internal/metrics"
 )
@@ -17,16 +19,65 @@ import (
 type mockProductRepo struct{ mock.Mock }
 
 func (m *mockProductRepo) InsertProduct(
-	ctx context.Context, pvzID,
-	productID uuid.UUID,
-	dateTime time.Time,
-	productType string) (uuid.UUID, error) {
-	args := m.Called(ctx, pvzID, productID, dateTime, productType)
+	ctx context.Context,
+	pvzID uuid.UUID,
+	product oapi.Product) (uuid.UUID, error) {
+	args := m.Called(ctx, pvzID, product)
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
 
-func (m *mockProductRepo) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
-	return m.Called(ctx, pvzID).Error(0)
+func (m *mockProductRepo) DeleteLastProduct(
+	ctx context.Context,
+	pvzID, deletedBy uuid.UUID,
+	deletedAt time.Time,
+) error {
+	return m.Called(ctx, pvzID, deletedBy, deletedAt).Error(0)
+}
+
+func (m *mockProductRepo) GetProductByBarcode(
+	ctx context.Context,
+	barcode string,
+	pvzID *uuid.UUID) (oapi.Product, error) {
+	args := m.Called(ctx, barcode, pvzID)
+	return args.Get(0).(oapi.Product), args.Error(1)
+}
+
+func (m *mockProductRepo) InsertProductBatch(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	products []oapi.Product,
+	atomic bool) (uuid.UUID, []error, error) {
+	args := m.Called(ctx, pvzID, products, atomic)
+	failures, _ := args.Get(1).([]error)
+	return args.Get(0).(uuid.UUID), failures, args.Error(2)
+}
+
+func (m *mockProductRepo) TrashProduct(
+	ctx context.Context,
+	productID, deletedBy uuid.UUID,
+	deletedAt time.Time) (oapi.DeletedProduct, error) {
+	args := m.Called(ctx, productID, deletedBy, deletedAt)
+	return args.Get(0).(oapi.DeletedProduct), args.Error(1)
+}
+
+func (m *mockProductRepo) UpdateProduct(
+	ctx context.Context,
+	productID uuid.UUID,
+	patch oapi.PatchProductsProductIdJSONRequestBody) (oapi.Product, error) {
+	args := m.Called(ctx, productID, patch)
+	return args.Get(0).(oapi.Product), args.Error(1)
+}
+
+func (m *mockProductRepo) RestoreProduct(ctx context.Context, productID uuid.UUID) (oapi.Product, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).(oapi.Product), args.Error(1)
+}
+
+func (m *mockProductRepo) SelectDeletedProducts(
+	ctx context.Context,
+	receptionID uuid.UUID) ([]oapi.DeletedProduct, error) {
+	args := m.Called(ctx, receptionID)
+	return args.Get(0).([]oapi.DeletedProduct), args.Error(1)
 }
 
 func TestAddProduct(t *testing.T) {
@@ -39,19 +90,13 @@ func TestAddProduct(t *testing.T) {
 		pvzID := uuid.New()
 		req := oapi.PostProductsJSONRequestBody{
 			PvzId: pvzID,
-			Type:  oapi.PostProductsJSONBodyType("T"),
+			Type:  "T",
 		}
 		mockRepo.
-			On("InsertProduct",
-				mock.Anything,
-				pvzID,
-				mock.AnythingOfType("uuid.UUID"),
-				mock.AnythingOfType("time.Time"),
-				string(req.Type),
-			).
+			On("InsertProduct", mock.Anything, pvzID, mock.AnythingOfType("oapi.Product")).
 			Return(uuid.Nil, errors.New("fail"))
 
-		_, err := svc.AddProduct(ctx, req)
+		_, err := svc.AddProduct(ctx, uuid.Nil, req)
 		require.Error(t, err)
 
 		mockRepo.AssertExpectations(t)
@@ -60,28 +105,24 @@ func TestAddProduct(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		pvzID := uuid.New()
 		req := oapi.PostProductsJSONRequestBody{
-			PvzId: pvzID,
-			Type:  oapi.PostProductsJSONBodyType("T"),
+			PvzId:   pvzID,
+			Type:    "T",
+			Barcode: strPtr(" 4601234567890 "),
+			Sku:     strPtr("SKU-1"),
 		}
 
 		expectedReceptionID := uuid.New()
 
-		var capturedProductID uuid.UUID
-		var capturedTime time.Time
+		var captured oapi.Product
 
 		mockRepo.
 			On("InsertProduct",
 				mock.Anything,
 				pvzID,
-				mock.MatchedBy(func(id uuid.UUID) bool {
-					capturedProductID = id
+				mock.MatchedBy(func(p oapi.Product) bool {
+					captured = p
 					return true
 				}),
-				mock.MatchedBy(func(tm time.Time) bool {
-					capturedTime = tm
-					return true
-				}),
-				string(req.Type),
 			).
 			Return(expectedReceptionID, nil)
 
@@ -89,20 +130,42 @@ func TestAddProduct(t *testing.T) {
 			On("SendBusinessMetricsUpdate", metrics.MetricsUpdate{ProductsAddedDelta: 1}).
 			Return()
 
-		prod, err := svc.AddProduct(ctx, req)
+		userID := uuid.New()
+		prod, err := svc.AddProduct(ctx, userID, req)
 		require.NoError(t, err)
 
-		require.Equal(t, &capturedProductID, prod.Id)
+		require.Equal(t, captured.Id, prod.Id)
+		require.Equal(t, &userID, captured.CreatedBy)
+		require.NotNil(t, prod.Id)
 
 		require.Equal(t, expectedReceptionID, prod.ReceptionId)
 
-		require.Equal(t, oapi.ProductType(req.Type), prod.Type)
+		require.Equal(t, req.Type, prod.Type)
+		require.Equal(t, "4601234567890", *prod.Barcode)
+		require.Equal(t, "4601234567890", *captured.Barcode)
+		require.Equal(t, "SKU-1", *prod.Sku)
 
-		require.WithinDuration(t, capturedTime, *prod.DateTime, time.Millisecond*10)
+		require.WithinDuration(t, time.Now(), *prod.DateTime, time.Second)
 
 		mockRepo.AssertExpectations(t)
 		mockMetrics.AssertExpectations(t)
 	})
+
+	t.Run("invalid barcode", func(t *testing.T) {
+		mockRepo := new(mockProductRepo)
+		svc := NewProductService(mockRepo, nil)
+
+		for _, req := range []oapi.PostProductsJSONRequestBody{
+			{PvzId: uuid.New(), Type: "T", Barcode: strPtr("  ")},
+			{PvzId: uuid.New(), Type: "T", Barcode: strPtr(strings.Repeat("1", maxBarcodeLen+1))},
+			{PvzId: uuid.New(), Type: "T", Sku: strPtr("")},
+		} {
+			_, err := svc.AddProduct(ctx, uuid.Nil, req)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidBarcode)
+		}
+		mockRepo.AssertNotCalled(t, "InsertProduct", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("metrics nil", func(t *testing.T) {
 		mockRepo := new(mockProductRepo)
 		svc := NewProductService(mockRepo, nil)
@@ -111,42 +174,53 @@ func TestAddProduct(t *testing.T) {
 		req := oapi.PostProductsJSONRequestBody{PvzId: pvzID, Type: "T"}
 		expectedReceptionID := uuid.New()
 
-		var capturedProductID uuid.UUID
-
 		mockRepo.
-			On("InsertProduct",
-				mock.Anything,
-				pvzID,
-				mock.MatchedBy(func(id uuid.UUID) bool {
-					capturedProductID = id
-					return true
-				}),
-				mock.MatchedBy(func(tm time.Time) bool {
-					return true
-				}),
-				string(req.Type),
-			).
+			On("InsertProduct", mock.Anything, pvzID, mock.AnythingOfType("oapi.Product")).
 			Return(expectedReceptionID, nil)
 
-		prod, err := svc.AddProduct(context.Background(), req)
+		prod, err := svc.AddProduct(context.Background(), uuid.Nil, req)
 		require.NoError(t, err)
-		require.Equal(t, &capturedProductID, prod.Id)
+		require.NotNil(t, prod.Id)
 		require.Equal(t, expectedReceptionID, prod.ReceptionId)
 	})
 }
 
+func TestGetProductByBarcode(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(mockProductRepo)
+	svc := NewProductService(mockRepo, nil)
+	pvzID := uuid.New()
+
+	t.Run("blank code", func(t *testing.T) {
+		_, err := svc.GetProductByBarcode(ctx, " ", oapi.GetProductsByBarcodeCodeParams{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidBarcode)
+	})
+
+	t.Run("trimmed and scoped", func(t *testing.T) {
+		mockRepo.
+			On("GetProductByBarcode", mock.Anything, "4601234567890", &pvzID).
+			Return(oapi.Product{Type: "обувь"}, nil)
+
+		got, err := svc.GetProductByBarcode(ctx, " 4601234567890", oapi.GetProductsByBarcodeCodeParams{PvzId: &pvzID})
+		require.NoError(t, err)
+		require.Equal(t, "обувь", got.Type)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestDeleteLastProduct(t *testing.T) {
 	ctx := context.Background()
+	userID := uuid.New()
 	mockRepo := new(mockProductRepo)
 	svc := NewProductService(mockRepo, nil)
 
 	t.Run("delete error", func(t *testing.T) {
 		pvzID := uuid.New()
 		mockRepo.
-			On("DeleteLastProduct", mock.Anything, pvzID).
+			On("DeleteLastProduct", mock.Anything, pvzID, userID, mock.AnythingOfType("time.Time")).
 			Return(errors.New("nope"))
 
-		err := svc.DeleteLastProduct(ctx, pvzID)
+		err := svc.DeleteLastProduct(ctx, userID, pvzID)
 		require.Error(t, err)
 
 		mockRepo.AssertExpectations(t)
@@ -155,10 +229,10 @@ func TestDeleteLastProduct(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		pvzID := uuid.New()
 		mockRepo.
-			On("DeleteLastProduct", mock.Anything, pvzID).
+			On("DeleteLastProduct", mock.Anything, pvzID, userID, mock.AnythingOfType("time.Time")).
 			Return(nil)
 
-		err := svc.DeleteLastProduct(ctx, pvzID)
+		err := svc.DeleteLastProduct(ctx, userID, pvzID)
 		require.NoError(t, err)
 
 		mockRepo.AssertExpectations(t)
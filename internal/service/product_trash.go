@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func (s *productService) DeleteProduct(ctx context.Context, userID, productID uuid.UUID) (oapi.DeletedProduct, error) {
+	return s.productRepo.TrashProduct(ctx, productID, userID, time.Now())
+}
+
+func (s *productService) UpdateProduct(
+	ctx context.Context,
+	productID uuid.UUID,
+	req oapi.PatchProductsProductIdJSONRequestBody,
+) (oapi.Product, error) {
+	if req.Type == nil && req.Barcode == nil && req.Sku == nil {
+		return oapi.Product{}, pvz_errors.ErrInvalidProductUpdate
+	}
+	codes := oapi.PostProductsJSONRequestBody{Barcode: req.Barcode, Sku: req.Sku}
+	if err := normalizeProductCodes(&codes); err != nil {
+		return oapi.Product{}, err
+	}
+	req.Barcode, req.Sku = codes.Barcode, codes.Sku
+	return s.productRepo.UpdateProduct(ctx, productID, req)
+}
+
+func (s *productService) RestoreProduct(ctx context.Context, productID uuid.UUID) (oapi.Product, error) {
+	return s.productRepo.RestoreProduct(ctx, productID)
+}
+
+func (s *productService) GetDeletedProducts(ctx context.Context, receptionID uuid.UUID) ([]oapi.DeletedProduct, error) {
+	return s.productRepo.SelectDeletedProducts(ctx, receptionID)
+}
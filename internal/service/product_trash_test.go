@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+func TestDeleteProduct(t *testing.T) {
+	repo := new(mockProductRepo)
+	svc := NewProductService(repo, nil)
+	userID, productID := uuid.New(), uuid.New()
+	repo.
+		On("TrashProduct", mock.Anything, productID, userID, mock.AnythingOfType("time.Time")).
+		Return(oapi.DeletedProduct{}, pvz_errors.ErrProductNotInOpenReception)
+
+	_, err := svc.DeleteProduct(context.Background(), userID, productID)
+	require.ErrorIs(t, err, pvz_errors.ErrProductNotInOpenReception)
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateProduct(t *testing.T) {
+	ctx := context.Background()
+	productID := uuid.New()
+
+	t.Run("empty patch", func(t *testing.T) {
+		svc := NewProductService(new(mockProductRepo), nil)
+		_, err := svc.UpdateProduct(ctx, productID, oapi.PatchProductsProductIdJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidProductUpdate)
+	})
+
+	t.Run("blank barcode", func(t *testing.T) {
+		svc := NewProductService(new(mockProductRepo), nil)
+		_, err := svc.UpdateProduct(ctx, productID, oapi.PatchProductsProductIdJSONRequestBody{Barcode: strPtr(" ")})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidBarcode)
+	})
+
+	t.Run("trims codes", func(t *testing.T) {
+		repo := new(mockProductRepo)
+		svc := NewProductService(repo, nil)
+		typ := "одежда"
+		repo.
+			On("UpdateProduct", mock.Anything, productID,
+				mock.MatchedBy(func(p oapi.PatchProductsProductIdJSONRequestBody) bool {
+					return *p.Type == typ && *p.Barcode == "B" && p.Sku == nil
+				})).
+			Return(oapi.Product{Type: typ}, nil)
+
+		got, err := svc.UpdateProduct(ctx, productID, oapi.PatchProductsProductIdJSONRequestBody{
+			Type:    &typ,
+			Barcode: strPtr(" B "),
+		})
+		require.NoError(t, err)
+		require.Equal(t, typ, got.Type)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestRestoreAndListDeletedProducts(t *testing.T) {
+	repo := new(mockProductRepo)
+	svc := NewProductService(repo, nil)
+	productID, receptionID := uuid.New(), uuid.New()
+	repo.On("RestoreProduct", mock.Anything, productID).Return(oapi.Product{ReceptionId: receptionID}, nil)
+	repo.On("SelectDeletedProducts", mock.Anything, receptionID).Return([]oapi.DeletedProduct{}, nil)
+
+	restored, err := svc.RestoreProduct(context.Background(), productID)
+	require.NoError(t, err)
+	require.Equal(t, receptionID, restored.ReceptionId)
+
+	deleted, err := svc.GetDeletedProducts(context.Background(), receptionID)
+	require.NoError(t, err)
+	require.Empty(t, deleted)
+	repo.AssertExpectations(t)
+}
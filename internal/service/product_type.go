@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	maxProductTypeCodeLen = 50
+	maxDisplayNameLen     = 255
+	maxLocaleLen          = 10
+)
+
+type productTypeRepository interface {
+	SelectProductTypes(ctx context.Context, includeInactive bool) ([]oapi.ProductTypeInfo, error)
+	InsertProductType(ctx context.Context, productType oapi.ProductTypeInfo) (oapi.ProductTypeInfo, error)
+	UpdateProductType(ctx context.Context, productType oapi.ProductTypeInfo) (oapi.ProductTypeInfo, error)
+}
+
+type productTypeService struct {
+	productTypeRepo productTypeRepository
+}
+
+func NewProductTypeService(repo productTypeRepository) *productTypeService {
+	return &productTypeService{productTypeRepo: repo}
+}
+
+func (s *productTypeService) GetProductTypes(
+	ctx context.Context,
+	params oapi.GetProductTypesParams,
+) ([]oapi.ProductTypeInfo, error) {
+	includeInactive := params.IncludeInactive != nil && *params.IncludeInactive
+	return s.productTypeRepo.SelectProductTypes(ctx, includeInactive)
+}
+
+func (s *productTypeService) CreateProductType(
+	ctx context.Context,
+	req oapi.PostProductTypesJSONRequestBody,
+) (oapi.ProductTypeInfo, error) {
+	code := strings.TrimSpace(req.Code)
+	if code == "" || utf8.RuneCountInString(code) > maxProductTypeCodeLen {
+		return oapi.ProductTypeInfo{}, pvz_errors.ErrInvalidProductType
+	}
+	names, err := normalizeDisplayNames(req.DisplayNames, pvz_errors.ErrInvalidProductType)
+	if err != nil {
+		return oapi.ProductTypeInfo{}, err
+	}
+
+	now := time.Now()
+	return s.productTypeRepo.InsertProductType(ctx, oapi.ProductTypeInfo{
+		Code:         code,
+		DisplayNames: names,
+		Fragile:      req.Fragile,
+		HighValue:    req.HighValue,
+		Oversized:    req.Oversized,
+		Active:       req.Active,
+		UpdatedAt:    &now,
+	})
+}
+
+func (s *productTypeService) UpdateProductType(
+	ctx context.Context,
+	code string,
+	req oapi.PutProductTypesCodeJSONRequestBody,
+) (oapi.ProductTypeInfo, error) {
+	names, err := normalizeDisplayNames(req.DisplayNames, pvz_errors.ErrInvalidProductType)
+	if err != nil {
+		return oapi.ProductTypeInfo{}, err
+	}
+
+	now := time.Now()
+	return s.productTypeRepo.UpdateProductType(ctx, oapi.ProductTypeInfo{
+		Code:         code,
+		DisplayNames: names,
+		Fragile:      req.Fragile,
+		HighValue:    req.HighValue,
+		Oversized:    req.Oversized,
+		Active:       req.Active,
+		UpdatedAt:    &now,
+	})
+}
+
+func normalizeDisplayNames(names map[string]string, invalidErr error) (map[string]string, error) {
+	if len(names) == 0 {
+		return nil, invalidErr
+	}
+	normalized := make(map[string]string, len(names))
+	for locale, name := range names {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		name = strings.TrimSpace(name)
+		if locale == "" || utf8.RuneCountInString(locale) > maxLocaleLen ||
+			name == "" || utf8.RuneCountInString(name) > maxDisplayNameLen {
+			return nil, invalidErr
+		}
+		normalized[locale] = name
+	}
+	return normalized, nil
+}
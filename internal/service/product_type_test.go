@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockProductTypeRepo struct{ mock.Mock }
+
+func (m *mockProductTypeRepo) SelectProductTypes(
+	ctx context.Context,
+	includeInactive bool) ([]oapi.ProductTypeInfo, error) {
+	args := m.Called(ctx, includeInactive)
+	return args.Get(0).([]oapi.ProductTypeInfo), args.Error(1)
+}
+
+func (m *mockProductTypeRepo) InsertProductType(
+	ctx context.Context,
+	productType oapi.ProductTypeInfo) (oapi.ProductTypeInfo, error) {
+	args := m.Called(ctx, productType)
+	return args.Get(0).(oapi.ProductTypeInfo), args.Error(1)
+}
+
+func (m *mockProductTypeRepo) UpdateProductType(
+	ctx context.Context,
+	productType oapi.ProductTypeInfo) (oapi.ProductTypeInfo, error) {
+	args := m.Called(ctx, productType)
+	return args.Get(0).(oapi.ProductTypeInfo), args.Error(1)
+}
+
+func TestGetProductTypes(t *testing.T) {
+	repo := new(mockProductTypeRepo)
+	svc := NewProductTypeService(repo)
+	include := true
+	repo.On("SelectProductTypes", mock.Anything, false).Return([]oapi.ProductTypeInfo{}, nil).Once()
+	repo.On("SelectProductTypes", mock.Anything, true).Return([]oapi.ProductTypeInfo{}, nil).Once()
+
+	_, err := svc.GetProductTypes(context.Background(), oapi.GetProductTypesParams{})
+	require.NoError(t, err)
+	_, err = svc.GetProductTypes(context.Background(), oapi.GetProductTypesParams{IncludeInactive: &include})
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateProductType(t *testing.T) {
+	ctx := context.Background()
+
+	invalid := map[string]oapi.PostProductTypesJSONRequestBody{
+		"empty code":   {Code: " ", DisplayNames: map[string]string{"ru": "Мебель"}},
+		"long code":    {Code: strings.Repeat("x", maxProductTypeCodeLen+1), DisplayNames: map[string]string{"ru": "x"}},
+		"no names":     {Code: "мебель"},
+		"blank name":   {Code: "мебель", DisplayNames: map[string]string{"ru": "  "}},
+		"blank locale": {Code: "мебель", DisplayNames: map[string]string{" ": "Мебель"}},
+		"long name value": {
+			Code:         "мебель",
+			DisplayNames: map[string]string{"ru": strings.Repeat("я", maxDisplayNameLen+1)},
+		},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			repo := new(mockProductTypeRepo)
+			svc := NewProductTypeService(repo)
+			_, err := svc.CreateProductType(ctx, req)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidProductType)
+			repo.AssertNotCalled(t, "InsertProductType", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("success normalizes", func(t *testing.T) {
+		repo := new(mockProductTypeRepo)
+		svc := NewProductTypeService(repo)
+		repo.
+			On("InsertProductType", mock.Anything, mock.MatchedBy(func(p oapi.ProductTypeInfo) bool {
+				return p.Code == "мебель" && p.DisplayNames["en"] == "Furniture" && p.Oversized && p.Active
+			})).
+			Return(oapi.ProductTypeInfo{Code: "мебель"}, nil)
+
+		_, err := svc.CreateProductType(ctx, oapi.PostProductTypesJSONRequestBody{
+			Code:         " мебель ",
+			DisplayNames: map[string]string{"EN ": " Furniture"},
+			Oversized:    true,
+			Active:       true,
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateProductType(t *testing.T) {
+	repo := new(mockProductTypeRepo)
+	svc := NewProductTypeService(repo)
+	repo.
+		On("UpdateProductType", mock.Anything, mock.MatchedBy(func(p oapi.ProductTypeInfo) bool {
+			return p.Code == "обувь" && !p.Active
+		})).
+		Return(oapi.ProductTypeInfo{}, pvz_errors.ErrProductTypeNotFound)
+
+	_, err := svc.UpdateProductType(context.Background(), "обувь", oapi.PutProductTypesCodeJSONRequestBody{
+		DisplayNames: map[string]string{"ru": "Обувь"},
+	})
+	require.ErrorIs(t, err, pvz_errors.ErrProductTypeNotFound)
+	repo.AssertExpectations(t)
+}
@@ -17,15 +17,31 @@ type productRepoReader interface {
 	GetProductsByReceptionIDs(ctx context.Context, receptionIDs []*uuid.UUID) ([]oapi.Product, error)
 }
 
+type containerRepoReader interface {
+	GetContainersByReceptionIDs(ctx context.Context, receptionIDs []*uuid.UUID) ([]oapi.Container, error)
+}
+
 type receptionRepoReader interface {
 	GetReceptionsByPVZ(ctx context.Context, pvzID uuid.UUID) ([]dto.Reception, error)
 }
 
+type regionLocator interface {
+	LocateRegion(ctx context.Context, latitude, longitude float64) (*uuid.UUID, error)
+}
+
 type pvzRepository interface {
-	InsertPVZ(ctx context.Context, city oapi.PVZCity, registrationDate time.Time) (oapi.PVZ, error)
+	InsertPVZ(ctx context.Context, pvz oapi.PVZ) (oapi.PVZ, error)
+	UpdatePVZLocation(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		latitude, longitude float64,
+		regionID *uuid.UUID,
+	) (oapi.PVZ, error)
+	UpdatePVZIntakeCapacity(ctx context.Context, pvzID uuid.UUID, capacity *int) (oapi.PVZ, error)
 	SelectPVZByOpenReceptions(
 		ctx context.Context,
 		startDate, endDate time.Time,
+		regionID *uuid.UUID,
 		limit, offset int,
 	) ([]oapi.PVZ, error)
 	SelectAllPVZs(ctx context.Context) ([]*proto.PVZ, error)
@@ -35,6 +51,8 @@ type pvzService struct {
 	pvzRepo       pvzRepository
 	receptionRepo receptionRepoReader
 	productRepo   productRepoReader
+	containerRepo containerRepoReader
+	regions       regionLocator
 	metrics       metrics.MetricsSender
 }
 
@@ -42,12 +60,16 @@ func NewPVZService(
 	pvzRepository pvzRepository,
 	receptionRepository receptionRepoReader,
 	productRepository productRepoReader,
+	containerRepository containerRepoReader,
+	regions regionLocator,
 	aggregator metrics.MetricsSender,
 ) *pvzService {
 	return &pvzService{
 		pvzRepo:       pvzRepository,
 		receptionRepo: receptionRepository,
 		productRepo:   productRepository,
+		containerRepo: containerRepository,
+		regions:       regions,
 		metrics:       aggregator,
 	}
 }
@@ -59,8 +81,20 @@ func (s *pvzService) CreatePVZ(ctx context.Context, req oapi.PostPvzJSONRequestB
 		return oapi.PVZ{}, pvz_errors.ErrInvalidPVZCity
 	}
 
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return oapi.PVZ{}, pvz_errors.ErrInvalidPVZLocation
+	}
 	now := time.Now()
-	pvz, err := s.pvzRepo.InsertPVZ(ctx, req.City, now)
+	newPVZ := oapi.PVZ{City: req.City, RegistrationDate: &now}
+	if req.Latitude != nil {
+		regionID, err := s.locate(ctx, *req.Latitude, *req.Longitude)
+		if err != nil {
+			return oapi.PVZ{}, err
+		}
+		newPVZ.Latitude, newPVZ.Longitude, newPVZ.RegionId = req.Latitude, req.Longitude, regionID
+	}
+
+	pvz, err := s.pvzRepo.InsertPVZ(ctx, newPVZ)
 	if err != nil {
 		return oapi.PVZ{}, fmt.Errorf("%w: %s", pvz_errors.ErrInsertPVZFailed, err.Error())
 	}
@@ -74,6 +108,39 @@ func (s *pvzService) CreatePVZ(ctx context.Context, req oapi.PostPvzJSONRequestB
 	return pvz, nil
 }
 
+func (s *pvzService) SetLocation(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PutPvzPvzIdLocationJSONRequestBody,
+) (oapi.PVZ, error) {
+	regionID, err := s.locate(ctx, req.Latitude, req.Longitude)
+	if err != nil {
+		return oapi.PVZ{}, err
+	}
+	return s.pvzRepo.UpdatePVZLocation(ctx, pvzID, req.Latitude, req.Longitude, regionID)
+}
+
+func (s *pvzService) SetIntakeCapacity(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody,
+) (oapi.PVZ, error) {
+	if req.DailyIntakeCapacity != nil && *req.DailyIntakeCapacity < 1 {
+		return oapi.PVZ{}, pvz_errors.ErrInvalidIntakeCapacity
+	}
+	return s.pvzRepo.UpdatePVZIntakeCapacity(ctx, pvzID, req.DailyIntakeCapacity)
+}
+
+func (s *pvzService) locate(ctx context.Context, latitude, longitude float64) (*uuid.UUID, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, pvz_errors.ErrInvalidPVZLocation
+	}
+	if s.regions == nil {
+		return nil, nil
+	}
+	return s.regions.LocateRegion(ctx, latitude, longitude)
+}
+
 func (s *pvzService) aggregatePVZData(ctx context.Context, pvzList []oapi.PVZ) ([]dto.PVZWithReceptions, error) {
 	aggregated := make([]dto.Reception, 0, len(pvzList))
 	for _, pvz := range pvzList {
@@ -106,6 +173,21 @@ func (s *pvzService) aggregatePVZData(ctx context.Context, pvzList []oapi.PVZ) (
 		prodByRec[key] = append(prodByRec[key], p)
 	}
 
+	var containers []oapi.Container
+	if len(receptions) > 0 && s.containerRepo != nil {
+		var err error
+		containers, err = s.containerRepo.GetContainersByReceptionIDs(ctx, receptions)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", pvz_errors.ErrSelectContainersFailed, err)
+		}
+	}
+
+	containersByRec := make(map[string][]oapi.Container, len(containers))
+	for _, c := range containers {
+		key := c.ReceptionId.String()
+		containersByRec[key] = append(containersByRec[key], c)
+	}
+
 	result := make([]dto.PVZWithReceptions, 0, len(pvzList))
 	for _, pvz := range pvzList {
 		var group []dto.ReceptionWithProducts
@@ -118,8 +200,9 @@ func (s *pvzService) aggregatePVZData(ctx context.Context, pvzList []oapi.PVZ) (
 				key = r.Id.String()
 			}
 			group = append(group, dto.ReceptionWithProducts{
-				Reception: r,
-				Products:  prodByRec[key],
+				Reception:  r,
+				Products:   prodByRec[key],
+				Containers: containersByRec[key],
 			})
 		}
 		result = append(result, dto.PVZWithReceptions{
@@ -156,6 +239,7 @@ func (s *pvzService) GetPVZ(ctx context.Context, params oapi.GetPvzParams) ([]dt
 		ctx,
 		startDate,
 		endDate,
+		params.RegionId,
 		limit,
 		offset,
 	)
@@ -19,16 +19,32 @@ import (
 
 type mockPVZRepo struct{ mock.Mock }
 
-func (m *mockPVZRepo) InsertPVZ(ctx context.Context, city oapi.PVZCity, registrationDate time.Time) (oapi.PVZ, error) {
-	args := m.Called(ctx, city, registrationDate)
+func (m *mockPVZRepo) InsertPVZ(ctx context.Context, pvz oapi.PVZ) (oapi.PVZ, error) {
+	args := m.Called(ctx, pvz)
+	return args.Get(0).(oapi.PVZ), args.Error(1)
+}
+
+func (m *mockPVZRepo) UpdatePVZLocation(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	latitude, longitude float64,
+	regionID *uuid.UUID,
+) (oapi.PVZ, error) {
+	args := m.Called(ctx, pvzID, latitude, longitude, regionID)
+	return args.Get(0).(oapi.PVZ), args.Error(1)
+}
+
+func (m *mockPVZRepo) UpdatePVZIntakeCapacity(ctx context.Context, pvzID uuid.UUID, capacity *int) (oapi.PVZ, error) {
+	args := m.Called(ctx, pvzID, capacity)
 	return args.Get(0).(oapi.PVZ), args.Error(1)
 }
 
 func (m *mockPVZRepo) SelectPVZByOpenReceptions(
 	ctx context.Context,
 	startDate, endDate time.Time,
+	regionID *uuid.UUID,
 	limit, offset int) ([]oapi.PVZ, error) {
-	args := m.Called(ctx, startDate, endDate, limit, offset)
+	args := m.Called(ctx, startDate, endDate, regionID, limit, offset)
 	return args.Get(0).([]oapi.PVZ), args.Error(1)
 }
 
@@ -57,7 +73,7 @@ func TestCreatePVZ(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(mockPVZRepo)
 	mockMetrics := new(mockMetrics)
-	svc := NewPVZService(mockRepo, nil, nil, mockMetrics)
+	svc := NewPVZService(mockRepo, nil, nil, nil, nil, mockMetrics)
 
 	t.Run("invalid city", func(t *testing.T) {
 		_, err := svc.CreatePVZ(ctx, oapi.PostPvzJSONRequestBody{City: "X"})
@@ -67,7 +83,7 @@ func TestCreatePVZ(t *testing.T) {
 	t.Run("insert error", func(t *testing.T) {
 		req := oapi.PostPvzJSONRequestBody{City: oapi.Москва}
 		mockRepo.
-			On("InsertPVZ", mock.Anything, req.City, mock.Anything).
+			On("InsertPVZ", mock.Anything, pvzInCity(req.City)).
 			Return(oapi.PVZ{}, errors.New("db"))
 		_, err := svc.CreatePVZ(ctx, req)
 		require.Error(t, err)
@@ -78,7 +94,7 @@ func TestCreatePVZ(t *testing.T) {
 		req := oapi.PostPvzJSONRequestBody{City: oapi.Казань}
 		returned := oapi.PVZ{Id: uuidPtr(uuid.New()), City: req.City}
 		mockRepo.
-			On("InsertPVZ", mock.Anything, req.City, mock.Anything).
+			On("InsertPVZ", mock.Anything, pvzInCity(req.City)).
 			Return(returned, nil)
 		mockMetrics.
 			On("SendBusinessMetricsUpdate", metrics.MetricsUpdate{PvzCreatedDelta: 1}).
@@ -93,13 +109,13 @@ func TestCreatePVZ(t *testing.T) {
 	})
 	t.Run("metrics nil", func(t *testing.T) {
 		mockRepo := new(mockPVZRepo)
-		svc := NewPVZService(mockRepo, nil, nil, nil)
+		svc := NewPVZService(mockRepo, nil, nil, nil, nil, nil)
 
 		req := oapi.PostPvzJSONRequestBody{City: oapi.Москва}
 		returned := oapi.PVZ{Id: uuidPtr(uuid.New()), City: req.City}
 
 		mockRepo.
-			On("InsertPVZ", mock.Anything, req.City, mock.Anything).
+			On("InsertPVZ", mock.Anything, pvzInCity(req.City)).
 			Return(returned, nil).
 			Once()
 
@@ -111,6 +127,98 @@ func TestCreatePVZ(t *testing.T) {
 	})
 }
 
+type mockRegionLocator struct{ mock.Mock }
+
+func (m *mockRegionLocator) LocateRegion(ctx context.Context, latitude, longitude float64) (*uuid.UUID, error) {
+	args := m.Called(ctx, latitude, longitude)
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
+func TestPVZLocation(t *testing.T) {
+	ctx := context.Background()
+	regionID := uuid.New()
+
+	t.Run("create in region", func(t *testing.T) {
+		mockRepo, regions := new(mockPVZRepo), new(mockRegionLocator)
+		svc := NewPVZService(mockRepo, nil, nil, nil, regions, nil)
+		lat, lon := 55.75, 37.62
+		regions.On("LocateRegion", ctx, lat, lon).Return(&regionID, nil)
+		mockRepo.
+			On("InsertPVZ", ctx, mock.MatchedBy(func(p oapi.PVZ) bool {
+				return *p.Latitude == lat && *p.Longitude == lon && *p.RegionId == regionID
+			})).
+			Return(oapi.PVZ{City: oapi.Москва, RegionId: &regionID}, nil)
+
+		pvz, err := svc.CreatePVZ(ctx, oapi.PostPvzJSONRequestBody{City: oapi.Москва, Latitude: &lat, Longitude: &lon})
+		require.NoError(t, err)
+		require.Equal(t, &regionID, pvz.RegionId)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("latitude without longitude", func(t *testing.T) {
+		svc := NewPVZService(new(mockPVZRepo), nil, nil, nil, new(mockRegionLocator), nil)
+		lat := 55.75
+		_, err := svc.CreatePVZ(ctx, oapi.PostPvzJSONRequestBody{City: oapi.Москва, Latitude: &lat})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidPVZLocation)
+	})
+
+	t.Run("move outside regions", func(t *testing.T) {
+		mockRepo, regions := new(mockPVZRepo), new(mockRegionLocator)
+		svc := NewPVZService(mockRepo, nil, nil, nil, regions, nil)
+		pvzID := uuid.New()
+		regions.On("LocateRegion", ctx, 59.93, 30.33).Return(noRegion, nil)
+		mockRepo.On("UpdatePVZLocation", ctx, pvzID, 59.93, 30.33, noRegion).Return(oapi.PVZ{Id: &pvzID}, nil)
+
+		_, err := svc.SetLocation(ctx, pvzID, oapi.PutPvzPvzIdLocationJSONRequestBody{Latitude: 59.93, Longitude: 30.33})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		svc := NewPVZService(new(mockPVZRepo), nil, nil, nil, new(mockRegionLocator), nil)
+		_, err := svc.SetLocation(ctx, uuid.New(), oapi.PutPvzPvzIdLocationJSONRequestBody{Latitude: 95})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidPVZLocation)
+	})
+}
+
+func TestSetIntakeCapacity(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+
+	t.Run("set", func(t *testing.T) {
+		mockRepo := new(mockPVZRepo)
+		svc := NewPVZService(mockRepo, nil, nil, nil, nil, nil)
+		capacity := 250
+		mockRepo.On("UpdatePVZIntakeCapacity", ctx, pvzID, &capacity).
+			Return(oapi.PVZ{Id: &pvzID, DailyIntakeCapacity: &capacity}, nil)
+
+		pvz, err := svc.SetIntakeCapacity(ctx, pvzID, oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody{
+			DailyIntakeCapacity: &capacity,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 250, *pvz.DailyIntakeCapacity)
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		mockRepo := new(mockPVZRepo)
+		svc := NewPVZService(mockRepo, nil, nil, nil, nil, nil)
+		mockRepo.On("UpdatePVZIntakeCapacity", ctx, pvzID, (*int)(nil)).Return(oapi.PVZ{Id: &pvzID}, nil)
+
+		_, err := svc.SetIntakeCapacity(ctx, pvzID, oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody{})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not positive", func(t *testing.T) {
+		svc := NewPVZService(new(mockPVZRepo), nil, nil, nil, nil, nil)
+		zero := 0
+		_, err := svc.SetIntakeCapacity(ctx, pvzID, oapi.PutPvzPvzIdIntakeCapacityJSONRequestBody{
+			DailyIntakeCapacity: &zero,
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidIntakeCapacity)
+	})
+}
+
 func TestGetPVZ(t *testing.T) {
 	ctx := context.Background()
 
@@ -118,10 +226,10 @@ func TestGetPVZ(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
 		mockRec := new(mockReceptionReader)
 		mockProd := new(mockProductReader)
-		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil)
+		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil, nil, nil)
 
 		mockPVZ.
-			On("SelectPVZByOpenReceptions", mock.Anything, time.Time{}, mock.Anything, 10, 0).
+			On("SelectPVZByOpenReceptions", mock.Anything, time.Time{}, mock.Anything, noRegion, 10, 0).
 			Return([]oapi.PVZ(nil), errors.New("fail"))
 
 		_, err := svc.GetPVZ(ctx, oapi.GetPvzParams{})
@@ -133,12 +241,12 @@ func TestGetPVZ(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
 		mockRec := new(mockReceptionReader)
 		mockProd := new(mockProductReader)
-		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil)
+		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil, nil, nil)
 
 		now := time.Now()
 		pvzList := []oapi.PVZ{{Id: uuidPtr(uuid.New()), City: oapi.Москва, RegistrationDate: &now}}
 		mockPVZ.
-			On("SelectPVZByOpenReceptions", mock.Anything, time.Time{}, mock.Anything, 10, 0).
+			On("SelectPVZByOpenReceptions", mock.Anything, time.Time{}, mock.Anything, noRegion, 10, 0).
 			Return(pvzList, nil)
 		mockRec.
 			On("GetReceptionsByPVZ", mock.Anything, *pvzList[0].Id).
@@ -157,7 +265,7 @@ func TestGetPVZ(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
 		mockRec := new(mockReceptionReader)
 		mockProd := new(mockProductReader)
-		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil)
+		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil, nil, nil)
 
 		now := time.Now()
 		id := uuid.New()
@@ -165,7 +273,7 @@ func TestGetPVZ(t *testing.T) {
 		recs := []dto.Reception{{Id: uuidPtr(uuid.New()), PvzId: id}}
 
 		mockPVZ.
-			On("SelectPVZByOpenReceptions", mock.Anything, time.Time{}, mock.Anything, 10, 0).
+			On("SelectPVZByOpenReceptions", mock.Anything, time.Time{}, mock.Anything, noRegion, 10, 0).
 			Return([]oapi.PVZ{pvz}, nil)
 		mockRec.
 			On("GetReceptionsByPVZ", mock.Anything, id).
@@ -187,13 +295,13 @@ func TestGetPVZ(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
 		mockRec := new(mockReceptionReader)
 		mockProd := new(mockProductReader)
-		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil)
+		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil, nil, nil)
 
 		now := time.Now()
 		pvzID := uuid.New()
 		pvz := oapi.PVZ{Id: &pvzID, City: oapi.Москва, RegistrationDate: &now}
 		mockPVZ.
-			On("SelectPVZByOpenReceptions", mock.Anything, mock.Anything, mock.Anything, 10, 0).
+			On("SelectPVZByOpenReceptions", mock.Anything, mock.Anything, mock.Anything, noRegion, 10, 0).
 			Return([]oapi.PVZ{pvz}, nil)
 
 		rec1 := dto.Reception{Id: uuidPtr(uuid.New()), PvzId: pvzID, DateTime: now, Status: oapi.InProgress}
@@ -226,7 +334,7 @@ func TestGetPVZ(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
 		mockRec := new(mockReceptionReader)
 		mockProd := new(mockProductReader)
-		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil)
+		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil, nil, nil)
 
 		startDate := time.Now().Add(-24 * time.Hour)
 		endDate := time.Now()
@@ -237,7 +345,7 @@ func TestGetPVZ(t *testing.T) {
 
 		pvzList := []oapi.PVZ{{Id: uuidPtr(uuid.New()), City: oapi.Москва, RegistrationDate: &endDate}}
 		mockPVZ.
-			On("SelectPVZByOpenReceptions", mock.Anything, startDate, endDate, 10, 0).
+			On("SelectPVZByOpenReceptions", mock.Anything, startDate, endDate, noRegion, 10, 0).
 			Return(pvzList, nil)
 		mockRec.
 			On("GetReceptionsByPVZ", mock.Anything, *pvzList[0].Id).
@@ -253,9 +361,24 @@ func TestGetPVZ(t *testing.T) {
 		mockRec.AssertExpectations(t)
 	})
 
+	t.Run("region filter", func(t *testing.T) {
+		mockPVZ := new(mockPVZRepo)
+		svc := NewPVZService(mockPVZ, nil, nil, nil, nil, nil)
+
+		regionID := uuid.New()
+		mockPVZ.
+			On("SelectPVZByOpenReceptions", mock.Anything, time.Time{}, mock.Anything, &regionID, 10, 0).
+			Return([]oapi.PVZ{}, nil)
+
+		out, err := svc.GetPVZ(ctx, oapi.GetPvzParams{RegionId: &regionID})
+		require.NoError(t, err)
+		require.Empty(t, out)
+		mockPVZ.AssertExpectations(t)
+	})
+
 	t.Run("pagination params", func(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
-		svc := NewPVZService(mockPVZ, nil, nil, nil)
+		svc := NewPVZService(mockPVZ, nil, nil, nil, nil, nil)
 
 		page, limit := 2, 5
 		params := oapi.GetPvzParams{Page: &page, Limit: &limit}
@@ -264,6 +387,7 @@ func TestGetPVZ(t *testing.T) {
 				mock.Anything,
 				mock.Anything,
 				mock.Anything,
+				noRegion,
 				limit,
 				(page-1)*limit,
 			).
@@ -279,7 +403,7 @@ func TestGetPVZ(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
 		mockRec := new(mockReceptionReader)
 		mockProd := new(mockProductReader)
-		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil)
+		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil, nil, nil)
 
 		now := time.Now()
 		pvzID1 := uuid.New()
@@ -288,7 +412,7 @@ func TestGetPVZ(t *testing.T) {
 		pvz2 := oapi.PVZ{Id: &pvzID2, City: oapi.Казань, RegistrationDate: &now}
 
 		mockPVZ.
-			On("SelectPVZByOpenReceptions", mock.Anything, mock.Anything, mock.Anything, 10, 0).
+			On("SelectPVZByOpenReceptions", mock.Anything, mock.Anything, mock.Anything, noRegion, 10, 0).
 			Return([]oapi.PVZ{pvz1, pvz2}, nil)
 
 		rec1 := dto.Reception{Id: uuidPtr(uuid.New()), PvzId: pvzID1, DateTime: now, Status: oapi.InProgress}
@@ -337,14 +461,14 @@ func TestGetPVZ(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
 		mockRec := new(mockReceptionReader)
 		mockProd := new(mockProductReader)
-		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil)
+		svc := NewPVZService(mockPVZ, mockRec, mockProd, nil, nil, nil)
 
 		now := time.Now()
 		pvzID := uuid.New()
 		pvz := oapi.PVZ{Id: &pvzID, City: oapi.Москва, RegistrationDate: &now}
 
 		mockPVZ.
-			On("SelectPVZByOpenReceptions", mock.Anything, mock.Anything, mock.Anything, 10, 0).
+			On("SelectPVZByOpenReceptions", mock.Anything, mock.Anything, mock.Anything, noRegion, 10, 0).
 			Return([]oapi.PVZ{pvz}, nil)
 
 		mockRec.
@@ -366,7 +490,7 @@ func TestGetAllPVZs(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
-		svc := NewPVZService(mockPVZ, nil, nil, nil)
+		svc := NewPVZService(mockPVZ, nil, nil, nil, nil, nil)
 
 		mockPVZ.
 			On("SelectAllPVZs", mock.Anything).
@@ -380,7 +504,7 @@ func TestGetAllPVZs(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockPVZ := new(mockPVZRepo)
-		svc := NewPVZService(mockPVZ, nil, nil, nil)
+		svc := NewPVZService(mockPVZ, nil, nil, nil, nil, nil)
 
 		list := []*proto.PVZ{{Id: uuid.New().String()}}
 		mockPVZ.
@@ -395,6 +519,12 @@ func TestGetAllPVZs(t *testing.T) {
 	})
 }
 
+var noRegion *uuid.UUID
+
+func pvzInCity(city oapi.PVZCity) any {
+	return mock.MatchedBy(func(p oapi.PVZ) bool { return p.City == city })
+}
+
 func uuidSliceMatcher(expected []*uuid.UUID) func([]*uuid.UUID) bool {
 	return func(actual []*uuid.UUID) bool {
 		if len(actual) != len(expected) {
@@ -412,3 +542,51 @@ func uuidSliceMatcher(expected []*uuid.UUID) func([]*uuid.UUID) bool {
 		return true
 	}
 }
+
+func TestGetPVZWithContainers(t *testing.T) {
+	mockPVZ := new(mockPVZRepo)
+	mockRec := new(mockReceptionReader)
+	mockProd := new(mockProductReader)
+	mockCont := new(mockContainerRepo)
+	svc := NewPVZService(mockPVZ, mockRec, mockProd, mockCont, nil, nil)
+
+	now := time.Now()
+	pvzID := uuid.New()
+	pvz := oapi.PVZ{Id: &pvzID, City: oapi.Казань, RegistrationDate: &now}
+	rec := dto.Reception{Id: uuidPtr(uuid.New()), PvzId: pvzID, DateTime: now, Status: oapi.InProgress}
+	container := oapi.Container{Id: uuidPtr(uuid.New()), ReceptionId: *rec.Id, Barcode: "C-1", State: "sealed"}
+
+	mockPVZ.
+		On("SelectPVZByOpenReceptions", mock.Anything, mock.Anything, mock.Anything, noRegion, 10, 0).
+		Return([]oapi.PVZ{pvz}, nil)
+	mockRec.
+		On("GetReceptionsByPVZ", mock.Anything, pvzID).
+		Return([]dto.Reception{rec}, nil)
+	mockProd.
+		On("GetProductsByReceptionIDs", mock.Anything, []*uuid.UUID{rec.Id}).
+		Return([]oapi.Product{}, nil)
+
+	t.Run("containers attached to reception", func(t *testing.T) {
+		mockCont.
+			On("GetContainersByReceptionIDs", mock.Anything, []*uuid.UUID{rec.Id}).
+			Return([]oapi.Container{container}, nil).
+			Once()
+
+		out, err := svc.GetPVZ(context.Background(), oapi.GetPvzParams{})
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		require.Equal(t, []oapi.Container{container}, out[0].Receptions[0].Containers)
+	})
+
+	t.Run("containers error", func(t *testing.T) {
+		mockCont.
+			On("GetContainersByReceptionIDs", mock.Anything, []*uuid.UUID{rec.Id}).
+			Return([]oapi.Container(nil), errors.New("fail")).
+			Once()
+
+		_, err := svc.GetPVZ(context.Background(), oapi.GetPvzParams{})
+		require.ErrorIs(t, err, pvz_errors.ErrSelectContainersFailed)
+	})
+
+	mockCont.AssertExpectations(t)
+}
@@ -2,31 +2,54 @@ package service
 
 import (
 	"context"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
 	"github.com/whaleship/pvz/internal/metrics"
 )
 
-type receptionRepoWriter interface {
-	CreateReception(ctx context.Context, req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error)
-	CloseLastReception(ctx context.Context, pvzID uuid.UUID) (oapi.Reception, error)
+const (
+	maxCarrierLen       = 255
+	maxVehiclePlateLen  = 32
+	maxWaybillNumberLen = 100
+	maxSealNumberLen    = 100
+	maxCommentLen       = 1000
+)
+
+type receptionRepository interface {
+	CreateReception(
+		ctx context.Context,
+		createdBy uuid.UUID,
+		req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error)
+	CloseLastReception(ctx context.Context, pvzID, closedBy uuid.UUID) (oapi.Reception, error)
+	SearchReceptionsByWaybill(ctx context.Context, waybillNumber string) ([]oapi.Reception, error)
+	GetReceptionSummary(ctx context.Context, receptionID uuid.UUID) (oapi.ReceptionSummary, error)
 }
 
 type receptionService struct {
-	receptionRepo receptionRepoWriter
+	receptionRepo receptionRepository
 	metrics       metrics.MetricsSender
 }
 
-func NewReceptionService(repo receptionRepoWriter, aggregator metrics.MetricsSender) *receptionService {
+func NewReceptionService(repo receptionRepository, aggregator metrics.MetricsSender) *receptionService {
 	return &receptionService{
 		receptionRepo: repo,
 		metrics:       aggregator,
 	}
 }
-func (s *receptionService) CreateReception(req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error) {
-	ctx := context.Background()
-	reception, err := s.receptionRepo.CreateReception(ctx, req)
+
+func (s *receptionService) CreateReception(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error) {
+	if err := normalizeWaybill(&req); err != nil {
+		return oapi.Reception{}, err
+	}
+
+	reception, err := s.receptionRepo.CreateReception(ctx, userID, req)
 	if err != nil {
 		return oapi.Reception{}, err
 	}
@@ -39,7 +62,53 @@ func (s *receptionService) CreateReception(req oapi.PostReceptionsJSONRequestBod
 	return reception, nil
 }
 
-func (s *receptionService) CloseLastReception(pvzID uuid.UUID) (oapi.Reception, error) {
-	ctx := context.Background()
-	return s.receptionRepo.CloseLastReception(ctx, pvzID)
+func (s *receptionService) CloseLastReception(
+	ctx context.Context,
+	userID, pvzID uuid.UUID,
+) (oapi.Reception, error) {
+	return s.receptionRepo.CloseLastReception(ctx, pvzID, userID)
+}
+
+func (s *receptionService) SearchReceptions(
+	ctx context.Context,
+	params oapi.GetReceptionsSearchParams) ([]oapi.Reception, error) {
+	waybill := strings.TrimSpace(params.WaybillNumber)
+	if waybill == "" || utf8.RuneCountInString(waybill) > maxWaybillNumberLen {
+		return nil, pvz_errors.ErrInvalidWaybill
+	}
+	return s.receptionRepo.SearchReceptionsByWaybill(ctx, waybill)
+}
+
+func (s *receptionService) GetReceptionSummary(
+	ctx context.Context,
+	receptionID uuid.UUID) (oapi.ReceptionSummary, error) {
+	return s.receptionRepo.GetReceptionSummary(ctx, receptionID)
+}
+
+func normalizeWaybill(req *oapi.PostReceptionsJSONRequestBody) error {
+	fields := []struct {
+		value  **string
+		maxLen int
+	}{
+		{&req.Carrier, maxCarrierLen},
+		{&req.VehiclePlate, maxVehiclePlateLen},
+		{&req.WaybillNumber, maxWaybillNumberLen},
+		{&req.SealNumber, maxSealNumberLen},
+		{&req.Comment, maxCommentLen},
+	}
+	for _, f := range fields {
+		if *f.value == nil {
+			continue
+		}
+		trimmed := strings.TrimSpace(**f.value)
+		if trimmed == "" {
+			*f.value = nil
+			continue
+		}
+		if utf8.RuneCountInString(trimmed) > f.maxLen {
+			return pvz_errors.ErrInvalidWaybill
+		}
+		*f.value = &trimmed
+	}
+	return nil
 }
@@ -3,12 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
 	"github.com/whaleship/pvz/internal/gen/oapi"
 	"github.com/whaleship/pvz/internal/metrics"
 )
@@ -17,27 +19,45 @@ type mockReceptionWriter struct{ mock.Mock }
 
 func (m *mockReceptionWriter) CreateReception(
 	ctx context.Context,
+	createdBy uuid.UUID,
 	req oapi.PostReceptionsJSONRequestBody) (oapi.Reception, error) {
-	args := m.Called(ctx, req)
+	args := m.Called(ctx, createdBy, req)
 	return args.Get(0).(oapi.Reception), args.Error(1)
 }
 
-func (m *mockReceptionWriter) CloseLastReception(ctx context.Context, pvzID uuid.UUID) (oapi.Reception, error) {
-	args := m.Called(ctx, pvzID)
+func (m *mockReceptionWriter) CloseLastReception(
+	ctx context.Context,
+	pvzID, closedBy uuid.UUID) (oapi.Reception, error) {
+	args := m.Called(ctx, pvzID, closedBy)
 	return args.Get(0).(oapi.Reception), args.Error(1)
 }
 
+func (m *mockReceptionWriter) SearchReceptionsByWaybill(
+	ctx context.Context,
+	waybillNumber string) ([]oapi.Reception, error) {
+	args := m.Called(ctx, waybillNumber)
+	return args.Get(0).([]oapi.Reception), args.Error(1)
+}
+
+func (m *mockReceptionWriter) GetReceptionSummary(
+	ctx context.Context,
+	receptionID uuid.UUID) (oapi.ReceptionSummary, error) {
+	args := m.Called(ctx, receptionID)
+	return args.Get(0).(oapi.ReceptionSummary), args.Error(1)
+}
+
 func TestCreateReception(t *testing.T) {
 	mockRepo := new(mockReceptionWriter)
 	mockMetrics := new(mockMetrics)
 	svc := NewReceptionService(mockRepo, mockMetrics)
+	userID := uuid.New()
 
 	t.Run("error", func(t *testing.T) {
 		req := oapi.PostReceptionsJSONRequestBody{PvzId: uuid.New()}
 		mockRepo.
-			On("CreateReception", mock.Anything, req).
+			On("CreateReception", mock.Anything, userID, req).
 			Return(oapi.Reception{}, errors.New("fail"))
-		_, err := svc.CreateReception(req)
+		_, err := svc.CreateReception(context.Background(), userID, req)
 		require.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
@@ -46,17 +66,55 @@ func TestCreateReception(t *testing.T) {
 		req := oapi.PostReceptionsJSONRequestBody{PvzId: uuid.New()}
 		ret := oapi.Reception{Id: uuidPtr(uuid.New())}
 		mockRepo.
-			On("CreateReception", mock.Anything, req).
+			On("CreateReception", mock.Anything, userID, req).
 			Return(ret, nil)
 		mockMetrics.
 			On("SendBusinessMetricsUpdate", metrics.MetricsUpdate{ReceptionsCreatedDelta: 1}).
 			Return()
-		res, err := svc.CreateReception(req)
+		res, err := svc.CreateReception(context.Background(), userID, req)
 		require.NoError(t, err)
 		require.Equal(t, ret, res)
 		mockRepo.AssertExpectations(t)
 		mockMetrics.AssertExpectations(t)
 	})
+
+	t.Run("waybill normalized", func(t *testing.T) {
+		mockRepo := new(mockReceptionWriter)
+		svc := NewReceptionService(mockRepo, nil)
+
+		pvzID := uuid.New()
+		req := oapi.PostReceptionsJSONRequestBody{
+			PvzId:         pvzID,
+			Carrier:       strPtr("  СДЭК "),
+			WaybillNumber: strPtr("WB-1"),
+			Comment:       strPtr("   "),
+		}
+		want := oapi.PostReceptionsJSONRequestBody{
+			PvzId:         pvzID,
+			Carrier:       strPtr("СДЭК"),
+			WaybillNumber: strPtr("WB-1"),
+		}
+		mockRepo.
+			On("CreateReception", mock.Anything, userID, want).
+			Return(oapi.Reception{}, nil)
+
+		_, err := svc.CreateReception(context.Background(), userID, req)
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("waybill too long", func(t *testing.T) {
+		mockRepo := new(mockReceptionWriter)
+		svc := NewReceptionService(mockRepo, nil)
+
+		req := oapi.PostReceptionsJSONRequestBody{
+			PvzId:        uuid.New(),
+			VehiclePlate: strPtr(strings.Repeat("А", maxVehiclePlateLen+1)),
+		}
+		_, err := svc.CreateReception(context.Background(), userID, req)
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidWaybill)
+		mockRepo.AssertNotCalled(t, "CreateReception")
+	})
 	t.Run("metrics nil", func(t *testing.T) {
 		mockRepo := new(mockReceptionWriter)
 		svc := NewReceptionService(mockRepo, nil)
@@ -65,10 +123,10 @@ func TestCreateReception(t *testing.T) {
 		expected := oapi.Reception{Id: uuidPtr(uuid.New())}
 
 		mockRepo.
-			On("CreateReception", mock.Anything, req).
+			On("CreateReception", mock.Anything, userID, req).
 			Return(expected, nil)
 
-		out, err := svc.CreateReception(req)
+		out, err := svc.CreateReception(context.Background(), userID, req)
 		require.NoError(t, err)
 		require.Equal(t, expected, out)
 		mockRepo.AssertExpectations(t)
@@ -78,13 +136,14 @@ func TestCreateReception(t *testing.T) {
 func TestCloseLastReception(t *testing.T) {
 	mockRepo := new(mockReceptionWriter)
 	svc := NewReceptionService(mockRepo, nil)
+	userID := uuid.New()
 
 	t.Run("error", func(t *testing.T) {
 		id := uuid.New()
 		mockRepo.
-			On("CloseLastReception", mock.Anything, id).
+			On("CloseLastReception", mock.Anything, id, userID).
 			Return(oapi.Reception{}, errors.New("no"))
-		_, err := svc.CloseLastReception(id)
+		_, err := svc.CloseLastReception(context.Background(), userID, id)
 		require.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
@@ -93,9 +152,62 @@ func TestCloseLastReception(t *testing.T) {
 		id := uuid.New()
 		ret := oapi.Reception{Id: uuidPtr(uuid.New())}
 		mockRepo.
-			On("CloseLastReception", mock.Anything, id).
+			On("CloseLastReception", mock.Anything, id, userID).
+			Return(ret, nil)
+		res, err := svc.CloseLastReception(context.Background(), userID, id)
+		require.NoError(t, err)
+		require.Equal(t, ret, res)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSearchReceptions(t *testing.T) {
+	t.Run("empty waybill", func(t *testing.T) {
+		mockRepo := new(mockReceptionWriter)
+		svc := NewReceptionService(mockRepo, nil)
+
+		_, err := svc.SearchReceptions(context.Background(), oapi.GetReceptionsSearchParams{WaybillNumber: "  "})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidWaybill)
+		mockRepo.AssertNotCalled(t, "SearchReceptionsByWaybill")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(mockReceptionWriter)
+		svc := NewReceptionService(mockRepo, nil)
+
+		ret := []oapi.Reception{{Id: uuidPtr(uuid.New()), WaybillNumber: strPtr("WB-7")}}
+		mockRepo.
+			On("SearchReceptionsByWaybill", mock.Anything, "WB-7").
+			Return(ret, nil)
+
+		out, err := svc.SearchReceptions(context.Background(), oapi.GetReceptionsSearchParams{WaybillNumber: " WB-7 "})
+		require.NoError(t, err)
+		require.Equal(t, ret, out)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetReceptionSummary(t *testing.T) {
+	mockRepo := new(mockReceptionWriter)
+	svc := NewReceptionService(mockRepo, nil)
+
+	t.Run("not found", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo.
+			On("GetReceptionSummary", mock.Anything, id).
+			Return(oapi.ReceptionSummary{}, pvz_errors.ErrReceptionSummaryNotFound)
+		_, err := svc.GetReceptionSummary(context.Background(), id)
+		require.ErrorIs(t, err, pvz_errors.ErrReceptionSummaryNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		id := uuid.New()
+		ret := oapi.ReceptionSummary{ReceptionId: id, TotalItems: 2, CountsByType: map[string]int{"обувь": 2}}
+		mockRepo.
+			On("GetReceptionSummary", mock.Anything, id).
 			Return(ret, nil)
-		res, err := svc.CloseLastReception(id)
+		res, err := svc.GetReceptionSummary(context.Background(), id)
 		require.NoError(t, err)
 		require.Equal(t, ret, res)
 		mockRepo.AssertExpectations(t)
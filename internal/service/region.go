@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/geo"
+)
+
+const maxRegionNameLength = 100
+
+type regionRepository interface {
+	UpsertRegions(ctx context.Context, regions []dto.Region, createdBy *uuid.UUID) error
+	SelectRegions(ctx context.Context) ([]dto.Region, error)
+	DeleteRegion(ctx context.Context, regionID uuid.UUID) error
+	SelectPVZLocations(ctx context.Context) ([]dto.PVZLocation, error)
+	AssignPVZRegions(ctx context.Context, pvzIDs []uuid.UUID, regionIDs []*uuid.UUID) error
+	SelectPVZPoints(ctx context.Context, filter dto.PVZPointFilter) ([]dto.PVZPoint, error)
+}
+
+type regionService struct {
+	regionRepo regionRepository
+}
+
+func NewRegionService(repo regionRepository) *regionService {
+	return &regionService{regionRepo: repo}
+}
+
+func (s *regionService) UploadRegions(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.RegionFeatureCollection,
+) (oapi.RegionFeatureCollection, error) {
+	if req.Type != oapi.FeatureCollection || len(req.Features) == 0 {
+		return oapi.RegionFeatureCollection{}, pvz_errors.ErrInvalidRegion
+	}
+	regions := make([]dto.Region, 0, len(req.Features))
+	names := make(map[string]bool, len(req.Features))
+	for _, feature := range req.Features {
+		name := strings.TrimSpace(feature.Properties.Name)
+		if feature.Type != oapi.Feature || name == "" || utf8.RuneCountInString(name) > maxRegionNameLength ||
+			names[name] {
+			return oapi.RegionFeatureCollection{}, pvz_errors.ErrInvalidRegion
+		}
+		names[name] = true
+
+		coordinates, err := json.Marshal(feature.Geometry.Coordinates)
+		if err != nil {
+			return oapi.RegionFeatureCollection{}, pvz_errors.ErrInvalidRegion
+		}
+		if _, err = geo.Parse(string(feature.Geometry.Type), coordinates); err != nil {
+			return oapi.RegionFeatureCollection{}, fmt.Errorf("%w: %s", pvz_errors.ErrInvalidRegion, name)
+		}
+		regions = append(regions, dto.Region{
+			ID:           uuid.New(),
+			Name:         name,
+			GeometryType: string(feature.Geometry.Type),
+			Coordinates:  coordinates,
+		})
+	}
+
+	var createdBy *uuid.UUID
+	if userID != uuid.Nil {
+		createdBy = &userID
+	}
+	if err := s.regionRepo.UpsertRegions(ctx, regions, createdBy); err != nil {
+		return oapi.RegionFeatureCollection{}, err
+	}
+	if err := s.reassignRegions(ctx); err != nil {
+		return oapi.RegionFeatureCollection{}, err
+	}
+	return s.GetRegions(ctx)
+}
+
+func (s *regionService) GetRegions(ctx context.Context) (oapi.RegionFeatureCollection, error) {
+	regions, err := s.regionRepo.SelectRegions(ctx)
+	if err != nil {
+		return oapi.RegionFeatureCollection{}, err
+	}
+	collection := oapi.RegionFeatureCollection{
+		Type:     oapi.FeatureCollection,
+		Features: make([]oapi.RegionFeature, 0, len(regions)),
+	}
+	for _, region := range regions {
+		id, pvzCount := region.ID, region.PvzCount
+		collection.Features = append(collection.Features, oapi.RegionFeature{
+			Type: oapi.Feature,
+			Id:   &id,
+			Geometry: oapi.RegionGeometry{
+				Type:        oapi.RegionGeometryType(region.GeometryType),
+				Coordinates: json.RawMessage(region.Coordinates),
+			},
+			Properties: oapi.RegionProperties{Name: region.Name, PvzCount: &pvzCount},
+		})
+	}
+	return collection, nil
+}
+
+func (s *regionService) DeleteRegion(ctx context.Context, regionID uuid.UUID) error {
+	if err := s.regionRepo.DeleteRegion(ctx, regionID); err != nil {
+		return err
+	}
+	return s.reassignRegions(ctx)
+}
+
+func (s *regionService) GetPVZGeoJSON(
+	ctx context.Context,
+	params oapi.GetPvzGeojsonParams,
+) (oapi.PvzFeatureCollection, error) {
+	points, err := s.regionRepo.SelectPVZPoints(ctx, dto.PVZPointFilter{
+		City:     trimmedOrNil(params.City),
+		RegionID: params.RegionId,
+	})
+	if err != nil {
+		return oapi.PvzFeatureCollection{}, err
+	}
+	collection := oapi.PvzFeatureCollection{
+		Type:     oapi.FeatureCollection,
+		Features: make([]oapi.PvzFeature, 0, len(points)),
+	}
+	for _, p := range points {
+		collection.Features = append(collection.Features, oapi.PvzFeature{
+			Type: oapi.Feature,
+			Id:   p.ID,
+			Geometry: oapi.PvzPoint{
+				Type:        oapi.Point,
+				Coordinates: []float64{p.Longitude, p.Latitude},
+			},
+			Properties: oapi.PvzFeatureProperties{
+				City:             p.City,
+				RegistrationDate: p.RegisteredAt,
+				RegionId:         p.RegionID,
+				RegionName:       p.RegionName,
+			},
+		})
+	}
+	return collection, nil
+}
+
+// LocateRegion returns the region containing the point, taking the first one
+// by name when regions overlap, or nil when the point is outside all of them.
+func (s *regionService) LocateRegion(ctx context.Context, latitude, longitude float64) (*uuid.UUID, error) {
+	areas, err := s.regionAreas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return locate(areas, latitude, longitude), nil
+}
+
+type regionArea struct {
+	id   uuid.UUID
+	area geo.MultiPolygon
+}
+
+func (s *regionService) regionAreas(ctx context.Context) ([]regionArea, error) {
+	regions, err := s.regionRepo.SelectRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	areas := make([]regionArea, 0, len(regions))
+	for _, region := range regions {
+		area, err := geo.Parse(region.GeometryType, region.Coordinates)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", pvz_errors.ErrSelectRegionsFailed, region.Name, err)
+		}
+		areas = append(areas, regionArea{id: region.ID, area: area})
+	}
+	return areas, nil
+}
+
+// reassignRegions matches every PVZ with coordinates against the current
+// regions; it runs after regions are uploaded or deleted.
+func (s *regionService) reassignRegions(ctx context.Context) error {
+	areas, err := s.regionAreas(ctx)
+	if err != nil {
+		return err
+	}
+	locations, err := s.regionRepo.SelectPVZLocations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(locations) == 0 {
+		return nil
+	}
+	pvzIDs := make([]uuid.UUID, 0, len(locations))
+	regionIDs := make([]*uuid.UUID, 0, len(locations))
+	for _, location := range locations {
+		pvzIDs = append(pvzIDs, location.ID)
+		regionIDs = append(regionIDs, locate(areas, location.Latitude, location.Longitude))
+	}
+	return s.regionRepo.AssignPVZRegions(ctx, pvzIDs, regionIDs)
+}
+
+func locate(areas []regionArea, latitude, longitude float64) *uuid.UUID {
+	for _, a := range areas {
+		if a.area.Contains(longitude, latitude) {
+			id := a.id
+			return &id
+		}
+	}
+	return nil
+}
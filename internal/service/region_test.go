@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockRegionRepo struct{ mock.Mock }
+
+func (m *mockRegionRepo) UpsertRegions(ctx context.Context, regions []dto.Region, createdBy *uuid.UUID) error {
+	args := m.Called(ctx, regions, createdBy)
+	return args.Error(0)
+}
+
+func (m *mockRegionRepo) SelectRegions(ctx context.Context) ([]dto.Region, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]dto.Region), args.Error(1)
+}
+
+func (m *mockRegionRepo) DeleteRegion(ctx context.Context, regionID uuid.UUID) error {
+	args := m.Called(ctx, regionID)
+	return args.Error(0)
+}
+
+func (m *mockRegionRepo) SelectPVZLocations(ctx context.Context) ([]dto.PVZLocation, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]dto.PVZLocation), args.Error(1)
+}
+
+func (m *mockRegionRepo) AssignPVZRegions(ctx context.Context, pvzIDs []uuid.UUID, regionIDs []*uuid.UUID) error {
+	args := m.Called(ctx, pvzIDs, regionIDs)
+	return args.Error(0)
+}
+
+func (m *mockRegionRepo) SelectPVZPoints(ctx context.Context, filter dto.PVZPointFilter) ([]dto.PVZPoint, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]dto.PVZPoint), args.Error(1)
+}
+
+var moscowSquare = []any{[]any{
+	[]any{37.3, 55.5}, []any{37.9, 55.5}, []any{37.9, 55.9}, []any{37.3, 55.9}, []any{37.3, 55.5},
+}}
+
+func regionFeature(name string, coordinates any) oapi.RegionFeature {
+	return oapi.RegionFeature{
+		Type:       oapi.Feature,
+		Geometry:   oapi.RegionGeometry{Type: oapi.Polygon, Coordinates: coordinates},
+		Properties: oapi.RegionProperties{Name: name},
+	}
+}
+
+func TestUploadRegions(t *testing.T) {
+	ctx := context.Background()
+	userID, regionID := uuid.New(), uuid.New()
+	inside, outside := uuid.New(), uuid.New()
+
+	t.Run("reassigns pvz", func(t *testing.T) {
+		repo := new(mockRegionRepo)
+		svc := NewRegionService(repo)
+		repo.On("UpsertRegions", ctx, mock.MatchedBy(func(regions []dto.Region) bool {
+			return len(regions) == 1 && regions[0].Name == "Москва" && regions[0].GeometryType == "Polygon" &&
+				string(regions[0].Coordinates) == `[[[37.3,55.5],[37.9,55.5],[37.9,55.9],[37.3,55.9],[37.3,55.5]]]`
+		}), &userID).Return(nil)
+		repo.On("SelectRegions", ctx).Return([]dto.Region{{
+			ID: regionID, Name: "Москва", GeometryType: "Polygon", PvzCount: 1,
+			Coordinates: []byte(`[[[37.3,55.5],[37.9,55.5],[37.9,55.9],[37.3,55.9],[37.3,55.5]]]`),
+		}}, nil)
+		repo.On("SelectPVZLocations", ctx).Return([]dto.PVZLocation{
+			{ID: inside, Latitude: 55.75, Longitude: 37.62},
+			{ID: outside, Latitude: 55.79, Longitude: 49.12},
+		}, nil)
+		repo.On("AssignPVZRegions", ctx, []uuid.UUID{inside, outside}, []*uuid.UUID{&regionID, nil}).Return(nil)
+
+		collection, err := svc.UploadRegions(ctx, userID, oapi.RegionFeatureCollection{
+			Type:     oapi.FeatureCollection,
+			Features: []oapi.RegionFeature{regionFeature(" Москва ", moscowSquare)},
+		})
+		require.NoError(t, err)
+		require.Len(t, collection.Features, 1)
+		require.Equal(t, regionID, *collection.Features[0].Id)
+		require.Equal(t, 1, *collection.Features[0].Properties.PvzCount)
+		repo.AssertExpectations(t)
+	})
+
+	unclosed := []any{[]any{[]any{37.3, 55.5}, []any{37.9, 55.5}, []any{37.9, 55.9}, []any{37.3, 55.9}}}
+	for name, features := range map[string][]oapi.RegionFeature{
+		"empty":          nil,
+		"no name":        {regionFeature(" ", moscowSquare)},
+		"duplicate name": {regionFeature("Москва", moscowSquare), regionFeature("Москва", moscowSquare)},
+		"not closed":     {regionFeature("Москва", unclosed)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := NewRegionService(new(mockRegionRepo))
+			_, err := svc.UploadRegions(ctx, userID, oapi.RegionFeatureCollection{
+				Type: oapi.FeatureCollection, Features: features,
+			})
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidRegion)
+		})
+	}
+}
+
+func TestDeleteRegion(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockRegionRepo)
+	svc := NewRegionService(repo)
+	regionID, missingID := uuid.New(), uuid.New()
+	repo.On("DeleteRegion", ctx, regionID).Return(nil)
+	repo.On("DeleteRegion", ctx, missingID).Return(pvz_errors.ErrRegionNotFound)
+	repo.On("SelectRegions", ctx).Return([]dto.Region{}, nil)
+	repo.On("SelectPVZLocations", ctx).Return([]dto.PVZLocation{}, nil)
+
+	require.NoError(t, svc.DeleteRegion(ctx, regionID))
+	require.ErrorIs(t, svc.DeleteRegion(ctx, missingID), pvz_errors.ErrRegionNotFound)
+	repo.AssertExpectations(t)
+}
+
+func TestGetPVZGeoJSON(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mockRegionRepo)
+	svc := NewRegionService(repo)
+	pvzID, regionID := uuid.New(), uuid.New()
+	registered := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	city := "Москва"
+	repo.On("SelectPVZPoints", ctx, dto.PVZPointFilter{City: &city, RegionID: &regionID}).
+		Return([]dto.PVZPoint{{
+			PVZLocation:  dto.PVZLocation{ID: pvzID, Latitude: 55.75, Longitude: 37.62},
+			City:         city,
+			RegisteredAt: registered,
+			RegionID:     &regionID,
+			RegionName:   strPtr("Центр"),
+		}}, nil)
+
+	untrimmed := " Москва "
+	collection, err := svc.GetPVZGeoJSON(ctx, oapi.GetPvzGeojsonParams{City: &untrimmed, RegionId: &regionID})
+	require.NoError(t, err)
+	require.Equal(t, oapi.FeatureCollection, collection.Type)
+	require.Len(t, collection.Features, 1)
+	feature := collection.Features[0]
+	require.Equal(t, pvzID, feature.Id)
+	require.Equal(t, []float64{37.62, 55.75}, feature.Geometry.Coordinates)
+	require.Equal(t, "Центр", *feature.Properties.RegionName)
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/whaleship/pvz/internal/config"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	maxReportPeriods = 400
+	maxSLAMinutes    = 24 * 60
+)
+
+type reportRepository interface {
+	SelectIntakeRows(ctx context.Context, filter dto.IntakeFilter) ([]dto.IntakeRow, error)
+	SelectIntakeTotals(ctx context.Context, filter dto.IntakeFilter) (oapi.IntakeCounts, error)
+	SelectEmployeeStats(ctx context.Context, filter dto.ProductivityFilter) ([]dto.EmployeeStats, error)
+	SelectReceptionSLA(ctx context.Context, filter dto.ReceptionSLAFilter) ([]dto.ReceptionSLAGroup, error)
+	SelectReceptionSLAHours(ctx context.Context, filter dto.ReceptionSLAFilter) ([]dto.ReceptionSLAHour, error)
+	CountSettledRollupDays(ctx context.Context, from, to time.Time) (int, error)
+}
+
+type reportService struct {
+	reportRepo reportRepository
+}
+
+func NewReportService(repo reportRepository) *reportService {
+	return &reportService{reportRepo: repo}
+}
+
+func (s *reportService) GetIntakeReport(
+	ctx context.Context,
+	params oapi.GetReportsIntakeParams,
+) (oapi.IntakeReport, error) {
+	granularity := oapi.Day
+	if params.Granularity != nil {
+		granularity = *params.Granularity
+	}
+	from, to, err := reportRange(params.From, params.To, granularity)
+	if err != nil {
+		return oapi.IntakeReport{}, err
+	}
+
+	filter := dto.IntakeFilter{
+		From:        shiftPeriod(from, granularity, -1),
+		To:          to,
+		Granularity: string(granularity),
+		City:        trimmedOrNil(params.City),
+		PvzID:       params.PvzId,
+		ProductType: trimmedOrNil(params.ProductType),
+	}
+	if params.GroupBy != nil {
+		switch *params.GroupBy {
+		case oapi.IntakeGroupByCity, oapi.IntakeGroupByPvz, oapi.IntakeGroupByProductType:
+			filter.GroupBy = string(*params.GroupBy)
+		default:
+			return oapi.IntakeReport{}, pvz_errors.ErrInvalidReport
+		}
+	}
+
+	// rollups hold distinct receptions per day, so only daily rows can be
+	// served from them: added up over a week, a month or the whole range, a
+	// reception that spans midnight would be counted more than once
+	if granularity == oapi.Day {
+		filter.Rollups, err = s.rollupsCover(ctx, filter.From, to)
+		if err != nil {
+			return oapi.IntakeReport{}, err
+		}
+	}
+
+	rows, err := s.reportRepo.SelectIntakeRows(ctx, filter)
+	if err != nil {
+		return oapi.IntakeReport{}, err
+	}
+
+	previousFrom := from.Add(-to.Sub(from))
+	filter.From, filter.Rollups = from, false
+	totals, err := s.reportRepo.SelectIntakeTotals(ctx, filter)
+	if err != nil {
+		return oapi.IntakeReport{}, err
+	}
+	filter.From, filter.To = previousFrom, from
+	previousTotals, err := s.reportRepo.SelectIntakeTotals(ctx, filter)
+	if err != nil {
+		return oapi.IntakeReport{}, err
+	}
+
+	products := make(map[string]map[time.Time]int)
+	for _, row := range rows {
+		if products[row.Key] == nil {
+			products[row.Key] = make(map[time.Time]int)
+		}
+		products[row.Key][row.PeriodStart] = row.Products
+	}
+
+	report := oapi.IntakeReport{
+		From:              from,
+		To:                to,
+		Granularity:       granularity,
+		GroupBy:           params.GroupBy,
+		Totals:            totals,
+		PreviousTotals:    previousTotals,
+		ProductsChangePct: changePct(totals.Products, previousTotals.Products),
+		Rows:              []oapi.IntakeReportRow{},
+	}
+	for _, row := range rows {
+		if row.PeriodStart.Before(from) {
+			continue
+		}
+		previous := products[row.Key][shiftPeriod(row.PeriodStart, granularity, -1)]
+		reportRow := oapi.IntakeReportRow{
+			PeriodStart:       row.PeriodStart,
+			Receptions:        row.Receptions,
+			Products:          row.Products,
+			PreviousProducts:  previous,
+			ProductsChangePct: changePct(row.Products, previous),
+		}
+		if filter.GroupBy != "" {
+			key := row.Key
+			reportRow.Key = &key
+		}
+		report.Rows = append(report.Rows, reportRow)
+	}
+	return report, nil
+}
+
+func (s *reportService) GetEmployeeProductivity(
+	ctx context.Context,
+	params oapi.GetReportsEmployeesParams,
+) (oapi.EmployeeProductivityReport, error) {
+	from, to := params.From.UTC(), params.To.UTC()
+	if !from.Before(to) || to.Sub(from) > maxReportPeriods*24*time.Hour {
+		return oapi.EmployeeProductivityReport{}, pvz_errors.ErrInvalidReport
+	}
+
+	stats, err := s.reportRepo.SelectEmployeeStats(ctx, dto.ProductivityFilter{From: from, To: to, PvzID: params.PvzId})
+	if err != nil {
+		return oapi.EmployeeProductivityReport{}, err
+	}
+
+	report := oapi.EmployeeProductivityReport{
+		From: from,
+		To:   to,
+		Rows: make([]oapi.EmployeeProductivity, 0, len(stats)),
+	}
+	for _, row := range stats {
+		employee := oapi.EmployeeProductivity{
+			UserId:            row.UserID,
+			Email:             row.Email,
+			ProductsScanned:   row.ProductsScanned,
+			ProductsDeleted:   row.ProductsDeleted,
+			ActiveHours:       row.ActiveHours,
+			ReceptionsHandled: row.ReceptionsHandled,
+			ItemsPerHour:      ratio(row.ProductsScanned, row.ActiveHours, 1),
+			DeletionRate:      ratio(row.ProductsDeleted, row.ProductsScanned, 3),
+		}
+		if row.AvgReceptionSeconds != nil {
+			seconds := int64(math.Round(*row.AvgReceptionSeconds))
+			employee.AvgReceptionDurationSeconds = &seconds
+		}
+		report.Rows = append(report.Rows, employee)
+	}
+	return report, nil
+}
+
+func (s *reportService) GetReceptionSLA(
+	ctx context.Context,
+	params oapi.GetReportsReceptionSlaParams,
+) (oapi.ReceptionSlaReport, error) {
+	from, to := params.From.UTC(), params.To.UTC()
+	if !from.Before(to) || to.Sub(from) > maxReportPeriods*24*time.Hour {
+		return oapi.ReceptionSlaReport{}, pvz_errors.ErrInvalidReport
+	}
+	slaMinutes := int(config.DefaultReceptionSLA / time.Minute)
+	if params.SlaMinutes != nil {
+		slaMinutes = *params.SlaMinutes
+	}
+	if slaMinutes < 1 || slaMinutes > maxSLAMinutes {
+		return oapi.ReceptionSlaReport{}, pvz_errors.ErrInvalidReport
+	}
+	timezone := "UTC"
+	if tz := trimmedOrNil(params.Timezone); tz != nil {
+		timezone = *tz
+	}
+	// Local names the server zone in Go and is unknown to Postgres
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		return oapi.ReceptionSlaReport{}, pvz_errors.ErrInvalidReport
+	}
+
+	filter := dto.ReceptionSLAFilter{
+		From:       from,
+		To:         to,
+		PvzID:      params.PvzId,
+		City:       trimmedOrNil(params.City),
+		SLASeconds: float64(slaMinutes * 60),
+		Timezone:   timezone,
+	}
+	groups, err := s.reportRepo.SelectReceptionSLA(ctx, filter)
+	if err != nil {
+		return oapi.ReceptionSlaReport{}, err
+	}
+	hours, err := s.reportRepo.SelectReceptionSLAHours(ctx, filter)
+	if err != nil {
+		return oapi.ReceptionSlaReport{}, err
+	}
+
+	report := oapi.ReceptionSlaReport{
+		From:       from,
+		To:         to,
+		SlaMinutes: slaMinutes,
+		Timezone:   timezone,
+		Pvzs:       []oapi.ReceptionSlaPvzRow{},
+		Cities:     []oapi.ReceptionSlaCityRow{},
+		ByHour:     make([]oapi.ReceptionSlaHourRow, 0, len(hours)),
+	}
+	for _, group := range groups {
+		switch {
+		case group.PvzID != nil && group.City != nil:
+			report.Pvzs = append(report.Pvzs, oapi.ReceptionSlaPvzRow{
+				PvzId: *group.PvzID,
+				City:  *group.City,
+				Stats: slaStats(group),
+			})
+		case group.City != nil:
+			report.Cities = append(report.Cities, oapi.ReceptionSlaCityRow{City: *group.City, Stats: slaStats(group)})
+		default:
+			report.Totals = slaStats(group)
+		}
+	}
+	for _, hour := range hours {
+		row := oapi.ReceptionSlaHourRow{
+			Weekday:    hour.Weekday,
+			Hour:       hour.Hour,
+			Receptions: hour.Receptions,
+			Breaches:   hour.Breaches,
+		}
+		if hour.DurationP50 != nil {
+			row.DurationP50Seconds = percentileSeconds([]float64{*hour.DurationP50}, 0)
+		}
+		report.ByHour = append(report.ByHour, row)
+	}
+	return report, nil
+}
+
+func slaStats(group dto.ReceptionSLAGroup) oapi.ReceptionSlaStats {
+	return oapi.ReceptionSlaStats{
+		Receptions:           group.Receptions,
+		Breaches:             group.Breaches,
+		BreachRate:           ratio(group.Breaches, group.Receptions, 3),
+		DurationP50Seconds:   percentileSeconds(group.Durations, 0),
+		DurationP90Seconds:   percentileSeconds(group.Durations, 1),
+		DurationP99Seconds:   percentileSeconds(group.Durations, 2),
+		ScanSpanP50Seconds:   percentileSeconds(group.ScanSpans, 0),
+		ScanSpanP90Seconds:   percentileSeconds(group.ScanSpans, 1),
+		MaxIdleGapP50Seconds: percentileSeconds(group.MaxIdleGaps, 0),
+		MaxIdleGapP90Seconds: percentileSeconds(group.MaxIdleGaps, 1),
+	}
+}
+
+func percentileSeconds(percentiles []float64, i int) *int64 {
+	if i >= len(percentiles) {
+		return nil
+	}
+	seconds := int64(math.Round(percentiles[i]))
+	return &seconds
+}
+
+// rollupsCover reports whether every day of [from, to) has settled rollups.
+func (s *reportService) rollupsCover(ctx context.Context, from, to time.Time) (bool, error) {
+	if !to.Equal(truncatePeriod(to, oapi.Day)) {
+		return false, nil
+	}
+	days, err := s.reportRepo.CountSettledRollupDays(ctx, from, to)
+	if err != nil {
+		return false, err
+	}
+	return days == int(to.Sub(from)/(24*time.Hour)), nil
+}
+
+// reportRange aligns from to the start of its period and limits the number
+// of periods a report may span.
+func reportRange(from, to time.Time, granularity oapi.ReportGranularity) (time.Time, time.Time, error) {
+	switch granularity {
+	case oapi.Day, oapi.Week, oapi.Month:
+	default:
+		return time.Time{}, time.Time{}, pvz_errors.ErrInvalidReport
+	}
+
+	from, to = truncatePeriod(from.UTC(), granularity), to.UTC()
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, pvz_errors.ErrInvalidReport
+	}
+	periods := 0
+	for t := from; t.Before(to); t = shiftPeriod(t, granularity, 1) {
+		if periods++; periods > maxReportPeriods {
+			return time.Time{}, time.Time{}, pvz_errors.ErrInvalidReport
+		}
+	}
+	return from, to, nil
+}
+
+func truncatePeriod(t time.Time, granularity oapi.ReportGranularity) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case oapi.Week:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case oapi.Month:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+func shiftPeriod(t time.Time, granularity oapi.ReportGranularity, n int) time.Time {
+	switch granularity {
+	case oapi.Week:
+		return t.AddDate(0, 0, 7*n)
+	case oapi.Month:
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+func changePct(current, previous int) *float32 {
+	if previous == 0 {
+		return nil
+	}
+	pct := float32(math.Round(float64(current-previous)/float64(previous)*1000) / 10)
+	return &pct
+}
+
+func ratio(numerator, denominator, digits int) float32 {
+	if denominator == 0 {
+		return 0
+	}
+	scale := math.Pow10(digits)
+	return float32(math.Round(float64(numerator)/float64(denominator)*scale) / scale)
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,484 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/cron"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/export"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+	"github.com/whaleship/pvz/internal/utils"
+)
+
+const (
+	maxSubscriptionTargets  = 10
+	maxSubscriptionLookback = 90
+	maxTargetAddressLen     = 512
+	subscriptionRunBatch    = 20
+	maxDeliveredDamageRows  = 10000
+)
+
+type reportSubscriptionRepository interface {
+	InsertReportSubscription(ctx context.Context, sub oapi.ReportSubscription) (oapi.ReportSubscription, error)
+	SelectReportSubscriptions(ctx context.Context) ([]oapi.ReportSubscription, error)
+	GetReportSubscription(ctx context.Context, subscriptionID uuid.UUID) (oapi.ReportSubscription, error)
+	UpdateReportSubscription(ctx context.Context, sub oapi.ReportSubscription) (oapi.ReportSubscription, error)
+	DeleteReportSubscription(ctx context.Context, subscriptionID uuid.UUID) error
+	SelectDueReportSubscriptions(ctx context.Context, now time.Time, limit int) ([]oapi.ReportSubscription, error)
+	ClaimReportSubscriptionRun(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		scheduledAt, nextRunAt time.Time,
+	) (bool, error)
+	InsertReportDelivery(ctx context.Context, delivery oapi.ReportDelivery) error
+	SelectReportDeliveries(
+		ctx context.Context,
+		subscriptionID uuid.UUID,
+		limit, offset int,
+	) ([]oapi.ReportDelivery, error)
+}
+
+type openReceptionSource interface {
+	SelectOpenReceptions(ctx context.Context, pvzID *uuid.UUID, city *string) ([]dto.OpenReception, error)
+}
+
+type damageIncidentSource interface {
+	SelectDamageIncidents(
+		ctx context.Context,
+		startDate, endDate time.Time,
+		pvzID *uuid.UUID,
+		carrier *string,
+		limit, offset int,
+	) ([]oapi.ProductDamage, error)
+}
+
+type reportDeliverer interface {
+	Deliver(ctx context.Context, target oapi.DeliveryTarget, file dto.ReportFile) error
+}
+
+type reportSubscriptionService struct {
+	subscriptionRepo reportSubscriptionRepository
+	reports          intakeReporter
+	openReceptions   openReceptionSource
+	damage           damageIncidentSource
+	deliverer        reportDeliverer
+}
+
+func NewReportSubscriptionService(
+	subscriptionRepo reportSubscriptionRepository,
+	reports intakeReporter,
+	openReceptions openReceptionSource,
+	damage damageIncidentSource,
+	deliverer reportDeliverer,
+) *reportSubscriptionService {
+	return &reportSubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		reports:          reports,
+		openReceptions:   openReceptions,
+		damage:           damage,
+		deliverer:        deliverer,
+	}
+}
+
+func (s *reportSubscriptionService) CreateSubscription(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReportSubscriptionsJSONRequestBody,
+) (oapi.ReportSubscription, error) {
+	now := time.Now()
+	sub, err := buildSubscription(req, now)
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	sub.Id = uuid.New()
+	sub.CreatedAt = now
+	if userID != uuid.Nil {
+		sub.CreatedBy = &userID
+	}
+	return s.subscriptionRepo.InsertReportSubscription(ctx, sub)
+}
+
+func (s *reportSubscriptionService) GetSubscriptions(ctx context.Context) ([]oapi.ReportSubscription, error) {
+	return s.subscriptionRepo.SelectReportSubscriptions(ctx)
+}
+
+func (s *reportSubscriptionService) GetSubscription(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+) (oapi.ReportSubscription, error) {
+	return s.subscriptionRepo.GetReportSubscription(ctx, subscriptionID)
+}
+
+func (s *reportSubscriptionService) UpdateSubscription(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	req oapi.PutReportSubscriptionsSubscriptionIdJSONRequestBody,
+) (oapi.ReportSubscription, error) {
+	sub, err := buildSubscription(req, time.Now())
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	sub.Id = subscriptionID
+	return s.subscriptionRepo.UpdateReportSubscription(ctx, sub)
+}
+
+func (s *reportSubscriptionService) DeleteSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	return s.subscriptionRepo.DeleteReportSubscription(ctx, subscriptionID)
+}
+
+func (s *reportSubscriptionService) GetDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	params oapi.GetReportSubscriptionsSubscriptionIdDeliveriesParams,
+) ([]oapi.ReportDelivery, error) {
+	if _, err := s.subscriptionRepo.GetReportSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	page, limit := 1, 30
+	if params.Page != nil && *params.Page > 0 {
+		page = *params.Page
+	}
+	if params.Limit != nil && *params.Limit > 0 && *params.Limit <= 100 {
+		limit = *params.Limit
+	}
+	return s.subscriptionRepo.SelectReportDeliveries(ctx, subscriptionID, limit, (page-1)*limit)
+}
+
+// DeliverDueReports runs every subscription whose next run has come. A run
+// missed while the service was down is delivered once, and the schedule
+// continues from now. A failing subscription does not hold back the others.
+func (s *reportSubscriptionService) DeliverDueReports(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	subs, err := s.subscriptionRepo.SelectDueReportSubscriptions(ctx, now, subscriptionRunBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	var errs []error
+	for _, sub := range subs {
+		ok, err := s.runSubscription(ctx, sub, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", sub.Id, err))
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, errors.Join(errs...)
+}
+
+func (s *reportSubscriptionService) runSubscription(
+	ctx context.Context,
+	sub oapi.ReportSubscription,
+	now time.Time,
+) (bool, error) {
+	schedule, loc, err := parseSchedule(sub.Schedule, sub.Timezone)
+	if err != nil {
+		return false, err
+	}
+	scheduledAt := *sub.NextRunAt
+	claimed, err := s.subscriptionRepo.ClaimReportSubscriptionRun(ctx, sub.Id, scheduledAt,
+		schedule.Next(now.In(loc)).UTC())
+	if err != nil || !claimed {
+		return false, err
+	}
+	return true, s.deliver(ctx, sub, scheduledAt.In(loc))
+}
+
+func (s *reportSubscriptionService) deliver(
+	ctx context.Context,
+	sub oapi.ReportSubscription,
+	scheduledAt time.Time,
+) error {
+	file, renderErr := s.render(ctx, sub, scheduledAt)
+	for _, target := range sub.Targets {
+		delivery := oapi.ReportDelivery{
+			Id:             uuid.New(),
+			SubscriptionId: sub.Id,
+			ScheduledAt:    scheduledAt.UTC(),
+			Target:         target,
+			Status:         oapi.Sent,
+		}
+		err := renderErr
+		if err == nil {
+			delivery.FileName = &file.Name
+			err = s.deliverer.Deliver(ctx, target, file)
+		}
+		if err != nil {
+			message := err.Error()
+			delivery.Status, delivery.Error = oapi.Undelivered, &message
+		}
+		delivery.CreatedAt = time.Now()
+		if err = s.subscriptionRepo.InsertReportDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render builds the report for the days that ended before the scheduled run,
+// in the subscription time zone.
+func (s *reportSubscriptionService) render(
+	ctx context.Context,
+	sub oapi.ReportSubscription,
+	scheduledAt time.Time,
+) (dto.ReportFile, error) {
+	to := time.Date(scheduledAt.Year(), scheduledAt.Month(), scheduledAt.Day(), 0, 0, 0, 0, scheduledAt.Location())
+	lookback := 1
+	if sub.Params.LookbackDays != nil {
+		lookback = *sub.Params.LookbackDays
+	}
+	from := to.AddDate(0, 0, -lookback)
+
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.Format(sub.Format), &buf)
+	if err != nil {
+		return dto.ReportFile{}, err
+	}
+	switch sub.Report {
+	case oapi.Intake:
+		err = s.writeIntake(ctx, sub.Params, from, to, w)
+	case oapi.OpenReceptions:
+		err = s.writeOpenReceptions(ctx, sub.Params, time.Now(), w)
+	case oapi.Damage:
+		err = s.writeDamage(ctx, sub.Params, from, to, w)
+	default:
+		err = pvz_errors.ErrInvalidReportSubscription
+	}
+	if err != nil {
+		return dto.ReportFile{}, err
+	}
+	if err = w.Close(); err != nil {
+		return dto.ReportFile{}, err
+	}
+
+	date := scheduledAt.Format(time.DateOnly)
+	return dto.ReportFile{
+		Name:        fmt.Sprintf("%s-%s.%s", sub.Report, date, sub.Format),
+		Subject:     fmt.Sprintf("%s, %s", sub.Name, date),
+		ContentType: export.ContentType(export.Format(sub.Format)),
+		Body:        buf.Bytes(),
+	}, nil
+}
+
+func (s *reportSubscriptionService) writeIntake(
+	ctx context.Context,
+	params oapi.ReportSubscriptionParams,
+	from, to time.Time,
+	w export.Writer,
+) error {
+	report, err := s.reports.GetIntakeReport(ctx, oapi.GetReportsIntakeParams{
+		From:        from.UTC(),
+		To:          to.UTC(),
+		Granularity: params.Granularity,
+		GroupBy:     params.GroupBy,
+		City:        params.City,
+		PvzId:       params.PvzId,
+		ProductType: params.ProductType,
+	})
+	if err != nil {
+		return err
+	}
+	return writeIntakeRows(w, report)
+}
+
+func (s *reportSubscriptionService) writeOpenReceptions(
+	ctx context.Context,
+	params oapi.ReportSubscriptionParams,
+	now time.Time,
+	w export.Writer,
+) error {
+	receptions, err := s.openReceptions.SelectOpenReceptions(ctx, params.PvzId, trimmedOrNil(params.City))
+	if err != nil {
+		return err
+	}
+	header := []string{"receptionId", "pvzId", "city", "openedAt", "openHours", "carrier", "waybillNumber", "products"}
+	if err = w.WriteHeader(header); err != nil {
+		return err
+	}
+	for _, r := range receptions {
+		err = w.WriteRow([]any{
+			r.ReceptionID, r.PvzID, r.City, r.OpenedAt, math.Round(now.Sub(r.OpenedAt).Hours()*10) / 10,
+			r.Carrier, r.WaybillNumber, r.Products,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *reportSubscriptionService) writeDamage(
+	ctx context.Context,
+	params oapi.ReportSubscriptionParams,
+	from, to time.Time,
+	w export.Writer,
+) error {
+	incidents, err := s.damage.SelectDamageIncidents(ctx, from.UTC(), to.UTC(), params.PvzId,
+		trimmedOrNil(params.Carrier), maxDeliveredDamageRows, 0)
+	if err != nil {
+		return err
+	}
+	header := []string{
+		"createdAt", "pvzId", "city", "carrier", "waybillNumber", "receptionId", "productId", "kind", "severity",
+		"comment",
+	}
+	if err = w.WriteHeader(header); err != nil {
+		return err
+	}
+	for _, d := range incidents {
+		err = w.WriteRow([]any{
+			d.CreatedAt, d.PvzId, d.City, d.Carrier, d.WaybillNumber, d.ReceptionId, d.ProductId,
+			string(d.Kind), string(d.Severity), d.Comment,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildSubscription(req oapi.ReportSubscriptionRequest, now time.Time) (oapi.ReportSubscription, error) {
+	sub := oapi.ReportSubscription{
+		Name:     strings.TrimSpace(req.Name),
+		Report:   req.Report,
+		Schedule: strings.Join(strings.Fields(req.Schedule), " "),
+		Timezone: "UTC",
+		Format:   oapi.Csv,
+		Active:   true,
+	}
+	if req.Params != nil {
+		sub.Params = *req.Params
+	}
+	if req.Timezone != nil {
+		sub.Timezone = strings.TrimSpace(*req.Timezone)
+	}
+	if req.Format != nil {
+		sub.Format = *req.Format
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+
+	if sub.Name == "" || len([]rune(sub.Name)) > 100 || export.ContentType(export.Format(sub.Format)) == "" {
+		return oapi.ReportSubscription{}, pvz_errors.ErrInvalidReportSubscription
+	}
+	switch sub.Report {
+	case oapi.Intake, oapi.OpenReceptions, oapi.Damage:
+	default:
+		return oapi.ReportSubscription{}, pvz_errors.ErrInvalidReportSubscription
+	}
+	if err := validateSubscriptionParams(sub.Params); err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	targets, err := normalizeTargets(req.Targets)
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	sub.Targets = targets
+
+	schedule, loc, err := parseSchedule(sub.Schedule, sub.Timezone)
+	if err != nil {
+		return oapi.ReportSubscription{}, err
+	}
+	next := schedule.Next(now.In(loc))
+	if next.IsZero() {
+		return oapi.ReportSubscription{}, pvz_errors.ErrInvalidReportSubscription
+	}
+	if sub.Active {
+		next = next.UTC()
+		sub.NextRunAt = &next
+	}
+	return sub, nil
+}
+
+func validateSubscriptionParams(params oapi.ReportSubscriptionParams) error {
+	if params.LookbackDays != nil && (*params.LookbackDays < 1 || *params.LookbackDays > maxSubscriptionLookback) {
+		return pvz_errors.ErrInvalidReportSubscription
+	}
+	if params.Granularity != nil {
+		switch *params.Granularity {
+		case oapi.Day, oapi.Week, oapi.Month:
+		default:
+			return pvz_errors.ErrInvalidReportSubscription
+		}
+	}
+	if params.GroupBy != nil {
+		switch *params.GroupBy {
+		case oapi.IntakeGroupByCity, oapi.IntakeGroupByPvz, oapi.IntakeGroupByProductType:
+		default:
+			return pvz_errors.ErrInvalidReportSubscription
+		}
+	}
+	return nil
+}
+
+func normalizeTargets(targets []oapi.DeliveryTarget) ([]oapi.DeliveryTarget, error) {
+	if len(targets) == 0 || len(targets) > maxSubscriptionTargets {
+		return nil, pvz_errors.ErrInvalidReportSubscription
+	}
+	result := make([]oapi.DeliveryTarget, 0, len(targets))
+	for _, target := range targets {
+		address := strings.TrimSpace(target.Address)
+		if address == "" || len(address) > maxTargetAddressLen {
+			return nil, pvz_errors.ErrInvalidReportSubscription
+		}
+		switch target.Type {
+		case oapi.Directory:
+			if !filepath.IsLocal(address) {
+				return nil, pvz_errors.ErrInvalidReportSubscription
+			}
+		case oapi.Email:
+			parsed, err := mail.ParseAddress(address)
+			if err != nil {
+				return nil, pvz_errors.ErrInvalidReportSubscription
+			}
+			address = parsed.Address
+		case oapi.Webhook:
+			u, err := url.Parse(address)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || isInternalHost(u.Hostname()) {
+				return nil, pvz_errors.ErrInvalidReportSubscription
+			}
+		default:
+			return nil, pvz_errors.ErrInvalidReportSubscription
+		}
+		result = append(result, oapi.DeliveryTarget{Type: target.Type, Address: address})
+	}
+	return result, nil
+}
+
+// isInternalHost catches webhook hosts that are obviously internal; names
+// resolving to internal addresses are refused when the report is delivered.
+func isInternalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && !utils.IsPublicAddr(addr)
+}
+
+func parseSchedule(spec, timezone string) (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return cron.Schedule{}, nil, pvz_errors.ErrInvalidReportSubscription
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return cron.Schedule{}, nil, pvz_errors.ErrInvalidReportSubscription
+	}
+	return schedule, loc, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockReportSubscriptionRepo struct{ mock.Mock }
+
+func (m *mockReportSubscriptionRepo) InsertReportSubscription(
+	ctx context.Context,
+	sub oapi.ReportSubscription,
+) (oapi.ReportSubscription, error) {
+	args := m.Called(ctx, sub)
+	return args.Get(0).(oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionRepo) SelectReportSubscriptions(
+	ctx context.Context,
+) ([]oapi.ReportSubscription, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionRepo) GetReportSubscription(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+) (oapi.ReportSubscription, error) {
+	args := m.Called(ctx, subscriptionID)
+	return args.Get(0).(oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionRepo) UpdateReportSubscription(
+	ctx context.Context,
+	sub oapi.ReportSubscription,
+) (oapi.ReportSubscription, error) {
+	args := m.Called(ctx, sub)
+	return args.Get(0).(oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionRepo) DeleteReportSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *mockReportSubscriptionRepo) SelectDueReportSubscriptions(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]oapi.ReportSubscription, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]oapi.ReportSubscription), args.Error(1)
+}
+
+func (m *mockReportSubscriptionRepo) ClaimReportSubscriptionRun(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	scheduledAt, nextRunAt time.Time,
+) (bool, error) {
+	args := m.Called(ctx, subscriptionID, scheduledAt, nextRunAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockReportSubscriptionRepo) InsertReportDelivery(ctx context.Context, delivery oapi.ReportDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *mockReportSubscriptionRepo) SelectReportDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	limit, offset int,
+) ([]oapi.ReportDelivery, error) {
+	args := m.Called(ctx, subscriptionID, limit, offset)
+	return args.Get(0).([]oapi.ReportDelivery), args.Error(1)
+}
+
+type mockOpenReceptionSource struct{ mock.Mock }
+
+func (m *mockOpenReceptionSource) SelectOpenReceptions(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	city *string,
+) ([]dto.OpenReception, error) {
+	args := m.Called(ctx, pvzID, city)
+	return args.Get(0).([]dto.OpenReception), args.Error(1)
+}
+
+type mockReportDeliverer struct{ mock.Mock }
+
+func (m *mockReportDeliverer) Deliver(ctx context.Context, target oapi.DeliveryTarget, file dto.ReportFile) error {
+	args := m.Called(ctx, target, file)
+	return args.Error(0)
+}
+
+func TestCreateReportSubscription(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	targets := []oapi.DeliveryTarget{
+		{Type: oapi.Directory, Address: " finance/daily "},
+		{Type: oapi.Email, Address: "Ops <ops@example.com>"},
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		repo := new(mockReportSubscriptionRepo)
+		svc := NewReportSubscriptionService(repo, nil, nil, nil, nil)
+		repo.On("InsertReportSubscription", ctx, mock.MatchedBy(func(sub oapi.ReportSubscription) bool {
+			return sub.Name == "Daily intake" && sub.Timezone == "UTC" && sub.Format == oapi.Csv && sub.Active &&
+				sub.Schedule == "0 7 * * *" && *sub.CreatedBy == userID &&
+				sub.Targets[0].Address == "finance/daily" && sub.Targets[1].Address == "ops@example.com" &&
+				sub.NextRunAt.Hour() == 7 && sub.NextRunAt.Minute() == 0 && sub.NextRunAt.After(time.Now())
+		})).Return(oapi.ReportSubscription{}, nil)
+
+		_, err := svc.CreateSubscription(ctx, userID, oapi.PostReportSubscriptionsJSONRequestBody{
+			Name:     " Daily intake ",
+			Report:   oapi.Intake,
+			Schedule: "0  7 * * *",
+			Targets:  targets,
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("paused subscription has no next run", func(t *testing.T) {
+		repo := new(mockReportSubscriptionRepo)
+		svc := NewReportSubscriptionService(repo, nil, nil, nil, nil)
+		active := false
+		repo.On("InsertReportSubscription", ctx, mock.MatchedBy(func(sub oapi.ReportSubscription) bool {
+			return !sub.Active && sub.NextRunAt == nil
+		})).Return(oapi.ReportSubscription{}, nil)
+
+		_, err := svc.CreateSubscription(ctx, userID, oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "Damage", Report: oapi.Damage, Schedule: "0 9 * * 1", Targets: targets, Active: &active,
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	lookback := 120
+	timezone := "Mars/Olympus"
+	cases := []struct {
+		name string
+		req  oapi.PostReportSubscriptionsJSONRequestBody
+	}{
+		{"empty name", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: " ", Report: oapi.Intake, Schedule: "0 7 * * *", Targets: targets,
+		}},
+		{"unknown report", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.ReportSubscriptionKind("orders"), Schedule: "0 7 * * *", Targets: targets,
+		}},
+		{"bad schedule", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * *", Targets: targets,
+		}},
+		{"never fires", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 30 2 *", Targets: targets,
+		}},
+		{"unknown timezone", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *", Timezone: &timezone, Targets: targets,
+		}},
+		{"long lookback", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *", Targets: targets,
+			Params: &oapi.ReportSubscriptionParams{LookbackDays: &lookback},
+		}},
+		{"no targets", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *",
+		}},
+		{"directory outside root", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *",
+			Targets: []oapi.DeliveryTarget{{Type: oapi.Directory, Address: "../etc"}},
+		}},
+		{"bad email", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *",
+			Targets: []oapi.DeliveryTarget{{Type: oapi.Email, Address: "ops"}},
+		}},
+		{"webhook without http", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *",
+			Targets: []oapi.DeliveryTarget{{Type: oapi.Webhook, Address: "ftp://example.com/in"}},
+		}},
+		{"webhook to localhost", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *",
+			Targets: []oapi.DeliveryTarget{{Type: oapi.Webhook, Address: "http://localhost:8080/in"}},
+		}},
+		{"webhook to private address", oapi.PostReportSubscriptionsJSONRequestBody{
+			Name: "x", Report: oapi.Intake, Schedule: "0 7 * * *",
+			Targets: []oapi.DeliveryTarget{{Type: oapi.Webhook, Address: "http://169.254.169.254/latest"}},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := NewReportSubscriptionService(new(mockReportSubscriptionRepo), nil, nil, nil, nil)
+			_, err := svc.CreateSubscription(ctx, userID, tc.req)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidReportSubscription)
+		})
+	}
+}
+
+func TestGetDeliveriesChecksSubscription(t *testing.T) {
+	ctx := context.Background()
+	subID := uuid.New()
+	repo := new(mockReportSubscriptionRepo)
+	svc := NewReportSubscriptionService(repo, nil, nil, nil, nil)
+	repo.On("GetReportSubscription", ctx, subID).
+		Return(oapi.ReportSubscription{}, pvz_errors.ErrReportSubscriptionNotFound)
+
+	_, err := svc.GetDeliveries(ctx, subID, oapi.GetReportSubscriptionsSubscriptionIdDeliveriesParams{})
+	require.ErrorIs(t, err, pvz_errors.ErrReportSubscriptionNotFound)
+	repo.AssertNotCalled(t, "SelectReportDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeliverDueReports(t *testing.T) {
+	ctx := context.Background()
+	scheduledAt := time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC)
+	pvzID := uuid.New()
+	dir := oapi.DeliveryTarget{Type: oapi.Directory, Address: "ops"}
+	hook := oapi.DeliveryTarget{Type: oapi.Webhook, Address: "https://example.com/reports"}
+
+	t.Run("renders once and records every target", func(t *testing.T) {
+		repo, source, deliverer := new(mockReportSubscriptionRepo), new(mockOpenReceptionSource), new(mockReportDeliverer)
+		svc := NewReportSubscriptionService(repo, nil, source, nil, deliverer)
+		sub := oapi.ReportSubscription{
+			Id: uuid.New(), Name: "Open receptions", Report: oapi.OpenReceptions, Schedule: "0 7 * * *",
+			Timezone: "Europe/Moscow", Format: oapi.Csv, Targets: []oapi.DeliveryTarget{dir, hook},
+			Params: oapi.ReportSubscriptionParams{PvzId: &pvzID}, NextRunAt: &scheduledAt,
+		}
+		repo.On("SelectDueReportSubscriptions", ctx, mock.Anything, subscriptionRunBatch).
+			Return([]oapi.ReportSubscription{sub}, nil)
+		repo.On("ClaimReportSubscriptionRun", ctx, sub.Id, scheduledAt, mock.MatchedBy(func(next time.Time) bool {
+			return next.Location() == time.UTC && next.Hour() == 4 && next.After(time.Now())
+		})).Return(true, nil)
+		source.On("SelectOpenReceptions", ctx, &pvzID, (*string)(nil)).Return([]dto.OpenReception{{
+			ReceptionID: uuid.New(), PvzID: pvzID, City: "Москва", OpenedAt: time.Now().Add(-90 * time.Minute),
+			Products: 3,
+		}}, nil)
+		isReport := mock.MatchedBy(func(file dto.ReportFile) bool {
+			body := string(file.Body)
+			return file.Name == "open_receptions-2025-03-10.csv" && file.Subject == "Open receptions, 2025-03-10" &&
+				strings.Contains(body, "receptionId,pvzId,") && strings.Contains(body, ",1.5,")
+		})
+		deliverer.On("Deliver", ctx, dir, isReport).Return(nil)
+		deliverer.On("Deliver", ctx, hook, isReport).Return(errors.New("webhook responded 502"))
+		repo.On("InsertReportDelivery", ctx, mock.MatchedBy(func(d oapi.ReportDelivery) bool {
+			return d.Target == dir && d.Status == oapi.Sent && d.Error == nil && d.ScheduledAt.Equal(scheduledAt)
+		})).Return(nil)
+		repo.On("InsertReportDelivery", ctx, mock.MatchedBy(func(d oapi.ReportDelivery) bool {
+			return d.Target == hook && d.Status == oapi.Undelivered && *d.Error == "webhook responded 502"
+		})).Return(nil)
+
+		delivered, err := svc.DeliverDueReports(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, delivered)
+		repo.AssertExpectations(t)
+		source.AssertNumberOfCalls(t, "SelectOpenReceptions", 1)
+		deliverer.AssertExpectations(t)
+	})
+
+	t.Run("damage covers the lookback days in the subscription timezone", func(t *testing.T) {
+		repo, damage, deliverer := new(mockReportSubscriptionRepo), new(mockDamageRepo), new(mockReportDeliverer)
+		svc := NewReportSubscriptionService(repo, nil, nil, damage, deliverer)
+		lookback := 7
+		sub := oapi.ReportSubscription{
+			Id: uuid.New(), Name: "Damage", Report: oapi.Damage, Schedule: "0 7 * * 1", Timezone: "Europe/Moscow",
+			Format: oapi.Ndjson, Targets: []oapi.DeliveryTarget{dir}, NextRunAt: &scheduledAt,
+			Params: oapi.ReportSubscriptionParams{LookbackDays: &lookback},
+		}
+		repo.On("SelectDueReportSubscriptions", ctx, mock.Anything, subscriptionRunBatch).
+			Return([]oapi.ReportSubscription{sub}, nil)
+		repo.On("ClaimReportSubscriptionRun", ctx, sub.Id, scheduledAt, mock.Anything).Return(true, nil)
+		from := time.Date(2025, 3, 2, 21, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 3, 9, 21, 0, 0, 0, time.UTC)
+		damage.On("SelectDamageIncidents", ctx, from, to, (*uuid.UUID)(nil), (*string)(nil), maxDeliveredDamageRows, 0).
+			Return([]oapi.ProductDamage{{
+				ProductId: uuid.New(), ReceptionId: uuid.New(),
+				Kind: oapi.ProductDamageKindWet, Severity: oapi.ProductDamageSeverityLow,
+			}}, nil)
+		deliverer.On("Deliver", ctx, dir, mock.MatchedBy(func(file dto.ReportFile) bool {
+			return file.Name == "damage-2025-03-10.ndjson" && strings.Contains(string(file.Body), `"kind":"wet"`)
+		})).Return(nil)
+		repo.On("InsertReportDelivery", ctx, mock.Anything).Return(nil)
+
+		delivered, err := svc.DeliverDueReports(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, delivered)
+		damage.AssertExpectations(t)
+		deliverer.AssertExpectations(t)
+	})
+
+	t.Run("run claimed elsewhere", func(t *testing.T) {
+		repo, deliverer := new(mockReportSubscriptionRepo), new(mockReportDeliverer)
+		svc := NewReportSubscriptionService(repo, nil, nil, nil, deliverer)
+		sub := oapi.ReportSubscription{
+			Id: uuid.New(), Report: oapi.OpenReceptions, Schedule: "0 7 * * *", Timezone: "UTC",
+			Targets: []oapi.DeliveryTarget{dir}, NextRunAt: &scheduledAt,
+		}
+		repo.On("SelectDueReportSubscriptions", ctx, mock.Anything, subscriptionRunBatch).
+			Return([]oapi.ReportSubscription{sub}, nil)
+		repo.On("ClaimReportSubscriptionRun", ctx, sub.Id, scheduledAt, mock.Anything).Return(false, nil)
+
+		delivered, err := svc.DeliverDueReports(ctx)
+		require.NoError(t, err)
+		require.Zero(t, delivered)
+		deliverer.AssertNotCalled(t, "Deliver", mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "InsertReportDelivery", mock.Anything, mock.Anything)
+	})
+}
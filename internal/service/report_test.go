@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockReportRepo struct{ mock.Mock }
+
+func (m *mockReportRepo) SelectIntakeRows(ctx context.Context, filter dto.IntakeFilter) ([]dto.IntakeRow, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]dto.IntakeRow), args.Error(1)
+}
+
+func (m *mockReportRepo) SelectIntakeTotals(ctx context.Context, filter dto.IntakeFilter) (oapi.IntakeCounts, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(oapi.IntakeCounts), args.Error(1)
+}
+
+func (m *mockReportRepo) SelectEmployeeStats(
+	ctx context.Context,
+	filter dto.ProductivityFilter) ([]dto.EmployeeStats, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]dto.EmployeeStats), args.Error(1)
+}
+
+func (m *mockReportRepo) SelectReceptionSLA(
+	ctx context.Context,
+	filter dto.ReceptionSLAFilter,
+) ([]dto.ReceptionSLAGroup, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]dto.ReceptionSLAGroup), args.Error(1)
+}
+
+func (m *mockReportRepo) SelectReceptionSLAHours(
+	ctx context.Context,
+	filter dto.ReceptionSLAFilter,
+) ([]dto.ReceptionSLAHour, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]dto.ReceptionSLAHour), args.Error(1)
+}
+
+func (m *mockReportRepo) CountSettledRollupDays(ctx context.Context, from, to time.Time) (int, error) {
+	args := m.Called(ctx, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func TestGetIntakeReport(t *testing.T) {
+	ctx := context.Background()
+	// Wednesday; weekly reports start on Monday 2025-03-03.
+	from := time.Date(2025, 3, 5, 15, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	to := monday.AddDate(0, 0, 14)
+
+	t.Run("weekly by city", func(t *testing.T) {
+		repo := new(mockReportRepo)
+		svc := NewReportService(repo)
+		granularity, groupBy := oapi.Week, oapi.IntakeGroupByCity
+		repo.
+			On("SelectIntakeRows", mock.Anything, mock.MatchedBy(func(f dto.IntakeFilter) bool {
+				return f.From.Equal(monday.AddDate(0, 0, -7)) && f.Granularity == "week" && f.GroupBy == "city" &&
+					!f.Rollups
+			})).
+			Return([]dto.IntakeRow{
+				{PeriodStart: monday.AddDate(0, 0, -7), Key: "Казань", Receptions: 1, Products: 10},
+				{PeriodStart: monday, Key: "Казань", Receptions: 2, Products: 15},
+				{PeriodStart: monday.AddDate(0, 0, 7), Key: "Казань", Receptions: 1, Products: 3},
+				{PeriodStart: monday.AddDate(0, 0, 7), Key: "Москва", Receptions: 1, Products: 4},
+			}, nil)
+		repo.
+			On("SelectIntakeTotals", mock.Anything, mock.MatchedBy(func(f dto.IntakeFilter) bool {
+				return f.From.Equal(monday)
+			})).
+			Return(oapi.IntakeCounts{Receptions: 4, Products: 22}, nil)
+		repo.
+			On("SelectIntakeTotals", mock.Anything, mock.MatchedBy(func(f dto.IntakeFilter) bool {
+				return f.To.Equal(monday)
+			})).
+			Return(oapi.IntakeCounts{Receptions: 2, Products: 20}, nil)
+
+		report, err := svc.GetIntakeReport(ctx, oapi.GetReportsIntakeParams{
+			From: from, To: to, Granularity: &granularity, GroupBy: &groupBy,
+		})
+		require.NoError(t, err)
+		require.Equal(t, monday, report.From)
+		require.Equal(t, float32(10), *report.ProductsChangePct)
+		require.Len(t, report.Rows, 3)
+		require.Equal(t, "Казань", *report.Rows[0].Key)
+		require.Equal(t, 10, report.Rows[0].PreviousProducts)
+		require.Equal(t, float32(50), *report.Rows[0].ProductsChangePct)
+		require.Equal(t, float32(-80), *report.Rows[1].ProductsChangePct)
+		require.Nil(t, report.Rows[2].ProductsChangePct)
+		repo.AssertNotCalled(t, "CountSettledRollupDays", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("daily from rollups", func(t *testing.T) {
+		repo := new(mockReportRepo)
+		svc := NewReportService(repo)
+		end := monday.AddDate(0, 0, 7)
+		repo.
+			On("CountSettledRollupDays", mock.Anything, monday.AddDate(0, 0, -1), end).
+			Return(8, nil)
+		repo.
+			On("SelectIntakeRows", mock.Anything, mock.MatchedBy(func(f dto.IntakeFilter) bool {
+				return f.Rollups && f.From.Equal(monday.AddDate(0, 0, -1))
+			})).
+			Return([]dto.IntakeRow{{PeriodStart: monday, Receptions: 1, Products: 5}}, nil)
+		repo.
+			On("SelectIntakeTotals", mock.Anything, mock.MatchedBy(func(f dto.IntakeFilter) bool {
+				return !f.Rollups
+			})).
+			Return(oapi.IntakeCounts{Receptions: 1, Products: 5}, nil)
+
+		report, err := svc.GetIntakeReport(ctx, oapi.GetReportsIntakeParams{From: monday, To: end})
+		require.NoError(t, err)
+		require.Equal(t, 5, report.Totals.Products)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("partial day skips rollups", func(t *testing.T) {
+		repo := new(mockReportRepo)
+		svc := NewReportService(repo)
+		repo.
+			On("SelectIntakeRows", mock.Anything, mock.MatchedBy(func(f dto.IntakeFilter) bool { return !f.Rollups })).
+			Return([]dto.IntakeRow{}, nil)
+		repo.
+			On("SelectIntakeTotals", mock.Anything, mock.Anything).
+			Return(oapi.IntakeCounts{}, nil)
+
+		_, err := svc.GetIntakeReport(ctx, oapi.GetReportsIntakeParams{From: monday, To: from})
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "CountSettledRollupDays", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	year, user := oapi.ReportGranularity("year"), oapi.IntakeGroupBy("user")
+	invalid := map[string]oapi.GetReportsIntakeParams{
+		"empty range":     {From: to, To: monday},
+		"too many days":   {From: monday, To: monday.AddDate(2, 0, 0)},
+		"bad granularity": {From: monday, To: to, Granularity: &year},
+		"bad group":       {From: monday, To: to, GroupBy: &user},
+	}
+	for name, params := range invalid {
+		t.Run(name, func(t *testing.T) {
+			svc := NewReportService(new(mockReportRepo))
+			_, err := svc.GetIntakeReport(ctx, params)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidReport)
+		})
+	}
+}
+
+func TestGetEmployeeProductivity(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	t.Run("rates", func(t *testing.T) {
+		repo := new(mockReportRepo)
+		svc := NewReportService(repo)
+		avg := 1799.6
+		stats := []dto.EmployeeStats{
+			{UserID: uuid.New(), ProductsScanned: 100, ProductsDeleted: 3, ActiveHours: 3, AvgReceptionSeconds: &avg},
+			{UserID: uuid.New()},
+		}
+		repo.
+			On("SelectEmployeeStats", mock.Anything, dto.ProductivityFilter{From: from, To: to}).
+			Return(stats, nil)
+
+		report, err := svc.GetEmployeeProductivity(ctx, oapi.GetReportsEmployeesParams{From: from, To: to})
+		require.NoError(t, err)
+		require.Len(t, report.Rows, 2)
+		require.Equal(t, float32(33.3), report.Rows[0].ItemsPerHour)
+		require.Equal(t, float32(0.03), report.Rows[0].DeletionRate)
+		require.Equal(t, int64(1800), *report.Rows[0].AvgReceptionDurationSeconds)
+		require.Zero(t, report.Rows[1].ItemsPerHour)
+		require.Nil(t, report.Rows[1].AvgReceptionDurationSeconds)
+		repo.AssertExpectations(t)
+	})
+
+	for name, params := range map[string]oapi.GetReportsEmployeesParams{
+		"empty range":   {From: to, To: from},
+		"too many days": {From: from, To: from.AddDate(2, 0, 0)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := NewReportService(new(mockReportRepo))
+			_, err := svc.GetEmployeeProductivity(ctx, params)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidReport)
+		})
+	}
+}
+
+func TestGetReceptionSLA(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	t.Run("groups", func(t *testing.T) {
+		repo := new(mockReportRepo)
+		svc := NewReportService(repo)
+		pvzID, city := uuid.New(), "Москва"
+		sla := 45
+		timezone := "Europe/Moscow"
+		filter := dto.ReceptionSLAFilter{From: from, To: to, SLASeconds: 2700, Timezone: timezone}
+		repo.On("SelectReceptionSLA", mock.Anything, filter).Return([]dto.ReceptionSLAGroup{
+			{PvzID: &pvzID, City: &city, Receptions: 3, Breaches: 1, Durations: []float64{1800.4, 3000, 3500}},
+			{City: &city, Receptions: 3, Breaches: 1, Durations: []float64{1800.4, 3000, 3500}},
+			{Receptions: 3, Breaches: 1, Durations: []float64{1800.4, 3000, 3500}, ScanSpans: []float64{900, 1500.5}},
+		}, nil)
+		p50 := 1800.4
+		repo.On("SelectReceptionSLAHours", mock.Anything, filter).Return([]dto.ReceptionSLAHour{
+			{Weekday: 1, Hour: 9, Receptions: 3, Breaches: 1, DurationP50: &p50},
+		}, nil)
+
+		report, err := svc.GetReceptionSLA(ctx, oapi.GetReportsReceptionSlaParams{
+			From: from, To: to, SlaMinutes: &sla, Timezone: &timezone,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 45, report.SlaMinutes)
+		require.Len(t, report.Pvzs, 1)
+		require.Equal(t, pvzID, report.Pvzs[0].PvzId)
+		require.Len(t, report.Cities, 1)
+		require.Equal(t, city, report.Cities[0].City)
+		require.Equal(t, 3, report.Totals.Receptions)
+		require.Equal(t, float32(0.333), report.Totals.BreachRate)
+		require.Equal(t, int64(1800), *report.Totals.DurationP50Seconds)
+		require.Equal(t, int64(3500), *report.Totals.DurationP99Seconds)
+		require.Equal(t, int64(1501), *report.Totals.ScanSpanP90Seconds)
+		require.Nil(t, report.Totals.MaxIdleGapP50Seconds)
+		require.Equal(t, int64(1800), *report.ByHour[0].DurationP50Seconds)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		repo := new(mockReportRepo)
+		svc := NewReportService(repo)
+		filter := dto.ReceptionSLAFilter{From: from, To: to, SLASeconds: 7200, Timezone: "UTC"}
+		repo.On("SelectReceptionSLA", mock.Anything, filter).Return([]dto.ReceptionSLAGroup{{}}, nil)
+		repo.On("SelectReceptionSLAHours", mock.Anything, filter).Return([]dto.ReceptionSLAHour{}, nil)
+
+		report, err := svc.GetReceptionSLA(ctx, oapi.GetReportsReceptionSlaParams{From: from, To: to})
+		require.NoError(t, err)
+		require.Equal(t, 120, report.SlaMinutes)
+		require.Zero(t, report.Totals.BreachRate)
+		require.Empty(t, report.Pvzs)
+		require.Empty(t, report.ByHour)
+	})
+
+	zero, local, unknown := 0, "Local", "Europe/Atlantis"
+	for name, params := range map[string]oapi.GetReportsReceptionSlaParams{
+		"empty range":      {From: to, To: from},
+		"zero sla":         {From: from, To: to, SlaMinutes: &zero},
+		"server time zone": {From: from, To: to, Timezone: &local},
+		"unknown zone":     {From: from, To: to, Timezone: &unknown},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc := NewReportService(new(mockReportRepo))
+			_, err := svc.GetReceptionSLA(ctx, params)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidReport)
+		})
+	}
+}
+
+func TestTruncatePeriod(t *testing.T) {
+	sunday := time.Date(2025, 3, 9, 23, 59, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC), truncatePeriod(sunday, oapi.Day))
+	require.Equal(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), truncatePeriod(sunday, oapi.Week))
+	require.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), truncatePeriod(sunday, oapi.Month))
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const maxReturnReasonCodeLen = 50
+
+type returnRepository interface {
+	SelectReturnReasons(ctx context.Context, includeInactive bool) ([]oapi.ReturnReason, error)
+	InsertReturnReason(ctx context.Context, reason oapi.ReturnReason) (oapi.ReturnReason, error)
+	InsertReturn(ctx context.Context, ret oapi.CustomerReturn) (oapi.CustomerReturn, error)
+	SelectReturns(
+		ctx context.Context,
+		pvzID *uuid.UUID,
+		status *oapi.CustomerReturnStatus,
+		limit, offset int,
+	) ([]oapi.CustomerReturn, error)
+}
+
+type returnService struct {
+	returnRepo returnRepository
+}
+
+func NewReturnService(repo returnRepository) *returnService {
+	return &returnService{returnRepo: repo}
+}
+
+func (s *returnService) GetReturnReasons(
+	ctx context.Context,
+	params oapi.GetReturnReasonsParams,
+) ([]oapi.ReturnReason, error) {
+	includeInactive := params.IncludeInactive != nil && *params.IncludeInactive
+	return s.returnRepo.SelectReturnReasons(ctx, includeInactive)
+}
+
+func (s *returnService) CreateReturnReason(
+	ctx context.Context,
+	req oapi.PostReturnReasonsJSONRequestBody,
+) (oapi.ReturnReason, error) {
+	code := strings.TrimSpace(req.Code)
+	if code == "" || utf8.RuneCountInString(code) > maxReturnReasonCodeLen {
+		return oapi.ReturnReason{}, pvz_errors.ErrInvalidReturnReason
+	}
+	names, err := normalizeDisplayNames(req.DisplayNames, pvz_errors.ErrInvalidReturnReason)
+	if err != nil {
+		return oapi.ReturnReason{}, err
+	}
+
+	now := time.Now()
+	return s.returnRepo.InsertReturnReason(ctx, oapi.ReturnReason{
+		Code:         code,
+		DisplayNames: names,
+		Active:       req.Active,
+		CreatedAt:    &now,
+	})
+}
+
+func (s *returnService) CreateReturn(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReturnsJSONRequestBody,
+) (oapi.CustomerReturn, error) {
+	reasonCode := strings.TrimSpace(req.ReasonCode)
+	if reasonCode == "" {
+		return oapi.CustomerReturn{}, pvz_errors.ErrInvalidReturn
+	}
+
+	var barcode *string
+	if req.Barcode != nil {
+		trimmed := strings.TrimSpace(*req.Barcode)
+		if utf8.RuneCountInString(trimmed) > maxBarcodeLen {
+			return oapi.CustomerReturn{}, pvz_errors.ErrInvalidReturn
+		}
+		if trimmed != "" {
+			barcode = &trimmed
+		}
+	}
+	if req.ProductId == nil && barcode == nil {
+		return oapi.CustomerReturn{}, pvz_errors.ErrInvalidReturn
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		trimmed := strings.TrimSpace(*req.Comment)
+		if utf8.RuneCountInString(trimmed) > maxCommentLen {
+			return oapi.CustomerReturn{}, pvz_errors.ErrInvalidReturn
+		}
+		if trimmed != "" {
+			comment = &trimmed
+		}
+	}
+
+	ret := oapi.CustomerReturn{
+		Id:         uuid.New(),
+		PvzId:      req.PvzId,
+		ProductId:  req.ProductId,
+		Barcode:    barcode,
+		ReasonCode: reasonCode,
+		Comment:    comment,
+		Status:     oapi.Accepted,
+		ReceivedAt: time.Now(),
+	}
+	if userID != uuid.Nil {
+		ret.ReceivedBy = &userID
+	}
+	return s.returnRepo.InsertReturn(ctx, ret)
+}
+
+func (s *returnService) GetReturns(
+	ctx context.Context,
+	params oapi.GetReturnsParams,
+) ([]oapi.CustomerReturn, error) {
+	if params.Status != nil {
+		switch *params.Status {
+		case oapi.Accepted, oapi.InShipment, oapi.Shipped:
+		default:
+			return nil, pvz_errors.ErrInvalidReturn
+		}
+	}
+
+	page, limit := 1, 30
+	if params.Page != nil && *params.Page > 0 {
+		page = *params.Page
+	}
+	if params.Limit != nil && *params.Limit > 0 && *params.Limit <= 100 {
+		limit = *params.Limit
+	}
+	offset := (page - 1) * limit
+
+	return s.returnRepo.SelectReturns(ctx, params.PvzId, params.Status, limit, offset)
+}
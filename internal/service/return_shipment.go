@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const maxShipmentReturns = 100
+
+type returnShipmentRepository interface {
+	InsertReturnShipment(ctx context.Context, shipment oapi.ReturnShipment) (oapi.ReturnShipment, error)
+	SelectReturnShipments(
+		ctx context.Context,
+		pvzID *uuid.UUID,
+		status *oapi.ReturnShipmentStatus,
+		limit, offset int,
+	) ([]oapi.ReturnShipment, error)
+	GetReturnShipment(ctx context.Context, shipmentID uuid.UUID) (oapi.ReturnShipment, error)
+	AttachReturns(ctx context.Context, shipmentID uuid.UUID, returnIDs []uuid.UUID) (oapi.ReturnShipment, error)
+	UpdateReturnShipmentStatus(
+		ctx context.Context,
+		shipmentID uuid.UUID,
+		status oapi.ReturnShipmentStatus,
+		changedAt time.Time,
+	) (oapi.ReturnShipment, error)
+}
+
+type returnShipmentService struct {
+	shipmentRepo returnShipmentRepository
+}
+
+func NewReturnShipmentService(repo returnShipmentRepository) *returnShipmentService {
+	return &returnShipmentService{shipmentRepo: repo}
+}
+
+func (s *returnShipmentService) CreateReturnShipment(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostReturnShipmentsJSONRequestBody,
+) (oapi.ReturnShipment, error) {
+	shipment := oapi.ReturnShipment{
+		Id:        uuid.New(),
+		PvzId:     req.PvzId,
+		Status:    oapi.Open,
+		CreatedAt: time.Now(),
+	}
+	if userID != uuid.Nil {
+		shipment.CreatedBy = &userID
+	}
+	return s.shipmentRepo.InsertReturnShipment(ctx, shipment)
+}
+
+func (s *returnShipmentService) GetReturnShipments(
+	ctx context.Context,
+	params oapi.GetReturnShipmentsParams,
+) ([]oapi.ReturnShipment, error) {
+	if params.Status != nil && !validReturnShipmentStatus(*params.Status) {
+		return nil, pvz_errors.ErrInvalidReturnShipment
+	}
+
+	page, limit := 1, 30
+	if params.Page != nil && *params.Page > 0 {
+		page = *params.Page
+	}
+	if params.Limit != nil && *params.Limit > 0 && *params.Limit <= 100 {
+		limit = *params.Limit
+	}
+	offset := (page - 1) * limit
+
+	return s.shipmentRepo.SelectReturnShipments(ctx, params.PvzId, params.Status, limit, offset)
+}
+
+func (s *returnShipmentService) GetReturnShipment(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+) (oapi.ReturnShipment, error) {
+	return s.shipmentRepo.GetReturnShipment(ctx, shipmentID)
+}
+
+func (s *returnShipmentService) AddReturns(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	req oapi.PostReturnShipmentsShipmentIdReturnsJSONRequestBody,
+) (oapi.ReturnShipment, error) {
+	returnIDs := uniqueIDs(req.ReturnIds)
+	if len(returnIDs) == 0 || len(returnIDs) > maxShipmentReturns {
+		return oapi.ReturnShipment{}, pvz_errors.ErrInvalidReturnShipment
+	}
+	return s.shipmentRepo.AttachReturns(ctx, shipmentID, returnIDs)
+}
+
+func (s *returnShipmentService) UpdateStatus(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	req oapi.PostReturnShipmentsShipmentIdStatusJSONRequestBody,
+) (oapi.ReturnShipment, error) {
+	if !validReturnShipmentStatus(req.Status) || req.Status == oapi.Open {
+		return oapi.ReturnShipment{}, pvz_errors.ErrInvalidReturnShipment
+	}
+	return s.shipmentRepo.UpdateReturnShipmentStatus(ctx, shipmentID, req.Status, time.Now())
+}
+
+func validReturnShipmentStatus(status oapi.ReturnShipmentStatus) bool {
+	switch status {
+	case oapi.Open, oapi.Dispatched, oapi.Delivered:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockReturnRepo struct{ mock.Mock }
+
+func (m *mockReturnRepo) SelectReturnReasons(ctx context.Context, includeInactive bool) ([]oapi.ReturnReason, error) {
+	args := m.Called(ctx, includeInactive)
+	return args.Get(0).([]oapi.ReturnReason), args.Error(1)
+}
+
+func (m *mockReturnRepo) InsertReturnReason(ctx context.Context, reason oapi.ReturnReason) (oapi.ReturnReason, error) {
+	args := m.Called(ctx, reason)
+	return args.Get(0).(oapi.ReturnReason), args.Error(1)
+}
+
+func (m *mockReturnRepo) InsertReturn(ctx context.Context, ret oapi.CustomerReturn) (oapi.CustomerReturn, error) {
+	args := m.Called(ctx, ret)
+	return args.Get(0).(oapi.CustomerReturn), args.Error(1)
+}
+
+func (m *mockReturnRepo) SelectReturns(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	status *oapi.CustomerReturnStatus,
+	limit, offset int,
+) ([]oapi.CustomerReturn, error) {
+	args := m.Called(ctx, pvzID, status, limit, offset)
+	return args.Get(0).([]oapi.CustomerReturn), args.Error(1)
+}
+
+type mockReturnShipmentRepo struct{ mock.Mock }
+
+func (m *mockReturnShipmentRepo) InsertReturnShipment(
+	ctx context.Context,
+	shipment oapi.ReturnShipment,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, shipment)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentRepo) SelectReturnShipments(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	status *oapi.ReturnShipmentStatus,
+	limit, offset int,
+) ([]oapi.ReturnShipment, error) {
+	args := m.Called(ctx, pvzID, status, limit, offset)
+	return args.Get(0).([]oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentRepo) GetReturnShipment(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, shipmentID)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentRepo) AttachReturns(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	returnIDs []uuid.UUID,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, shipmentID, returnIDs)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func (m *mockReturnShipmentRepo) UpdateReturnShipmentStatus(
+	ctx context.Context,
+	shipmentID uuid.UUID,
+	status oapi.ReturnShipmentStatus,
+	changedAt time.Time,
+) (oapi.ReturnShipment, error) {
+	args := m.Called(ctx, shipmentID, status, changedAt)
+	return args.Get(0).(oapi.ReturnShipment), args.Error(1)
+}
+
+func TestCreateReturn(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("nothing to identify the item", func(t *testing.T) {
+		svc := NewReturnService(new(mockReturnRepo))
+		_, err := svc.CreateReturn(ctx, userID, oapi.PostReturnsJSONRequestBody{
+			PvzId:      uuid.New(),
+			ReasonCode: "defect",
+			Barcode:    strPtr("  "),
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidReturn)
+	})
+
+	t.Run("missing reason", func(t *testing.T) {
+		svc := NewReturnService(new(mockReturnRepo))
+		_, err := svc.CreateReturn(ctx, userID, oapi.PostReturnsJSONRequestBody{
+			PvzId:   uuid.New(),
+			Barcode: strPtr("B1"),
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidReturn)
+	})
+
+	t.Run("trims input", func(t *testing.T) {
+		repo := new(mockReturnRepo)
+		svc := NewReturnService(repo)
+		repo.
+			On("InsertReturn", mock.Anything, mock.MatchedBy(func(r oapi.CustomerReturn) bool {
+				return *r.Barcode == "B1" && r.ReasonCode == "defect" && r.Comment == nil &&
+					r.Status == oapi.Accepted && *r.ReceivedBy == userID
+			})).
+			Return(oapi.CustomerReturn{ReasonCode: "defect"}, nil)
+
+		_, err := svc.CreateReturn(ctx, userID, oapi.PostReturnsJSONRequestBody{
+			PvzId:      uuid.New(),
+			ReasonCode: " defect ",
+			Barcode:    strPtr(" B1 "),
+			Comment:    strPtr(" "),
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestCreateReturnReason(t *testing.T) {
+	svc := NewReturnService(new(mockReturnRepo))
+	_, err := svc.CreateReturnReason(context.Background(), oapi.PostReturnReasonsJSONRequestBody{
+		Code:         "damaged_box",
+		DisplayNames: map[string]string{"ru": " "},
+	})
+	require.ErrorIs(t, err, pvz_errors.ErrInvalidReturnReason)
+}
+
+func TestReturnShipmentService(t *testing.T) {
+	ctx := context.Background()
+	shipmentID := uuid.New()
+
+	t.Run("add deduplicates returns", func(t *testing.T) {
+		repo := new(mockReturnShipmentRepo)
+		svc := NewReturnShipmentService(repo)
+		returnID := uuid.New()
+		repo.
+			On("AttachReturns", mock.Anything, shipmentID, []uuid.UUID{returnID}).
+			Return(oapi.ReturnShipment{Id: shipmentID}, nil)
+
+		_, err := svc.AddReturns(ctx, shipmentID, oapi.PostReturnShipmentsShipmentIdReturnsJSONRequestBody{
+			ReturnIds: []uuid.UUID{returnID, returnID},
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("add nothing", func(t *testing.T) {
+		svc := NewReturnShipmentService(new(mockReturnShipmentRepo))
+		_, err := svc.AddReturns(ctx, shipmentID, oapi.PostReturnShipmentsShipmentIdReturnsJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidReturnShipment)
+	})
+
+	t.Run("cannot reopen", func(t *testing.T) {
+		svc := NewReturnShipmentService(new(mockReturnShipmentRepo))
+		_, err := svc.UpdateStatus(ctx, shipmentID, oapi.PostReturnShipmentsShipmentIdStatusJSONRequestBody{
+			Status: oapi.Open,
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidReturnShipment)
+	})
+
+	t.Run("dispatch", func(t *testing.T) {
+		repo := new(mockReturnShipmentRepo)
+		svc := NewReturnShipmentService(repo)
+		repo.
+			On("UpdateReturnShipmentStatus", mock.Anything, shipmentID, oapi.Dispatched, mock.AnythingOfType("time.Time")).
+			Return(oapi.ReturnShipment{Status: oapi.Dispatched}, nil)
+
+		got, err := svc.UpdateStatus(ctx, shipmentID, oapi.PostReturnShipmentsShipmentIdStatusJSONRequestBody{
+			Status: oapi.Dispatched,
+		})
+		require.NoError(t, err)
+		require.Equal(t, oapi.Dispatched, got.Status)
+	})
+}
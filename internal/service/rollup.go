@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const rollupBatchDays = 31
+
+type rollupRepository interface {
+	SelectStaleRollupDays(ctx context.Context, from, to time.Time, limit int) ([]time.Time, error)
+	RefreshRollupDay(ctx context.Context, day, computedAt time.Time) error
+}
+
+type rollupService struct {
+	rollupRepo rollupRepository
+}
+
+func NewRollupService(repo rollupRepository) *rollupService {
+	return &rollupService{rollupRepo: repo}
+}
+
+// RefreshRollups recomputes the newest days that are missing, dirty or not
+// yet settled, within the range reports can span. Today is recomputed on
+// every run; older history is backfilled rollupBatchDays at a time.
+func (s *rollupService) RefreshRollups(ctx context.Context) (int, error) {
+	now := time.Now()
+	today := truncatePeriod(now.UTC(), oapi.Day)
+	days, err := s.rollupRepo.SelectStaleRollupDays(ctx, today.AddDate(0, 0, -maxReportPeriods), today, rollupBatchDays)
+	if err != nil {
+		return 0, err
+	}
+	for i, day := range days {
+		if err = s.rollupRepo.RefreshRollupDay(ctx, day, now); err != nil {
+			return i, err
+		}
+	}
+	return len(days), nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRollupRepo struct{ mock.Mock }
+
+func (m *mockRollupRepo) SelectStaleRollupDays(
+	ctx context.Context,
+	from, to time.Time,
+	limit int,
+) ([]time.Time, error) {
+	args := m.Called(ctx, from, to, limit)
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *mockRollupRepo) RefreshRollupDay(ctx context.Context, day, computedAt time.Time) error {
+	return m.Called(ctx, day, computedAt).Error(0)
+}
+
+func TestRefreshRollups(t *testing.T) {
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	t.Run("recomputes stale days", func(t *testing.T) {
+		repo := new(mockRollupRepo)
+		svc := NewRollupService(repo)
+		repo.
+			On("SelectStaleRollupDays", mock.Anything, today.AddDate(0, 0, -maxReportPeriods), today, rollupBatchDays).
+			Return([]time.Time{today, yesterday}, nil)
+		repo.On("RefreshRollupDay", mock.Anything, today, mock.AnythingOfType("time.Time")).Return(nil)
+		repo.On("RefreshRollupDay", mock.Anything, yesterday, mock.AnythingOfType("time.Time")).Return(nil)
+
+		refreshed, err := svc.RefreshRollups(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, refreshed)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("stops on failure", func(t *testing.T) {
+		repo := new(mockRollupRepo)
+		svc := NewRollupService(repo)
+		repo.
+			On("SelectStaleRollupDays", mock.Anything, mock.Anything, mock.Anything, rollupBatchDays).
+			Return([]time.Time{today, yesterday}, nil)
+		repo.On("RefreshRollupDay", mock.Anything, today, mock.Anything).Return(errors.New("db down"))
+
+		refreshed, err := svc.RefreshRollups(ctx)
+		require.Error(t, err)
+		require.Zero(t, refreshed)
+		repo.AssertNotCalled(t, "RefreshRollupDay", mock.Anything, yesterday, mock.Anything)
+	})
+}
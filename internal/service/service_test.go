@@ -7,6 +7,8 @@ import (
 )
 
 func uuidPtr(u uuid.UUID) *uuid.UUID { return &u }
+func strPtr(s string) *string        { return &s }
+func intPtr(i int) *int              { return &i }
 
 type mockMetrics struct{ mock.Mock }
 
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	maxCellsPerRequest = 500
+	maxCellLocationLen = 20
+	maxCellCapacity    = 1000
+)
+
+type storageCellRepository interface {
+	UpsertStorageCells(
+		ctx context.Context,
+		pvzID uuid.UUID,
+		cells []oapi.StorageCell,
+		createdAt time.Time,
+	) ([]oapi.StorageCell, error)
+	SelectStorageCells(ctx context.Context, pvzID uuid.UUID) ([]oapi.StorageCell, error)
+	MoveProductToCell(ctx context.Context, productID, cellID uuid.UUID) (oapi.Product, error)
+	SelectProductLocations(
+		ctx context.Context,
+		barcode *string,
+		orderID, pvzID *uuid.UUID,
+	) ([]oapi.ProductLocation, error)
+}
+
+type storageCellService struct {
+	cellRepo storageCellRepository
+}
+
+func NewStorageCellService(repo storageCellRepository) *storageCellService {
+	return &storageCellService{cellRepo: repo}
+}
+
+func (s *storageCellService) ConfigureCells(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	req oapi.PostPvzPvzIdCellsJSONRequestBody,
+) ([]oapi.StorageCell, error) {
+	if len(req.Cells) == 0 || len(req.Cells) > maxCellsPerRequest {
+		return nil, pvz_errors.ErrInvalidCell
+	}
+
+	seen := make(map[[3]string]struct{}, len(req.Cells))
+	cells := make([]oapi.StorageCell, 0, len(req.Cells))
+	for _, spec := range req.Cells {
+		zone, okZone := normalizeCellPart(spec.Zone)
+		rack, okRack := normalizeCellPart(spec.Rack)
+		shelf, okShelf := normalizeCellPart(spec.Shelf)
+		if !okZone || !okRack || !okShelf || spec.Capacity < 1 || spec.Capacity > maxCellCapacity {
+			return nil, pvz_errors.ErrInvalidCell
+		}
+		key := [3]string{zone, rack, shelf}
+		if _, ok := seen[key]; ok {
+			return nil, pvz_errors.ErrInvalidCell
+		}
+		seen[key] = struct{}{}
+
+		active := true
+		if spec.Active != nil {
+			active = *spec.Active
+		}
+		cells = append(cells, oapi.StorageCell{
+			Id:       uuid.New(),
+			PvzId:    pvzID,
+			Zone:     zone,
+			Rack:     rack,
+			Shelf:    shelf,
+			Capacity: spec.Capacity,
+			Active:   active,
+		})
+	}
+	return s.cellRepo.UpsertStorageCells(ctx, pvzID, cells, time.Now())
+}
+
+func (s *storageCellService) GetCells(ctx context.Context, pvzID uuid.UUID) ([]oapi.StorageCell, error) {
+	return s.cellRepo.SelectStorageCells(ctx, pvzID)
+}
+
+func (s *storageCellService) PlaceProduct(
+	ctx context.Context,
+	productID uuid.UUID,
+	req oapi.PutProductsProductIdCellJSONRequestBody,
+) (oapi.Product, error) {
+	if req.CellId == uuid.Nil {
+		return oapi.Product{}, pvz_errors.ErrInvalidCell
+	}
+	return s.cellRepo.MoveProductToCell(ctx, productID, req.CellId)
+}
+
+func (s *storageCellService) GetProductLocations(
+	ctx context.Context,
+	params oapi.GetProductsLocationsParams,
+) ([]oapi.ProductLocation, error) {
+	var barcode *string
+	if params.Barcode != nil {
+		trimmed := strings.TrimSpace(*params.Barcode)
+		if trimmed == "" || len(trimmed) > maxBarcodeLen {
+			return nil, pvz_errors.ErrInvalidCell
+		}
+		barcode = &trimmed
+	}
+	if barcode == nil && params.OrderId == nil {
+		return nil, pvz_errors.ErrInvalidCell
+	}
+	return s.cellRepo.SelectProductLocations(ctx, barcode, params.OrderId, params.PvzId)
+}
+
+func normalizeCellPart(part string) (string, bool) {
+	trimmed := strings.TrimSpace(part)
+	if trimmed == "" || utf8.RuneCountInString(trimmed) > maxCellLocationLen {
+		return "", false
+	}
+	return trimmed, true
+}
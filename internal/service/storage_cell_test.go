@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockStorageCellRepo struct{ mock.Mock }
+
+func (m *mockStorageCellRepo) UpsertStorageCells(
+	ctx context.Context,
+	pvzID uuid.UUID,
+	cells []oapi.StorageCell,
+	createdAt time.Time,
+) ([]oapi.StorageCell, error) {
+	args := m.Called(ctx, pvzID, cells, createdAt)
+	return args.Get(0).([]oapi.StorageCell), args.Error(1)
+}
+
+func (m *mockStorageCellRepo) SelectStorageCells(ctx context.Context, pvzID uuid.UUID) ([]oapi.StorageCell, error) {
+	args := m.Called(ctx, pvzID)
+	return args.Get(0).([]oapi.StorageCell), args.Error(1)
+}
+
+func (m *mockStorageCellRepo) MoveProductToCell(
+	ctx context.Context,
+	productID, cellID uuid.UUID,
+) (oapi.Product, error) {
+	args := m.Called(ctx, productID, cellID)
+	return args.Get(0).(oapi.Product), args.Error(1)
+}
+
+func (m *mockStorageCellRepo) SelectProductLocations(
+	ctx context.Context,
+	barcode *string,
+	orderID, pvzID *uuid.UUID,
+) ([]oapi.ProductLocation, error) {
+	args := m.Called(ctx, barcode, orderID, pvzID)
+	return args.Get(0).([]oapi.ProductLocation), args.Error(1)
+}
+
+func TestConfigureCells(t *testing.T) {
+	ctx := context.Background()
+	pvzID := uuid.New()
+
+	invalid := map[string][]oapi.StorageCellSpec{
+		"empty":         nil,
+		"blank zone":    {{Zone: " ", Rack: "1", Shelf: "1", Capacity: 1}},
+		"long shelf":    {{Zone: "A", Rack: "1", Shelf: strings.Repeat("ш", 21), Capacity: 1}},
+		"zero capacity": {{Zone: "A", Rack: "1", Shelf: "1", Capacity: 0}},
+		"huge capacity": {{Zone: "A", Rack: "1", Shelf: "1", Capacity: maxCellCapacity + 1}},
+		"duplicate cells": {
+			{Zone: "A", Rack: "1", Shelf: "1", Capacity: 1},
+			{Zone: "A ", Rack: "1", Shelf: "1", Capacity: 2},
+		},
+	}
+	for name, cells := range invalid {
+		t.Run(name, func(t *testing.T) {
+			svc := NewStorageCellService(new(mockStorageCellRepo))
+			_, err := svc.ConfigureCells(ctx, pvzID, oapi.PostPvzPvzIdCellsJSONRequestBody{Cells: cells})
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidCell)
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mockStorageCellRepo)
+		svc := NewStorageCellService(repo)
+		inactive := false
+		repo.
+			On("UpsertStorageCells", mock.Anything, pvzID, mock.MatchedBy(func(cells []oapi.StorageCell) bool {
+				return len(cells) == 2 && cells[0].Zone == "A" && cells[0].Active &&
+					!cells[1].Active && cells[1].PvzId == pvzID
+			}), mock.Anything).
+			Return([]oapi.StorageCell{{}, {}}, nil)
+
+		got, err := svc.ConfigureCells(ctx, pvzID, oapi.PostPvzPvzIdCellsJSONRequestBody{
+			Cells: []oapi.StorageCellSpec{
+				{Zone: " A ", Rack: "1", Shelf: "1", Capacity: 10},
+				{Zone: "A", Rack: "1", Shelf: "2", Capacity: 10, Active: &inactive},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestGetProductLocations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no filter", func(t *testing.T) {
+		svc := NewStorageCellService(new(mockStorageCellRepo))
+		_, err := svc.GetProductLocations(ctx, oapi.GetProductsLocationsParams{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidCell)
+	})
+
+	t.Run("by barcode", func(t *testing.T) {
+		repo := new(mockStorageCellRepo)
+		svc := NewStorageCellService(repo)
+		barcode := " 4601234567890 "
+		trimmed := "4601234567890"
+		repo.
+			On("SelectProductLocations", mock.Anything, &trimmed, (*uuid.UUID)(nil), (*uuid.UUID)(nil)).
+			Return([]oapi.ProductLocation{{ProductId: uuid.New()}}, nil)
+
+		got, err := svc.GetProductLocations(ctx, oapi.GetProductsLocationsParams{Barcode: &barcode})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+	})
+}
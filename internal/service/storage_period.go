@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	defaultStorageDays    = 14
+	maxStorageDays        = 365
+	storageSweepBatch     = 500
+	storageReminderWindow = 48 * time.Hour
+)
+
+type storagePeriodRepository interface {
+	SelectStoragePeriods(ctx context.Context) ([]oapi.StoragePeriod, error)
+	UpsertStoragePeriod(ctx context.Context, period oapi.StoragePeriod) (oapi.StoragePeriod, error)
+	FlagOverdueProducts(ctx context.Context, now time.Time, defaultDays, limit int) (int, error)
+	SelectExpiringProducts(
+		ctx context.Context,
+		now time.Time,
+		defaultDays int,
+		remindBefore time.Time,
+		limit int,
+	) ([]dto.StorageReminder, error)
+	MarkStorageReminded(ctx context.Context, productIDs []uuid.UUID, remindedAt time.Time) error
+	SelectUnclaimedItems(ctx context.Context, pvzID *uuid.UUID, limit, offset int) ([]oapi.UnclaimedItem, error)
+}
+
+type storageReminderNotifier interface {
+	NotifyStorageExpiring(ctx context.Context, reminders []dto.StorageReminder) error
+}
+
+type storagePeriodService struct {
+	storageRepo storagePeriodRepository
+	notifier    storageReminderNotifier
+}
+
+func NewStoragePeriodService(
+	repo storagePeriodRepository,
+	notifier storageReminderNotifier,
+) *storagePeriodService {
+	return &storagePeriodService{storageRepo: repo, notifier: notifier}
+}
+
+func (s *storagePeriodService) GetStoragePeriods(ctx context.Context) ([]oapi.StoragePeriod, error) {
+	return s.storageRepo.SelectStoragePeriods(ctx)
+}
+
+func (s *storagePeriodService) SetStoragePeriod(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PutStoragePeriodsJSONRequestBody,
+) (oapi.StoragePeriod, error) {
+	if req.Days < 1 || req.Days > maxStorageDays {
+		return oapi.StoragePeriod{}, pvz_errors.ErrInvalidStoragePeriod
+	}
+
+	now := time.Now()
+	period := oapi.StoragePeriod{
+		PvzId:     req.PvzId,
+		Days:      req.Days,
+		UpdatedAt: &now,
+	}
+	if req.ProductType != nil {
+		productType := strings.TrimSpace(*req.ProductType)
+		if productType == "" {
+			return oapi.StoragePeriod{}, pvz_errors.ErrInvalidStoragePeriod
+		}
+		period.ProductType = &productType
+	}
+	if userID != uuid.Nil {
+		period.UpdatedBy = &userID
+	}
+	return s.storageRepo.UpsertStoragePeriod(ctx, period)
+}
+
+func (s *storagePeriodService) GetUnclaimed(
+	ctx context.Context,
+	params oapi.GetUnclaimedParams,
+) ([]oapi.UnclaimedItem, error) {
+	page, limit := 1, 30
+	if params.Page != nil && *params.Page > 0 {
+		page = *params.Page
+	}
+	if params.Limit != nil && *params.Limit > 0 && *params.Limit <= 100 {
+		limit = *params.Limit
+	}
+	offset := (page - 1) * limit
+
+	return s.storageRepo.SelectUnclaimedItems(ctx, params.PvzId, limit, offset)
+}
+
+func (s *storagePeriodService) ProcessStorage(ctx context.Context) (int, error) {
+	now := time.Now()
+	flagged := 0
+	for {
+		n, err := s.storageRepo.FlagOverdueProducts(ctx, now, defaultStorageDays, storageSweepBatch)
+		if err != nil {
+			return flagged, err
+		}
+		flagged += n
+		if n < storageSweepBatch {
+			break
+		}
+	}
+
+	if s.notifier == nil {
+		return flagged, nil
+	}
+	for {
+		reminders, err := s.storageRepo.SelectExpiringProducts(
+			ctx, now, defaultStorageDays, now.Add(storageReminderWindow), storageSweepBatch)
+		if err != nil || len(reminders) == 0 {
+			return flagged, err
+		}
+		if err = s.notifier.NotifyStorageExpiring(ctx, reminders); err != nil {
+			return flagged, err
+		}
+
+		productIDs := make([]uuid.UUID, 0, len(reminders))
+		for _, reminder := range reminders {
+			productIDs = append(productIDs, reminder.ProductID)
+		}
+		if err = s.storageRepo.MarkStorageReminded(ctx, productIDs, now); err != nil {
+			return flagged, err
+		}
+		if len(reminders) < storageSweepBatch {
+			return flagged, nil
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whaleship/pvz/internal/dto"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockStoragePeriodRepo struct{ mock.Mock }
+
+func (m *mockStoragePeriodRepo) SelectStoragePeriods(ctx context.Context) ([]oapi.StoragePeriod, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]oapi.StoragePeriod), args.Error(1)
+}
+
+func (m *mockStoragePeriodRepo) UpsertStoragePeriod(
+	ctx context.Context,
+	period oapi.StoragePeriod,
+) (oapi.StoragePeriod, error) {
+	args := m.Called(ctx, period)
+	return args.Get(0).(oapi.StoragePeriod), args.Error(1)
+}
+
+func (m *mockStoragePeriodRepo) FlagOverdueProducts(
+	ctx context.Context,
+	now time.Time,
+	defaultDays, limit int,
+) (int, error) {
+	args := m.Called(ctx, now, defaultDays, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockStoragePeriodRepo) SelectExpiringProducts(
+	ctx context.Context,
+	now time.Time,
+	defaultDays int,
+	remindBefore time.Time,
+	limit int,
+) ([]dto.StorageReminder, error) {
+	args := m.Called(ctx, now, defaultDays, remindBefore, limit)
+	return args.Get(0).([]dto.StorageReminder), args.Error(1)
+}
+
+func (m *mockStoragePeriodRepo) MarkStorageReminded(
+	ctx context.Context,
+	productIDs []uuid.UUID,
+	remindedAt time.Time,
+) error {
+	args := m.Called(ctx, productIDs, remindedAt)
+	return args.Error(0)
+}
+
+func (m *mockStoragePeriodRepo) SelectUnclaimedItems(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	limit, offset int,
+) ([]oapi.UnclaimedItem, error) {
+	args := m.Called(ctx, pvzID, limit, offset)
+	return args.Get(0).([]oapi.UnclaimedItem), args.Error(1)
+}
+
+type mockStorageNotifier struct{ mock.Mock }
+
+func (m *mockStorageNotifier) NotifyStorageExpiring(ctx context.Context, reminders []dto.StorageReminder) error {
+	args := m.Called(ctx, reminders)
+	return args.Error(0)
+}
+
+func TestSetStoragePeriod(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	blank := "  "
+
+	invalid := map[string]oapi.PutStoragePeriodsJSONRequestBody{
+		"zero days":  {Days: 0},
+		"too long":   {Days: maxStorageDays + 1},
+		"blank type": {Days: 7, ProductType: &blank},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			svc := NewStoragePeriodService(new(mockStoragePeriodRepo), nil)
+			_, err := svc.SetStoragePeriod(ctx, userID, req)
+			require.ErrorIs(t, err, pvz_errors.ErrInvalidStoragePeriod)
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mockStoragePeriodRepo)
+		svc := NewStoragePeriodService(repo, nil)
+		productType := " обувь "
+		repo.
+			On("UpsertStoragePeriod", mock.Anything, mock.MatchedBy(func(p oapi.StoragePeriod) bool {
+				return p.Days == 10 && *p.ProductType == "обувь" && *p.UpdatedBy == userID && p.UpdatedAt != nil
+			})).
+			Return(oapi.StoragePeriod{Days: 10}, nil)
+
+		got, err := svc.SetStoragePeriod(ctx, userID, oapi.PutStoragePeriodsJSONRequestBody{
+			Days:        10,
+			ProductType: &productType,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 10, got.Days)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestProcessStorage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("flags in batches without notifier", func(t *testing.T) {
+		repo := new(mockStoragePeriodRepo)
+		svc := NewStoragePeriodService(repo, nil)
+		repo.
+			On("FlagOverdueProducts", mock.Anything, mock.Anything, defaultStorageDays, storageSweepBatch).
+			Return(storageSweepBatch, nil).Once()
+		repo.
+			On("FlagOverdueProducts", mock.Anything, mock.Anything, defaultStorageDays, storageSweepBatch).
+			Return(3, nil).Once()
+
+		flagged, err := svc.ProcessStorage(ctx)
+		require.NoError(t, err)
+		require.Equal(t, storageSweepBatch+3, flagged)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "SelectExpiringProducts")
+	})
+
+	t.Run("notifies expiring products", func(t *testing.T) {
+		repo := new(mockStoragePeriodRepo)
+		notifier := new(mockStorageNotifier)
+		svc := NewStoragePeriodService(repo, notifier)
+		reminders := []dto.StorageReminder{{ProductID: uuid.New()}}
+		repo.
+			On("FlagOverdueProducts", mock.Anything, mock.Anything, defaultStorageDays, storageSweepBatch).
+			Return(0, nil)
+		repo.
+			On("SelectExpiringProducts", mock.Anything, mock.Anything, defaultStorageDays,
+				mock.Anything, storageSweepBatch).
+			Return(reminders, nil)
+		notifier.On("NotifyStorageExpiring", mock.Anything, reminders).Return(nil)
+		repo.
+			On("MarkStorageReminded", mock.Anything, []uuid.UUID{reminders[0].ProductID}, mock.Anything).
+			Return(nil)
+
+		_, err := svc.ProcessStorage(ctx)
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("failed notification is retried later", func(t *testing.T) {
+		repo := new(mockStoragePeriodRepo)
+		notifier := new(mockStorageNotifier)
+		svc := NewStoragePeriodService(repo, notifier)
+		reminders := []dto.StorageReminder{{ProductID: uuid.New()}}
+		repo.
+			On("FlagOverdueProducts", mock.Anything, mock.Anything, defaultStorageDays, storageSweepBatch).
+			Return(0, nil)
+		repo.
+			On("SelectExpiringProducts", mock.Anything, mock.Anything, defaultStorageDays,
+				mock.Anything, storageSweepBatch).
+			Return(reminders, nil)
+		notifier.On("NotifyStorageExpiring", mock.Anything, reminders).Return(errors.New("unavailable"))
+
+		_, err := svc.ProcessStorage(ctx)
+		require.Error(t, err)
+		repo.AssertNotCalled(t, "MarkStorageReminded")
+	})
+}
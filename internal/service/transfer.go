@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+const (
+	maxTransferItems      = 500
+	defaultTransferWindow = 72 * time.Hour
+)
+
+type transferRepository interface {
+	InsertTransfer(ctx context.Context, transfer oapi.Transfer, productIDs []uuid.UUID) (oapi.Transfer, error)
+	GetTransfer(ctx context.Context, transferID uuid.UUID) (oapi.Transfer, error)
+	ReceiveTransferItems(
+		ctx context.Context,
+		transferID uuid.UUID,
+		productIDs []uuid.UUID,
+		receivedBy *uuid.UUID,
+		receivedAt time.Time,
+	) (oapi.Transfer, error)
+	SelectOverdueTransfers(
+		ctx context.Context,
+		pvzID *uuid.UUID,
+		now time.Time,
+		limit, offset int,
+	) ([]oapi.Transfer, error)
+}
+
+type transferService struct {
+	transferRepo transferRepository
+}
+
+func NewTransferService(repo transferRepository) *transferService {
+	return &transferService{transferRepo: repo}
+}
+
+func (s *transferService) CreateTransfer(
+	ctx context.Context,
+	userID uuid.UUID,
+	req oapi.PostTransfersJSONRequestBody,
+) (oapi.Transfer, error) {
+	productIDs := uniqueIDs(req.ProductIds)
+	if req.SourcePvzId == req.DestinationPvzId ||
+		len(productIDs) == 0 || len(productIDs) > maxTransferItems {
+		return oapi.Transfer{}, pvz_errors.ErrInvalidTransfer
+	}
+
+	now := time.Now()
+	expectedAt := now.Add(defaultTransferWindow)
+	if req.ExpectedAt != nil {
+		if !req.ExpectedAt.After(now) {
+			return oapi.Transfer{}, pvz_errors.ErrInvalidTransfer
+		}
+		expectedAt = *req.ExpectedAt
+	}
+
+	transfer := oapi.Transfer{
+		Id:               uuid.New(),
+		SourcePvzId:      req.SourcePvzId,
+		DestinationPvzId: req.DestinationPvzId,
+		Status:           oapi.AwaitingReceipt,
+		CreatedAt:        now,
+		ExpectedAt:       expectedAt,
+	}
+	if userID != uuid.Nil {
+		transfer.CreatedBy = &userID
+	}
+	return s.transferRepo.InsertTransfer(ctx, transfer, productIDs)
+}
+
+func (s *transferService) GetTransfer(ctx context.Context, transferID uuid.UUID) (oapi.Transfer, error) {
+	return s.transferRepo.GetTransfer(ctx, transferID)
+}
+
+func (s *transferService) ReceiveTransfer(
+	ctx context.Context,
+	userID, transferID uuid.UUID,
+	req oapi.PostTransfersTransferIdReceiveJSONRequestBody,
+) (oapi.Transfer, error) {
+	productIDs := uniqueIDs(req.ProductIds)
+	if len(productIDs) == 0 || len(productIDs) > maxTransferItems {
+		return oapi.Transfer{}, pvz_errors.ErrInvalidTransfer
+	}
+
+	var receivedBy *uuid.UUID
+	if userID != uuid.Nil {
+		receivedBy = &userID
+	}
+	return s.transferRepo.ReceiveTransferItems(ctx, transferID, productIDs, receivedBy, time.Now())
+}
+
+func (s *transferService) GetOverdueTransfers(
+	ctx context.Context,
+	params oapi.GetTransfersOverdueParams,
+) ([]oapi.Transfer, error) {
+	page, limit := 1, 30
+	if params.Page != nil && *params.Page > 0 {
+		page = *params.Page
+	}
+	if params.Limit != nil && *params.Limit > 0 && *params.Limit <= 100 {
+		limit = *params.Limit
+	}
+	offset := (page - 1) * limit
+
+	return s.transferRepo.SelectOverdueTransfers(ctx, params.PvzId, time.Now(), limit, offset)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pvz_errors "github.com/whaleship/pvz/internal/errors"
+	"github.com/whaleship/pvz/internal/gen/oapi"
+)
+
+type mockTransferRepo struct{ mock.Mock }
+
+func (m *mockTransferRepo) InsertTransfer(
+	ctx context.Context,
+	transfer oapi.Transfer,
+	productIDs []uuid.UUID,
+) (oapi.Transfer, error) {
+	args := m.Called(ctx, transfer, productIDs)
+	return args.Get(0).(oapi.Transfer), args.Error(1)
+}
+
+func (m *mockTransferRepo) GetTransfer(ctx context.Context, transferID uuid.UUID) (oapi.Transfer, error) {
+	args := m.Called(ctx, transferID)
+	return args.Get(0).(oapi.Transfer), args.Error(1)
+}
+
+func (m *mockTransferRepo) ReceiveTransferItems(
+	ctx context.Context,
+	transferID uuid.UUID,
+	productIDs []uuid.UUID,
+	receivedBy *uuid.UUID,
+	receivedAt time.Time,
+) (oapi.Transfer, error) {
+	args := m.Called(ctx, transferID, productIDs, receivedBy, receivedAt)
+	return args.Get(0).(oapi.Transfer), args.Error(1)
+}
+
+func (m *mockTransferRepo) SelectOverdueTransfers(
+	ctx context.Context,
+	pvzID *uuid.UUID,
+	now time.Time,
+	limit, offset int,
+) ([]oapi.Transfer, error) {
+	args := m.Called(ctx, pvzID, now, limit, offset)
+	return args.Get(0).([]oapi.Transfer), args.Error(1)
+}
+
+func TestCreateTransfer(t *testing.T) {
+	ctx := context.Background()
+	userID, pvzID := uuid.New(), uuid.New()
+	productID := uuid.New()
+
+	t.Run("same pvz", func(t *testing.T) {
+		svc := NewTransferService(new(mockTransferRepo))
+		_, err := svc.CreateTransfer(ctx, userID, oapi.PostTransfersJSONRequestBody{
+			SourcePvzId:      pvzID,
+			DestinationPvzId: pvzID,
+			ProductIds:       []uuid.UUID{productID},
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidTransfer)
+	})
+
+	t.Run("deadline in the past", func(t *testing.T) {
+		svc := NewTransferService(new(mockTransferRepo))
+		past := time.Now().Add(-time.Hour)
+		_, err := svc.CreateTransfer(ctx, userID, oapi.PostTransfersJSONRequestBody{
+			SourcePvzId:      pvzID,
+			DestinationPvzId: uuid.New(),
+			ProductIds:       []uuid.UUID{productID},
+			ExpectedAt:       &past,
+		})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidTransfer)
+	})
+
+	t.Run("default deadline", func(t *testing.T) {
+		repo := new(mockTransferRepo)
+		svc := NewTransferService(repo)
+		repo.
+			On("InsertTransfer", mock.Anything, mock.MatchedBy(func(tr oapi.Transfer) bool {
+				return tr.Status == oapi.AwaitingReceipt && *tr.CreatedBy == userID &&
+					tr.ExpectedAt.Sub(tr.CreatedAt) == defaultTransferWindow
+			}), []uuid.UUID{productID}).
+			Return(oapi.Transfer{Status: oapi.AwaitingReceipt}, nil)
+
+		_, err := svc.CreateTransfer(ctx, userID, oapi.PostTransfersJSONRequestBody{
+			SourcePvzId:      pvzID,
+			DestinationPvzId: uuid.New(),
+			ProductIds:       []uuid.UUID{productID, productID},
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestReceiveTransfer(t *testing.T) {
+	ctx := context.Background()
+	userID, transferID := uuid.New(), uuid.New()
+
+	t.Run("no products", func(t *testing.T) {
+		svc := NewTransferService(new(mockTransferRepo))
+		_, err := svc.ReceiveTransfer(ctx, userID, transferID, oapi.PostTransfersTransferIdReceiveJSONRequestBody{})
+		require.ErrorIs(t, err, pvz_errors.ErrInvalidTransfer)
+	})
+
+	t.Run("records receiver", func(t *testing.T) {
+		repo := new(mockTransferRepo)
+		svc := NewTransferService(repo)
+		ids := []uuid.UUID{uuid.New()}
+		repo.
+			On("ReceiveTransferItems", mock.Anything, transferID, ids, &userID, mock.AnythingOfType("time.Time")).
+			Return(oapi.Transfer{Status: oapi.FullyReceived}, nil)
+
+		got, err := svc.ReceiveTransfer(ctx, userID, transferID, oapi.PostTransfersTransferIdReceiveJSONRequestBody{
+			ProductIds: ids,
+		})
+		require.NoError(t, err)
+		require.Equal(t, oapi.FullyReceived, got.Status)
+	})
+}
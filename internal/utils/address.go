@@ -0,0 +1,20 @@
+package utils
+
+import "net/netip"
+
+// IsPublicAddr reports whether addr may be reached from outside the host's
+// own networks: loopback, private, link-local, CGNAT and unspecified
+// addresses are not.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsUnspecified() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
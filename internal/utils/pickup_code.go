@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/whaleship/pvz/internal/config"
+)
+
+const PickupCodeLen = 6
+
+var pickupCodeLimit = big.NewInt(1_000_000)
+
+func GeneratePickupCode() (string, error) {
+	n, err := rand.Int(rand.Reader, pickupCodeLimit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", PickupCodeLen, n.Int64()), nil
+}
+
+// HashPickupCode keys the hash with a server secret: with only a million
+// possible codes a plain hash is reversed by trying them all.
+func HashPickupCode(code string) string {
+	mac := hmac.New(sha256.New, config.GetPickupCodeSecret())
+	mac.Write([]byte(code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePickupCode(t *testing.T) {
+	t.Run("six digits", func(t *testing.T) {
+		code, err := GeneratePickupCode()
+		require.NoError(t, err)
+		require.Len(t, code, PickupCodeLen)
+		for _, r := range code {
+			require.True(t, r >= '0' && r <= '9')
+		}
+	})
+}
+
+func TestHashPickupCode(t *testing.T) {
+	hash := HashPickupCode("123456")
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, HashPickupCode("123456"))
+	require.NotEqual(t, hash, HashPickupCode("123457"))
+	require.NotEqual(t, HashPassword("123456"), hash)
+}
@@ -8,13 +8,30 @@ CREATE TABLE users (
     role VARCHAR(50) NOT NULL CHECK (role IN ('employee', 'moderator'))
 );
 
+CREATE TABLE regions (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    geometry JSONB NOT NULL,
+    created_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
 CREATE TABLE pvz (
     id UUID PRIMARY KEY,
     city VARCHAR(255) NOT NULL,
-    registration_date TIMESTAMP NOT NULL DEFAULT NOW()
+    registration_date TIMESTAMP NOT NULL DEFAULT NOW(),
+    latitude DOUBLE PRECISION NULL CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION NULL CHECK (longitude BETWEEN -180 AND 180),
+    region_id UUID NULL,
+    daily_intake_capacity INTEGER NULL CHECK (daily_intake_capacity > 0),
+    CONSTRAINT fk_pvz_region
+        FOREIGN KEY (region_id)
+            REFERENCES regions(id)
+            ON DELETE SET NULL
 );
 
 CREATE INDEX idx_pvz_registration_date ON pvz(registration_date);
+CREATE INDEX idx_pvz_region ON pvz(region_id) WHERE region_id IS NOT NULL;
 
 CREATE TABLE receptions (
     id UUID PRIMARY KEY,
@@ -22,6 +39,13 @@ CREATE TABLE receptions (
     date_time TIMESTAMP NOT NULL DEFAULT NOW(),
     close_date_time TIMESTAMP NULL,
     status VARCHAR(50) NOT NULL CHECK (status IN ('in_progress', 'close')),
+    carrier VARCHAR(255) NULL,
+    vehicle_plate VARCHAR(32) NULL,
+    waybill_number VARCHAR(100) NULL,
+    seal_number VARCHAR(100) NULL,
+    comment TEXT NULL,
+    created_by UUID NULL,
+    closed_by UUID NULL,
     CONSTRAINT fk_receptions_pvz
         FOREIGN KEY (pvz_id)
             REFERENCES pvz(id)
@@ -29,6 +53,8 @@ CREATE TABLE receptions (
 );
 CREATE INDEX idx_receptions_pvz_date ON receptions(pvz_id, date_time DESC);
 CREATE INDEX idx_receptions_pvz_close_date ON receptions(pvz_id, close_date_time DESC);
+CREATE INDEX idx_receptions_waybill_number ON receptions(waybill_number)
+    WHERE waybill_number IS NOT NULL;
 
 CREATE INDEX idx_receptions_active
     ON receptions(pvz_id, date_time DESC)
@@ -38,16 +64,530 @@ CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_open_reception
 ON receptions(pvz_id)
 WHERE status = 'in_progress';
 
+CREATE TABLE product_types (
+    code VARCHAR(50) PRIMARY KEY,
+    display_names JSONB NOT NULL DEFAULT '{}'::jsonb,
+    fragile BOOLEAN NOT NULL DEFAULT FALSE,
+    high_value BOOLEAN NOT NULL DEFAULT FALSE,
+    oversized BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO product_types (code, display_names, fragile, high_value, oversized)
+VALUES
+    ('электроника', '{"ru": "Электроника", "en": "Electronics"}', TRUE, TRUE, FALSE),
+    ('одежда', '{"ru": "Одежда", "en": "Clothing"}', FALSE, FALSE, FALSE),
+    ('обувь', '{"ru": "Обувь", "en": "Footwear"}', FALSE, FALSE, FALSE);
+
+CREATE TABLE containers (
+    id UUID PRIMARY KEY,
+    reception_id UUID NOT NULL,
+    barcode VARCHAR(128) NOT NULL UNIQUE,
+    kind VARCHAR(50) NOT NULL CHECK (kind IN ('container', 'pallet')),
+    state VARCHAR(50) NOT NULL CHECK (state IN ('sealed', 'opened', 'verified')),
+    declared_items INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    opened_at TIMESTAMP NULL,
+    verified_at TIMESTAMP NULL,
+    CONSTRAINT fk_containers_reception
+        FOREIGN KEY (reception_id)
+            REFERENCES receptions(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_containers_reception ON containers(reception_id, created_at);
+
 CREATE TABLE products (
     id UUID PRIMARY KEY,
     reception_id UUID NOT NULL,
     date_time TIMESTAMP NOT NULL DEFAULT NOW(),
-    type VARCHAR(50) NOT NULL CHECK (type IN ('электроника', 'одежда', 'обувь')),
+    type VARCHAR(50) NOT NULL,
+    container_id UUID NULL,
+    verified_at TIMESTAMP NULL,
+    barcode VARCHAR(128) NULL,
+    sku VARCHAR(128) NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'received'
+        CHECK (status IN ('received', 'ready', 'issued', 'in_transit', 'lost', 'unclaimed')),
+    issued_at TIMESTAMP NULL,
+    current_pvz_id UUID NULL,
+    transfer_id UUID NULL,
+    cell_id UUID NULL,
+    storage_reminded_at TIMESTAMP NULL,
+    created_by UUID NULL,
     CONSTRAINT fk_products_reception
         FOREIGN KEY (reception_id)
             REFERENCES receptions(id)
-            ON DELETE CASCADE
+            ON DELETE CASCADE,
+    CONSTRAINT fk_products_type
+        FOREIGN KEY (type)
+            REFERENCES product_types(code),
+    CONSTRAINT fk_products_container
+        FOREIGN KEY (container_id)
+            REFERENCES containers(id)
+            ON DELETE SET NULL,
+    CONSTRAINT fk_products_current_pvz
+        FOREIGN KEY (current_pvz_id)
+            REFERENCES pvz(id),
+    CONSTRAINT chk_products_transit
+        CHECK ((status = 'in_transit') = (transfer_id IS NOT NULL))
 );
 
 CREATE INDEX idx_products_reception_date_desc 
     ON products(reception_id, date_time DESC);
+CREATE INDEX idx_products_container ON products(container_id)
+    WHERE container_id IS NOT NULL;
+CREATE INDEX idx_products_barcode ON products(barcode)
+    WHERE barcode IS NOT NULL;
+CREATE UNIQUE INDEX idx_products_reception_barcode ON products(reception_id, barcode)
+    WHERE barcode IS NOT NULL;
+
+CREATE TABLE orders (
+    id UUID PRIMARY KEY,
+    pvz_id UUID NOT NULL,
+    customer_ref VARCHAR(128) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'awaiting_pickup'
+        CHECK (status IN ('awaiting_pickup', 'partially_issued', 'completed')),
+    pickup_code_hash VARCHAR(64) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    created_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    issued_at TIMESTAMP NULL,
+    CONSTRAINT fk_orders_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_orders_pvz_created ON orders(pvz_id, created_at DESC);
+
+CREATE TABLE order_items (
+    order_id UUID NOT NULL,
+    product_id UUID NOT NULL UNIQUE,
+    issued_at TIMESTAMP NULL,
+    issued_by UUID NULL,
+    PRIMARY KEY (order_id, product_id),
+    CONSTRAINT fk_order_items_order
+        FOREIGN KEY (order_id)
+            REFERENCES orders(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_order_items_product
+        FOREIGN KEY (product_id)
+            REFERENCES products(id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE deleted_products (
+    id UUID PRIMARY KEY,
+    reception_id UUID NOT NULL,
+    date_time TIMESTAMP NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    container_id UUID NULL,
+    verified_at TIMESTAMP NULL,
+    barcode VARCHAR(128) NULL,
+    sku VARCHAR(128) NULL,
+    cell_id UUID NULL,
+    damage JSONB NULL,
+    created_by UUID NULL,
+    deleted_by UUID NULL,
+    deleted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_deleted_products_reception
+        FOREIGN KEY (reception_id)
+            REFERENCES receptions(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_deleted_products_reception ON deleted_products(reception_id, deleted_at DESC);
+CREATE INDEX idx_products_created_by ON products(created_by, date_time) WHERE created_by IS NOT NULL;
+
+CREATE TABLE reception_summaries (
+    reception_id UUID PRIMARY KEY,
+    total_items INTEGER NOT NULL,
+    counts_by_type JSONB NOT NULL DEFAULT '{}'::jsonb,
+    first_scan_at TIMESTAMP NULL,
+    last_scan_at TIMESTAMP NULL,
+    open_duration_seconds BIGINT NOT NULL,
+    items_per_minute DOUBLE PRECISION NOT NULL,
+    CONSTRAINT fk_reception_summaries_reception
+        FOREIGN KEY (reception_id)
+            REFERENCES receptions(id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE product_damages (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL UNIQUE,
+    reception_id UUID NOT NULL,
+    kind VARCHAR(50) NOT NULL CHECK (kind IN ('damaged', 'wet', 'opened')),
+    severity VARCHAR(50) NOT NULL CHECK (severity IN ('low', 'medium', 'high')),
+    comment TEXT NULL,
+    reported_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_product_damages_product
+        FOREIGN KEY (product_id)
+            REFERENCES products(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_product_damages_reception
+        FOREIGN KEY (reception_id)
+            REFERENCES receptions(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_product_damages_reception ON product_damages(reception_id);
+CREATE INDEX idx_product_damages_created_at ON product_damages(created_at DESC);
+
+CREATE TABLE approval_rules (
+    operation VARCHAR(50) PRIMARY KEY CHECK (operation IN ('delete_last_product', 'delete_product', 'close_reception')),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by UUID NULL,
+    updated_at TIMESTAMP NULL
+);
+
+INSERT INTO approval_rules (operation, enabled)
+VALUES ('delete_last_product', FALSE), ('delete_product', FALSE), ('close_reception', FALSE);
+
+CREATE TABLE approvals (
+    id UUID PRIMARY KEY,
+    operation VARCHAR(50) NOT NULL CHECK (operation IN ('delete_last_product', 'delete_product', 'close_reception')),
+    status VARCHAR(50) NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    pvz_id UUID NOT NULL,
+    target_id UUID NOT NULL,
+    requested_by UUID NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    decided_by UUID NULL,
+    decided_at TIMESTAMP NULL,
+    comment TEXT NULL,
+    CONSTRAINT fk_approvals_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_approvals_pending_target ON approvals(operation, target_id)
+    WHERE status = 'pending';
+CREATE INDEX idx_approvals_status_requested ON approvals(status, requested_at);
+
+CREATE TABLE return_reasons (
+    code VARCHAR(50) PRIMARY KEY,
+    display_names JSONB NOT NULL DEFAULT '{}'::jsonb,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO return_reasons (code, display_names)
+VALUES
+    ('defect', '{"ru": "Брак", "en": "Defect"}'),
+    ('wrong_item', '{"ru": "Не тот товар", "en": "Wrong item"}'),
+    ('not_fit', '{"ru": "Не подошёл", "en": "Did not fit"}'),
+    ('changed_mind', '{"ru": "Передумал", "en": "Changed mind"}'),
+    ('unclaimed', '{"ru": "Не востребован", "en": "Unclaimed"}');
+
+CREATE TABLE return_shipments (
+    id UUID PRIMARY KEY,
+    pvz_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'dispatched', 'delivered')),
+    created_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP NULL,
+    delivered_at TIMESTAMP NULL,
+    CONSTRAINT fk_return_shipments_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_return_shipments_pvz_created ON return_shipments(pvz_id, created_at DESC);
+
+CREATE TABLE customer_returns (
+    id UUID PRIMARY KEY,
+    pvz_id UUID NOT NULL,
+    product_id UUID NULL,
+    order_id UUID NULL,
+    barcode VARCHAR(128) NULL,
+    reason_code VARCHAR(50) NOT NULL,
+    comment TEXT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'accepted'
+        CHECK (status IN ('accepted', 'in_shipment', 'shipped')),
+    shipment_id UUID NULL,
+    received_by UUID NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_customer_returns_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_customer_returns_product
+        FOREIGN KEY (product_id)
+            REFERENCES products(id)
+            ON DELETE SET NULL,
+    CONSTRAINT fk_customer_returns_order
+        FOREIGN KEY (order_id)
+            REFERENCES orders(id)
+            ON DELETE SET NULL,
+    CONSTRAINT fk_customer_returns_reason
+        FOREIGN KEY (reason_code)
+            REFERENCES return_reasons(code),
+    CONSTRAINT fk_customer_returns_shipment
+        FOREIGN KEY (shipment_id)
+            REFERENCES return_shipments(id)
+            ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_customer_returns_product ON customer_returns(product_id)
+    WHERE product_id IS NOT NULL;
+CREATE INDEX idx_customer_returns_pvz_received ON customer_returns(pvz_id, received_at DESC);
+CREATE INDEX idx_customer_returns_shipment ON customer_returns(shipment_id)
+    WHERE shipment_id IS NOT NULL;
+
+CREATE TABLE transfers (
+    id UUID PRIMARY KEY,
+    source_pvz_id UUID NOT NULL,
+    destination_pvz_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'awaiting_receipt'
+        CHECK (status IN ('awaiting_receipt', 'partially_received', 'fully_received')),
+    created_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expected_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP NULL,
+    CONSTRAINT fk_transfers_source_pvz
+        FOREIGN KEY (source_pvz_id)
+            REFERENCES pvz(id),
+    CONSTRAINT fk_transfers_destination_pvz
+        FOREIGN KEY (destination_pvz_id)
+            REFERENCES pvz(id),
+    CONSTRAINT chk_transfers_pvz
+        CHECK (source_pvz_id <> destination_pvz_id)
+);
+
+CREATE INDEX idx_transfers_open_expected ON transfers(expected_at)
+    WHERE status <> 'fully_received';
+
+ALTER TABLE products
+    ADD CONSTRAINT fk_products_transfer
+        FOREIGN KEY (transfer_id)
+            REFERENCES transfers(id);
+
+CREATE TABLE transfer_items (
+    transfer_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    received_at TIMESTAMP NULL,
+    received_by UUID NULL,
+    PRIMARY KEY (transfer_id, product_id),
+    CONSTRAINT fk_transfer_items_transfer
+        FOREIGN KEY (transfer_id)
+            REFERENCES transfers(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_transfer_items_product
+        FOREIGN KEY (product_id)
+            REFERENCES products(id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_transfer_items_open_product ON transfer_items(product_id)
+    WHERE received_at IS NULL;
+
+CREATE TABLE storage_cells (
+    id UUID PRIMARY KEY,
+    pvz_id UUID NOT NULL,
+    zone VARCHAR(20) NOT NULL,
+    rack VARCHAR(20) NOT NULL,
+    shelf VARCHAR(20) NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_storage_cells_location UNIQUE (pvz_id, zone, rack, shelf),
+    CONSTRAINT fk_storage_cells_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+ALTER TABLE products
+    ADD CONSTRAINT fk_products_cell
+        FOREIGN KEY (cell_id)
+            REFERENCES storage_cells(id)
+            ON DELETE SET NULL;
+
+CREATE INDEX idx_products_cell ON products(cell_id)
+    WHERE cell_id IS NOT NULL;
+
+CREATE TABLE inventory_sessions (
+    id UUID PRIMARY KEY,
+    pvz_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'counting'
+        CHECK (status IN ('counting', 'counted')),
+    started_by UUID NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    closed_by UUID NULL,
+    closed_at TIMESTAMP NULL,
+    CONSTRAINT fk_inventory_sessions_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_inventory_sessions_counting ON inventory_sessions(pvz_id)
+    WHERE status = 'counting';
+
+CREATE TABLE inventory_scans (
+    session_id UUID NOT NULL,
+    barcode VARCHAR(128) NOT NULL,
+    cell_id UUID NULL,
+    scanned_by UUID NULL,
+    scanned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (session_id, barcode),
+    CONSTRAINT fk_inventory_scans_session
+        FOREIGN KEY (session_id)
+            REFERENCES inventory_sessions(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_inventory_scans_cell
+        FOREIGN KEY (cell_id)
+            REFERENCES storage_cells(id)
+            ON DELETE SET NULL
+);
+
+CREATE TABLE inventory_discrepancies (
+    session_id UUID NOT NULL,
+    kind VARCHAR(50) NOT NULL CHECK (kind IN ('missing', 'unexpected', 'misplaced')),
+    barcode VARCHAR(128) NOT NULL,
+    product_id UUID NULL,
+    expected_cell_id UUID NULL,
+    actual_cell_id UUID NULL,
+    accepted_by UUID NULL,
+    accepted_at TIMESTAMP NULL,
+    PRIMARY KEY (session_id, barcode),
+    CONSTRAINT fk_inventory_discrepancies_session
+        FOREIGN KEY (session_id)
+            REFERENCES inventory_sessions(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_inventory_discrepancies_product
+        FOREIGN KEY (product_id)
+            REFERENCES products(id)
+            ON DELETE SET NULL
+);
+
+CREATE TABLE storage_periods (
+    pvz_id UUID NULL,
+    product_type VARCHAR(50) NULL,
+    days INTEGER NOT NULL CHECK (days > 0),
+    updated_by UUID NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_storage_periods_scope UNIQUE NULLS NOT DISTINCT (pvz_id, product_type),
+    CONSTRAINT fk_storage_periods_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_storage_periods_type
+        FOREIGN KEY (product_type)
+            REFERENCES product_types(code)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_products_storage_pending ON products(status)
+    WHERE status IN ('received', 'ready');
+CREATE INDEX idx_customer_returns_unclaimed ON customer_returns(pvz_id, received_at)
+    WHERE reason_code = 'unclaimed' AND status = 'accepted';
+
+CREATE TABLE label_templates (
+    pvz_id UUID PRIMARY KEY,
+    symbology VARCHAR(16) NOT NULL CHECK (symbology IN ('code128', 'qr')),
+    width_mm INTEGER NOT NULL CHECK (width_mm BETWEEN 30 AND 150),
+    height_mm INTEGER NOT NULL CHECK (height_mm BETWEEN 30 AND 150),
+    dpi INTEGER NOT NULL CHECK (dpi IN (203, 300, 600)),
+    header VARCHAR(64) NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_label_templates_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL,
+    format VARCHAR(16) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(16) NOT NULL DEFAULT 'queued'
+        CHECK (status IN ('queued', 'running', 'succeeded', 'errored', 'cancelled', 'expired')),
+    progress INTEGER NOT NULL DEFAULT 0,
+    error TEXT NULL,
+    created_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    expires_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_jobs_queued ON jobs(created_at) WHERE status = 'queued';
+CREATE INDEX idx_jobs_expiring ON jobs(expires_at) WHERE status IN ('succeeded', 'errored', 'cancelled');
+
+CREATE TABLE rollup_days (
+    day DATE PRIMARY KEY,
+    computed_at TIMESTAMP NOT NULL,
+    dirty BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE daily_pvz_rollups (
+    day DATE NOT NULL,
+    pvz_id UUID NOT NULL,
+    receptions_opened INTEGER NOT NULL,
+    scanned_receptions INTEGER NOT NULL,
+    open_seconds BIGINT NOT NULL,
+    PRIMARY KEY (day, pvz_id),
+    CONSTRAINT fk_daily_pvz_rollups_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE daily_product_rollups (
+    day DATE NOT NULL,
+    pvz_id UUID NOT NULL,
+    product_type VARCHAR(50) NOT NULL,
+    received INTEGER NOT NULL,
+    deleted INTEGER NOT NULL,
+    issued INTEGER NOT NULL,
+    receptions INTEGER NOT NULL,
+    PRIMARY KEY (day, pvz_id, product_type),
+    CONSTRAINT fk_daily_product_rollups_pvz
+        FOREIGN KEY (pvz_id)
+            REFERENCES pvz(id)
+            ON DELETE CASCADE
+);
+
+CREATE TABLE report_subscriptions (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    report VARCHAR(32) NOT NULL CHECK (report IN ('intake', 'open_receptions', 'damage')),
+    params JSONB NOT NULL DEFAULT '{}',
+    schedule VARCHAR(100) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    format VARCHAR(16) NOT NULL,
+    targets JSONB NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    next_run_at TIMESTAMP NULL,
+    last_run_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_report_subscriptions_due ON report_subscriptions(next_run_at) WHERE active;
+
+CREATE TABLE report_deliveries (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    scheduled_at TIMESTAMP NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_address VARCHAR(512) NOT NULL,
+    status VARCHAR(16) NOT NULL CHECK (status IN ('sent', 'undelivered')),
+    file_name VARCHAR(255) NULL,
+    error TEXT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_report_deliveries_subscription
+        FOREIGN KEY (subscription_id)
+            REFERENCES report_subscriptions(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX idx_report_deliveries_subscription ON report_deliveries(subscription_id, created_at DESC);
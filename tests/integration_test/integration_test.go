@@ -26,6 +26,7 @@ import (
 
 var (
 	handler fasthttp.RequestHandler
+	pool    database.PgxIface
 )
 
 func runMigrations(ctx context.Context, pool database.PgxIface) error {
@@ -90,7 +91,7 @@ func TestMain(m *testing.M) {
 	pvzApp := app.New(false)
 	pvzApp.InitDBConnection()
 
-	pool := pvzApp.GetDBConn()
+	pool = pvzApp.GetDBConn()
 	if err := runMigrations(ctx, pool); err != nil {
 		fmt.Println("migration error:", err)
 		os.Exit(1)
@@ -105,7 +106,7 @@ func TestMain(m *testing.M) {
 	os.Exit(exitCode)
 }
 
-func TestFullWorkflow(t *testing.T) {
+func newExpect(t *testing.T) *httpexpect.Expect {
 	ln := fasthttputil.NewInmemoryListener()
 	go fasthttp.Serve(ln, handler)
 	t.Cleanup(func() { ln.Close() })
@@ -118,17 +119,23 @@ func TestFullWorkflow(t *testing.T) {
 		},
 	}
 
-	expect := httpexpect.WithConfig(httpexpect.Config{
+	return httpexpect.WithConfig(httpexpect.Config{
 		BaseURL:  "http://localhost",
 		Reporter: httpexpect.NewAssertReporter(t),
 		Client:   httpClient,
 	})
+}
 
-	modRaw := expect.POST("/dummyLogin").
-		WithJSON(map[string]string{"role": "moderator"}).
+func authHeaders(expect *httpexpect.Expect, role string) map[string]string {
+	raw := expect.POST("/dummyLogin").
+		WithJSON(map[string]string{"role": role}).
 		Expect().Status(http.StatusOK).Body().Raw()
-	modToken := strings.Trim(modRaw, `"`)
-	headersMod := map[string]string{"Authorization": fmt.Sprintf("Bearer %s", modToken)}
+	return map[string]string{"Authorization": fmt.Sprintf("Bearer %s", strings.Trim(raw, `"`))}
+}
+
+func TestFullWorkflow(t *testing.T) {
+	expect := newExpect(t)
+	headersMod := authHeaders(expect, "moderator")
 
 	var createdPVZ struct {
 		ID string `json:"id"`
@@ -138,11 +145,7 @@ func TestFullWorkflow(t *testing.T) {
 		Expect().Status(http.StatusCreated).
 		JSON().Object().Decode(&createdPVZ)
 
-	empRaw := expect.POST("/dummyLogin").
-		WithJSON(map[string]string{"role": "employee"}).
-		Expect().Status(http.StatusOK).Body().Raw()
-	empToken := strings.Trim(empRaw, `"`)
-	headersEmp := map[string]string{"Authorization": fmt.Sprintf("Bearer %s", empToken)}
+	headersEmp := authHeaders(expect, "employee")
 
 	var createdRec struct {
 		ID string `json:"id"`
@@ -168,3 +171,48 @@ func TestFullWorkflow(t *testing.T) {
 
 	assert.Equal(t, createdRec.ID, closedRec.ID)
 }
+
+func TestDeleteLastProductGoesToTrash(t *testing.T) {
+	ctx := context.Background()
+	expect := newExpect(t)
+	headersMod := authHeaders(expect, "moderator")
+	headersEmp := authHeaders(expect, "employee")
+
+	var createdPVZ struct {
+		ID string `json:"id"`
+	}
+	expect.POST("/pvz").WithHeaders(headersMod).
+		WithJSON(map[string]string{"city": "Москва"}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Decode(&createdPVZ)
+
+	var createdRec struct {
+		ID string `json:"id"`
+	}
+	expect.POST("/receptions").WithHeaders(headersEmp).
+		WithJSON(map[string]string{"pvzId": createdPVZ.ID}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Decode(&createdRec)
+
+	expect.POST("/products").WithHeaders(headersEmp).
+		WithJSON(map[string]string{"type": "обувь", "pvzId": createdPVZ.ID}).
+		Expect().Status(http.StatusCreated)
+
+	_, err := pool.Exec(ctx, `INSERT INTO rollup_days (day, computed_at, dirty)
+								SELECT date_time::date, NOW(), FALSE FROM products WHERE reception_id = $1
+								ON CONFLICT (day) DO UPDATE SET dirty = FALSE`, createdRec.ID)
+	assert.NoError(t, err)
+
+	expect.POST(fmt.Sprintf("/pvz/%s/delete_last_product", createdPVZ.ID)).WithHeaders(headersEmp).
+		Expect().Status(http.StatusOK)
+
+	var deleted int
+	var dirty bool
+	err = pool.QueryRow(ctx, `SELECT COUNT(*), BOOL_AND(d.dirty)
+								FROM deleted_products p
+								JOIN rollup_days d ON d.day = p.date_time::date
+								WHERE p.reception_id = $1`, createdRec.ID).Scan(&deleted, &dirty)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.True(t, dirty)
+}